@@ -37,6 +37,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/deployer"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/persistence/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 )
 
@@ -113,12 +114,18 @@ func deploy(ctx context.Context, fs afero.Fs, opts deployOpts) error {
 	log.Debug("Deploying to accounts: %q", maps.Keys(accounts))
 	log.Debug("Deploying projects: %q", maps.Keys(projects))
 
-	resources, err := loader.LoadResources(fs, opts.workingDir, projects)
+	return DeployAccounts(ctx, fs, opts.workingDir, accounts, projects, opts.dryRun)
+}
+
+// DeployAccounts loads the account management resources of the given projects and deploys them to all given accounts.
+// On dry-runs, the resources are only loaded and validated.
+func DeployAccounts(ctx context.Context, fs afero.Fs, workingDir string, accounts map[string]manifest.Account, projects manifest.ProjectDefinitionByProjectID, dryRun bool) error {
+	resources, err := loader.LoadResources(fs, workingDir, projects)
 	if err != nil {
 		return fmt.Errorf("failed to load all account management resources: %w", err)
 	}
 
-	if opts.dryRun {
+	if dryRun {
 		log.Info("Successfully validated account management resources")
 		return nil
 	}
@@ -0,0 +1,85 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/authcheck"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// authCheck loads the manifest and all projects and checks whether the credentials of every selected environment are
+// granted all scopes required to deploy the projects.
+func authCheck(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, selector string, specificProjects []string) error {
+	absManifestPath, err := filepath.Abs(filepath.Clean(manifestPath))
+	if err != nil {
+		return fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
+	}
+
+	loadedManifest, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: absManifestPath,
+		Groups:       environmentGroups,
+		Environments: specificEnvironments,
+		Selector:     selector,
+		Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return errors.New("error while loading manifest")
+	}
+
+	loadedProjects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(absManifestPath),
+		Manifest:        loadedManifest,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, specificProjects)
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load projects - %d errors occurred", len(errs))
+	}
+
+	if err := deploy.ValidateAuthenticationWithProjectConfigs(loadedProjects, loadedManifest.Environments); err != nil {
+		return fmt.Errorf("manifest auth field misconfigured: %w", err)
+	}
+
+	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments, false)
+	if err != nil {
+		return fmt.Errorf("failed to create API clients: %w", err)
+	}
+
+	if err := authcheck.CheckEnvironments(ctx, loadedProjects, loadedManifest.Environments, clientSets.AccessTokensClients()); err != nil {
+		return err
+	}
+
+	log.Info("The credentials of all environments are granted all required scopes")
+	return nil
+}
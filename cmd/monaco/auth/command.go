@@ -0,0 +1,96 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+)
+
+// Command returns the 'auth' command, grouping commands concerning the credentials of environments.
+func Command(fs afero.Fs) *cobra.Command {
+	authCmd := &cobra.Command{
+		Use:   "auth <command>",
+		Short: "Check the credentials of the environments defined in a manifest",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	authCmd.AddCommand(getAuthCheckCommand(fs))
+	return authCmd
+}
+
+func getAuthCheckCommand(fs afero.Fs) (checkCmd *cobra.Command) {
+	var manifestName, selector string
+	var environment, project, groups []string
+
+	checkCmd = &cobra.Command{
+		Use:   "check <manifest.yaml>",
+		Short: "Check that the credentials of all environments are granted the scopes required to deploy the projects",
+		Long: "Check loads the manifest and all projects and determines the scopes required to deploy the configurations of every environment. " +
+			"The scopes granted to the access token are looked up using the token lookup API, the scopes granted to the OAuth client are taken from its token response. " +
+			"The command fails if any required scope is missing. The scopes of platform tokens can't be determined and are not checked.",
+		Example:           "monaco auth check manifest.yaml -e dev-environment",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.DeployCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName = args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			}
+
+			return authCheck(cmd.Context(), fs, manifestName, groups, environment, selector, project)
+		},
+	}
+
+	checkCmd.Flags().StringSliceVarP(&environment, "environment", "e", []string{},
+		"Specify one (or multiple) environment(s) to check. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'.")
+	checkCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) to check. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--environment'")
+	checkCmd.Flags().StringVar(&selector, "selector", "",
+		"Only check environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"If combined with '--environment' or '--group', environments must match both.")
+	checkCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project whose configurations determine the required scopes (also includes any dependent configurations)")
+
+	err := checkCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	err = checkCmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	checkCmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	return checkCmd
+}
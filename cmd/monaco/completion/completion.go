@@ -31,6 +31,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
 )
 
 func DeleteCompletion(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -83,6 +84,10 @@ func AllAvailableApis(cmd *cobra.Command, _ []string, _ string) ([]string, cobra
 	return slices.Difference(allApis, value.GetSlice()), cobra.ShellCompDirectiveDefault
 }
 
+func ValueDetectors(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return append(value_extraction.BuiltinDetectorNames(), "all"), cobra.ShellCompDirectiveNoFileComp
+}
+
 func EnvironmentByManifestFlag(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 	return loadEnvironmentsFromManifest(cmd.Flag("manifest").Value.String())
 }
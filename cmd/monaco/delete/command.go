@@ -27,16 +27,19 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
 func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 	var environments, groups []string
-	var manifestName string
+	var manifestName, selector string
 	var deleteFile string
+	var allowDestructiveChanges bool
 
 	deleteCmd = &cobra.Command{
 		Use:     "delete --manifest <manifest.yaml> --file <delete.yaml>",
@@ -68,6 +71,7 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 				ManifestPath: absManifestFilePath,
 				Environments: environments,
 				Groups:       groups,
+				Selector:     selector,
 				Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
 			})
 			if len(errs) > 0 {
@@ -81,7 +85,15 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 				return fmt.Errorf("encountered errors while parsing %s: %w", deleteFile, err)
 			}
 
-			return Delete(cmd.Context(), manifest.Environments, entriesToDelete)
+			// the bucket configs are required to know which buckets may be deleted
+			var projects []project.Project
+			if featureflags.BucketSafety.Enabled() && len(entriesToDelete["bucket"]) > 0 {
+				if projects, err = delete.LoadBucketConfigs(cmd.Context(), fs, filepath.Dir(absManifestFilePath), manifest); err != nil {
+					return err
+				}
+			}
+
+			return Delete(cmd.Context(), manifest.Environments, entriesToDelete, projects, allowDestructiveChanges)
 		},
 		ValidArgsFunction: completion.DeleteCompletion,
 	}
@@ -101,6 +113,14 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 			"This flag is mutually exclusive with '--group'. "+
 			"If this flag is specified, configuration will be deleted from all specified environments. "+
 			"If neither --groups nor --environment is present, all environments will be used for deletion")
+	deleteCmd.Flags().StringVar(&selector, "selector", "",
+		"Only delete from environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"If combined with '--environment' or '--group', environments must match both.")
+
+	if featureflags.BucketSafety.Enabled() {
+		deleteCmd.Flags().BoolVar(&allowDestructiveChanges, "allow-destructive-changes", false, "Delete all Grail buckets of the delete file whose config is not protected. "+
+			"Without this flag, only buckets with 'allowDestructive: true' set in their config are deleted.")
+	}
 
 	if err := deleteCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
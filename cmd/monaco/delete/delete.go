@@ -24,6 +24,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
 // Delete removes configurations from multiple Dynatrace environments based on the specified deletion entries.
@@ -31,25 +32,29 @@ import (
 // Parameters:
 //   - environments: A list of Dynatrace environments to perform the deletion on.
 //   - entriesToDelete: Deletion entries specifying what configurations to remove.
+//   - projects: The projects whose bucket configs decide which Grail buckets may be deleted.
+//   - allowDestructiveChanges: Whether all Grail buckets whose config is not protected may be deleted.
 //
 // Returns:
 //   - error: If an error occurs during the deletion process, an error is returned, describing the issue.
 //     If no errors occur, nil is returned.
-func Delete(ctx context.Context, environments manifest.Environments, entriesToDelete delete.DeleteEntries) error {
+func Delete(ctx context.Context, environments manifest.Environments, entriesToDelete delete.DeleteEntries, projects []project.Project, allowDestructiveChanges bool) error {
 	var envsWithDeleteErrs []string
 	for _, env := range environments {
 		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
-		if containsPlatformTypes(entriesToDelete) && env.Auth.OAuth == nil {
-			log.WithCtxFields(ctx).Warn("Delete file contains Dynatrace Platform specific types, but no oAuth credentials are defined for environment %q - Dynatrace Platform configurations won't be deleted.", env.Name)
+		if containsPlatformTypes(entriesToDelete) && !env.Auth.HasPlatformAuth() {
+			log.WithCtxFields(ctx).Warn("Delete file contains Dynatrace Platform specific types, but no oAuth credentials or platform token are defined for environment %q - Dynatrace Platform configurations won't be deleted.", env.Name)
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Transport: env.Transport})
 		if err != nil {
 			return fmt.Errorf("failed to create API client for environment %q due to the following error: %w", env.Name, err)
 		}
 
 		log.WithCtxFields(ctx).Info("Deleting configs for environment %q...", env.Name)
 
+		ctx = delete.NewContextWithBucketProtection(ctx, delete.NewBucketProtection(projects, env.Name, allowDestructiveChanges))
+
 		if err := delete.Configs(ctx, *clientSet, entriesToDelete); err != nil {
 			log.Error("Failed to delete all configurations from environment %q - check log for details", env.Name)
 			envsWithDeleteErrs = append(envsWithDeleteErrs, env.Name)
@@ -0,0 +1,50 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// deployAccounts deploys the account management resources of the given projects to all accounts of the manifest. If no
+// projects are specified, the resources of all projects are deployed.
+func deployAccounts(ctx context.Context, fs afero.Fs, manifestPath string, m *manifest.Manifest, specificProjects []string, dryRun bool) error {
+	if len(m.Accounts) == 0 {
+		return errors.New("no accounts are defined in the manifest")
+	}
+
+	projects := manifest.ProjectDefinitionByProjectID{}
+	for name, definition := range m.Projects {
+		if len(specificProjects) == 0 || slices.Contains(specificProjects, name) {
+			projects[name] = definition
+		}
+	}
+
+	if err := account.DeployAccounts(ctx, fs, filepath.Dir(manifestPath), m.Accounts, projects, dryRun); err != nil {
+		return fmt.Errorf("failed to deploy account management resources: %w", err)
+	}
+	return nil
+}
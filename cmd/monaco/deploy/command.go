@@ -27,6 +27,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
@@ -34,8 +35,8 @@ import (
 )
 
 func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
-	var dryRun, continueOnError bool
-	var manifestName string
+	var dryRun, continueOnError, resolveIDs, includeAccounts, allowDestructiveChanges bool
+	var manifestName, selector string
 	var environment, project, groups []string
 
 	deployCmd = &cobra.Command{
@@ -56,7 +57,7 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 				return err
 			}
 
-			return deployConfigs(ctx, fs, manifestName, groups, environment, project, continueOnError, dryRun)
+			return deployConfigs(ctx, fs, manifestName, groups, environment, selector, project, continueOnError, dryRun, resolveIDs, includeAccounts, allowDestructiveChanges)
 		},
 	}
 
@@ -69,9 +70,23 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
 			"If this flag is specified, all environments within this group will be used for deployment. "+
 			"This flag is mutually exclusive with '--environment'")
+	deployCmd.Flags().StringVar(&selector, "selector", "",
+		"Only deploy to environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"Supported requirements are 'key=value', 'key!=value', 'key in (a,b)', 'key notin (a,b)', 'key' and '!key'. "+
+			"If combined with '--environment' or '--group', environments must match both.")
 	deployCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
 	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
 	deployCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
+	deployCmd.Flags().BoolVar(&resolveIDs, "resolve-entity-ids", false, "Replace the entity IDs extracted into parameters with the IDs of the entities of the same type and display name in each environment deployed to. "+
+		"Requires the 'ids.yaml' file written by 'download --annotate-ids' in the project folder and an API token. IDs that can not be resolved unambiguously are kept. Not applied on dry-runs.")
+	deployCmd.Flags().BoolVar(&includeAccounts, "include-accounts", false, "Additionally deploy the account management resources of the deployed projects to all accounts defined in the manifest. "+
+		"Accounts are deployed after all environments, in the same run and report.")
+
+	if featureflags.BucketSafety.Enabled() {
+		deployCmd.Flags().BoolVar(&allowDestructiveChanges, "allow-destructive-changes", false, "Allow deploying changes to Grail buckets that destroy data, like decreasing their retention days or changing their table. "+
+			"Without this flag, such changes are only deployed for buckets with 'allowDestructive: true' set in their config. "+
+			"Dry-runs only fetch the deployed buckets of environments with platform credentials, and report buckets whose changes are not checked otherwise.")
+	}
 
 	err := deployCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
 	if err != nil {
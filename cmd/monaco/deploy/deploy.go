@@ -26,9 +26,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy/internal/logging"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/authcheck"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
@@ -38,7 +40,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
-func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, continueOnErr bool, dryRun bool) error {
+func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, selector string, specificProjects []string, continueOnErr bool, dryRun bool, resolveIDs bool, includeAccounts bool, allowDestructiveChanges bool) error {
 	absManifestPath, err := absPath(manifestPath)
 	if err != nil {
 		formattedErr := fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
@@ -46,7 +48,7 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		return formattedErr
 	}
 
-	loadedManifest, err := loadManifest(ctx, fs, absManifestPath, environmentGroups, specificEnvironments)
+	loadedManifest, err := loadManifest(ctx, fs, absManifestPath, environmentGroups, specificEnvironments, selector)
 	if err != nil {
 		return err
 	}
@@ -61,14 +63,14 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		return err
 	}
 
-	if err := validateProjectsWithEnvironments(ctx, loadedProjects, loadedManifest.Environments); err != nil {
+	if err := ValidateProjectsWithEnvironments(ctx, loadedProjects, loadedManifest.Environments); err != nil {
 		return err
 	}
 
 	logging.LogProjectsInfo(loadedProjects)
 	logging.LogEnvironmentsInfo(loadedManifest.Environments)
 
-	err = validateAuthenticationWithProjectConfigs(loadedProjects, loadedManifest.Environments)
+	err = ValidateAuthenticationWithProjectConfigs(loadedProjects, loadedManifest.Environments)
 	if err != nil {
 		formattedErr := fmt.Errorf("manifest auth field misconfigured: %w", err)
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
@@ -82,11 +84,48 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		return formattedErr
 	}
 
-	err = deploy.DeployForAllEnvironments(ctx, loadedProjects, clientSets, deploy.DeployConfigsOptions{ContinueOnErr: continueOnErr, DryRun: dryRun})
+	// groups are only looked up if referenced by name, as account credentials are otherwise not required for deploying
+	if !dryRun && featureflags.DocumentSharing.Enabled() && referencesGroupsByName(loadedProjects) && len(loadedManifest.Accounts) > 0 {
+		accountGroupsClient, err := dynatrace.CreateAccountGroupsClient(ctx, loadedManifest.Accounts)
+		if err != nil {
+			formattedErr := fmt.Errorf("failed to create account API clients: %w", err)
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
+			return formattedErr
+		}
+		for _, clientSet := range clientSets {
+			clientSet.AccountGroupsClient = accountGroupsClient
+		}
+	}
+
+	if !dryRun && featureflags.AuthScopeCheck.Enabled() {
+		if err := authcheck.CheckEnvironments(ctx, loadedProjects, loadedManifest.Environments, clientSets.AccessTokensClients()); err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
+
+	if resolveIDs && !dryRun {
+		if err := resolveEntityIDs(ctx, fs, absManifestPath, loadedManifest, loadedProjects, clientSets); err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
+
+	err = deploy.DeployForAllEnvironments(ctx, loadedProjects, clientSets, deploy.DeployConfigsOptions{ContinueOnErr: continueOnErr, DryRun: dryRun, AllowDestructiveChanges: allowDestructiveChanges})
 	if err != nil {
 		return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(dryRun), err)
 	}
 
+	// account groups may grant permissions on environment configurations like management zones, so accounts are
+	// deployed after all environments
+	if includeAccounts {
+		if err := deployAccounts(ctx, fs, absManifestPath, loadedManifest, specificProjects, dryRun); err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(dryRun), err)
+		}
+		report.GetReporterFromContextOrDiscard(ctx).ReportInfo(fmt.Sprintf("%s of account management resources for %d account(s) finished", logging.GetOperationNounForLogging(dryRun), len(loadedManifest.Accounts)))
+	}
+
 	log.Info("%s finished without errors", logging.GetOperationNounForLogging(dryRun))
 	return nil
 }
@@ -96,12 +135,13 @@ func absPath(manifestPath string) (string, error) {
 	return filepath.Abs(manifestPath)
 }
 
-func loadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string) (*manifest.Manifest, error) {
+func loadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string, selector string) (*manifest.Manifest, error) {
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
 		ManifestPath: manifestPath,
 		Groups:       groups,
 		Environments: environments,
+		Selector:     selector,
 		Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
 	})
 
@@ -148,7 +188,9 @@ type KindCoordinates map[string][]coordinate.Coordinate
 type KindCoordinatesPerEnvironment map[string]KindCoordinates
 type CoordinatesPerEnvironment map[string][]coordinate.Coordinate
 
-func validateProjectsWithEnvironments(ctx context.Context, projects []project.Project, envs manifest.Environments) error {
+// ValidateProjectsWithEnvironments checks that the environments of all projects are defined and able to deploy their
+// configs. All problems found are reported and returned.
+func ValidateProjectsWithEnvironments(ctx context.Context, projects []project.Project, envs manifest.Environments) error {
 	undefinedEnvironments := map[string]struct{}{}
 	openPipelineKindCoordinatesPerEnvironment := KindCoordinatesPerEnvironment{}
 	platformCoordinatesPerEnvironment := CoordinatesPerEnvironment{}
@@ -189,7 +231,8 @@ func collectOpenPipelineCoordinatesByKind(cfgPerType project.ConfigsPerType, des
 			continue
 		}
 
-		if openPipelineType, ok := cfg.Type.(config.OpenPipelineType); ok {
+		// partial configs are merged with the other configs of their kind, so only whole configurations are collected
+		if openPipelineType, ok := cfg.Type.(config.OpenPipelineType); ok && openPipelineType.Partial == nil {
 			dest[openPipelineType.Kind] = append(dest[openPipelineType.Kind], cfg.Coordinate)
 		}
 	}
@@ -266,12 +309,12 @@ func collectRequiresPlatformErrors(platformCoordinatesPerEnvironment Coordinates
 }
 
 func platformEnvironment(e manifest.EnvironmentDefinition) bool {
-	return e.Auth.OAuth != nil
+	return e.Auth.HasPlatformAuth()
 }
 
-// validateAuthenticationWithProjectConfigs validates each config entry against the manifest if required credentials are set
+// ValidateAuthenticationWithProjectConfigs validates each config entry against the manifest if required credentials are set
 // it takes into consideration the project, environments and the skip parameter in each config entry
-func validateAuthenticationWithProjectConfigs(projects []project.Project, environments manifest.Environments) error {
+func ValidateAuthenticationWithProjectConfigs(projects []project.Project, environments manifest.Environments) error {
 	for _, p := range projects {
 		for envName, env := range p.Configs {
 			for _, file := range env {
@@ -287,15 +330,15 @@ func validateAuthenticationWithProjectConfigs(projects []project.Project, enviro
 						}
 					case config.SettingsType:
 						t, ok := conf.Type.(config.SettingsType)
-						if ok && t.AllUserPermission != nil && environments[envName].Auth.OAuth == nil {
-							return fmt.Errorf("using permission property on settings API requires OAuth, schema '%s' enviroment '%s'", t.SchemaId, envName)
+						if ok && t.AllUserPermission != nil && !environments[envName].Auth.HasPlatformAuth() {
+							return fmt.Errorf("using permission property on settings API requires OAuth or a platform token, schema '%s' enviroment '%s'", t.SchemaId, envName)
 						}
-						if environments[envName].Auth.Token == nil && environments[envName].Auth.OAuth == nil {
-							return fmt.Errorf("API of type '%s' requires a token or OAuth for environment '%s'", conf.Type, envName)
+						if environments[envName].Auth.Token == nil && !environments[envName].Auth.HasPlatformAuth() {
+							return fmt.Errorf("API of type '%s' requires a token, OAuth or a platform token for environment '%s'", conf.Type, envName)
 						}
 					default:
-						if environments[envName].Auth.OAuth == nil {
-							return fmt.Errorf("API of type '%s' requires OAuth for environment '%s'", conf.Type, envName)
+						if !environments[envName].Auth.HasPlatformAuth() {
+							return fmt.Errorf("API of type '%s' requires OAuth or a platform token for environment '%s'", conf.Type, envName)
 						}
 					}
 				}
@@ -304,3 +347,23 @@ func validateAuthenticationWithProjectConfigs(projects []project.Project, enviro
 	}
 	return nil
 }
+
+// referencesGroupsByName returns whether any document of the projects is shared with a group referenced by its name.
+func referencesGroupsByName(projects []project.Project) bool {
+	for _, p := range projects {
+		for _, envConfigs := range p.Configs {
+			for c := range envConfigs.AllConfigs {
+				t, ok := c.Type.(config.DocumentType)
+				if !ok || t.Sharing == nil {
+					continue
+				}
+				for _, g := range t.Sharing.Groups {
+					if g.ID == "" {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
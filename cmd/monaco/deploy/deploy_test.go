@@ -56,7 +56,7 @@ func Test_DoDeploy_InvalidManifest(t *testing.T) {
 	manifestPath, _ := filepath.Abs("manifest.yaml")
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
-	err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+	err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, "", []string{}, true, true, false, false, false)
 	assert.Error(t, err)
 }
 
@@ -97,31 +97,106 @@ environmentGroups:
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
 	t.Run("Wrong environment group", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"NOT_EXISTING_GROUP"}, []string{}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"NOT_EXISTING_GROUP"}, []string{}, "", []string{}, true, true, false, false, false)
 		assert.Error(t, err)
 	})
 	t.Run("Wrong environment name", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"NOT_EXISTING_ENV"}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"NOT_EXISTING_ENV"}, "", []string{}, true, true, false, false, false)
 		assert.Error(t, err)
 	})
 
 	t.Run("Wrong project name", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"NON_EXISTING_PROJECT"}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, "", []string{"NON_EXISTING_PROJECT"}, true, true, false, false, false)
 		assert.Error(t, err)
 	})
 
 	t.Run("no parameters", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, "", []string{}, true, true, false, false, false)
 		assert.NoError(t, err)
 	})
 
 	t.Run("correct parameters", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"project"}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, "", []string{"project"}, true, true, false, false, false)
 		assert.NoError(t, err)
 	})
 
 }
 
+func Test_DoDeploy_IncludeAccounts(t *testing.T) {
+	t.Setenv("ENV_TOKEN", "mock env token")
+	t.Setenv("ENV_CLIENT_ID", "mock client id")
+	t.Setenv("ENV_CLIENT_SECRET", "mock client secret")
+
+	environmentsYaml := `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: project
+    url:
+      value: https://abcde.dev.dynatracelabs.com
+    auth:
+      token:
+        name: ENV_TOKEN
+`
+	accountsYaml := `accounts:
+- name: account
+  accountUUID: 8f9935ee-2068-455d-85ce-47447f19d5d5
+  oAuth:
+    clientId:
+      name: ENV_CLIENT_ID
+    clientSecret:
+      name: ENV_CLIENT_SECRET
+`
+	configYaml := `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: profile.json
+  type:
+    api: alerting-profile
+`
+	policiesYaml := `policies:
+- id: my-policy
+  name: My Policy
+  level:
+    type: account
+  policy: ALLOW automation:workflows:read;
+`
+
+	newFs := func(t *testing.T, manifestYaml, policiesYaml string) (afero.Fs, string) {
+		fs := afero.NewMemMapFs()
+		configPath, _ := filepath.Abs("project/alerting-profile/profile.yaml")
+		_ = afero.WriteFile(fs, configPath, []byte(configYaml), 0644)
+		templatePath, _ := filepath.Abs("project/alerting-profile/profile.json")
+		_ = afero.WriteFile(fs, templatePath, []byte("{}"), 0644)
+		policiesPath, _ := filepath.Abs("project/policies.yaml")
+		_ = afero.WriteFile(fs, policiesPath, []byte(policiesYaml), 0644)
+		manifestPath, _ := filepath.Abs("manifest.yaml")
+		_ = afero.WriteFile(fs, manifestPath, []byte(manifestYaml), 0644)
+		return fs, manifestPath
+	}
+
+	t.Run("environments and accounts are validated", func(t *testing.T) {
+		fs, manifestPath := newFs(t, environmentsYaml+accountsYaml, policiesYaml)
+		err := deployConfigs(t.Context(), fs, manifestPath, []string{}, []string{}, "", []string{}, false, true, false, true, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails without accounts in the manifest", func(t *testing.T) {
+		fs, manifestPath := newFs(t, environmentsYaml, policiesYaml)
+		err := deployConfigs(t.Context(), fs, manifestPath, []string{}, []string{}, "", []string{}, false, true, false, true, false)
+		assert.ErrorContains(t, err, "no accounts are defined")
+	})
+
+	t.Run("fails for invalid account resources", func(t *testing.T) {
+		fs, manifestPath := newFs(t, environmentsYaml+accountsYaml, "policies:\n- id: my-policy\n")
+		err := deployConfigs(t.Context(), fs, manifestPath, []string{}, []string{}, "", []string{}, false, true, false, true, false)
+		assert.ErrorContains(t, err, "failed to load all account management resources")
+	})
+}
+
 func Test_checkEnvironments(t *testing.T) {
 
 	env1Id := "env1"
@@ -147,7 +222,7 @@ func Test_checkEnvironments(t *testing.T) {
 	project2Id := "project2"
 
 	t.Run("defined environment in project succeeds", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -164,7 +239,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("undefined environment in project fails", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -181,7 +256,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("platform config with platform environment succeeds", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -200,7 +275,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("platform config without platform environment fails", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -219,7 +294,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two different openpipeline configs in same project succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -239,7 +314,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two different openpipeline configs in different projects succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -268,7 +343,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in same project but different environments succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -295,7 +370,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in different projects and environments succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -327,7 +402,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in same project and environments fail", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -350,7 +425,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in different projects and same environments fail", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -380,6 +455,37 @@ func Test_checkEnvironments(t *testing.T) {
 		assert.ErrorContains(t, err, "has multiple openpipeline configurations of kind")
 	})
 
+	t.Run("partial openpipeline configs of the same kind as a whole config in same environment succeed", func(t *testing.T) {
+		partialConfig := createOpenPipelineConfigForTest("bizevents2-openpipeline-id", "bizevents", project2Id)
+		partialConfig.Type = config.OpenPipelineType{Kind: "bizevents", Partial: &config.OpenPipelinePartial{Type: config.OpenPipelineRoutingEntryPartial}}
+
+		err := ValidateProjectsWithEnvironments(
+			t.Context(),
+			[]project.Project{
+				{
+					Id: project1Id,
+					Configs: project.ConfigsPerTypePerEnvironments{
+						env1Id: project.ConfigsPerType{
+							"openpipeline": []config.Config{
+								createOpenPipelineConfigForTest("bizevents1-openpipeline-id", "bizevents", project1Id),
+							},
+						},
+					},
+				},
+				{
+					Id: project2Id,
+					Configs: project.ConfigsPerTypePerEnvironments{
+						env1Id: project.ConfigsPerType{
+							"openpipeline": []config.Config{partialConfig},
+						},
+					},
+				},
+			},
+			manifest.Environments{
+				env1Id: env1Definition,
+			})
+		assert.NoError(t, err)
+	})
 }
 
 func createOpenPipelineConfigForTest(configId string, kind string, project string) config.Config {
@@ -399,6 +505,7 @@ func Test_ValidateAuthenticationWithProjectConfigs(t *testing.T) {
 	oAuth := manifest.OAuth{
 		ClientID:     manifest.AuthSecret{Name: "id", Value: "value"},
 		ClientSecret: manifest.AuthSecret{Name: "id", Value: "value"}}
+	platformToken := manifest.AuthSecret{Name: "platform-token", Value: "value"}
 	documentConf := config.Config{
 		Type: config.DocumentType{},
 		Skip: false,
@@ -476,7 +583,7 @@ func Test_ValidateAuthenticationWithProjectConfigs(t *testing.T) {
 				}},
 			project.ConfigsPerType{
 				string(config.DocumentTypeID): []config.Config{documentConf}},
-			"requires OAuth for environment",
+			"requires OAuth or a platform token for environment",
 		},
 		{
 			"oAuth manifest with document and classic api expect validation error",
@@ -557,11 +664,50 @@ func Test_ValidateAuthenticationWithProjectConfigs(t *testing.T) {
 			},
 			"using permission property on settings API requires OAuth",
 		},
+		{
+			"platform token manifest with document api",
+			manifest.Environments{
+				envId: manifest.EnvironmentDefinition{
+					Name: envId,
+					Auth: manifest.Auth{
+						PlatformToken: &platformToken},
+				}},
+			project.ConfigsPerType{
+				string(config.DocumentTypeID): []config.Config{documentConf}},
+			"",
+		},
+		{
+			"platform token manifest with settings api and permissions",
+			manifest.Environments{
+				envId: manifest.EnvironmentDefinition{
+					Name: envId,
+					Auth: manifest.Auth{
+						PlatformToken: &platformToken},
+				}},
+			project.ConfigsPerType{
+				string(config.SettingsTypeID): []config.Config{settingsConfWithPermission},
+			},
+			"",
+		},
+		{
+			"platform token manifest with document and classic api expect validation error",
+			manifest.Environments{
+				envId: manifest.EnvironmentDefinition{
+					Name: envId,
+					Auth: manifest.Auth{
+						PlatformToken: &platformToken},
+				}},
+			project.ConfigsPerType{
+				string(config.DocumentTypeID):   []config.Config{documentConf},
+				string(config.ClassicApiTypeID): []config.Config{classicConf},
+			},
+			"requires a token for environment",
+		},
 	}
 
 	for _, tc := range success_tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateAuthenticationWithProjectConfigs(
+			err := ValidateAuthenticationWithProjectConfigs(
 				[]project.Project{
 					{
 						Id: "some id",
@@ -0,0 +1,68 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// resolveEntityIDs replaces the entity IDs extracted into parameters of each project with the IDs of the same entities
+// in the environments deployed to, using the annotations written by 'download --annotate-ids'. Projects without
+// annotations are not changed.
+func resolveEntityIDs(ctx context.Context, fs afero.Fs, manifestPath string, m *manifest.Manifest, projects []project.Project, clientSets dynatrace.EnvironmentClients) error {
+	for _, p := range projects {
+		definition, found := m.Projects[p.Id]
+		if !found {
+			continue
+		}
+		annotations, err := entityids.Load(fs, filepath.Join(filepath.Dir(manifestPath), definition.Path))
+		if err != nil {
+			return err
+		}
+		if len(annotations) == 0 {
+			continue
+		}
+
+		for env, clientSet := range clientSets {
+			configs, found := p.Configs[env.Name]
+			if !found {
+				continue
+			}
+			if clientSet.EntitiesClient == nil {
+				log.WithFields(field.Environment(env.Name, env.Group)).Warn("Entity IDs of project %q can not be resolved without an API token", p.Id)
+				continue
+			}
+
+			log.WithFields(field.Environment(env.Name, env.Group)).Info("Resolving entity IDs of project %q", p.Id)
+			if err := entityids.Resolve(ctx, clientSet.EntitiesClient, annotations, configs); err != nil {
+				return fmt.Errorf("failed to resolve entity IDs of project %q for environment %q: %w", p.Id, env.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/devserver"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var opts serverOptions
+
+	cmd = &cobra.Command{
+		Use:   "dev-server",
+		Short: "Run a local server emulating a Dynatrace environment",
+		Long: "Dev-server runs a stateful fake of a Dynatrace environment on the local machine. " +
+			"It emulates the classic config APIs, Settings 2.0, documents, automations, buckets, segments and SLOs, " +
+			"so that configurations can be deployed, downloaded and deleted without a real environment. " +
+			"Point the URL of an environment in your manifest to the server; any token or OAuth client credentials are accepted. " +
+			"For OAuth, set the token endpoint of the environment to " + devserver.TokenPath + " of the server.\n\n" +
+			"All objects are kept in memory, unless a storage file is given.",
+		Example: "monaco dev-server --address localhost:8080 --storage-file dev-server.json",
+		Args:    cobra.NoArgs,
+		PreRun:  cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), fs, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.address, "address", "localhost:8080", "The address the server listens on.")
+	cmd.Flags().StringVar(&opts.storageFile, "storage-file", "", "A file the state of the server is persisted to. If it exists, the state is loaded from it on startup.")
+	cmd.Flags().StringVar(&opts.schemasFile, "schemas", "", "A JSON file holding a list of Settings 2.0 schemas, defining whether they are ordered and their unique-key constraints.")
+	cmd.Flags().StringVar(&opts.version, "dynatrace-version", devserver.DefaultVersion, "The Dynatrace version reported by the server.")
+
+	return cmd
+}
@@ -0,0 +1,117 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/devserver"
+)
+
+type serverOptions struct {
+	address     string
+	storageFile string
+	schemasFile string
+	version     string
+}
+
+func run(ctx context.Context, fs afero.Fs, opts serverOptions) error {
+	server, err := newServer(fs, opts)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", opts.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", opts.address, err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	return serve(ctx, listener, server)
+}
+
+func newServer(fs afero.Fs, opts serverOptions) (*devserver.Server, error) {
+	serverOpts := []func(*devserver.Server){devserver.WithVersion(opts.version)}
+
+	if opts.storageFile != "" {
+		serverOpts = append(serverOpts, devserver.WithStorageFile(fs, opts.storageFile))
+	}
+
+	if opts.schemasFile != "" {
+		schemas, err := readSchemas(fs, opts.schemasFile)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, devserver.WithSchemas(schemas...))
+	}
+
+	return devserver.New(serverOpts...)
+}
+
+func readSchemas(fs afero.Fs, path string) ([]devserver.Schema, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schemas file %q: %w", path, err)
+	}
+
+	var schemas []devserver.Schema
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to parse schemas file %q: %w", path, err)
+	}
+	return schemas, nil
+}
+
+// serve serves requests on the listener until the context is done.
+func serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(listener)
+	}()
+	log.Info("Dev server listening on http://%s", listener.Addr())
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Info("Shutting down dev server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down dev server: %w", err)
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/devserver"
+)
+
+func TestNewServer_ReadsSchemas(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "schemas.json", []byte(`[{"schemaId":"builtin:a","ordered":true}]`), 0644))
+
+	schemas, err := readSchemas(fs, "schemas.json")
+	require.NoError(t, err)
+	assert.Equal(t, []devserver.Schema{{SchemaID: "builtin:a", Ordered: true}}, schemas)
+
+	_, err = newServer(fs, serverOptions{schemasFile: "schemas.json", storageFile: "state.json"})
+	assert.NoError(t, err)
+}
+
+func TestNewServer_FailsOnInvalidSchemas(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "schemas.json", []byte(`{}`), 0644))
+
+	_, err := newServer(fs, serverOptions{schemasFile: "schemas.json"})
+	assert.Error(t, err)
+
+	_, err = newServer(fs, serverOptions{schemasFile: "missing.json"})
+	assert.Error(t, err)
+}
+
+func TestServe_StopsWhenContextIsDone(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- serve(ctx, listener, http.NotFoundHandler())
+	}()
+
+	resp, err := http.Get("http://" + listener.Addr().String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
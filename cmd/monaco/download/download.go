@@ -17,8 +17,10 @@ package download
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/url"
 	"path"
+	"slices"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/sort"
@@ -56,17 +59,30 @@ type downloadOptionsShared struct {
 	outputFolder           string
 	projectName            string
 	forceOverwriteManifest bool
+	// stable defines that the downloaded project is written in a canonical form that only changes if objects change
+	stable bool
+	// accounts are the manifest accounts whose account management resources are downloaded along with the configurations
+	accounts map[string]manifest.Account
 }
 
-func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptionsShared, fs afero.Fs) error {
-	proj := download.CreateProjectData(downloadedConfigs, opts.projectName)
+func writeConfigs(downloadedConfigs project.ConfigsPerType, annotations entityids.Annotations, accounts []download.AccountResources, opts downloadOptionsShared, fs afero.Fs) error {
+	return writeProject(download.CreateProjectData(downloadedConfigs, opts.projectName), opts, nil, annotations, accounts, fs)
+}
 
+// writeProject writes the project and a manifest to deploy it. If environments are given, the manifest contains them
+// instead of a single environment named after the project. If annotations are given, they are written next to the
+// project's configurations. If account resources are given, they are written to an additional project.
+func writeProject(proj project.Project, opts downloadOptionsShared, environments manifest.Environments, annotations entityids.Annotations, accounts []download.AccountResources, fs afero.Fs) error {
 	downloadWriterContext := download.WriterContext{
-		EnvironmentUrl: opts.environmentURL,
-		ProjectToWrite: proj,
-		Auth:           opts.auth,
-		OutputFolder:   opts.outputFolder,
-		ForceOverwrite: opts.forceOverwriteManifest,
+		EnvironmentUrl:      opts.environmentURL,
+		ProjectToWrite:      proj,
+		Auth:                opts.auth,
+		Environments:        environments,
+		EntityIDAnnotations: annotations,
+		Accounts:            accounts,
+		OutputFolder:        opts.outputFolder,
+		ForceOverwrite:      opts.forceOverwriteManifest,
+		Stable:              opts.stable,
 	}
 	err := download.WriteToDisk(fs, downloadWriterContext)
 	if err != nil {
@@ -85,7 +101,7 @@ func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptions
 }
 
 func reportForCircularDependencies(p project.Project) error {
-	_, errs := sort.ConfigsPerEnvironment([]project.Project{p}, []string{p.Id})
+	_, errs := sort.ConfigsPerEnvironment([]project.Project{p}, slices.Sorted(maps.Keys(p.Configs)))
 	if len(errs) != 0 {
 		errutils.PrintWarnings(errs)
 		return fmt.Errorf("there are circular dependencies between %d configurations that need to be resolved manually", len(errs))
@@ -0,0 +1,55 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/downloader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// downloadAccounts downloads the account management resources of all given accounts, sorted by account name.
+func downloadAccounts(ctx context.Context, accounts map[string]manifest.Account) ([]download.AccountResources, error) {
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+
+	accountClients, err := dynatrace.CreateAccountClients(ctx, accounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account clients: %w", err)
+	}
+
+	result := make([]download.AccountResources, 0, len(accountClients))
+	for accInfo, accClient := range accountClients {
+		log.Info("Downloading account management resources from account '%v'", accInfo.Name)
+		resources, err := downloader.New(&accInfo, accClient).DownloadResources(context.WithValue(ctx, log.CtxKeyAccount{}, accInfo.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to download resources of account %q: %w", accInfo.Name, err)
+		}
+		result = append(result, download.AccountResources{Account: accounts[accInfo.Name], Resources: *resources})
+	}
+
+	slices.SortFunc(result, func(a, b download.AccountResources) int { return strings.Compare(a.Account.Name, b.Account.Name) })
+	return result, nil
+}
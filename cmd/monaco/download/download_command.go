@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -33,6 +34,7 @@ import (
 	clientAuth "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/auth"
 	versionClient "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
@@ -70,7 +72,10 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 
 	// download via manifest
 	cmd.Flags().StringVarP(&f.manifestFile, "manifest", "m", "manifest.yaml", "Name (and the path) to the manifest file. Defaults to 'manifest.yaml'.")
-	cmd.Flags().StringVarP(&f.specificEnvironmentName, "environment", "e", "", "Specify an environment defined in the manifest to download the configurations.")
+	cmd.Flags().StringSliceVarP(&f.specificEnvironmentNames, "environment", "e", nil, "Specify one or more environments defined in the manifest to download the configurations from. "+
+		"Configurations of several environments are combined into a single project, using overrides for everything that differs between the environments. (Repeat flag or use comma-separated values)")
+	cmd.Flags().StringVar(&f.selector, "selector", "", "Select the environments defined in the manifest to download the configurations from by their labels, e.g. 'tier=dev,region=eu'. "+
+		"If several environments match, their configurations are combined into a single project.")
 	// download without manifest
 	cmd.Flags().StringVar(&f.environmentURL, "url", "", "URL to the Dynatrace environment from which to download the configuration. "+
 		"To be able to connect to any Dynatrace environment, an API-Token needs to be provided using '--token'. "+
@@ -88,12 +93,40 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&f.onlyAutomation, "only-automation", false, "Only download automation objects, skip all other configuration types")
 	cmd.Flags().BoolVar(&f.onlyDocuments, "only-documents", false, "Only download documents, skip all other configuration types")
 	cmd.Flags().BoolVar(&f.onlyBuckets, "only-buckets", false, "Only download buckets, skip all other configuration types")
+	cmd.Flags().StringVar(&f.mergeInto, "merge-into", "", "Merge the downloaded configurations into an existing project folder instead of creating a new project. "+
+		"Templates of existing configurations are updated, while their parameters, overrides and file layout are kept. New objects are added as new configurations.")
+	cmd.Flags().StringVar(&f.filterFile, "filter-file", "", "YAML file defining include and exclude rules per classic API and settings schema. "+
+		"Downloaded objects are matched by name, owner (classic APIs only), scope, modification time or properties of their JSON payload. The rules are applied in addition to the built-in download filters.")
+	cmd.Flags().StringVar(&f.modifiedSince, "modified-since", "", "Only download objects modified after the given point in time, either an RFC 3339 timestamp (e.g. '2025-01-31T22:00:00Z'), a date (e.g. '2025-01-31') or a duration before now (e.g. '24h'). "+
+		"Only settings, automations and documents have a modification time, objects of other types are downloaded regardless.")
+	cmd.Flags().StringSliceVar(&f.extractValues, "extract-values", nil, fmt.Sprintf("Extract environment-specific values found in the downloaded configurations into parameters. "+
+		"Equal values share the same parameter name across the project. Supported detectors are %s, or 'all'. (Repeat flag or use comma-separated values)", strings.Join(value_extraction.BuiltinDetectorNames(), ", ")))
+	cmd.Flags().StringArrayVar(&f.extractPatterns, "extract-pattern", nil, "Extract all values matching a regular expression into parameters, defined as 'name=regex'. "+
+		"If the expression contains a capturing group, only the value of the first group is extracted. (Repeat flag for several patterns)")
+	cmd.Flags().BoolVar(&f.extractAsEnvVars, "extract-as-env-vars", false, "Extract values into environment variable parameters instead of value parameters, e.g. 'EMAIL_1'. "+
+		"The environment variables need to be set to deploy the downloaded project.")
+	cmd.Flags().BoolVar(&f.stable, "stable", false, "Write the downloaded project in a canonical form, so that downloading unchanged objects again does not change any file. "+
+		"JSON templates are formatted with sorted keys and volatile properties like modification times are removed. "+
+		"File names do not contain timestamps: the output folder defaults to 'download', and an existing manifest and project folder are replaced.")
+	cmd.Flags().BoolVar(&f.annotateIDs, "annotate-ids", false, "Look up the type, display name and tags of the monitored entity IDs extracted into parameters and write them to an 'ids.yaml' file in the project folder. "+
+		"Deploying with '--resolve-entity-ids' uses them to find the same entities in the target environment. Requires an API token.")
+	cmd.Flags().BoolVar(&f.includeAccounts, "include-accounts", false, "Additionally download the account management resources of all accounts defined in the manifest. "+
+		"They are written to an 'accounts' project next to the downloaded configurations, and the accounts are added to the written manifest.")
 
 	// combinations
 	cmd.MarkFlagsMutuallyExclusive("settings-schema", "only-apis", "only-settings", "only-automation")
 	cmd.MarkFlagsMutuallyExclusive("api", "only-apis", "only-settings", "only-automation")
 	cmd.MarkFlagsMutuallyExclusive("settings-schema", "only-apis", "only-settings", "only-automation", "only-documents")
 	cmd.MarkFlagsMutuallyExclusive("api", "only-apis", "only-settings", "only-automation", "only-documents")
+	cmd.MarkFlagsMutuallyExclusive("merge-into", "output-folder")
+	cmd.MarkFlagsMutuallyExclusive("merge-into", "project")
+	cmd.MarkFlagsMutuallyExclusive("include-accounts", "merge-into")
+	// merging only updates the templates of existing configurations, so values can not be extracted into parameters
+	cmd.MarkFlagsMutuallyExclusive("merge-into", "extract-values")
+	cmd.MarkFlagsMutuallyExclusive("merge-into", "extract-pattern")
+	cmd.MarkFlagsMutuallyExclusive("merge-into", "extract-as-env-vars")
+	cmd.MarkFlagsMutuallyExclusive("merge-into", "annotate-ids")
+	cmd.MarkFlagsMutuallyExclusive("include-accounts", "url")
 
 	if featureflags.OpenPipeline.Enabled() {
 		cmd.Flags().BoolVar(&f.onlyOpenPipeline, "only-openpipeline", false, "Only download openpipeline configurations, skip all other configuration types")
@@ -107,14 +140,32 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 		cmd.Flags().BoolVar(&f.onlySLOsV2, "only-slo-v2", false, fmt.Sprintf("Only download %s, skip all other configuration types", config.ServiceLevelObjectiveID))
 	}
 
+	if featureflags.AnyDocumentKind.Enabled() {
+		cmd.Flags().StringSliceVar(&f.documentTypes, "document-types", nil, "Download only documents of one or more types, e.g. 'dashboard'. By default, documents of all types are downloaded. (Repeat flag or use comma-separated values)")
+		cmd.Flags().StringSliceVar(&f.excludeDocumentTypes, "exclude-document-types", nil, "Skip documents of one or more types when downloading. (Repeat flag or use comma-separated values)")
+	}
+
+	if featureflags.DocumentSharing.Enabled() {
+		cmd.Flags().BoolVar(&f.includeDocumentOwner, "include-document-owner", false, "Download the owner of documents together with their shares. "+
+			"Deploying the owner transfers each document to that user, so only use this if the user exists on all target environments.")
+	}
+
+	if featureflags.ExtensionsV2.Enabled() {
+		cmd.Flags().BoolVar(&f.onlyExtensionsV2, "only-extensions-v2", false, "Only download Extensions 2.0 extensions and their monitoring configurations, skip all other configuration types")
+	}
+
 	err := errors.Join(
+		cmd.MarkFlagDirname("merge-into"),
+
 		cmd.RegisterFlagCompletionFunc("token", completion.EnvVarName),
 		cmd.RegisterFlagCompletionFunc("oauth-client-id", completion.EnvVarName),
 		cmd.RegisterFlagCompletionFunc("oauth-client-secret", completion.EnvVarName),
 
 		cmd.RegisterFlagCompletionFunc("manifest", completion.YamlFile),
+		cmd.RegisterFlagCompletionFunc("filter-file", completion.YamlFile),
 
 		cmd.RegisterFlagCompletionFunc("api", completion.AllAvailableApis),
+		cmd.RegisterFlagCompletionFunc("extract-values", completion.ValueDetectors),
 	)
 
 	if err != nil {
@@ -128,8 +179,8 @@ func preRunChecks(f downloadCmdOptions) error {
 	switch {
 	case f.environmentURL != "" && f.manifestFile != "manifest.yaml":
 		return errors.New("'url' and 'manifest' are mutually exclusive")
-	case f.environmentURL != "" && f.specificEnvironmentName != "":
-		return errors.New("'environment' is specific to manifest-based download and incompatible with direct download from 'url'")
+	case f.environmentURL != "" && (len(f.specificEnvironmentNames) > 0 || f.selector != ""):
+		return errors.New("'environment' and 'selector' are specific to manifest-based download and incompatible with direct download from 'url'")
 	case f.environmentURL != "":
 		switch {
 		case f.token == "":
@@ -143,8 +194,8 @@ func preRunChecks(f downloadCmdOptions) error {
 		switch {
 		case f.token != "" || f.clientID != "" || f.clientSecret != "":
 			return errors.New("'token', 'oauth-client-id' and 'oauth-client-secret' can only be used with 'url', while 'manifest' must NOT be set ")
-		case f.specificEnvironmentName == "":
-			return errors.New("to download with manifest, 'environment' needs to be specified")
+		case len(f.specificEnvironmentNames) == 0 && f.selector == "":
+			return errors.New("to download with manifest, 'environment' or 'selector' needs to be specified")
 		}
 	}
 
@@ -167,8 +218,8 @@ func setupSharedFlags(cmd *cobra.Command, project, outputFolder *string, forceOv
 // notifying the user that downloaded objects cannot be uploaded to the same environment.
 // It verifies the version of the tenant and, depending on the result, it may or may not display the warning.
 func checkIfAbleToUploadToSameEnvironment(ctx context.Context, env manifest.EnvironmentDefinition) {
-	// ignore server version check if OAuth is provided (can't be below the specified version)
-	if env.Auth.OAuth != nil {
+	// ignore server version check if platform credentials are provided (can't be below the specified version)
+	if env.Auth.HasPlatformAuth() {
 		return
 	}
 
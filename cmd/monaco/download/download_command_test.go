@@ -32,13 +32,20 @@ func TestGetDownloadCommand(t *testing.T) {
 		assert.EqualError(t, err, "'url' and 'manifest' are mutually exclusive")
 	})
 
+	t.Run("merge-into can not be combined with value extraction", func(t *testing.T) {
+		for _, flag := range []string{"--extract-values email", "--extract-pattern team=team-.*", "--extract-as-env-vars", "--annotate-ids"} {
+			err := newMonaco(t).download("--environment env --merge-into project " + flag)
+			assert.ErrorContains(t, err, "none of the others can be")
+		}
+	})
+
 	t.Run("Download via manifest - manifest set explicitly", func(t *testing.T) {
 		m := newMonaco(t)
 
 		expected := downloadCmdOptions{
-			manifestFile:            "path/to/my-manifest.yaml",
-			specificEnvironmentName: "my-environment1",
-			projectName:             "project",
+			manifestFile:             "path/to/my-manifest.yaml",
+			specificEnvironmentNames: []string{"my-environment1"},
+			projectName:              "project",
 		}
 		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
 
@@ -47,13 +54,28 @@ func TestGetDownloadCommand(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Download via manifest - several environments", func(t *testing.T) {
+		m := newMonaco(t)
+
+		expected := downloadCmdOptions{
+			manifestFile:             "manifest.yaml",
+			specificEnvironmentNames: []string{"env1", "env2", "env3"},
+			projectName:              "project",
+		}
+		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
+
+		err := m.download("--environment env1 -e env2,env3")
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("Download via manifest - manifest is not set (will take default value)", func(t *testing.T) {
 		m := newMonaco(t)
 
 		expected := downloadCmdOptions{
-			manifestFile:            "manifest.yaml",
-			specificEnvironmentName: "my-environment",
-			projectName:             "project",
+			manifestFile:             "manifest.yaml",
+			specificEnvironmentNames: []string{"my-environment"},
+			projectName:              "project",
 		}
 		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
 
@@ -64,7 +86,7 @@ func TestGetDownloadCommand(t *testing.T) {
 
 	t.Run("Download via manifest.yaml - environment missing", func(t *testing.T) {
 		err := newMonaco(t).download("")
-		assert.EqualError(t, err, "to download with manifest, 'environment' needs to be specified")
+		assert.EqualError(t, err, "to download with manifest, 'environment' or 'selector' needs to be specified")
 	})
 
 	t.Run("Download w/o manifest.yaml - authorization via token", func(t *testing.T) {
@@ -119,11 +141,11 @@ func TestGetDownloadCommand(t *testing.T) {
 		m := newMonaco(t)
 
 		expected := downloadCmdOptions{
-			manifestFile:            "path/my-manifest.yaml",
-			specificEnvironmentName: "my-environment",
-			projectName:             "my-project",
-			outputFolder:            "path/to/my-folder",
-			forceOverwrite:          true,
+			manifestFile:             "path/my-manifest.yaml",
+			specificEnvironmentNames: []string{"my-environment"},
+			projectName:              "my-project",
+			outputFolder:             "path/to/my-folder",
+			forceOverwrite:           true,
 		}
 		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
 
@@ -136,9 +158,9 @@ func TestGetDownloadCommand(t *testing.T) {
 		m := newMonaco(t)
 
 		expected := downloadCmdOptions{
-			manifestFile:            "manifest.yaml",
-			specificEnvironmentName: "my_environment",
-			projectName:             "project",
+			manifestFile:             "manifest.yaml",
+			specificEnvironmentNames: []string{"my_environment"},
+			projectName:              "project",
 		}
 		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
 
@@ -150,10 +172,10 @@ func TestGetDownloadCommand(t *testing.T) {
 		m := newMonaco(t)
 
 		expected := downloadCmdOptions{
-			manifestFile:            "manifest.yaml",
-			specificEnvironmentName: "myEnvironment",
-			projectName:             "project",
-			specificAPIs:            []string{"test", "test2", "test3", "test4"},
+			manifestFile:             "manifest.yaml",
+			specificEnvironmentNames: []string{"myEnvironment"},
+			projectName:              "project",
+			specificAPIs:             []string{"test", "test2", "test3", "test4"},
 		}
 		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
 
@@ -192,10 +214,10 @@ func TestGetDownloadCommand(t *testing.T) {
 
 	t.Run("Settings schema selection - set of wanted settings schema", func(t *testing.T) {
 		expected := downloadCmdOptions{
-			manifestFile:            "manifest.yaml",
-			specificEnvironmentName: "myEnvironment",
-			projectName:             "project",
-			specificSchemas:         []string{"settings:schema:1", "settings:schema:2", "settings:schema:3", "settings:schema:4"},
+			manifestFile:             "manifest.yaml",
+			specificEnvironmentNames: []string{"myEnvironment"},
+			projectName:              "project",
+			specificSchemas:          []string{"settings:schema:1", "settings:schema:2", "settings:schema:3", "settings:schema:4"},
 		}
 		m := newMonaco(t)
 		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
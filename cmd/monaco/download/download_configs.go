@@ -19,6 +19,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/spf13/afero"
 
@@ -31,16 +34,21 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/canonical"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/extension"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/id_extraction"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/merge"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/openpipeline"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/segment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/slo"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
@@ -52,18 +60,32 @@ type downloadCmdOptions struct {
 	forceOverwrite bool
 	environmentURL string
 	auth
-	manifestFile            string
-	specificEnvironmentName string
-	specificAPIs            []string
-	specificSchemas         []string
-	onlyAPIs                bool
-	onlySettings            bool
-	onlyAutomation          bool
-	onlyDocuments           bool
-	onlyOpenPipeline        bool
-	onlySegments            bool
-	onlySLOsV2              bool
-	onlyBuckets             bool
+	manifestFile             string
+	specificEnvironmentNames []string
+	selector                 string
+	specificAPIs             []string
+	specificSchemas          []string
+	onlyAPIs                 bool
+	onlySettings             bool
+	onlyAutomation           bool
+	onlyDocuments            bool
+	onlyOpenPipeline         bool
+	onlySegments             bool
+	onlySLOsV2               bool
+	onlyBuckets              bool
+	onlyExtensionsV2         bool
+	mergeInto                string
+	filterFile               string
+	modifiedSince            string
+	documentTypes            []string
+	excludeDocumentTypes     []string
+	includeDocumentOwner     bool
+	extractValues            []string
+	extractPatterns          []string
+	extractAsEnvVars         bool
+	annotateIDs              bool
+	stable                   bool
+	includeAccounts          bool
 }
 
 type auth struct {
@@ -107,11 +129,11 @@ func readEnvVariable(envVar string) (manifest.AuthSecret, error) {
 }
 
 func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs afero.Fs, cmdOptions downloadCmdOptions) error {
-
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
 		ManifestPath: cmdOptions.manifestFile,
-		Environments: []string{cmdOptions.specificEnvironmentName},
+		Environments: cmdOptions.specificEnvironmentNames,
+		Selector:     cmdOptions.selector,
 		Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
 	})
 	if len(errs) > 0 {
@@ -119,10 +141,23 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs a
 		return err
 	}
 
-	env, found := m.Environments[cmdOptions.specificEnvironmentName]
-	if !found {
-		return fmt.Errorf("environment %q was not available in manifest %q", cmdOptions.specificEnvironmentName, cmdOptions.manifestFile)
+	envs, err := selectDownloadEnvironments(m, cmdOptions)
+	if err != nil {
+		return err
+	}
+
+	var accounts map[string]manifest.Account
+	if cmdOptions.includeAccounts {
+		if len(m.Accounts) == 0 {
+			return fmt.Errorf("'include-accounts' requires accounts to be defined in manifest %q", cmdOptions.manifestFile)
+		}
+		accounts = m.Accounts
+	}
+
+	if len(envs) > 1 {
+		return downloadEnvironments(ctx, fs, envs, accounts, cmdOptions)
 	}
+	env := envs[0]
 
 	ok := dynatrace.VerifyEnvironmentGeneration(ctx, manifest.Environments{env.Name: env})
 	if !ok {
@@ -131,28 +166,26 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs a
 
 	checkIfAbleToUploadToSameEnvironment(ctx, env)
 
-	if !cmdOptions.forceOverwrite {
-		cmdOptions.projectName = fmt.Sprintf("%s_%s", cmdOptions.projectName, cmdOptions.specificEnvironmentName)
+	if cmdOptions.mergeInto != "" {
+		cmdOptions.projectName = projectNameForFolder(m, cmdOptions.manifestFile, cmdOptions.mergeInto)
+	} else if !cmdOptions.forceOverwrite {
+		cmdOptions.projectName = fmt.Sprintf("%s_%s", cmdOptions.projectName, env.Name)
 	}
 
-	options := downloadConfigsOptions{
-		downloadOptionsShared: downloadOptionsShared{
-			environmentURL:         env.URL,
-			auth:                   env.Auth,
-			outputFolder:           cmdOptions.outputFolder,
-			projectName:            cmdOptions.projectName,
-			forceOverwriteManifest: cmdOptions.forceOverwrite,
-		},
-		specificAPIs:     cmdOptions.specificAPIs,
-		specificSchemas:  cmdOptions.specificSchemas,
-		onlyAPIs:         cmdOptions.onlyAPIs,
-		onlySettings:     cmdOptions.onlySettings,
-		onlyAutomation:   cmdOptions.onlyAutomation,
-		onlyDocuments:    cmdOptions.onlyDocuments,
-		onlyOpenPipeline: cmdOptions.onlyOpenPipeline,
-		onlySegment:      cmdOptions.onlySegments,
-		onlySLOV2:        cmdOptions.onlySLOsV2,
-		onlyBuckets:      cmdOptions.onlyBuckets,
+	options := newDownloadConfigsOptions(cmdOptions, env)
+	options.accounts = accounts
+	if options.filters, err = newDownloadFilters(fs, cmdOptions); err != nil {
+		return err
+	}
+	if options.valueExtraction, err = newValueExtractionOptions(cmdOptions); err != nil {
+		return err
+	}
+	if cmdOptions.mergeInto != "" {
+		options.mergeInto = &merge.Options{
+			ProjectFolder: cmdOptions.mergeInto,
+			ProjectName:   cmdOptions.projectName,
+			Environment:   env,
+		}
 	}
 
 	if errs := options.valid(); len(errs) != 0 {
@@ -160,7 +193,7 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs a
 		return err
 	}
 
-	clientSet, err := client.CreateClientSet(ctx, options.environmentURL.Value, options.auth)
+	clientSet, err := client.CreateClientSetWithOptions(ctx, options.environmentURL.Value, options.auth, client.ClientOptions{Transport: env.Transport, PersistentCachingDisabled: true})
 	if err != nil {
 		return err
 	}
@@ -168,6 +201,60 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs a
 	return doDownloadConfigs(ctx, fs, clientSet, prepareAPIs(api.NewAPIs(), options), options)
 }
 
+// newDownloadConfigsOptions returns the options to download the configurations of the given manifest environment.
+func newDownloadConfigsOptions(cmdOptions downloadCmdOptions, env manifest.EnvironmentDefinition) downloadConfigsOptions {
+	return downloadConfigsOptions{
+		downloadOptionsShared: downloadOptionsShared{
+			environmentURL:         env.URL,
+			auth:                   env.Auth,
+			outputFolder:           cmdOptions.outputFolder,
+			projectName:            cmdOptions.projectName,
+			forceOverwriteManifest: cmdOptions.forceOverwrite,
+			stable:                 cmdOptions.stable,
+		},
+		specificAPIs:         cmdOptions.specificAPIs,
+		specificSchemas:      cmdOptions.specificSchemas,
+		onlyAPIs:             cmdOptions.onlyAPIs,
+		onlySettings:         cmdOptions.onlySettings,
+		onlyAutomation:       cmdOptions.onlyAutomation,
+		onlyDocuments:        cmdOptions.onlyDocuments,
+		onlyOpenPipeline:     cmdOptions.onlyOpenPipeline,
+		onlySegment:          cmdOptions.onlySegments,
+		onlySLOV2:            cmdOptions.onlySLOsV2,
+		onlyBuckets:          cmdOptions.onlyBuckets,
+		onlyExtensionsV2:     cmdOptions.onlyExtensionsV2,
+		annotateIDs:          cmdOptions.annotateIDs,
+		includeDocumentOwner: cmdOptions.includeDocumentOwner,
+	}
+}
+
+// selectDownloadEnvironments returns the environments to download from, sorted by name. They are either the
+// environments specified by name, or all environments matching the label selector.
+func selectDownloadEnvironments(m manifest.Manifest, cmdOptions downloadCmdOptions) ([]manifest.EnvironmentDefinition, error) {
+	var envs []manifest.EnvironmentDefinition
+	if len(cmdOptions.specificEnvironmentNames) > 0 {
+		for _, name := range cmdOptions.specificEnvironmentNames {
+			env, found := m.Environments[name]
+			if !found {
+				return nil, fmt.Errorf("environment %q was not available in manifest %q", name, cmdOptions.manifestFile)
+			}
+			if !slices.ContainsFunc(envs, func(e manifest.EnvironmentDefinition) bool { return e.Name == name }) {
+				envs = append(envs, env)
+			}
+		}
+	} else {
+		if len(m.Environments) == 0 {
+			return nil, fmt.Errorf("selector %q does not match any environment", cmdOptions.selector)
+		}
+		for _, env := range m.Environments {
+			envs = append(envs, env)
+		}
+	}
+
+	slices.SortFunc(envs, func(a, b manifest.EnvironmentDefinition) int { return strings.Compare(a.Name, b.Name) })
+	return envs, nil
+}
+
 func (d DefaultCommand) DownloadConfigs(ctx context.Context, fs afero.Fs, cmdOptions downloadCmdOptions) error {
 	a, errs := cmdOptions.mapToAuth()
 	errs = append(errs, validateParameters(cmdOptions.environmentURL, cmdOptions.projectName)...)
@@ -186,15 +273,34 @@ func (d DefaultCommand) DownloadConfigs(ctx context.Context, fs afero.Fs, cmdOpt
 			outputFolder:           cmdOptions.outputFolder,
 			projectName:            cmdOptions.projectName,
 			forceOverwriteManifest: cmdOptions.forceOverwrite,
+			stable:                 cmdOptions.stable,
 		},
-		specificAPIs:     cmdOptions.specificAPIs,
-		specificSchemas:  cmdOptions.specificSchemas,
-		onlyAPIs:         cmdOptions.onlyAPIs,
-		onlySettings:     cmdOptions.onlySettings,
-		onlyAutomation:   cmdOptions.onlyAutomation,
-		onlyDocuments:    cmdOptions.onlyDocuments,
-		onlyOpenPipeline: cmdOptions.onlyOpenPipeline,
-		onlyBuckets:      cmdOptions.onlyBuckets,
+		specificAPIs:         cmdOptions.specificAPIs,
+		specificSchemas:      cmdOptions.specificSchemas,
+		onlyAPIs:             cmdOptions.onlyAPIs,
+		onlySettings:         cmdOptions.onlySettings,
+		onlyAutomation:       cmdOptions.onlyAutomation,
+		onlyDocuments:        cmdOptions.onlyDocuments,
+		onlyOpenPipeline:     cmdOptions.onlyOpenPipeline,
+		onlyBuckets:          cmdOptions.onlyBuckets,
+		onlyExtensionsV2:     cmdOptions.onlyExtensionsV2,
+		annotateIDs:          cmdOptions.annotateIDs,
+		includeDocumentOwner: cmdOptions.includeDocumentOwner,
+	}
+	var err error
+	if options.filters, err = newDownloadFilters(fs, cmdOptions); err != nil {
+		return err
+	}
+	if options.valueExtraction, err = newValueExtractionOptions(cmdOptions); err != nil {
+		return err
+	}
+	if cmdOptions.mergeInto != "" {
+		options.projectName = filepath.Base(cmdOptions.mergeInto)
+		options.mergeInto = &merge.Options{
+			ProjectFolder: cmdOptions.mergeInto,
+			ProjectName:   options.projectName,
+			Environment:   manifest.EnvironmentDefinition{Name: mergeEnvironmentName, Group: mergeEnvironmentName},
+		}
 	}
 
 	if errs := options.valid(); len(errs) != 0 {
@@ -202,7 +308,7 @@ func (d DefaultCommand) DownloadConfigs(ctx context.Context, fs afero.Fs, cmdOpt
 		return err
 	}
 
-	clientSet, err := client.CreateClientSet(ctx, options.environmentURL.Value, options.auth)
+	clientSet, err := client.CreateClientSetWithOptions(ctx, options.environmentURL.Value, options.auth, client.ClientOptions{PersistentCachingDisabled: true})
 	if err != nil {
 		return err
 	}
@@ -211,7 +317,12 @@ func (d DefaultCommand) DownloadConfigs(ctx context.Context, fs afero.Fs, cmdOpt
 }
 
 func doDownloadConfigs(ctx context.Context, fs afero.Fs, clientSet *client.ClientSet, apisToDownload api.APIs, opts downloadConfigsOptions) error {
-	err := preDownloadValidations(fs, opts.downloadOptionsShared)
+	var err error
+	if opts.mergeInto != nil {
+		err = validateMergeFolder(fs, opts.mergeInto.ProjectFolder)
+	} else {
+		err = preDownloadValidations(fs, opts.downloadOptionsShared)
+	}
 	if err != nil {
 		return err
 	}
@@ -227,18 +338,10 @@ func doDownloadConfigs(ctx context.Context, fs afero.Fs, clientSet *client.Clien
 		return nil
 	}
 
-	for c := range downloadedConfigs.AllConfigs {
-		// We would need quite a huge refactoring to support Classic- and Automation-APIS here.
-		// Automation already also does what we do here, but does set custom {{.variables}} that we can't easily escape here.
-		// To fix this, it might be better do extract the variables at a later place instead of doing it before.
-		if c.Type.ID() == config.ClassicApiTypeID || c.Type.ID() == config.AutomationTypeID {
-			continue
-		}
+	escapeTemplates(downloadedConfigs)
 
-		err := escapeGoTemplating(&c)
-		if err != nil {
-			log.WithFields(field.Coordinate(c.Coordinate), field.Error(err)).Warn("Failed to escape Go templating expressions. Template needs manual adaptation: %s", err)
-		}
+	if opts.mergeInto != nil {
+		return mergeConfigs(ctx, fs, downloadedConfigs, *opts.mergeInto)
 	}
 
 	log.Info("Resolving dependencies between configurations")
@@ -247,6 +350,15 @@ func doDownloadConfigs(ctx context.Context, fs afero.Fs, clientSet *client.Clien
 		return err
 	}
 
+	if len(opts.valueExtraction.Detectors) > 0 {
+		log.Info("Extracting environment-specific values into parameters")
+		// must happen before the ID extraction, as detected values (e.g. URLs) might contain IDs
+		downloadedConfigs, err = value_extraction.ExtractValuesIntoParameters(downloadedConfigs, opts.valueExtraction)
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Info("Extracting additional identifiers into YAML parameters")
 	// must happen after dep-resolution, as it removes IDs from the JSONs in which the dep-resolution searches as well
 	downloadedConfigs, err = id_extraction.ExtractIDsIntoYAML(downloadedConfigs)
@@ -254,7 +366,55 @@ func doDownloadConfigs(ctx context.Context, fs afero.Fs, clientSet *client.Clien
 		return err
 	}
 
-	return writeConfigs(downloadedConfigs, opts.downloadOptionsShared, fs)
+	var annotations entityids.Annotations
+	if opts.annotateIDs {
+		if annotations, err = annotateEntityIDs(ctx, clientSet.EntitiesClient, downloadedConfigs); err != nil {
+			return err
+		}
+	}
+
+	accountResources, err := downloadAccounts(ctx, opts.accounts)
+	if err != nil {
+		return err
+	}
+
+	return writeConfigs(downloadedConfigs, annotations, accountResources, opts.downloadOptionsShared, fs)
+}
+
+// annotateEntityIDs looks up the entities of the IDs extracted into parameters. Entities can only be looked up using an
+// API token, otherwise no annotations are returned.
+func annotateEntityIDs(ctx context.Context, entitiesClient client.EntitiesClient, configs project.ConfigsPerType) (entityids.Annotations, error) {
+	if entitiesClient == nil {
+		log.Warn("Extracted entity IDs can not be annotated without an API token")
+		return nil, nil
+	}
+
+	log.Info("Annotating extracted entity IDs")
+	annotations, err := entityids.Annotate(ctx, entitiesClient, configs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to annotate extracted entity IDs: %w", err)
+	}
+	return annotations, nil
+}
+
+func escapeTemplates(configs project.ConfigsPerType) {
+	for c := range configs.AllConfigs {
+		// We would need quite a huge refactoring to support Classic- and Automation-APIS here.
+		// Automation already also does what we do here, but does set custom {{.variables}} that we can't easily escape here.
+		// To fix this, it might be better do extract the variables at a later place instead of doing it before.
+		if c.Type.ID() == config.ClassicApiTypeID || c.Type.ID() == config.AutomationTypeID {
+			continue
+		}
+		// extension packages are binary archives which are not templated
+		if c.Type.ID() == config.ExtensionV2TypeID {
+			continue
+		}
+
+		err := escapeGoTemplating(&c)
+		if err != nil {
+			log.WithFields(field.Coordinate(c.Coordinate), field.Error(err)).Warn("Failed to escape Go templating expressions. Template needs manual adaptation: %s", err)
+		}
+	}
 }
 
 func escapeGoTemplating(c *config.Config) error {
@@ -278,10 +438,11 @@ type downloadFn struct {
 	settingsDownload     func(context.Context, client.SettingsClient, string, settings.Filters, ...config.SettingsType) (project.ConfigsPerType, error)
 	automationDownload   func(context.Context, client.AutomationClient, string, ...config.AutomationType) (project.ConfigsPerType, error)
 	bucketDownload       func(context.Context, client.BucketClient, string) (project.ConfigsPerType, error)
-	documentDownload     func(context.Context, client.DocumentClient, string) (project.ConfigsPerType, error)
+	documentDownload     func(context.Context, client.DocumentClient, client.DocumentSharingClient, string, document.Options) (project.ConfigsPerType, error)
 	openPipelineDownload func(context.Context, client.OpenPipelineClient, string) (project.ConfigsPerType, error)
 	segmentDownload      func(context.Context, segment.DownloadSegmentClient, string) (project.ConfigsPerType, error)
 	sloDownload          func(context.Context, slo.DownloadSloClient, string) (project.ConfigsPerType, error)
+	extensionDownload    func(context.Context, extension.DownloadExtensionsClient, string) (project.ConfigsPerType, error)
 }
 
 var defaultDownloadFn = downloadFn{
@@ -293,6 +454,7 @@ var defaultDownloadFn = downloadFn{
 	openPipelineDownload: openpipeline.Download,
 	segmentDownload:      segment.Download,
 	sloDownload:          slo.Download,
+	extensionDownload:    extension.Download,
 }
 
 func downloadConfigs(ctx context.Context, clientSet *client.ClientSet, apisToDownload api.APIs, opts downloadConfigsOptions, fn downloadFn) (project.ConfigsPerType, error) {
@@ -318,7 +480,7 @@ func downloadConfigs(ctx context.Context, clientSet *client.ClientSet, apisToDow
 	}
 
 	if shouldDownloadAutomationResources(opts) {
-		if opts.auth.OAuth != nil {
+		if opts.auth.HasPlatformAuth() {
 			log.Info("Downloading automation resources")
 			automationCfgs, err := fn.automationDownload(ctx, clientSet.AutClient, opts.projectName)
 			if err != nil {
@@ -326,11 +488,11 @@ func downloadConfigs(ctx context.Context, clientSet *client.ClientSet, apisToDow
 			}
 			copyConfigs(configs, automationCfgs)
 		} else if opts.onlyAutomation {
-			return nil, errors.New("can't download automation resources: no OAuth credentials configured")
+			return nil, errors.New("can't download automation resources: no OAuth credentials or platform token configured")
 		}
 	}
 
-	if shouldDownloadBuckets(opts) && opts.auth.OAuth != nil {
+	if shouldDownloadBuckets(opts) && opts.auth.HasPlatformAuth() {
 		log.Info("Downloading Grail buckets")
 		bucketCfgs, err := fn.bucketDownload(ctx, clientSet.BucketClient, opts.projectName)
 		if err != nil {
@@ -340,60 +502,88 @@ func downloadConfigs(ctx context.Context, clientSet *client.ClientSet, apisToDow
 	}
 
 	if shouldDownloadDocuments(opts) {
-		if opts.auth.OAuth != nil {
+		if opts.auth.HasPlatformAuth() {
 			log.Info("Downloading documents")
-			documentCfgs, err := fn.documentDownload(ctx, clientSet.DocumentClient, opts.projectName)
+			var sharingClient client.DocumentSharingClient
+			if featureflags.DocumentSharing.Enabled() {
+				sharingClient = clientSet.DocumentSharingClient
+			}
+			documentCfgs, err := fn.documentDownload(ctx, clientSet.DocumentClient, sharingClient, opts.projectName, document.Options{
+				ModifiedSince: opts.filters.modifiedSince,
+				IncludeTypes:  opts.filters.documentTypes,
+				ExcludeTypes:  opts.filters.excludedDocumentTypes,
+				IncludeOwner:  opts.includeDocumentOwner,
+			})
 			if err != nil {
 				return nil, err
 			}
 			copyConfigs(configs, documentCfgs)
 		} else if opts.onlyDocuments {
-			return nil, errors.New("can't download documents: no OAuth credentials configured")
+			return nil, errors.New("can't download documents: no OAuth credentials or platform token configured")
 		}
 	}
 
 	if featureflags.OpenPipeline.Enabled() {
 		if shouldDownloadOpenPipeline(opts) {
-			if opts.auth.OAuth != nil {
+			if opts.auth.HasPlatformAuth() {
 				openPipelineCfgs, err := fn.openPipelineDownload(ctx, clientSet.OpenPipelineClient, opts.projectName)
 				if err != nil {
 					return nil, err
 				}
 				copyConfigs(configs, openPipelineCfgs)
 			} else if opts.onlyOpenPipeline {
-				return nil, errors.New("can't download openpipeline resources: no OAuth credentials configured")
+				return nil, errors.New("can't download openpipeline resources: no OAuth credentials or platform token configured")
 			}
 		}
 	}
 
 	if featureflags.Segments.Enabled() {
 		if shouldDownloadSegments(opts) {
-			if opts.auth.OAuth != nil {
+			if opts.auth.HasPlatformAuth() {
 				segmentCgfs, err := fn.segmentDownload(ctx, clientSet.SegmentClient, opts.projectName)
 				if err != nil {
 					return nil, err
 				}
 				copyConfigs(configs, segmentCgfs)
 			} else if opts.onlySegment {
-				return nil, errors.New("can't download segment resources: no OAuth credentials configured")
+				return nil, errors.New("can't download segment resources: no OAuth credentials or platform token configured")
 			}
 		}
 	}
 
 	if featureflags.ServiceLevelObjective.Enabled() {
 		if shouldDownloadSLOsV2(opts) {
-			if opts.auth.OAuth != nil {
+			if opts.auth.HasPlatformAuth() {
 				sloCgfs, err := fn.sloDownload(ctx, clientSet.ServiceLevelObjectiveClient, opts.projectName)
 				if err != nil {
 					return nil, err
 				}
 				copyConfigs(configs, sloCgfs)
 			} else if opts.onlySLOV2 {
-				return nil, fmt.Errorf("can't download %s resources: no OAuth credentials configured", config.ServiceLevelObjectiveID)
+				return nil, fmt.Errorf("can't download %s resources: no OAuth credentials or platform token configured", config.ServiceLevelObjectiveID)
 			}
 		}
 	}
 
+	if featureflags.ExtensionsV2.Enabled() {
+		if shouldDownloadExtensionsV2(opts) {
+			if opts.auth.Token != nil {
+				log.Info("Downloading Extensions 2.0 extensions")
+				extensionCfgs, err := fn.extensionDownload(ctx, clientSet.ExtensionsClient, opts.projectName)
+				if err != nil {
+					return nil, err
+				}
+				copyConfigs(configs, extensionCfgs)
+			} else if opts.onlyExtensionsV2 {
+				return nil, errors.New("can't download Extensions 2.0 extensions: no API token configured")
+			}
+		}
+	}
+
+	configs = opts.filters.apply(configs)
+	if opts.stable {
+		return canonical.CanonicalizeTemplates(configs)
+	}
 	return configs, nil
 }
 
@@ -420,7 +610,8 @@ func shouldDownloadConfigs(opts downloadConfigsOptions) bool {
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment &&
 		!opts.onlySLOV2 &&
-		!opts.onlyBuckets
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
 }
 
 // shouldDownloadSettings returns true unless onlyAPIs or specificAPIs but no specificSchemas are defined
@@ -432,7 +623,8 @@ func shouldDownloadSettings(opts downloadConfigsOptions) bool {
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment &&
 		!opts.onlySLOV2 &&
-		!opts.onlyBuckets
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
 }
 
 // shouldDownloadAutomationResources returns true unless download is limited to settings or config API types
@@ -443,7 +635,8 @@ func shouldDownloadAutomationResources(opts downloadConfigsOptions) bool {
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment &&
 		!opts.onlySLOV2 &&
-		!opts.onlyBuckets
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
 }
 
 // shouldDownloadBuckets returns true if download is not limited to another specific type
@@ -454,7 +647,8 @@ func shouldDownloadBuckets(opts downloadConfigsOptions) bool {
 		!opts.onlyDocuments &&
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment &&
-		!opts.onlySLOV2
+		!opts.onlySLOV2 &&
+		!opts.onlyExtensionsV2
 }
 
 func shouldDownloadDocuments(opts downloadConfigsOptions) bool {
@@ -464,7 +658,8 @@ func shouldDownloadDocuments(opts downloadConfigsOptions) bool {
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment &&
 		!opts.onlySLOV2 &&
-		!opts.onlyBuckets
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
 }
 
 func shouldDownloadOpenPipeline(opts downloadConfigsOptions) bool {
@@ -474,7 +669,8 @@ func shouldDownloadOpenPipeline(opts downloadConfigsOptions) bool {
 		!opts.onlyDocuments &&
 		!opts.onlySegment &&
 		!opts.onlySLOV2 &&
-		!opts.onlyBuckets
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
 }
 
 func shouldDownloadSegments(opts downloadConfigsOptions) bool {
@@ -484,7 +680,8 @@ func shouldDownloadSegments(opts downloadConfigsOptions) bool {
 		!opts.onlyDocuments &&
 		!opts.onlyOpenPipeline &&
 		!opts.onlySLOV2 &&
-		!opts.onlyBuckets
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
 }
 
 func shouldDownloadSLOsV2(opts downloadConfigsOptions) bool {
@@ -494,5 +691,17 @@ func shouldDownloadSLOsV2(opts downloadConfigsOptions) bool {
 		!opts.onlyDocuments &&
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment &&
+		!opts.onlyBuckets &&
+		!opts.onlyExtensionsV2
+}
+
+func shouldDownloadExtensionsV2(opts downloadConfigsOptions) bool {
+	return !opts.onlySettings && len(opts.specificSchemas) == 0 && // only settings requested
+		!opts.onlyAPIs && len(opts.specificAPIs) == 0 && // only Config APIs requested
+		!opts.onlyAutomation &&
+		!opts.onlyDocuments &&
+		!opts.onlyOpenPipeline &&
+		!opts.onlySegment &&
+		!opts.onlySLOV2 &&
 		!opts.onlyBuckets
 }
@@ -36,9 +36,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/segment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/slo"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
@@ -376,7 +378,7 @@ func TestDownload_Options(t *testing.T) {
 					}
 					return nil, nil
 				},
-				documentDownload: func(ctx context.Context, b client.DocumentClient, s string) (project.ConfigsPerType, error) {
+				documentDownload: func(ctx context.Context, b client.DocumentClient, _ client.DocumentSharingClient, s string, _ document.Options) (project.ConfigsPerType, error) {
 					if !tt.want.document {
 						t.Fatalf("document download was not meant to be called but was")
 					}
@@ -581,7 +583,7 @@ func TestDownloadConfigs_OnlyAutomationWithoutAutomationCredentials(t *testing.T
 	}
 
 	err := doDownloadConfigs(t.Context(), testutils.CreateTestFileSystem(), &client.ClientSet{}, nil, opts)
-	assert.ErrorContains(t, err, "no OAuth credentials configured")
+	assert.ErrorContains(t, err, "no OAuth credentials or platform token configured")
 }
 
 func TestDownloadConfigs_OnlySettings(t *testing.T) {
@@ -613,6 +615,49 @@ func Test_downloadConfigsOptions_valid(t *testing.T) {
 	})
 }
 
+func Test_selectDownloadEnvironments(t *testing.T) {
+	m := manifest.Manifest{
+		Environments: manifest.Environments{
+			"b": {Name: "b", Group: "group"},
+			"a": {Name: "a", Group: "group"},
+		},
+	}
+
+	t.Run("environments by name are sorted", func(t *testing.T) {
+		envs, err := selectDownloadEnvironments(m, downloadCmdOptions{specificEnvironmentNames: []string{"b", "a", "b"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []manifest.EnvironmentDefinition{m.Environments["a"], m.Environments["b"]}, envs)
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		_, err := selectDownloadEnvironments(m, downloadCmdOptions{specificEnvironmentNames: []string{"a", "c"}, manifestFile: "manifest.yaml"})
+
+		assert.EqualError(t, err, `environment "c" was not available in manifest "manifest.yaml"`)
+	})
+
+	t.Run("all environments matching the selector", func(t *testing.T) {
+		envs, err := selectDownloadEnvironments(m, downloadCmdOptions{selector: "tier=dev"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []manifest.EnvironmentDefinition{m.Environments["a"], m.Environments["b"]}, envs)
+	})
+
+	t.Run("selector without matches", func(t *testing.T) {
+		_, err := selectDownloadEnvironments(manifest.Manifest{}, downloadCmdOptions{selector: "tier=dev"})
+
+		assert.EqualError(t, err, `selector "tier=dev" does not match any environment`)
+	})
+}
+
+func Test_downloadEnvironments_MergeIntoRequiresSingleEnvironment(t *testing.T) {
+	envs := []manifest.EnvironmentDefinition{{Name: "a"}, {Name: "b"}}
+
+	err := downloadEnvironments(t.Context(), afero.NewMemMapFs(), envs, nil, downloadCmdOptions{mergeInto: "project"})
+
+	assert.EqualError(t, err, "'merge-into' requires a single environment, but 2 environments were selected")
+}
+
 func Test_copyConfigs(t *testing.T) {
 	t.Run("Copy configs to empty", func(t *testing.T) {
 		dest := project.ConfigsPerType{}
@@ -699,3 +744,23 @@ func Test_copyConfigs(t *testing.T) {
 		})
 	})
 }
+
+func Test_newValueExtractionOptions(t *testing.T) {
+	t.Run("no extraction without flags", func(t *testing.T) {
+		opts, err := newValueExtractionOptions(downloadCmdOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, opts.Detectors)
+	})
+
+	t.Run("all built-in detectors and user patterns", func(t *testing.T) {
+		opts, err := newValueExtractionOptions(downloadCmdOptions{extractValues: []string{"all"}, extractPatterns: []string{"team=team-[a-z]+"}, extractAsEnvVars: true})
+		assert.NoError(t, err)
+		assert.Len(t, opts.Detectors, len(value_extraction.BuiltinDetectorNames())+1)
+		assert.True(t, opts.AsEnvironmentVariables)
+	})
+
+	t.Run("unknown detector", func(t *testing.T) {
+		_, err := newValueExtractionOptions(downloadCmdOptions{extractValues: []string{"unknown"}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/filter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// downloadFilters are the filters requested by the user that are applied to all downloaded configurations, in addition
+// to the built-in download filters.
+type downloadFilters struct {
+	// userFilters are the filters defined in the filter file. If nil, all configurations are kept.
+	userFilters *filter.Filters
+	// modifiedSince restricts the download to objects modified after this time. If zero, all objects are kept.
+	modifiedSince time.Time
+	// documentTypes restricts the download to documents of these types. If empty, documents of all types are kept.
+	documentTypes []string
+	// excludedDocumentTypes are the types of documents that are not downloaded.
+	excludedDocumentTypes []string
+}
+
+// newDownloadFilters returns the filters defined by the '--filter-file', '--modified-since', '--document-types' and
+// '--exclude-document-types' flags.
+func newDownloadFilters(fs afero.Fs, cmdOptions downloadCmdOptions) (downloadFilters, error) {
+	f := downloadFilters{
+		documentTypes:         cmdOptions.documentTypes,
+		excludedDocumentTypes: cmdOptions.excludeDocumentTypes,
+	}
+	if cmdOptions.filterFile != "" {
+		userFilters, err := filter.Load(fs, cmdOptions.filterFile)
+		if err != nil {
+			return downloadFilters{}, err
+		}
+		f.userFilters = userFilters
+	}
+
+	if cmdOptions.modifiedSince != "" {
+		if onlyTypesWithoutModificationTime(cmdOptions) {
+			return downloadFilters{}, fmt.Errorf("'modified-since' can not be used to download only %s, as their modification time is not available", typesWithoutModificationTime)
+		}
+		since, err := parseModifiedSince(cmdOptions.modifiedSince, time.Now())
+		if err != nil {
+			return downloadFilters{}, err
+		}
+		f.modifiedSince = since
+		log.Warn("'modified-since' only applies to settings, automations and documents - all objects of %s are downloaded regardless of their modification time", typesWithoutModificationTime)
+	}
+	return f, nil
+}
+
+// typesWithoutModificationTime describes the configuration types whose objects have no modification time.
+const typesWithoutModificationTime = "classic APIs, SLOs, segments, buckets, OpenPipeline configurations and Extensions 2.0 extensions"
+
+// onlyTypesWithoutModificationTime returns whether the download is restricted to types without modification time, for
+// which filtering by modification time has no effect.
+func onlyTypesWithoutModificationTime(cmdOptions downloadCmdOptions) bool {
+	return cmdOptions.onlyAPIs ||
+		(len(cmdOptions.specificAPIs) > 0 && len(cmdOptions.specificSchemas) == 0) ||
+		cmdOptions.onlySegments ||
+		cmdOptions.onlySLOsV2 ||
+		cmdOptions.onlyBuckets ||
+		cmdOptions.onlyOpenPipeline ||
+		cmdOptions.onlyExtensionsV2
+}
+
+// parseModifiedSince parses the value of the '--modified-since' flag. It is either an RFC 3339 timestamp, a date, or a
+// duration relative to now.
+func parseModifiedSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid value %q for 'modified-since': expected an RFC 3339 timestamp (e.g. '2025-01-31T22:00:00Z'), a date (e.g. '2025-01-31') or a positive duration (e.g. '24h')", s)
+}
+
+// apply returns the configurations that are kept by the filters.
+func (f downloadFilters) apply(configs project.ConfigsPerType) project.ConfigsPerType {
+	configs = f.userFilters.Apply(configs)
+	if f.modifiedSince.IsZero() {
+		return configs
+	}
+
+	log.Info("Keeping only objects modified since %s", f.modifiedSince.Format(time.RFC3339))
+	notFiltered := make(map[string]struct{})
+	result := filter.Select(configs, func(c config.Config) (bool, string) {
+		switch {
+		case c.Type.ID() == config.DocumentTypeID:
+			// documents are already filtered by their modification time when listing them
+		case c.OriginModified.IsZero():
+			notFiltered[c.Coordinate.Type] = struct{}{}
+		case !c.OriginModified.After(f.modifiedSince):
+			return false, fmt.Sprintf("not modified since %s", f.modifiedSince.Format(time.RFC3339))
+		}
+		return true, ""
+	})
+
+	if len(notFiltered) > 0 {
+		log.Warn("The modification time is not available for objects of the following types, all of their objects were downloaded: %s", strings.Join(slices.Sorted(maps.Keys(notFiltered)), ", "))
+	}
+	return result
+}
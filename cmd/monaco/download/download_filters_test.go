@@ -0,0 +1,163 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func Test_newDownloadFilters(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "filters.yaml", []byte("apis:\n  dashboard:\n    include:\n      - name: \"^team-\"\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "invalid.yaml", []byte("apis:\n  dashboard:\n    include:\n      - name: \"(\"\n"), 0644))
+
+	t.Run("no filters without flags", func(t *testing.T) {
+		f, err := newDownloadFilters(fs, downloadCmdOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, f.userFilters)
+		assert.True(t, f.modifiedSince.IsZero())
+	})
+
+	t.Run("filter file and modified since", func(t *testing.T) {
+		f, err := newDownloadFilters(fs, downloadCmdOptions{filterFile: "filters.yaml", modifiedSince: "2025-01-31"})
+		require.NoError(t, err)
+		assert.NotNil(t, f.userFilters)
+		assert.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), f.modifiedSince)
+	})
+
+	t.Run("invalid filter file", func(t *testing.T) {
+		_, err := newDownloadFilters(fs, downloadCmdOptions{filterFile: "invalid.yaml"})
+		assert.Error(t, err)
+	})
+
+	t.Run("modified since for types without modification time", func(t *testing.T) {
+		for _, opts := range []downloadCmdOptions{
+			{modifiedSince: "24h", onlyAPIs: true},
+			{modifiedSince: "24h", specificAPIs: []string{api.Dashboard}},
+			{modifiedSince: "24h", onlySegments: true},
+			{modifiedSince: "24h", onlySLOsV2: true},
+		} {
+			_, err := newDownloadFilters(fs, opts)
+			assert.ErrorContains(t, err, "modification time is not available")
+		}
+
+		_, err := newDownloadFilters(fs, downloadCmdOptions{modifiedSince: "24h", specificAPIs: []string{api.Dashboard}, specificSchemas: []string{"builtin:alerting.profile"}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid modified since", func(t *testing.T) {
+		_, err := newDownloadFilters(fs, downloadCmdOptions{modifiedSince: "yesterday"})
+		assert.Error(t, err)
+	})
+}
+
+func Test_parseModifiedSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		given   string
+		want    time.Time
+		wantErr bool
+	}{
+		{given: "2025-03-01T08:30:00Z", want: time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{given: "2025-03-01", want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{given: "36h", want: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{given: "-1h", wantErr: true},
+		{given: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			got, err := parseModifiedSince(tt.given, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "expected %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func Test_downloadFilters_apply(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	newConfig := func(typ config.Type, id string, modified time.Time) config.Config {
+		return config.Config{
+			Template:       template.NewInMemoryTemplate(id, "{}"),
+			Coordinate:     coordinate.Coordinate{Project: "project", Type: string(typ.ID()), ConfigId: id},
+			Type:           typ,
+			Parameters:     config.Parameters{config.NameParameter: value.New(id)},
+			OriginModified: modified,
+		}
+	}
+	settingsType := config.SettingsType{SchemaId: "builtin:alerting.profile"}
+
+	configs := project.ConfigsPerType{
+		"builtin:alerting.profile": {
+			newConfig(settingsType, "changed", since.Add(time.Hour)),
+			newConfig(settingsType, "unchanged", since.Add(-time.Hour)),
+		},
+		api.Dashboard: {newConfig(config.ClassicApiType{Api: api.Dashboard}, "dashboard", time.Time{})},
+		"document":    {newConfig(config.DocumentType{Kind: config.DashboardKind}, "document", time.Time{})},
+	}
+
+	result := downloadFilters{modifiedSince: since}.apply(configs)
+
+	require.Len(t, result["builtin:alerting.profile"], 1)
+	assert.Equal(t, "changed", result["builtin:alerting.profile"][0].Coordinate.ConfigId)
+	assert.Len(t, result[api.Dashboard], 1, "objects without modification time are kept")
+	assert.Len(t, result["document"], 1, "documents are filtered when listing them")
+}
+
+func Test_downloadFilters_apply_InsertAfterIsRelinked(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	newSetting := func(id string, modified time.Time, insertAfter *config.Config) config.Config {
+		c := config.Config{
+			Template:       template.NewInMemoryTemplate(id, "{}"),
+			Coordinate:     coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: id},
+			Type:           config.SettingsType{SchemaId: "builtin:alerting.profile"},
+			Parameters:     config.Parameters{config.ScopeParameter: value.New("environment")},
+			OriginModified: modified,
+		}
+		if insertAfter != nil {
+			c.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(insertAfter.Coordinate, "id")
+		}
+		return c
+	}
+
+	first := newSetting("first", since.Add(time.Hour), nil)
+	unchanged := newSetting("unchanged", since.Add(-time.Hour), &first)
+	last := newSetting("last", since.Add(time.Hour), &unchanged)
+
+	result := downloadFilters{modifiedSince: since}.apply(project.ConfigsPerType{"builtin:alerting.profile": {first, unchanged, last}})
+
+	require.Len(t, result["builtin:alerting.profile"], 2)
+	assert.Equal(t, reference.NewWithCoordinate(first.Coordinate, "id"), result["builtin:alerting.profile"][1].Parameters[config.InsertAfterParameter])
+}
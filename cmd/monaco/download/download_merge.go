@@ -0,0 +1,81 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/merge"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// mergeEnvironmentName is used to load the project to merge into when downloading without a manifest.
+const mergeEnvironmentName = "default"
+
+// projectNameForFolder returns the name of the manifest project stored in the given folder. If the folder does not
+// belong to any project of the manifest, the name of the folder is used.
+func projectNameForFolder(m manifest.Manifest, manifestPath, folder string) string {
+	target, err := filepath.Abs(folder)
+	if err != nil {
+		return filepath.Base(folder)
+	}
+
+	for _, p := range m.Projects {
+		path, err := filepath.Abs(filepath.Join(filepath.Dir(manifestPath), p.Path))
+		if err == nil && path == target {
+			return p.Name
+		}
+	}
+	return filepath.Base(folder)
+}
+
+func validateMergeFolder(fs afero.Fs, folder string) error {
+	isDir, err := afero.IsDir(fs, folder)
+	if err != nil {
+		return fmt.Errorf("project folder %q to merge into can not be read: %w", folder, err)
+	}
+	if !isDir {
+		return fmt.Errorf("project folder %q to merge into is not a directory", folder)
+	}
+	return nil
+}
+
+func mergeConfigs(ctx context.Context, fs afero.Fs, downloadedConfigs project.ConfigsPerType, opts merge.Options) error {
+	log.Info("Merging downloaded configurations into project %q", opts.ProjectFolder)
+	result, err := merge.Merge(ctx, fs, opts, downloadedConfigs)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Merged configurations: %d updated, %d unchanged, %d added, %d disappeared", len(result.Updated), len(result.Unchanged), len(result.Added), len(result.Disappeared))
+	for _, c := range result.Disappeared {
+		log.WithFields(field.Coordinate(c)).Warn("Configuration %q was not found in the environment - it was deleted or excluded from this download", c)
+	}
+	for _, issue := range result.Issues {
+		log.WithFields(field.Coordinate(issue.Coordinate)).Warn("%s", issue)
+	}
+
+	log.Info("Finished download")
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/id_extraction"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/multienv"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// downloadEnvironments downloads the configurations of several manifest environments into a single project. The same
+// object downloaded from different environments is written as a single configuration with overrides for the
+// environments it differs in. The written manifest contains all downloaded environments, and the given accounts if
+// their account management resources are downloaded as well.
+func downloadEnvironments(ctx context.Context, fs afero.Fs, envs []manifest.EnvironmentDefinition, accounts map[string]manifest.Account, cmdOptions downloadCmdOptions) error {
+	if cmdOptions.mergeInto != "" {
+		return fmt.Errorf("'merge-into' requires a single environment, but %d environments were selected", len(envs))
+	}
+
+	environments := make(manifest.Environments, len(envs))
+	for _, env := range envs {
+		environments[env.Name] = env
+	}
+
+	if ok := dynatrace.VerifyEnvironmentGeneration(ctx, environments); !ok {
+		return fmt.Errorf("unable to verify Dynatrace environment generation")
+	}
+
+	for _, env := range envs {
+		checkIfAbleToUploadToSameEnvironment(ctx, env)
+	}
+
+	shared := downloadOptionsShared{
+		outputFolder:           cmdOptions.outputFolder,
+		projectName:            cmdOptions.projectName,
+		forceOverwriteManifest: cmdOptions.forceOverwrite,
+		stable:                 cmdOptions.stable,
+		accounts:               accounts,
+	}
+	if err := preDownloadValidations(fs, shared); err != nil {
+		return err
+	}
+
+	filters, err := newDownloadFilters(fs, cmdOptions)
+	if err != nil {
+		return err
+	}
+	valueExtraction, err := newValueExtractionOptions(cmdOptions)
+	if err != nil {
+		return err
+	}
+
+	downloaded := make([]multienv.Environment, 0, len(envs))
+	settingsClients := make([]client.SettingsClient, 0, len(envs))
+	entitiesClients := make(map[string]client.EntitiesClient, len(envs))
+	for _, env := range envs {
+		options := newDownloadConfigsOptions(cmdOptions, env)
+		options.filters = filters
+		if errs := options.valid(); len(errs) != 0 {
+			return printAndFormatErrors(errs, "command options are not valid")
+		}
+
+		clientSet, err := client.CreateClientSetWithOptions(ctx, options.environmentURL.Value, options.auth, client.ClientOptions{Transport: env.Transport, PersistentCachingDisabled: true})
+		if err != nil {
+			return err
+		}
+
+		log.Info("Downloading from environment '%v' into project '%v'", env.Name, cmdOptions.projectName)
+		configs, err := downloadConfigs(ctx, clientSet, prepareAPIs(api.NewAPIs(), options), options, defaultDownloadFn)
+		if err != nil {
+			return err
+		}
+		escapeTemplates(configs)
+
+		downloaded = append(downloaded, multienv.Environment{Name: env.Name, Group: env.Group, Configs: configs})
+		settingsClients = append(settingsClients, clientSet.SettingsClient)
+		entitiesClients[env.Name] = clientSet.EntitiesClient
+	}
+
+	if !containsConfigs(downloaded) {
+		log.Info("No configurations downloaded. No project will be created.")
+		return nil
+	}
+
+	combined, err := combineEnvironments(downloaded, uniqueSettingsProperties(ctx, settingsClients), valueExtraction)
+	if err != nil {
+		return err
+	}
+
+	var annotations entityids.Annotations
+	if cmdOptions.annotateIDs {
+		annotations = make(entityids.Annotations)
+		for _, env := range envs {
+			a, err := annotateEntityIDs(ctx, entitiesClients[env.Name], combined[env.Name])
+			if err != nil {
+				return err
+			}
+			annotations.Merge(a)
+		}
+	}
+
+	accountResources, err := downloadAccounts(ctx, shared.accounts)
+	if err != nil {
+		return err
+	}
+
+	return writeProject(project.Project{Id: cmdOptions.projectName, Configs: combined}, shared, environments, annotations, accountResources, fs)
+}
+
+// combineEnvironments matches the downloaded configurations of all environments, resolves their dependencies and
+// returns them combined per environment.
+func combineEnvironments(downloaded []multienv.Environment, uniqueProperties multienv.UniquePropertiesFunc, valueExtraction value_extraction.Options) (project.ConfigsPerTypePerEnvironments, error) {
+	log.Info("Matching configurations of %d environments", len(downloaded))
+	multienv.Match(downloaded, uniqueProperties)
+
+	for i, env := range downloaded {
+		log.WithFields(field.Environment(env.Name, env.Group)).Info("Resolving dependencies between configurations")
+		configs, err := dependency_resolution.ResolveDependencies(env.Configs)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(valueExtraction.Detectors) > 0 {
+			log.WithFields(field.Environment(env.Name, env.Group)).Info("Extracting environment-specific values into parameters")
+			configs, err = value_extraction.ExtractValuesIntoParameters(configs, valueExtraction)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		log.WithFields(field.Environment(env.Name, env.Group)).Info("Extracting additional identifiers into YAML parameters")
+		// must happen after dep-resolution, as it removes IDs from the JSONs in which the dep-resolution searches as well
+		configs, err = id_extraction.ExtractIDsIntoYAML(configs)
+		if err != nil {
+			return nil, err
+		}
+
+		// extracted IDs are enumerated, so that templates only differing in IDs can be shared between environments
+		configs, err = id_extraction.EnumerateExtractedIDs(configs)
+		if err != nil {
+			return nil, err
+		}
+		downloaded[i].Configs = configs
+	}
+
+	return multienv.Combine(downloaded)
+}
+
+func containsConfigs(envs []multienv.Environment) bool {
+	for _, env := range envs {
+		if len(env.Configs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueSettingsProperties returns the unique properties of settings schemas, as defined by the first environment the
+// schema can be fetched from.
+func uniqueSettingsProperties(ctx context.Context, settingsClients []client.SettingsClient) multienv.UniquePropertiesFunc {
+	cache := make(map[string][][]string)
+	return func(schemaId string) [][]string {
+		if properties, found := cache[schemaId]; found {
+			return properties
+		}
+
+		var properties [][]string
+		for _, c := range settingsClients {
+			schema, err := c.GetSchema(ctx, schemaId)
+			if err != nil {
+				log.WithFields(field.Error(err)).Debug("Failed to fetch schema %q: %v", schemaId, err)
+				continue
+			}
+			properties = schema.UniqueProperties
+			break
+		}
+
+		cache[schemaId] = properties
+		return properties
+	}
+}
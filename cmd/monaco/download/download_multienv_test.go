@@ -0,0 +1,122 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/multienv"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func TestCombineEnvironments_WrittenProjectLoadsPerEnvironment(t *testing.T) {
+	dashboard := func(id, hostId string) config.Config {
+		return config.Config{
+			Template:   template.NewInMemoryTemplate(id, `{"dashboardMetadata": {"name": "my-dashboard"}, "host": "`+hostId+`"}`),
+			Coordinate: coordinate.Coordinate{Project: "project", Type: api.Dashboard, ConfigId: id},
+			Type:       config.ClassicApiType{Api: api.Dashboard},
+			Parameters: config.Parameters{config.NameParameter: value.New("my-dashboard")},
+		}
+	}
+	profile := func(id, name string) config.Config {
+		return config.Config{
+			Template:   template.NewInMemoryTemplate(id, `{"name": "`+name+`"}`),
+			Coordinate: coordinate.Coordinate{Project: "project", Type: api.AlertingProfile, ConfigId: id},
+			Type:       config.ClassicApiType{Api: api.AlertingProfile},
+			Parameters: config.Parameters{config.NameParameter: value.New(name)},
+		}
+	}
+
+	downloaded := []multienv.Environment{
+		{Name: "dev", Group: "dev", Configs: project.ConfigsPerType{
+			api.Dashboard:       {dashboard("dev-dashboard", "HOST-1111111111111111")},
+			api.AlertingProfile: {profile("dev-profile", "profile")},
+		}},
+		{Name: "prod", Group: "prod", Configs: project.ConfigsPerType{
+			api.Dashboard: {dashboard("prod-dashboard", "HOST-2222222222222222")},
+		}},
+	}
+
+	combined, err := combineEnvironments(downloaded, nil, value_extraction.Options{})
+	require.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	environments := manifest.Environments{
+		"dev":  {Name: "dev", Group: "dev", URL: manifest.URLDefinition{Type: manifest.ValueURLType, Value: "https://dev.url"}, Auth: manifest.Auth{Token: &manifest.AuthSecret{Name: "TOKEN"}}},
+		"prod": {Name: "prod", Group: "prod", URL: manifest.URLDefinition{Type: manifest.ValueURLType, Value: "https://prod.url"}, Auth: manifest.Auth{Token: &manifest.AuthSecret{Name: "TOKEN"}}},
+	}
+	err = writeProject(project.Project{Id: "project", Configs: combined}, downloadOptionsShared{outputFolder: "out", projectName: "project"}, environments, nil, nil, fs)
+	require.NoError(t, err)
+
+	dashboardTemplates, err := afero.Glob(fs, filepath.Join("out", "project", api.Dashboard, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, dashboardTemplates, 1, "dashboards only differing in extracted IDs share a template")
+
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: filepath.Join("out", "manifest.yaml"),
+		Opts:         manifestloader.Options{DoNotResolveEnvVars: true, RequireEnvironmentGroups: true},
+	})
+	require.Empty(t, errs)
+
+	projects, errs := project.LoadProjects(t.Context(), fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      "out",
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, nil)
+	require.Empty(t, errs)
+	require.Len(t, projects, 1)
+
+	devDashboards := projects[0].Configs["dev"][api.Dashboard]
+	prodDashboards := projects[0].Configs["prod"][api.Dashboard]
+	require.Len(t, devDashboards, 1)
+	require.Len(t, prodDashboards, 1)
+	assert.Equal(t, devDashboards[0].Coordinate, prodDashboards[0].Coordinate)
+	assert.Empty(t, devDashboards[0].OriginObjectId)
+	assert.Equal(t, "prod-dashboard", prodDashboards[0].OriginObjectId)
+	assert.Equal(t, map[any]any{"id_1": "HOST-1111111111111111"}, extractedIDs(t, devDashboards[0]))
+	assert.Equal(t, map[any]any{"id_1": "HOST-2222222222222222"}, extractedIDs(t, prodDashboards[0]))
+
+	require.Len(t, projects[0].Configs["dev"][api.AlertingProfile], 1)
+	require.Len(t, projects[0].Configs["prod"][api.AlertingProfile], 1)
+	assert.False(t, projects[0].Configs["dev"][api.AlertingProfile][0].Skip)
+	assert.True(t, projects[0].Configs["prod"][api.AlertingProfile][0].Skip, "objects missing in an environment are skipped")
+}
+
+func extractedIDs(t *testing.T, c config.Config) any {
+	t.Helper()
+	v, err := c.Parameters["extractedIDs"].ResolveValue(parameter.ResolveContext{})
+	require.NoError(t, err)
+	return v
+}
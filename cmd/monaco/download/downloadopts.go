@@ -18,12 +18,18 @@ package download
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/merge"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/value_extraction"
 )
 
+// allValueDetectors can be passed to '--extract-values' to enable all built-in value detectors
+const allValueDetectors = "all"
+
 type downloadConfigsOptions struct {
 	downloadOptionsShared
 	specificAPIs     []string
@@ -36,6 +42,17 @@ type downloadConfigsOptions struct {
 	onlySegment      bool
 	onlySLOV2        bool
 	onlyBuckets      bool
+	onlyExtensionsV2 bool
+	// mergeInto defines the existing project downloaded configurations are merged into. If nil, a new project is created.
+	mergeInto *merge.Options
+	// filters are applied to the downloaded configurations
+	filters downloadFilters
+	// valueExtraction defines the environment-specific values that are extracted into parameters
+	valueExtraction value_extraction.Options
+	// annotateIDs defines that the extracted entity IDs are annotated with their display names in a sidecar file
+	annotateIDs bool
+	// includeDocumentOwner defines that the owner of documents is downloaded together with their shares
+	includeDocumentOwner bool
 }
 
 func (opts downloadConfigsOptions) valid() []error {
@@ -63,6 +80,8 @@ func prepareAPIs(apis api.APIs, opts downloadConfigsOptions) api.APIs {
 		return nil
 	case opts.onlySegment:
 		return nil
+	case opts.onlyExtensionsV2:
+		return nil
 	case opts.onlyAPIs:
 		return apis.Filter(removeSkipDownload, removeDeprecated(withWarn()))
 	case len(opts.specificAPIs) > 0:
@@ -116,3 +135,18 @@ func warnDeprecated() api.Filter {
 		return false
 	}
 }
+
+// newValueExtractionOptions returns the value extraction defined by the '--extract-values', '--extract-pattern' and
+// '--extract-as-env-vars' flags.
+func newValueExtractionOptions(cmdOptions downloadCmdOptions) (value_extraction.Options, error) {
+	builtins := cmdOptions.extractValues
+	if slices.Contains(builtins, allValueDetectors) {
+		builtins = value_extraction.BuiltinDetectorNames()
+	}
+
+	detectors, err := value_extraction.NewDetectors(builtins, cmdOptions.extractPatterns)
+	if err != nil {
+		return value_extraction.Options{}, fmt.Errorf("invalid value extraction: %w", err)
+	}
+	return value_extraction.Options{Detectors: detectors, AsEnvironmentVariables: cmdOptions.extractAsEnvVars}, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
@@ -50,6 +51,11 @@ func VerifyEnvironmentGeneration(ctx context.Context, envs manifest.Environments
 	if !featureflags.VerifyEnvironmentType.Enabled() {
 		return true
 	}
+	if _, ok := trafficlogs.ReplayTransportFromContext(ctx); ok {
+		// the requests verifying environments are not part of recordings
+		log.Debug("Skipping verification of environments while replaying recorded traffic")
+		return true
+	}
 	for _, env := range envs {
 		if !isValidEnvironment(ctx, env) {
 			return false
@@ -59,33 +65,40 @@ func VerifyEnvironmentGeneration(ctx context.Context, envs manifest.Environments
 }
 
 func isValidEnvironment(ctx context.Context, env manifest.EnvironmentDefinition) bool {
-	if env.Auth.Token == nil && env.Auth.OAuth == nil {
-		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, errors.New("no token or oAuth credentials provided in the manifest"), "", nil)
-		log.Error("No token or oAuth credentials provided in the manifest")
+	if env.Auth.Token == nil && !env.Auth.HasPlatformAuth() {
+		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, errors.New("no token, oAuth credentials or platform token provided in the manifest"), "", nil)
+		log.Error("No token, oAuth credentials or platform token provided in the manifest")
 		return false
 	}
 
-	if env.Auth.OAuth == nil {
-		return isClassicEnvironment(ctx, env)
+	var transport http.RoundTripper
+	if env.Transport != nil {
+		t, err := client.NewHTTPTransport(*env.Transport)
+		if err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, fmt.Errorf("could not apply transport settings of environment %q: %w", env.Name, err), "", nil)
+			log.Error("Could not apply transport settings of environment %q: %v", env.Name, err)
+			return false
+		}
+		transport = t
+		ctx = client.ContextWithTransport(ctx, transport)
 	}
 
-	return isPlatformEnvironment(ctx, env)
+	if !env.Auth.HasPlatformAuth() {
+		return isClassicEnvironment(ctx, env, transport)
+	}
+
+	return isPlatformEnvironment(ctx, env, transport)
 }
 
-func isClassicEnvironment(ctx context.Context, env manifest.EnvironmentDefinition) bool {
-	client, err := clients.Factory().
-		WithClassicURL(env.URL.Value).
-		WithAccessToken(env.Auth.Token.Value.Value()).
-		WithRateLimiter(true).
-		WithRetryOptions(&client.DefaultRetryOptions).
-		CreateClassicClient()
+func isClassicEnvironment(ctx context.Context, env manifest.EnvironmentDefinition, transport http.RoundTripper) bool {
+	restClient, err := createClassicClient(ctx, env, transport)
 	if err != nil {
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, fmt.Errorf("could not create client %q (%s): %w", env.Name, env.URL.Value, err), "", nil)
 		log.Error("Could not create client %q (%s): %v", env.Name, env.URL.Value, err)
 		return false
 	}
 
-	if _, err := version.GetDynatraceVersion(ctx, client); err != nil {
+	if _, err := version.GetDynatraceVersion(ctx, restClient); err != nil {
 		handleAuthError(ctx, env, err)
 		log.Error("Please verify that this environment is a Dynatrace Classic environment.")
 		return false
@@ -93,14 +106,22 @@ func isClassicEnvironment(ctx context.Context, env manifest.EnvironmentDefinitio
 	return true
 }
 
-func isPlatformEnvironment(ctx context.Context, env manifest.EnvironmentDefinition) bool {
-	oauthCreds := clientcredentials.Config{
-		ClientID:     env.Auth.OAuth.ClientID.Value.Value(),
-		ClientSecret: env.Auth.OAuth.ClientSecret.Value.Value(),
-		TokenURL:     env.Auth.OAuth.GetTokenEndpointValue(),
+// createClassicClient creates a client for the classic APIs of the environment. If a transport is given, all requests are sent using it.
+func createClassicClient(ctx context.Context, env manifest.EnvironmentDefinition, transport http.RoundTripper) (*corerest.Client, error) {
+	if transport == nil {
+		return clients.Factory().
+			WithClassicURL(env.URL.Value).
+			WithAccessToken(env.Auth.Token.Value.Value()).
+			WithRateLimiter(true).
+			WithRetryOptions(&client.DefaultRetryOptions).
+			CreateClassicClient()
 	}
 
-	if _, err := getDynatraceClassicURL(ctx, env.URL.Value, oauthCreds); err != nil {
+	return client.NewClassicRestClient(ctx, env.URL.Value, env.Auth.Token.Value.Value(), transport)
+}
+
+func isPlatformEnvironment(ctx context.Context, env manifest.EnvironmentDefinition, transport http.RoundTripper) bool {
+	if _, err := getDynatraceClassicURL(ctx, env, transport); err != nil {
 		handleAuthError(ctx, env, err)
 		log.Error("Please verify that this environment is a Dynatrace Platform environment.")
 		return false
@@ -130,6 +151,18 @@ func CreateAccountClients(ctx context.Context, manifestAccounts map[string]manif
 			TokenURL:     acc.OAuth.GetTokenEndpointValue(),
 		}
 
+		accCtx := ctx
+		if acc.Transport != nil {
+			transport, err := client.NewHTTPTransport(*acc.Transport)
+			if err != nil {
+				return accClients, fmt.Errorf("failed to apply transport settings of account %q: %w", acc.Name, err)
+			}
+			accCtx = client.ContextWithTransport(ctx, transport)
+		}
+		if replay, ok := trafficlogs.ReplayTransportFromContext(ctx); ok {
+			accCtx = client.ContextWithTransport(ctx, replay)
+		}
+
 		factory := clients.Factory().
 			WithConcurrentRequestLimit(concurrentRequestLimit).
 			WithOAuthCredentials(oauthCreds).
@@ -142,7 +175,7 @@ func CreateAccountClients(ctx context.Context, manifestAccounts map[string]manif
 			factory = factory.WithHTTPListener(&corerest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles})
 		}
 
-		accClient, err := factory.AccountClient(ctx)
+		accClient, err := factory.AccountClient(accCtx)
 		if err != nil {
 			return accClients, err
 		}
@@ -155,6 +188,20 @@ func CreateAccountClients(ctx context.Context, manifestAccounts map[string]manif
 	return accClients, nil
 }
 
+// CreateAccountGroupsClient gives back a client looking up the groups of all given accounts
+func CreateAccountGroupsClient(ctx context.Context, manifestAccounts map[string]manifest.Account) (client.AccountGroupsClient, error) {
+	accClients, err := CreateAccountClients(ctx, manifestAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	byUUID := make(map[string]*accounts.Client, len(accClients))
+	for info, accClient := range accClients {
+		byUUID[info.AccountUUID] = accClient
+	}
+	return client.NewAccountGroupsClient(byUUID), nil
+}
+
 // accountApiUrlOrDefault returns the API URL if available or the default.
 func accountApiUrlOrDefault(apiUrl *manifest.URLDefinition) string {
 	if apiUrl == nil || apiUrl.Value == "" {
@@ -183,6 +230,15 @@ func (e EnvironmentClients) Names() []string {
 	return n
 }
 
+// AccessTokensClients gives back the access tokens clients of all environments, by environment name
+func (e EnvironmentClients) AccessTokensClients() map[string]client.AccessTokensClient {
+	c := make(map[string]client.AccessTokensClient, len(e))
+	for k, clientSet := range e {
+		c[k.Name] = clientSet.AccessTokensClient
+	}
+	return c
+}
+
 // CreateEnvironmentClients gives back clients to use for specific environments
 func CreateEnvironmentClients(ctx context.Context, environments manifest.Environments, dryRun bool) (EnvironmentClients, error) {
 	clients := make(EnvironmentClients, len(environments))
@@ -191,11 +247,11 @@ func CreateEnvironmentClients(ctx context.Context, environments manifest.Environ
 			clients[EnvironmentInfo{
 				Name:  env.Name,
 				Group: env.Group,
-			}] = &client.DummyClientSet
+			}] = createDryRunClientSet(ctx, env)
 			continue
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Transport: env.Transport})
 		if err != nil {
 			return EnvironmentClients{}, err
 		}
@@ -209,20 +265,54 @@ func CreateEnvironmentClients(ctx context.Context, environments manifest.Environ
 	return clients, nil
 }
 
-func getDynatraceClassicURL(ctx context.Context, platformURL string, oauthCreds clientcredentials.Config) (string, error) {
+// createDryRunClientSet returns the client set used in dry-runs. If the bucket safety checks are enabled and the
+// environment has platform credentials, the deployed buckets are fetched read-only to check changes to them.
+// Otherwise, or if the clients can't be created, nothing is fetched and the DummyClientSet is returned.
+func createDryRunClientSet(ctx context.Context, env manifest.EnvironmentDefinition) *client.ClientSet {
+	if !featureflags.BucketSafety.Enabled() || !env.Auth.HasPlatformAuth() {
+		return &client.DummyClientSet
+	}
+
+	clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Transport: env.Transport, CachingDisabled: true})
+	if err != nil {
+		log.WithFields(field.Environment(env.Name, env.Group)).Warn("Failed to create clients for environment %q, changes to buckets are not checked in the dry-run: %v", env.Name, err)
+		return &client.DummyClientSet
+	}
+
+	dryRunClientSet := client.DummyClientSet
+	dryRunClientSet.BucketClient = client.NewDryRunBucketClient(clientSet.BucketClient)
+	return &dryRunClientSet
+}
+
+func getDynatraceClassicURL(ctx context.Context, env manifest.EnvironmentDefinition, transport http.RoundTripper) (string, error) {
 	if featureflags.BuildSimpleClassicURL.Enabled() {
-		if classicURL, ok := findSimpleClassicURL(ctx, platformURL); ok {
+		if classicURL, ok := findSimpleClassicURL(ctx, env.URL.Value); ok {
 			return classicURL, nil
 		}
 	}
 
-	client, err := clients.Factory().WithPlatformURL(platformURL).WithOAuthCredentials(oauthCreds).CreatePlatformClient(ctx)
+	client, err := createPlatformClient(ctx, env, transport)
 	if err != nil {
 		return "", err
 	}
 	return metadata.GetDynatraceClassicURL(ctx, *client)
 }
 
+// createPlatformClient creates a client for the platform APIs of the environment, authenticating either via OAuth or
+// the platform token. If a transport is given, all requests are sent using it.
+func createPlatformClient(ctx context.Context, env manifest.EnvironmentDefinition, transport http.RoundTripper) (*corerest.Client, error) {
+	if env.Auth.OAuth != nil {
+		oauthCreds := clientcredentials.Config{
+			ClientID:     env.Auth.OAuth.ClientID.Value.Value(),
+			ClientSecret: env.Auth.OAuth.ClientSecret.Value.Value(),
+			TokenURL:     env.Auth.OAuth.GetTokenEndpointValue(),
+		}
+		return clients.Factory().WithPlatformURL(env.URL.Value).WithOAuthCredentials(oauthCreds).CreatePlatformClient(ctx)
+	}
+
+	return client.NewPlatformTokenRestClient(ctx, env.URL.Value, env.Auth.PlatformToken.Value.Value(), transport)
+}
+
 func findSimpleClassicURL(ctx context.Context, platformURL string) (classicUrl string, ok bool) {
 	if !strings.Contains(platformURL, ".apps.") {
 		log.Debug("Environment URL not matching expected Platform URL pattern, unable to build Classic environment URL directly.")
@@ -27,8 +27,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
@@ -232,3 +236,48 @@ func TestVerifyEnvironmentGen(t *testing.T) {
 		assert.False(t, ok)
 	})
 }
+
+func TestCreateEnvironmentClients_DryRun(t *testing.T) {
+	t.Setenv(featureflags.BucketSafety.EnvName(), "true")
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		if req.URL.Path == metadata.ClassicEnvironmentDomainPath {
+			_, _ = rw.Write([]byte(`{"domain": "http://classic.env"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"bucketName": "my-bucket", "table": "logs", "retentionDays": 35}`))
+	}))
+	defer server.Close()
+
+	env := manifest.EnvironmentDefinition{
+		Name: "env",
+		URL:  manifest.URLDefinition{Type: manifest.ValueURLType, Value: server.URL},
+	}
+
+	t.Run("environments without platform credentials get the dummy client set", func(t *testing.T) {
+		clients, err := CreateEnvironmentClients(t.Context(), manifest.Environments{"env": env}, true)
+		require.NoError(t, err)
+		assert.Same(t, &client.DummyClientSet, clients[EnvironmentInfo{Name: "env"}])
+	})
+
+	t.Run("deployed buckets are fetched for environments with platform credentials", func(t *testing.T) {
+		requests = nil
+		env := env
+		env.Auth = manifest.Auth{PlatformToken: &manifest.AuthSecret{Name: "PLATFORM_TOKEN", Value: "secret"}}
+
+		clients, err := CreateEnvironmentClients(t.Context(), manifest.Environments{"env": env}, true)
+		require.NoError(t, err)
+		clientSet := clients[EnvironmentInfo{Name: "env"}]
+
+		resp, err := clientSet.BucketClient.Get(t.Context(), "my-bucket")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"bucketName": "my-bucket", "table": "logs", "retentionDays": 35}`, string(resp.Data))
+
+		_, err = clientSet.BucketClient.Upsert(t.Context(), "my-bucket", []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"GET " + metadata.ClassicEnvironmentDomainPath, "GET /platform/storage/management/v1/bucket-definitions/my-bucket"}, requests)
+		assert.Same(t, client.DummyClientSet.SettingsClient, clientSet.SettingsClient)
+	})
+}
@@ -36,7 +36,7 @@ import (
 
 func Command(fs afero.Fs) (cmd *cobra.Command) {
 
-	var fileName, outputFolder string
+	var fileName, outputFolder, selector string
 	var projects, environments []string
 	var includeTypes, excludeTypes []string
 
@@ -59,6 +59,7 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 			m, errs := manifestloader.Load(&manifestloader.Context{
 				Fs:           fs,
 				ManifestPath: manifestName,
+				Selector:     selector,
 				Opts: manifestloader.Options{
 					DoNotResolveEnvVars:      true,
 					RequireEnvironmentGroups: true,
@@ -107,6 +108,8 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 
 	cmd.Flags().StringSliceVarP(&environments, "environment", "e", []string{},
 		"Specify one (or multiple) environment(s) to generate delete entries for. If not defined, entries for all environments will be generated. It is generally safe and recommended to generate a full delete file for all environments, but you may sometimes want to create a file limited to a specific environment's overrides.")
+	cmd.Flags().StringVar(&selector, "selector", "",
+		"Only generate delete entries for environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'.")
 
 	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
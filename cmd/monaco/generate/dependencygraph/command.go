@@ -32,7 +32,7 @@ const jsonEncoding = "json"
 func Command(fs afero.Fs) (cmd *cobra.Command) {
 
 	var environments, groups []string
-	var outputFolder string
+	var outputFolder, selector string
 	var idEncoding string
 
 	cmd = &cobra.Command{
@@ -53,7 +53,7 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 
 			writeJSONIDs := idEncoding == jsonEncoding
 
-			err := writeGraphFiles(cmd.Context(), fs, manifestName, environments, groups, outputFolder, writeJSONIDs)
+			err := writeGraphFiles(cmd.Context(), fs, manifestName, environments, groups, selector, outputFolder, writeJSONIDs)
 			if err != nil {
 				log.WithFields(field.Error(err), field.F("manifestFile", manifestName), field.F("outputFolder", outputFolder)).Error("Failed to create dependency graph files: %v", err)
 			}
@@ -73,6 +73,9 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 			"This flag is mutually exclusive with '--group'. "+
 			"If this flag is specified, a dependency graph will be generated for each specified environment. "+
 			"If neither --groups nor --environment is present, all environments are used.")
+	cmd.Flags().StringVar(&selector, "selector", "",
+		"Only create dependency graphs for environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"If combined with '--environment' or '--group', environments must match both.")
 
 	cmd.Flags().StringVarP(&outputFolder, "output-folder", "o", "", "The folder generated dependency graph DOT files should be written to. If not set, files will be created in the current directory.")
 
@@ -53,13 +53,14 @@ func (e ExportError) Error() string {
 	return fmt.Sprintf("%s: %v", e.message, e.Reason)
 }
 
-func writeGraphFiles(ctx context.Context, fs afero.Fs, manifestPath string, environmentNames []string, environmentGroups []string, outputFolder string, writeJSONIDs bool) error {
+func writeGraphFiles(ctx context.Context, fs afero.Fs, manifestPath string, environmentNames []string, environmentGroups []string, selector string, outputFolder string, writeJSONIDs bool) error {
 
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
 		ManifestPath: manifestPath,
 		Environments: environmentNames,
 		Groups:       environmentGroups,
+		Selector:     selector,
 		Opts: manifestloader.Options{
 			DoNotResolveEnvVars:      true,
 			RequireEnvironmentGroups: true,
@@ -0,0 +1,75 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/migrate"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var outputFolder string
+	var projects []string
+	var failOnIssues bool
+
+	cmd = &cobra.Command{
+		Use:   "migrate <manifest.yaml>",
+		Short: "Migrate configurations of deprecated classic APIs to their Settings 2.0 replacement",
+		Long: "Migrate rewrites configurations of deprecated classic config APIs to the Settings 2.0 schema replacing them, " +
+			"transforms their JSON templates to the structure of the schema and updates references to migrated configurations. " +
+			"The manifest and projects are written to the output folder keeping their file layout; only config files and templates affected by the migration are rewritten, " +
+			"all other files, including group and environment overrides, are kept as they are. The original files are not modified. " +
+			"Fields that can not be migrated automatically are reported and need to be reviewed manually.\n\n" +
+			"Supported APIs: " + strings.Join(migrate.SupportedAPIs(), ", "),
+		Example:           "monaco migrate manifest.yaml -p my-project -o migrated",
+		Args:              cobra.ExactArgs(1),
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			return migrateProjects(cmd.Context(), fs, manifestName, migrateOptions{
+				projects:     projects,
+				outputFolder: outputFolder,
+				failOnIssues: failOnIssues,
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&projects, "project", "p", nil, "Projects to migrate (also migrates any projects they depend on). If not defined, all projects in the manifest will be migrated.")
+	cmd.Flags().StringVarP(&outputFolder, "output-folder", "o", "migrated", "The folder the migrated manifest and projects are written to.")
+	cmd.Flags().BoolVar(&failOnIssues, "fail-on-issues", false, "Return an error if any configuration could not be migrated completely. The migrated configurations are written regardless.")
+
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,192 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/migrate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+type migrateOptions struct {
+	projects     []string
+	outputFolder string
+	failOnIssues bool
+}
+
+func migrateProjects(ctx context.Context, fs afero.Fs, manifestPath string, opts migrateOptions) error {
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifestloader.Options{
+			DoNotResolveEnvVars:      true,
+			RequireEnvironmentGroups: true,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	loadedProjects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, opts.projects)
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load projects")
+	}
+
+	result := migrate.Projects(loadedProjects)
+	log.Info("Migrated %d configuration(s) to Settings 2.0", len(result.Migrated))
+
+	if err := writeMigrated(fs, manifestPath, m, loadedProjects, result, opts.outputFolder); err != nil {
+		return fmt.Errorf("failed to write migrated projects: %w", err)
+	}
+	log.WithFields(field.F("outputFolder", opts.outputFolder)).Info("Migrated manifest and projects written to %q", opts.outputFolder)
+
+	if len(result.Issues) == 0 {
+		return nil
+	}
+
+	log.Warn("%d issue(s) need to be reviewed manually:", len(result.Issues))
+	for _, issue := range result.Issues {
+		log.WithFields(field.Coordinate(issue.Coordinate)).Warn("%s", issue)
+	}
+
+	if opts.failOnIssues {
+		return fmt.Errorf("migration finished with %d issue(s)", len(result.Issues))
+	}
+	return nil
+}
+
+// writeMigrated writes the manifest and the folders of all given projects to the output folder, keeping their layout.
+// Only config files and templates affected by the migration are rewritten, all other files are copied as they are.
+func writeMigrated(fs afero.Fs, manifestPath string, m manifest.Manifest, projects []project.Project, result migrate.Result, outputFolder string) error {
+	if err := copyFile(fs, manifestPath, filepath.Join(outputFolder, filepath.Base(manifestPath))); err != nil {
+		return err
+	}
+
+	workingDir := filepath.Dir(manifestPath)
+	absOutput, err := filepath.Abs(outputFolder)
+	if err != nil {
+		return err
+	}
+
+	var definitions []manifest.ProjectDefinition
+	for _, p := range projects {
+		if d, found := m.Projects[p.Id]; found {
+			definitions = append(definitions, d)
+		}
+	}
+	// nested project folders are handled first, so that their files are rewritten for the correct project
+	slices.SortFunc(definitions, func(a, b manifest.ProjectDefinition) int {
+		return len(b.Path) - len(a.Path)
+	})
+
+	written := map[string]struct{}{}
+	for _, d := range definitions {
+		err := afero.Walk(fs, filepath.Join(workingDir, d.Path), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if absPath, err := filepath.Abs(path); err == nil && (absPath == absOutput || strings.HasPrefix(absPath, absOutput+string(filepath.Separator))) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(workingDir, path)
+			if err != nil {
+				return err
+			}
+			if _, done := written[rel]; done {
+				return nil
+			}
+			written[rel] = struct{}{}
+
+			return writeMigratedFile(fs, path, filepath.Join(outputFolder, rel), rel, d.Name, result)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMigratedFile writes a single file of a project to the output path, migrating its content if needed.
+func writeMigratedFile(fs afero.Fs, path, outputPath, relativePath, projectID string, result migrate.Result) error {
+	if content, migrated := result.Templates[relativePath]; migrated {
+		return writeFile(fs, outputPath, []byte(content))
+	}
+
+	if !files.IsYamlFileExtension(path) {
+		return copyFile(fs, path, outputPath)
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return err
+	}
+	migrated, changed, err := migrate.ConfigFile(content, projectID, result.Migrated)
+	if err != nil {
+		log.WithFields(field.F("file", path), field.Error(err)).Warn("Failed to migrate %q, copying it unchanged: %v", path, err)
+		return writeFile(fs, outputPath, content)
+	}
+	if changed {
+		log.Debug("Migrated config file %q", path)
+	}
+	return writeFile(fs, outputPath, migrated)
+}
+
+func copyFile(fs afero.Fs, src, dst string) error {
+	content, err := afero.ReadFile(fs, src)
+	if err != nil {
+		return err
+	}
+	return writeFile(fs, dst, content)
+}
+
+func writeFile(fs afero.Fs, path string, content []byte) error {
+	if err := fs.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, path, content, 0644)
+}
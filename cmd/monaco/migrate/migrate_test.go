@@ -0,0 +1,183 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestYaml = `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: dev
+    url:
+      value: https://abcde.dev.dynatracelabs.com
+    auth:
+      token:
+        type: environment
+        name: ENV_TOKEN
+`
+
+const configYaml = `configs:
+- id: profile
+  config:
+    name: my-profile
+    template: profile.json
+  type:
+    api: alerting-profile
+- id: notification
+  config:
+    name: my-notification
+    template: notification.json
+    parameters:
+      profile:
+        type: reference
+        configType: alerting-profile
+        configId: profile
+        property: id
+  type:
+    api: notification
+`
+
+func setupFs(t *testing.T) (afero.Fs, string) {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	root := t.TempDir()
+	files := map[string]string{
+		"manifest.yaml":                     manifestYaml,
+		"project/classic/config.yaml":       configYaml,
+		"project/classic/profile.json":      `{"displayName": "{{ .name }}", "rules": [], "eventTypeFilters": [{"customEventFilter": {}}]}`,
+		"project/classic/notification.json": `{"name": "{{ .name }}", "alertingProfile": "{{ .profile }}"}`,
+	}
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(root, name), []byte(content), 0644))
+	}
+	return fs, root
+}
+
+func TestMigrateProjects(t *testing.T) {
+	fs, root := setupFs(t)
+	output := filepath.Join(root, "migrated")
+
+	err := migrateProjects(t.Context(), fs, filepath.Join(root, "manifest.yaml"), migrateOptions{outputFolder: output})
+	require.NoError(t, err)
+
+	assert.Equal(t, manifestYaml, readFile(t, fs, filepath.Join(output, "manifest.yaml")))
+
+	migratedConfig := readFile(t, fs, filepath.Join(output, "project", "classic", "config.yaml"))
+	assert.Contains(t, migratedConfig, "schema: builtin:alerting.profile")
+	assert.Contains(t, migratedConfig, "scope: environment")
+	assert.Contains(t, migratedConfig, "configType: builtin:alerting.profile")
+	assert.Contains(t, migratedConfig, "api: notification")
+
+	profileTemplate := readFile(t, fs, filepath.Join(output, "project", "classic", "profile.json"))
+	assert.JSONEq(t, `{"name": "{{ .name }}", "severityRules": [], "eventFilters": []}`, profileTemplate)
+
+	assertFileExists(t, fs, filepath.Join(output, "project", "classic", "notification.json"))
+
+	// the original project is untouched
+	assert.Contains(t, readFile(t, fs, filepath.Join(root, "project", "classic", "config.yaml")), "api: alerting-profile")
+}
+
+func TestMigrateProjects_FailOnIssues(t *testing.T) {
+	fs, root := setupFs(t)
+	output := filepath.Join(root, "migrated")
+
+	err := migrateProjects(t.Context(), fs, filepath.Join(root, "manifest.yaml"), migrateOptions{outputFolder: output, failOnIssues: true})
+	assert.ErrorContains(t, err, "migration finished with 2 issue(s)")
+
+	// migrated configs are written regardless
+	assertFileExists(t, fs, filepath.Join(output, "project", "classic", "config.yaml"))
+}
+
+func TestMigrateProjects_KeepsLayoutAndOverrides(t *testing.T) {
+	fs, root := setupFs(t)
+	output := filepath.Join(root, "migrated")
+
+	overrides := `configs:
+- id: other-profile
+  config:
+    name: other
+    template: ../../classic/profile.json
+  type:
+    api: alerting-profile
+  groupOverrides:
+  - group: default
+    override:
+      name: group-name
+  environmentOverrides:
+  - environment: dev
+    override:
+      parameters:
+        profile: ["alerting-profile", "profile", "id"]
+- id: dashboard
+  config:
+    name: my-dashboard
+    template: ../../classic/notification.json
+  type:
+    api: dashboard
+`
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(root, "project", "nested", "folder", "overrides.yaml"), []byte(overrides), 0644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(root, "project", "README.md"), []byte("docs"), 0644))
+
+	err := migrateProjects(t.Context(), fs, filepath.Join(root, "manifest.yaml"), migrateOptions{outputFolder: output})
+	require.NoError(t, err)
+
+	assert.Equal(t, "docs", readFile(t, fs, filepath.Join(output, "project", "README.md")))
+
+	migrated := readFile(t, fs, filepath.Join(output, "project", "nested", "folder", "overrides.yaml"))
+	assert.Contains(t, migrated, "schema: builtin:alerting.profile")
+	assert.Contains(t, migrated, "group: default")
+	assert.Contains(t, migrated, "name: group-name")
+	assert.Contains(t, migrated, "environment: dev")
+	assert.Contains(t, migrated, "- builtin:alerting.profile")
+	assert.Contains(t, migrated, "api: dashboard")
+}
+
+func TestMigrateProjects_InvalidManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manifestPath := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, afero.WriteFile(fs, manifestPath, []byte(`manifestVersion: "1.0"`), 0644))
+
+	err := migrateProjects(t.Context(), fs, manifestPath, migrateOptions{outputFolder: "out"})
+	assert.Error(t, err)
+}
+
+func readFile(t *testing.T, fs afero.Fs, path string) string {
+	t.Helper()
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func assertFileExists(t *testing.T, fs afero.Fs, path string) {
+	t.Helper()
+	exists, err := afero.Exists(fs, path)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected file %q to exist", path)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 )
@@ -33,6 +34,7 @@ func GetPurgeCommand(fs afero.Fs) (purgeCmd *cobra.Command) {
 	var environment []string
 	var manifestName string
 	var specificApis []string
+	var allowDestructiveChanges bool
 
 	purgeCmd = &cobra.Command{
 		Use:     "purge <manifest.yaml>",
@@ -50,7 +52,7 @@ func GetPurgeCommand(fs afero.Fs) (purgeCmd *cobra.Command) {
 				return err
 			}
 
-			return purge(cmd.Context(), fs, manifestName, environment, specificApis)
+			return purge(cmd.Context(), fs, manifestName, environment, specificApis, allowDestructiveChanges)
 		},
 		ValidArgsFunction: completion.PurgeCompletion,
 	}
@@ -58,6 +60,11 @@ func GetPurgeCommand(fs afero.Fs) (purgeCmd *cobra.Command) {
 	purgeCmd.Flags().StringSliceVarP(&environment, "environment", "e", make([]string, 0), "Deletes configuration only for specified environments. All environments are included if this property is not set. ")
 	purgeCmd.Flags().StringSliceVarP(&specificApis, "api", "a", make([]string, 0), "One or more specific APIs to delete from (flag can be repeated or value defined as comma-separated list)")
 
+	if featureflags.BucketSafety.Enabled() {
+		purgeCmd.Flags().BoolVar(&allowDestructiveChanges, "allow-destructive-changes", false, "Delete all Grail buckets whose config is not protected. "+
+			"Without this flag, only buckets with 'allowDestructive: true' set in their config are deleted.")
+	}
+
 	if err := purgeCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
 	}
@@ -26,15 +26,17 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
-func purge(ctx context.Context, fs afero.Fs, deploymentManifestPath string, environmentNames []string, apiNames []string) error {
+func purge(ctx context.Context, fs afero.Fs, deploymentManifestPath string, environmentNames []string, apiNames []string, allowDestructiveChanges bool) error {
 
 	deploymentManifestPath = filepath.Clean(deploymentManifestPath)
 	deploymentManifestPath, manifestErr := filepath.Abs(deploymentManifestPath)
@@ -57,12 +59,21 @@ func purge(ctx context.Context, fs afero.Fs, deploymentManifestPath string, envi
 		return errors.New("error while loading manifest")
 	}
 
-	return purgeConfigs(ctx, maps.Values(mani.Environments), apis)
+	var projects []project.Project
+	if featureflags.BucketSafety.Enabled() {
+		var err error
+		if projects, err = delete.LoadBucketConfigs(ctx, fs, filepath.Dir(deploymentManifestPath), mani); err != nil {
+			return err
+		}
+	}
+
+	return purgeConfigs(ctx, maps.Values(mani.Environments), apis, projects, allowDestructiveChanges)
 }
 
-func purgeConfigs(ctx context.Context, environments []manifest.EnvironmentDefinition, apis api.APIs) error {
+func purgeConfigs(ctx context.Context, environments []manifest.EnvironmentDefinition, apis api.APIs, projects []project.Project, allowDestructiveChanges bool) error {
 
 	for _, env := range environments {
+		ctx := delete.NewContextWithBucketProtection(ctx, delete.NewBucketProtection(projects, env.Name, allowDestructiveChanges))
 		err := purgeForEnvironment(ctx, env, apis)
 		if err != nil {
 			return err
@@ -75,7 +86,7 @@ func purgeConfigs(ctx context.Context, environments []manifest.EnvironmentDefini
 func purgeForEnvironment(ctx context.Context, env manifest.EnvironmentDefinition, apis api.APIs) error {
 	ctx = context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
 
-	clients, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+	clients, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Transport: env.Transport})
 	if err != nil {
 		return fmt.Errorf("failed to create a client for env `%s`: %w", env.Name, err)
 	}
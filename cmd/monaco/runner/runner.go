@@ -17,18 +17,24 @@ package runner
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/auth"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/devserver"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/download"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/migrate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/purge"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/supportarchive"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/validate"
 	versionCommand "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/version"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/cache"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
@@ -64,6 +70,9 @@ func writeSupportArchive(fs afero.Fs) func() {
 func BuildCmdWithLogSpy(fs afero.Fs, logSpy io.Writer) *cobra.Command {
 	var verbose bool
 	var supportArchive bool
+	var replay string
+	var cacheDir string
+	var cacheTTL time.Duration
 
 	var rootCmd = &cobra.Command{
 		Use:   "monaco <command>",
@@ -76,7 +85,7 @@ Examples:
   Deploy a specific environment within an manifest
     monaco deploy service.yaml -e dev`,
 
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if supportArchive {
 				cobra.OnFinalize(writeSupportArchive(fs))
 				cmd.SetContext(supportarchive.ContextWithSupportArchive(cmd.Context()))
@@ -96,6 +105,28 @@ Examples:
 			}
 
 			memory.SetDefaultLimit()
+
+			if replay != "" {
+				exchanges, err := trafficlogs.LoadRecording(fs, replay)
+				if err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+				log.Warn("Replaying %d recorded exchanges from %q - no requests are sent to Dynatrace", len(exchanges), replay)
+				cmd.SetContext(trafficlogs.ContextWithReplay(cmd.Context(), trafficlogs.NewReplayTransport(exchanges)))
+			}
+
+			if cacheDir != "" {
+				log.Info("Reusing listings of remote configurations from %q, if they are less than %s old", cacheDir, cacheTTL)
+				store := cache.NewStore(fs, cacheDir, cacheTTL)
+				cmd.SetContext(cache.ContextWithStore(cmd.Context(), store))
+			}
+			return nil
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if store, ok := cache.StoreFromContext(cmd.Context()); ok {
+				store.Persist()
+			}
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			_ = cmd.Help()
@@ -106,13 +137,22 @@ Examples:
 	// global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&supportArchive, "support-archive", false, "Create support archive")
+	rootCmd.PersistentFlags().StringVar(&replay, "replay", "", "Serve all Dynatrace API requests from a traffic recording instead of sending them. "+
+		"Recordings are written to the support archive, with one JSON record per request and response.")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Keep listings of remote configurations in the given directory and reuse them in later runs. "+
+		"Listings are kept up to date with changes made by monaco, but not with changes made by others. Downloads always fetch fresh listings.")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", time.Hour, "Maximum age of listings reused from the --cache-dir")
 
 	// commands
 	rootCmd.AddCommand(download.GetDownloadCommand(fs, &download.DefaultCommand{}))
 	rootCmd.AddCommand(deploy.GetDeployCommand(fs))
+	rootCmd.AddCommand(validate.Command(fs))
+	rootCmd.AddCommand(auth.Command(fs))
 	rootCmd.AddCommand(delete.GetDeleteCommand(fs))
 	rootCmd.AddCommand(versionCommand.GetVersionCommand())
 	rootCmd.AddCommand(generate.Command(fs))
+	rootCmd.AddCommand(migrate.Command(fs))
+	rootCmd.AddCommand(devserver.Command(fs))
 
 	rootCmd.AddCommand(account.Command(fs))
 
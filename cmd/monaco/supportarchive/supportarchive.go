@@ -52,6 +52,7 @@ func Write(fs afero.Fs) error {
 	files := []string{
 		trafficlogs.RequestFilePath(),
 		trafficlogs.ResponseFilePath(),
+		trafficlogs.RecordingFilePath(),
 		log.LogFilePath(),
 		log.ErrorFilePath(),
 		ffState,
@@ -0,0 +1,116 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+)
+
+// Command returns the 'validate' command, validating configurations without connecting to any environment.
+func Command(fs afero.Fs) (validateCmd *cobra.Command) {
+	var manifestName, selector, output, outputFile string
+	var environment, project, groups []string
+
+	validateCmd = &cobra.Command{
+		Use:   "validate <manifest.yaml>",
+		Short: "Validate configurations without connecting to any Dynatrace environment",
+		Long: "Validate loads the manifest and all projects, resolves all parameters and renders all templates for every environment, " +
+			"exactly like a dry-run deployment, but without creating API clients or contacting any environment. " +
+			"Secrets referenced in the manifest are not resolved, but environment variables used for URLs and variables must be set. " +
+			"The command fails if any problem is found.",
+		Example:           "monaco validate manifest.yaml -e dev-environment --output json",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.DeployCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName = args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			}
+
+			if output != validateOutputText && output != validateOutputJSON {
+				return fmt.Errorf("unknown output format %q, expected %q or %q", output, validateOutputText, validateOutputJSON)
+			}
+
+			problems, err := validateConfigs(cmd.Context(), fs, manifestName, groups, environment, selector, project)
+			if err != nil {
+				return err
+			}
+
+			logProblems(problems)
+
+			var w io.Writer = os.Stdout
+			if outputFile != "" {
+				f, err := fs.Create(outputFile)
+				if err != nil {
+					return fmt.Errorf("failed to create output file %q: %w", outputFile, err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if err := writeProblems(w, problems, output); err != nil {
+				return fmt.Errorf("failed to write validation result: %w", err)
+			}
+
+			if len(problems) > 0 {
+				return fmt.Errorf("validation failed: %d problem(s) found", len(problems))
+			}
+			return nil
+		},
+	}
+
+	validateCmd.Flags().StringSliceVarP(&environment, "environment", "e", []string{},
+		"Specify one (or multiple) environment(s) to validate for. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'.")
+	validateCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) to validate for. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--environment'")
+	validateCmd.Flags().StringVar(&selector, "selector", "",
+		"Only validate for environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"If combined with '--environment' or '--group', environments must match both.")
+	validateCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to validate (also validates any dependent configurations)")
+	validateCmd.Flags().StringVarP(&output, "output", "o", validateOutputText, "Output format of the found problems, either 'text' or 'json'")
+	validateCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the found problems to the given file instead of stdout")
+
+	err := validateCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	err = validateCmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	validateCmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	return validateCmd
+}
@@ -0,0 +1,324 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	monacoDeploy "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/multierror"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+)
+
+const (
+	validateOutputText = "text"
+	validateOutputJSON = "json"
+)
+
+// Problem is a single issue found while validating a manifest and its projects.
+type Problem struct {
+	// Coordinate of the affected configuration, if the problem concerns a single configuration
+	Coordinate *coordinate.Coordinate `json:"coordinate,omitempty"`
+	// Environment the problem was found for, if it is environment-specific
+	Environment string `json:"environment,omitempty"`
+	// Group of the environment the problem was found for
+	Group string `json:"group,omitempty"`
+	// File containing the problem, if known
+	File string `json:"file,omitempty"`
+	// Reason describing the problem
+	Reason string `json:"reason"`
+}
+
+func (p Problem) String() string {
+	s := ""
+	if p.File != "" {
+		s += p.File + ": "
+	}
+	if p.Coordinate != nil {
+		s += p.Coordinate.String() + ": "
+	}
+	if p.Environment != "" {
+		s += fmt.Sprintf("[environment: %s, group: %s] ", p.Environment, p.Group)
+	}
+	return s + p.Reason
+}
+
+// validateConfigs loads the manifest and all projects and validates them for every selected environment, without
+// connecting to any Dynatrace environment. Parameters are resolved and templates are rendered as they would be during a
+// dry-run deployment. All problems that were found are returned.
+func validateConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, selector string, specificProjects []string) ([]Problem, error) {
+	absManifestPath, err := filepath.Abs(filepath.Clean(manifestPath))
+	if err != nil {
+		return nil, fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
+	}
+
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: absManifestPath,
+		Groups:       environmentGroups,
+		Environments: specificEnvironments,
+		Selector:     selector,
+		Opts: manifestloader.Options{
+			// secrets are not needed as no environment is contacted, but variables are resolved as they are rendered
+			// into templates
+			DoNotResolveSecrets:      true,
+			RequireEnvironmentGroups: true,
+		},
+	})
+	if len(errs) > 0 {
+		return problemsFromErrors(errs, absManifestPath, "", ""), nil
+	}
+
+	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(absManifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, specificProjects)
+	if len(errs) > 0 {
+		return problemsFromErrors(errs, "", "", ""), nil
+	}
+
+	if err := monacoDeploy.ValidateProjectsWithEnvironments(ctx, projects, m.Environments); err != nil {
+		return problemsFromErrors([]error{err}, absManifestPath, "", ""), nil
+	}
+
+	if err := monacoDeploy.ValidateAuthenticationWithProjectConfigs(projects, m.Environments); err != nil {
+		return problemsFromErrors([]error{fmt.Errorf("manifest auth field misconfigured: %w", err)}, absManifestPath, "", ""), nil
+	}
+
+	var problems []Problem
+	for _, env := range sortedEnvironments(m.Environments) {
+		problems = append(problems, validateEnvironment(ctx, projects, env)...)
+	}
+
+	for i := range problems {
+		if problems[i].File == "" && problems[i].Coordinate != nil {
+			problems[i].File = templateFileOf(projects, problems[i].Environment, *problems[i].Coordinate)
+		}
+	}
+
+	return problems, nil
+}
+
+// validateEnvironment runs a dry-run deployment of all projects against a single environment and collects every
+// reported error.
+func validateEnvironment(ctx context.Context, projects []project.Project, env manifest.EnvironmentDefinition) []Problem {
+	c := &problemCollector{environment: env.Name, group: env.Group}
+	ctx = report.NewContextWithReporter(ctx, c)
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: env.Name, Group: env.Group}: &client.DummyClientSet,
+	}
+	if err := deploy.DeployForAllEnvironments(ctx, projects, clients, deploy.DeployConfigsOptions{DryRun: true}); err != nil && len(c.problems) == 0 {
+		// errors are reported while deploying, only add the returned error if nothing was reported
+		c.ReportLoading(report.StateError, err, "", nil)
+	}
+
+	return c.problems
+}
+
+func sortedEnvironments(envs manifest.Environments) []manifest.EnvironmentDefinition {
+	result := make([]manifest.EnvironmentDefinition, 0, len(envs))
+	for _, e := range envs {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// templateFileOf returns the path of the template file of the config with the given coordinate, or an empty string
+// if the config can't be found or is not based on a file.
+func templateFileOf(projects []project.Project, env string, c coordinate.Coordinate) string {
+	for _, p := range projects {
+		if cfg, found := p.GetConfigFor(env, c); found {
+			if t, ok := cfg.Template.(*template.FileBasedTemplate); ok {
+				return t.FilePath()
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// problemCollector is a report.Reporter collecting all reported errors of a single environment as Problems.
+type problemCollector struct {
+	environment string
+	group       string
+
+	mu       sync.Mutex
+	problems []Problem
+}
+
+var _ report.Reporter = (*problemCollector)(nil)
+
+func (c *problemCollector) ReportDeployment(coord coordinate.Coordinate, state report.RecordState, _ []report.Detail, err error) {
+	if state != report.StateError || err == nil {
+		return
+	}
+	c.add(problemsFromErrors([]error{err}, "", c.environment, c.group), &coord)
+}
+
+func (c *problemCollector) ReportLoading(state report.RecordState, err error, _ string, coord *coordinate.Coordinate) {
+	if state != report.StateError || err == nil {
+		return
+	}
+
+	// validation errors are reported for all environments, only keep the ones of the environment currently validated
+	var envErrs deployErrors.EnvironmentDeploymentErrors
+	if errors.As(err, &envErrs) {
+		c.add(problemsFromErrors(envErrs[c.environment], "", c.environment, c.group), coord)
+		return
+	}
+	c.add(problemsFromErrors([]error{err}, "", c.environment, c.group), coord)
+}
+
+func (c *problemCollector) add(problems []Problem, coord *coordinate.Coordinate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range problems {
+		if p.Coordinate == nil && coord != nil {
+			p.Coordinate = coord
+		}
+		c.problems = append(c.problems, p)
+	}
+}
+
+func (c *problemCollector) ReportInfo(string) {}
+
+func (c *problemCollector) GetSummary() string { return "" }
+
+func (c *problemCollector) Stop() {}
+
+// problemsFromErrors converts the given errors into Problems, unpacking errors that group multiple errors.
+// Location details contained in the errors take precedence over the given defaults.
+func problemsFromErrors(errs []error, file string, env string, group string) []Problem {
+	var problems []Problem
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var multiErr multierror.MultiError
+		if errors.As(err, &multiErr) {
+			problems = append(problems, problemsFromErrors(multiErr.Errors, file, env, group)...)
+			continue
+		}
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			problems = append(problems, problemsFromErrors(joined.Unwrap(), file, env, group)...)
+			continue
+		}
+
+		problems = append(problems, problemFromError(err, file, env, group))
+	}
+	return problems
+}
+
+func problemFromError(err error, file string, env string, group string) Problem {
+	p := Problem{Environment: env, Group: group, File: file, Reason: err.Error()}
+
+	var configErr configErrors.ConfigError
+	if errors.As(err, &configErr) {
+		c := configErr.Coordinates()
+		p.Coordinate = &c
+	}
+
+	var detailedErr configErrors.DetailedConfigError
+	if errors.As(err, &detailedErr) {
+		if details := detailedErr.LocationDetails(); details.Environment != "" {
+			p.Environment = details.Environment
+			p.Group = details.Group
+		}
+	}
+
+	var loaderErr configErrors.ConfigLoaderError
+	var parserErr configErrors.DefinitionParserError
+	var detailedParserErr configErrors.DetailedDefinitionParserError
+	var paramParserErr configErrors.ParameterDefinitionParserError
+	var jsonErr configErrors.InvalidJsonError
+	switch {
+	case errors.As(err, &jsonErr):
+		p.File = jsonErr.TemplateFilePath
+	case errors.As(err, &paramParserErr):
+		p.File = paramParserErr.Path
+	case errors.As(err, &detailedParserErr):
+		p.File = detailedParserErr.Path
+	case errors.As(err, &parserErr):
+		p.File = parserErr.Path
+	case errors.As(err, &loaderErr):
+		p.File = loaderErr.Path
+	}
+
+	return p
+}
+
+// writeProblems writes the given problems in the requested format.
+func writeProblems(w io.Writer, problems []Problem, format string) error {
+	switch format {
+	case validateOutputJSON:
+		if problems == nil {
+			problems = []Problem{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Problems []Problem `json:"problems"`
+		}{problems})
+	case validateOutputText:
+		for _, p := range problems {
+			if _, err := fmt.Fprintln(w, p.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, expected %q or %q", format, validateOutputText, validateOutputJSON)
+	}
+}
+
+func logProblems(problems []Problem) {
+	if len(problems) == 0 {
+		log.Info("Validation finished without problems")
+		return
+	}
+	log.Error("Validation failed - %d problem(s) found", len(problems))
+}
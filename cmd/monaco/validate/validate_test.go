@@ -0,0 +1,191 @@
+//go:build unit
+
+// @license
+// Copyright 2025 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+const validateManifestYaml = `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: dev
+    url:
+      value: https://abcde.dev.dynatracelabs.com
+    auth:
+      token:
+        type: environment
+        name: UNSET_TOKEN_FOR_VALIDATION
+`
+
+func setupValidateFs(t *testing.T, configYaml string, templates map[string]string) (afero.Fs, string) {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	configPath, _ := filepath.Abs("project/alerting-profile/profile.yaml")
+	require.NoError(t, afero.WriteFile(fs, configPath, []byte(configYaml), 0644))
+	for name, content := range templates {
+		templatePath, _ := filepath.Abs(filepath.Join("project/alerting-profile", name))
+		require.NoError(t, afero.WriteFile(fs, templatePath, []byte(content), 0644))
+	}
+	manifestPath, _ := filepath.Abs("manifest.yaml")
+	require.NoError(t, afero.WriteFile(fs, manifestPath, []byte(validateManifestYaml), 0644))
+	return fs, manifestPath
+}
+
+func TestValidateConfigs(t *testing.T) {
+	t.Run("valid project has no problems", func(t *testing.T) {
+		fs, manifestPath := setupValidateFs(t, `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: profile.json
+  type:
+    api: alerting-profile
+`, map[string]string{"profile.json": `{"name": "{{ .name }}"}`})
+
+		problems, err := validateConfigs(t.Context(), fs, manifestPath, nil, nil, "", nil)
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("invalid JSON template is reported with its file", func(t *testing.T) {
+		fs, manifestPath := setupValidateFs(t, `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: profile.json
+  type:
+    api: alerting-profile
+`, map[string]string{"profile.json": `{"name": "{{ .name }}",}`})
+
+		problems, err := validateConfigs(t.Context(), fs, manifestPath, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, problems, 1)
+
+		assert.Equal(t, &coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"}, problems[0].Coordinate)
+		assert.Equal(t, "dev", problems[0].Environment)
+		assert.Equal(t, "default", problems[0].Group)
+		assert.Equal(t, filepath.Join("project", "alerting-profile", "profile.json"), problems[0].File)
+		assert.NotEmpty(t, problems[0].Reason)
+	})
+
+	t.Run("reference to undefined config is reported", func(t *testing.T) {
+		fs, manifestPath := setupValidateFs(t, `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: profile.json
+    parameters:
+      other:
+        type: reference
+        configType: alerting-profile
+        configId: does-not-exist
+        property: id
+  type:
+    api: alerting-profile
+`, map[string]string{"profile.json": `{"name": "{{ .name }}", "other": "{{ .other }}"}`})
+
+		problems, err := validateConfigs(t.Context(), fs, manifestPath, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, problems)
+		assert.Equal(t, "profile", problems[0].Coordinate.ConfigId)
+		assert.Equal(t, "dev", problems[0].Environment)
+	})
+
+	t.Run("invalid manifest is reported", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		manifestPath, _ := filepath.Abs("manifest.yaml")
+		require.NoError(t, afero.WriteFile(fs, manifestPath, []byte(`manifestVersion: "1.0"`), 0644))
+
+		problems, err := validateConfigs(t.Context(), fs, manifestPath, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, problems)
+		assert.Equal(t, manifestPath, problems[0].File)
+	})
+
+	t.Run("variables from unset environment variables are reported", func(t *testing.T) {
+		fs, manifestPath := setupValidateFs(t, `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: profile.json
+  type:
+    api: alerting-profile
+`, map[string]string{"profile.json": `{"name": "{{ .name }}"}`})
+		require.NoError(t, afero.WriteFile(fs, manifestPath, []byte(validateManifestYaml+`variables:
+  region: {type: environment, value: UNSET_VARIABLE_FOR_VALIDATION}
+`), 0644))
+
+		problems, err := validateConfigs(t.Context(), fs, manifestPath, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, problems, 1)
+		assert.Equal(t, manifestPath, problems[0].File)
+		assert.Contains(t, problems[0].Reason, "UNSET_VARIABLE_FOR_VALIDATION")
+	})
+}
+
+func TestWriteProblems(t *testing.T) {
+	problems := []Problem{
+		{
+			Coordinate:  &coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"},
+			Environment: "dev",
+			Group:       "default",
+			File:        "project/alerting-profile/profile.json",
+			Reason:      "invalid JSON",
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeProblems(&buf, problems, validateOutputJSON))
+
+		var result struct {
+			Problems []Problem `json:"problems"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, problems, result.Problems)
+	})
+
+	t.Run("json without problems writes empty list", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeProblems(&buf, nil, validateOutputJSON))
+		assert.JSONEq(t, `{"problems": []}`, buf.String())
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeProblems(&buf, problems, validateOutputText))
+		assert.Equal(t, "project/alerting-profile/profile.json: project:alerting-profile:profile: [environment: dev, group: default] invalid JSON\n", buf.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		assert.Error(t, writeProblems(&bytes.Buffer{}, problems, "xml"))
+	})
+}
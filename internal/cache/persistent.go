@@ -0,0 +1,239 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+)
+
+// Store is a directory in which [PersistentCache]s keep their entries between runs.
+type Store struct {
+	fs  afero.Fs
+	dir string
+	ttl time.Duration
+
+	lock   sync.Mutex
+	caches []interface{ Persist() }
+}
+
+// NewStore creates a new Store keeping its files in dir. Persisted entries older than ttl are considered outdated.
+func NewStore(fs afero.Fs, dir string, ttl time.Duration) *Store {
+	return &Store{fs: fs, dir: dir, ttl: ttl}
+}
+
+// Persist writes the modified entries of all caches created for this store to disk.
+func (s *Store) Persist() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, c := range s.caches {
+		c.Persist()
+	}
+}
+
+type storeCtxKey struct{}
+
+// ContextWithStore returns a copy of ctx signaling that clients created with it persist their caches in the given store.
+func ContextWithStore(ctx context.Context, store *Store) context.Context {
+	return context.WithValue(ctx, storeCtxKey{}, store)
+}
+
+// StoreFromContext returns the store of the context, if caches are to be persisted.
+func StoreFromContext(ctx context.Context) (*Store, bool) {
+	s, ok := ctx.Value(storeCtxKey{}).(*Store)
+	return s, ok
+}
+
+// persistedEntry is the content of a single cache file.
+type persistedEntry[T any] struct {
+	Key      string    `json:"key"`
+	StoredAt time.Time `json:"storedAt"`
+	Entries  T         `json:"entries"`
+}
+
+// PersistentCache is an implementation of Cache that keeps all values in memory and additionally persists them in
+// files, so that they can be reused by later runs until they exceed the store's TTL.
+//
+// As entries may be updated many times during a run, Set only marks them as modified. Modified entries are written
+// by Persist and Clear, which otherwise only clears the in-memory values. Updating an entry doesn't extend its
+// lifetime: an entry expires once its TTL has passed since it was first set.
+//
+// Errors reading or writing the files are logged, but are never returned, as the cache is not required for correct
+// operation.
+type PersistentCache[T any] struct {
+	memory DefaultCache[T]
+	fs     afero.Fs
+	dir    string
+	ttl    time.Duration
+	now    func() time.Time
+
+	lock     sync.Mutex
+	storedAt map[string]time.Time
+	modified map[string]struct{}
+}
+
+// NewPersistentCache creates a new PersistentCache persisting its entries in the given store. The namespace separates
+// caches sharing the same store, e.g. caches of different environments or APIs.
+func NewPersistentCache[T any](store *Store, namespace string) *PersistentCache[T] {
+	c := &PersistentCache[T]{
+		fs:       store.fs,
+		dir:      filepath.Join(store.dir, hash(namespace)),
+		ttl:      store.ttl,
+		now:      time.Now,
+		storedAt: make(map[string]time.Time),
+		modified: make(map[string]struct{}),
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	store.caches = append(store.caches, c)
+
+	return c
+}
+
+// Get retrieves the value associated with the given key. If the value isn't known in memory, it is read from its file,
+// unless the file doesn't exist or is outdated.
+func (p *PersistentCache[T]) Get(key string) (T, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if e, ok := p.memory.Get(key); ok {
+		return e, true
+	}
+
+	var res T
+	data, err := afero.ReadFile(p.fs, p.path(key))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("Failed to read persisted cache entry for %q: %s", key, err)
+		}
+		return res, false
+	}
+
+	var entry persistedEntry[T]
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		log.Warn("Ignoring invalid persisted cache entry for %q", key)
+		return res, false
+	}
+
+	if age := p.now().Sub(entry.StoredAt); age > p.ttl {
+		log.Debug("Ignoring persisted cache entry for %q, as it is outdated (stored %s ago)", key, age.Round(time.Second))
+		return res, false
+	}
+
+	log.Debug("Using persisted cache entry for %q", key)
+	p.memory.Set(key, entry.Entries)
+	p.storedAt[key] = entry.StoredAt
+	return entry.Entries, true
+}
+
+// Set adds or updates the entry with the specified key and marks it to be persisted.
+func (p *PersistentCache[T]) Set(key string, entries T) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.memory.Set(key, entries)
+	p.modified[key] = struct{}{}
+	if _, ok := p.storedAt[key]; !ok {
+		p.storedAt[key] = p.now()
+	}
+}
+
+// Delete removes the entry with the specified key both from memory and from disk.
+func (p *PersistentCache[T]) Delete(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.memory.Delete(key)
+	delete(p.storedAt, key)
+	delete(p.modified, key)
+
+	if err := p.fs.Remove(p.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("Failed to remove persisted cache entry for %q: %s", key, err)
+	}
+}
+
+// Clear persists all modified entries and removes all entries from memory.
+func (p *PersistentCache[T]) Clear() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.persist()
+	p.memory.Clear()
+	clear(p.storedAt)
+}
+
+// Persist writes all modified entries to disk.
+func (p *PersistentCache[T]) Persist() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.persist()
+}
+
+func (p *PersistentCache[T]) persist() {
+	for key := range p.modified {
+		if entries, ok := p.memory.Get(key); ok {
+			p.write(key, p.storedAt[key], entries)
+		}
+	}
+	clear(p.modified)
+}
+
+func (p *PersistentCache[T]) write(key string, storedAt time.Time, entries T) {
+	data, err := json.Marshal(persistedEntry[T]{Key: key, StoredAt: storedAt, Entries: entries})
+	if err != nil {
+		log.Warn("Failed to persist cache entry for %q: %s", key, err)
+		return
+	}
+
+	if err := p.fs.MkdirAll(p.dir, 0700); err != nil {
+		log.Warn("Failed to persist cache entry for %q: %s", key, err)
+		return
+	}
+
+	// write to a temporary file first, so that concurrent runs never read a partially written entry
+	tmp := p.path(key) + ".tmp"
+	if err := afero.WriteFile(p.fs, tmp, data, 0600); err != nil {
+		log.Warn("Failed to persist cache entry for %q: %s", key, err)
+		return
+	}
+	if err := p.fs.Rename(tmp, p.path(key)); err != nil {
+		log.Warn("Failed to persist cache entry for %q: %s", key, err)
+	}
+}
+
+func (p *PersistentCache[T]) path(key string) string {
+	return filepath.Join(p.dir, hash(key)+".json")
+}
+
+func hash(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,142 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore() *Store {
+	return NewStore(afero.NewMemMapFs(), "cache", time.Hour)
+}
+
+func TestPersistentCache_EntriesAreAvailableToLaterInstances(t *testing.T) {
+	store := newTestStore()
+
+	c := NewPersistentCache[[]string](store, "env")
+	c.Set("key", []string{"a", "b"})
+	store.Persist()
+
+	value, found := NewPersistentCache[[]string](store, "env").Get("key")
+	assert.True(t, found)
+	assert.Equal(t, []string{"a", "b"}, value)
+}
+
+func TestPersistentCache_NamespacesAreSeparated(t *testing.T) {
+	store := newTestStore()
+
+	NewPersistentCache[int](store, "env-1").Set("key", 1)
+	store.Persist()
+
+	_, found := NewPersistentCache[int](store, "env-2").Get("key")
+	assert.False(t, found)
+}
+
+func TestPersistentCache_OutdatedEntriesAreIgnored(t *testing.T) {
+	store := newTestStore()
+	NewPersistentCache[int](store, "env").Set("key", 1)
+	store.Persist()
+
+	c := NewPersistentCache[int](store, "env")
+	c.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, found := c.Get("key")
+	assert.False(t, found)
+}
+
+func TestPersistentCache_EntriesArePersistedOnlyOnceModified(t *testing.T) {
+	store := newTestStore()
+	c := NewPersistentCache[int](store, "env")
+	c.Set("key", 1)
+
+	_, found := NewPersistentCache[int](store, "env").Get("key")
+	assert.False(t, found, "entry must not be persisted before Persist is called")
+
+	c.Persist()
+
+	value, found := NewPersistentCache[int](store, "env").Get("key")
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+}
+
+func TestPersistentCache_ClearPersistsEntries(t *testing.T) {
+	c := NewPersistentCache[int](newTestStore(), "env")
+	c.Set("key", 1)
+	c.Clear()
+
+	value, found := c.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+}
+
+func TestPersistentCache_UpdatesDoNotExtendLifetime(t *testing.T) {
+	store := newTestStore()
+	c := NewPersistentCache[int](store, "env")
+	c.Set("key", 1)
+	c.now = func() time.Time { return time.Now().Add(50 * time.Minute) }
+	c.Set("key", 2)
+	c.Persist()
+
+	later := NewPersistentCache[int](store, "env")
+	later.now = func() time.Time { return time.Now().Add(70 * time.Minute) }
+
+	_, found := later.Get("key")
+	assert.False(t, found)
+}
+
+func TestPersistentCache_DeleteRemovesPersistedEntries(t *testing.T) {
+	store := newTestStore()
+	c := NewPersistentCache[int](store, "env")
+	c.Set("key", 1)
+	c.Persist()
+	c.Delete("key")
+
+	_, found := c.Get("key")
+	assert.False(t, found)
+
+	_, found = NewPersistentCache[int](store, "env").Get("key")
+	assert.False(t, found)
+}
+
+func TestPersistentCache_InvalidFilesAreIgnored(t *testing.T) {
+	store := newTestStore()
+	c := NewPersistentCache[int](store, "env")
+	c.Set("key", 1)
+	c.Persist()
+	require.NoError(t, afero.WriteFile(store.fs, c.path("key"), []byte("{invalid"), 0600))
+
+	_, found := NewPersistentCache[int](store, "env").Get("key")
+	assert.False(t, found)
+}
+
+func TestStoreFromContext(t *testing.T) {
+	_, ok := StoreFromContext(context.Background())
+	assert.False(t, ok)
+
+	store := newTestStore()
+	s, ok := StoreFromContext(ContextWithStore(context.Background(), store))
+	assert.True(t, ok)
+	assert.Equal(t, store, s)
+}
@@ -30,6 +30,7 @@ const (
 	KeyUserActionWebWaitSecondsEnvKey = "MONACO_KUA_WEB_WAIT_SECONDS"
 	MaxFilenameLenKey                 = "MONACO_MAX_FILENAME_LEN"
 	DeploymentReportFilename          = "MONACO_DEPLOYMENT_REPORT_FILENAME"
+	SettingsBatchSizeEnvKey           = "MONACO_SETTINGS_BATCH_SIZE"
 )
 
 var defaultValuesInt = map[string]int{
@@ -38,6 +39,7 @@ var defaultValuesInt = map[string]int{
 	defaultValueKey:                   0,
 	KeyUserActionWebWaitSecondsEnvKey: 1,
 	MaxFilenameLenKey:                 254,
+	SettingsBatchSizeEnvKey:           0,
 }
 
 var logStringInt = map[string]string{
@@ -45,12 +47,14 @@ var logStringInt = map[string]string{
 	ConcurrentDeploymentsEnvKey:       "Concurrent Deployments Limit: %d, from '%s' environment variable",
 	defaultValueKey:                   "Environment variable %s: %d",
 	KeyUserActionWebWaitSecondsEnvKey: "Key User Action Web wait seconds: %d, from '%s' environment variable",
+	SettingsBatchSizeEnvKey:           "Settings Batch Size: %d, from '%s' environment variable",
 }
 var logStringIntDefault = map[string]string{
 	ConcurrentRequestsEnvKey:          "Concurrent Request Limit: %d, '%s' environment variable is NOT set, using default value",
 	ConcurrentDeploymentsEnvKey:       "Concurrent Deployments Limit: %d, '%s' environment variable is NOT set, using default value",
 	defaultValueKey:                   "Environment variable %s: %d, variable is NOT set, using default value",
 	KeyUserActionWebWaitSecondsEnvKey: "Key User Action Web wait seconds: %d, from '%s' environment variable is NOT set, using default value",
+	SettingsBatchSizeEnvKey:           "Settings Batch Size: %d, '%s' environment variable is NOT set, using default value",
 }
 
 func getDefaultInt(env string) int {
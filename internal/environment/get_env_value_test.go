@@ -57,3 +57,11 @@ func TestWithParallelRequestLimitFromEnvOption(t *testing.T) {
 	require.Equal(t, 11, GetEnvValueIntLog(testEnvVar))
 	require.Equal(t, "Environment variable %s: %d", getLogMessage(testEnvVar, logStringInt))
 }
+
+func TestSettingsBatchingIsDisabledByDefault(t *testing.T) {
+	t.Setenv(SettingsBatchSizeEnvKey, "")
+	require.Equal(t, 0, GetEnvValueInt(SettingsBatchSizeEnvKey), "expected settings batching to be opt-in")
+
+	t.Setenv(SettingsBatchSizeEnvKey, "50")
+	require.Equal(t, 50, GetEnvValueInt(SettingsBatchSizeEnvKey))
+}
@@ -49,6 +49,29 @@ const (
 	// SanitizeBucketNames toggles whether bucket names created by Monaco are sanitized or not.
 	// Introduced: v2.23.0
 	SanitizeBucketNames FeatureFlag = "MONACO_SANITIZE_BUCKET_NAMES"
+	// ExtensionsV2 toggles whether Extensions 2.0 packages and their monitoring configurations are downloaded and / or deployed.
+	// Introduced: v2.24.0
+	ExtensionsV2 FeatureFlag = "MONACO_FEAT_EXTENSIONS_V2"
+	// AuthScopeCheck toggles whether deployments check that the credentials of all environments are granted the
+	// scopes required by the deployed configurations before deploying. It is disabled by default, as the required scopes
+	// are derived from the config types and might be incomplete or stricter than needed for some APIs.
+	// Introduced: v2.24.0
+	AuthScopeCheck FeatureFlag = "MONACO_AUTH_SCOPE_CHECK"
+	// DocumentSharing toggles whether the sharing and ownership of documents is downloaded and / or deployed.
+	// Introduced: v2.24.0
+	DocumentSharing FeatureFlag = "MONACO_FEAT_DOCUMENT_SHARING"
+	// AnyDocumentKind toggles whether documents of any type are downloaded and deployed, instead of only the known
+	// document kinds.
+	// Introduced: v2.24.0
+	AnyDocumentKind FeatureFlag = "MONACO_FEAT_ANY_DOCUMENT_KIND"
+	// OpenPipelinePartials toggles whether openpipeline configurations can be composed of partial configurations, e.g.
+	// of single pipelines, processors or routing entries, which are merged into one configuration per kind on deploy.
+	// Introduced: v2.24.0
+	OpenPipelinePartials FeatureFlag = "MONACO_FEAT_OPENPIPELINE_PARTIALS"
+	// BucketSafety toggles whether destructive changes to Grail buckets, like decreasing their retention or deleting
+	// them, need to be allowed explicitly, and whether buckets can be protected from being deleted.
+	// Introduced: v2.24.0
+	BucketSafety FeatureFlag = "MONACO_FEAT_BUCKET_SAFETY"
 )
 
 // temporaryDefaultValues defines temporary feature flags and their default values.
@@ -65,4 +88,10 @@ var temporaryDefaultValues = map[FeatureFlag]defaultValue{
 	ServiceLevelObjective:              true,
 	AccessControlSettings:              false,
 	SanitizeBucketNames:                true,
+	ExtensionsV2:                       false,
+	AuthScopeCheck:                     false,
+	DocumentSharing:                    false,
+	AnyDocumentKind:                    false,
+	OpenPipelinePartials:               false,
+	BucketSafety:                       false,
 }
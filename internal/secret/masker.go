@@ -18,6 +18,7 @@ package secret
 
 import (
 	"encoding/json"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -68,6 +69,37 @@ func Mask(data []byte) []byte {
 
 	return mask(data, keysToSearch)
 }
+
+// MaskForm masks the values of all fields of the given URL encoded form whose name contains one of the masked keys.
+func MaskForm(data []byte) []byte {
+	keysToMask := maskedKeysFromEnv()
+	if len(keysToMask) == 0 {
+		return data
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return []byte("NON-FORM CONTENT")
+	}
+
+	masked := false
+	for name, vs := range values {
+		for _, key := range keysToMask {
+			if strings.Contains(strings.ToLower(name), strings.ToLower(key)) {
+				for i := range vs {
+					vs[i] = "########"
+				}
+				masked = true
+				break
+			}
+		}
+	}
+	if !masked {
+		return data
+	}
+	return []byte(values.Encode())
+}
+
 func mask(jsonStr []byte, keysToSearch []string) []byte {
 	var data interface{}
 	err := json.Unmarshal(jsonStr, &data)
@@ -92,3 +92,35 @@ func TestMaskingTurnedOff(t *testing.T) {
 	masked := Mask([]byte(`{"password":"1234","user":{"searchKey2":{"user":{"searchKey3":"1234","username":"user1"}}}}`))
 	assert.Equal(t, []byte(jsonStr), masked)
 }
+
+func TestMaskForm(t *testing.T) {
+	t.Setenv(EnvMonacoSupportArchiveMaskKeys, "secret,password")
+
+	tests := []struct {
+		name string
+		form string
+		want string
+	}{
+		{
+			name: "Masking field values",
+			form: "grant_type=client_credentials&client_id=my-client&client_secret=1234",
+			want: "client_id=my-client&client_secret=%23%23%23%23%23%23%23%23&grant_type=client_credentials",
+		},
+		{
+			name: "Not masking forms without masked keys",
+			form: "grant_type=client_credentials&scope=a+b",
+			want: "grant_type=client_credentials&scope=a+b",
+		},
+		{
+			name: "Masking with invalid form",
+			form: "password=%zz",
+			want: "NON-FORM CONTENT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(MaskForm([]byte(tt.form))))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const actionPlaceholderPrefix = "__MONACO_TEMPLATE_ACTION_"
+
+var templateAction = regexp.MustCompile(`(?s){{.*?}}`)
+
+// ActionMasker replaces Go template actions in JSON templates with placeholder strings, so that the templates can be
+// parsed as JSON, and restores them afterward. As templates may contain actions in places where they would not be valid
+// JSON (e.g. '"count": {{ .count }}'), such actions are replaced by a quoted placeholder.
+// Placeholders are unique across all contents masked by the same ActionMasker.
+type ActionMasker struct {
+	actions map[string]string
+}
+
+// NewActionMasker returns a new, empty ActionMasker.
+func NewActionMasker() *ActionMasker {
+	return &ActionMasker{actions: map[string]string{}}
+}
+
+// Mask replaces all template actions of the given content with placeholders.
+func (m *ActionMasker) Mask(content string) string {
+	var b strings.Builder
+	inString, last := false, 0
+	for _, match := range templateAction.FindAllStringIndex(content, -1) {
+		inString = updateStringState(content[last:match[0]], inString)
+		b.WriteString(content[last:match[0]])
+
+		placeholder := fmt.Sprintf("%s%d__", actionPlaceholderPrefix, len(m.actions))
+		if !inString {
+			placeholder = `"` + placeholder + `"`
+		}
+		b.WriteString(placeholder)
+		m.actions[placeholder] = content[match[0]:match[1]]
+
+		last = match[1]
+	}
+	b.WriteString(content[last:])
+
+	return b.String()
+}
+
+// Unmask restores all template actions in the given content, which is usually masked content that has been parsed and
+// serialized again.
+func (m *ActionMasker) Unmask(content string) string {
+	for placeholder, action := range m.actions {
+		content = strings.ReplaceAll(content, placeholder, action)
+	}
+	return content
+}
+
+// IsMasked returns whether the given parsed JSON value contains a masked template action.
+func IsMasked(v any) bool {
+	s, ok := v.(string)
+	return ok && strings.Contains(s, actionPlaceholderPrefix)
+}
+
+// updateStringState returns whether the end of the given JSON fragment is inside a string, given it starts inside one
+// or not.
+func updateStringState(fragment string, inString bool) bool {
+	for i := 0; i < len(fragment); i++ {
+		switch fragment[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		}
+	}
+	return inString
+}
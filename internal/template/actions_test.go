@@ -0,0 +1,54 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionMasker_Mask(t *testing.T) {
+	masker := NewActionMasker()
+
+	assert.Equal(t, `{"a": "__MONACO_TEMPLATE_ACTION_0__", "b": "__MONACO_TEMPLATE_ACTION_1__"}`, masker.Mask(`{"a": "{{ .a }}", "b": {{ .b }}}`))
+	assert.Equal(t, `{"c": "x __MONACO_TEMPLATE_ACTION_2__ \"y\""}`, masker.Mask(`{"c": "x {{ .c }} \"y\""}`), "placeholders must be unique across contents")
+	assert.Equal(t, map[string]string{
+		"__MONACO_TEMPLATE_ACTION_0__":   "{{ .a }}",
+		`"__MONACO_TEMPLATE_ACTION_1__"`: "{{ .b }}",
+		"__MONACO_TEMPLATE_ACTION_2__":   "{{ .c }}",
+	}, masker.actions)
+}
+
+func TestActionMasker_Unmask(t *testing.T) {
+	content := `{"a": "{{ .a }}", "b": {{ .b }}, "c": "{{ .c }} and {{ .d }}"}`
+
+	masker := NewActionMasker()
+	assert.Equal(t, content, masker.Unmask(masker.Mask(content)))
+}
+
+func TestIsMasked(t *testing.T) {
+	masker := NewActionMasker()
+	masker.Mask(`{"a": "{{ .a }}"}`)
+
+	assert.True(t, IsMasked("__MONACO_TEMPLATE_ACTION_0__"))
+	assert.True(t, IsMasked("prefix __MONACO_TEMPLATE_ACTION_0__"))
+	assert.False(t, IsMasked("plain"))
+	assert.False(t, IsMasked(42))
+}
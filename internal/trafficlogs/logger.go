@@ -44,11 +44,16 @@ type trafficLogger struct {
 	fs            afero.Fs
 	reqFilePath   string
 	respFilePath  string
+	recFilePath   string
 	reqLogFile    afero.File
 	respLogFile   afero.File
+	recLogFile    afero.File
 	respBufWriter *bufio.Writer
 	reqBufWriter  *bufio.Writer
-	lock          sync.Mutex
+	recBufWriter  *bufio.Writer
+	// pendingRequests are the recorded requests for which no response was received yet, by request ID
+	pendingRequests map[string]RecordedRequest
+	lock            sync.Mutex
 }
 
 func GetInstance() *trafficLogger {
@@ -57,6 +62,7 @@ func GetInstance() *trafficLogger {
 			fs:           afero.NewOsFs(),
 			reqFilePath:  RequestFilePath(),
 			respFilePath: ResponseFilePath(),
+			recFilePath:  RecordingFilePath(),
 		}
 	})
 	return tr
@@ -72,19 +78,47 @@ func ResponseFilePath() string {
 	return path.Join(log.LogDirectory, timeutils.TimeAnchor().Format(TrafficLogFilePrefixFormat)+"-"+"resp.log")
 }
 
+// RecordingFilePath returns the full path of the HTTP traffic recording file for the current execution time - if no traffic logs are written (yet) no file may exist at this path.
+func RecordingFilePath() string {
+	return path.Join(log.LogDirectory, timeutils.TimeAnchor().Format(TrafficLogFilePrefixFormat)+"-"+"recording.jsonl")
+}
+
 // LogToFiles takes a record containing request and response information and tries to write it into the files
 // created by this logger.
 func (l *trafficLogger) LogToFiles(record lib.RequestResponse) {
 	if req, ok := record.IsRequest(); ok {
-		if err := l.logRequest(record.ID, req, req.Body); err != nil {
+		body, err := readBody(req.Body)
+		if err != nil {
+			l.logError(record.ID, "request", err)
+		}
+		if err := l.logRequest(record.ID, req, body); err != nil {
 			l.logError(record.ID, "request", err)
 		}
+		l.recordRequest(record.ID, req, body)
 	}
 	if resp, ok := record.IsResponse(); ok {
-		if err := l.logResponse(record.ID, resp, resp.Body); err != nil {
+		body, err := readBody(resp.Body)
+		if err != nil {
+			l.logError(record.ID, "response", err)
+		}
+		if err := l.logResponse(record.ID, resp, body); err != nil {
 			l.logError(record.ID, "response", err)
 		}
+		if err := l.recordResponse(record.ID, resp, body); err != nil {
+			l.logError(record.ID, "recording", err)
+		}
+	} else if record.Error != nil {
+		l.discardRequest(record.ID)
+	}
+}
+
+// readBody reads the given body, which may be nil. Bodies of the rest client can be read again after reaching EOF.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		return nil, nil
 	}
+	defer body.Close()
+	return io.ReadAll(body)
 }
 
 func (l *trafficLogger) Sync() error {
@@ -110,10 +144,20 @@ func (l *trafficLogger) Sync() error {
 		}
 		l.respLogFile = nil
 	}
+
+	if l.recLogFile != nil {
+		if err := l.recBufWriter.Flush(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := l.recLogFile.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+		l.recLogFile = nil
+	}
 	return errors.Join(errs...)
 }
 
-func (l *trafficLogger) logRequest(id string, request *http.Request, body io.ReadCloser) error {
+func (l *trafficLogger) logRequest(id string, request *http.Request, body []byte) error {
 	l.lock.Lock()
 	defer l.lock.Unlock()
 	if err := l.openRequestLogFile(); err != nil {
@@ -142,12 +186,7 @@ func (l *trafficLogger) logRequest(id string, request *http.Request, body io.Rea
 
 	// write body
 	if body != nil {
-		defer body.Close()
-		data, err := io.ReadAll(body)
-		if err != nil {
-			return err
-		}
-		maskedData := secret.Mask(data)
+		maskedData := secret.Mask(body)
 		if _, err = io.Copy(l.reqBufWriter, bytes.NewReader(maskedData)); err != nil {
 			return err
 		}
@@ -160,7 +199,7 @@ func (l *trafficLogger) logRequest(id string, request *http.Request, body io.Rea
 	return nil
 }
 
-func (l *trafficLogger) logResponse(id string, response *http.Response, body io.ReadCloser) error {
+func (l *trafficLogger) logResponse(id string, response *http.Response, body []byte) error {
 	l.lock.Lock()
 	defer l.lock.Unlock()
 	if err := l.openResponseLogFile(); err != nil {
@@ -185,12 +224,7 @@ func (l *trafficLogger) logResponse(id string, response *http.Response, body io.
 
 	// write body
 	if body != nil {
-		defer body.Close()
-		data, err := io.ReadAll(body)
-		if err != nil {
-			return err
-		}
-		maskedData := secret.Mask(data)
+		maskedData := secret.Mask(body)
 		if _, err = io.Copy(l.respBufWriter, bytes.NewReader(maskedData)); err != nil {
 			return err
 		}
@@ -223,6 +257,16 @@ func (l *trafficLogger) openResponseLogFile() error {
 	return nil
 }
 
+func (l *trafficLogger) openRecordingFile() error {
+	if l.recLogFile == nil {
+		var err error
+		if l.recLogFile, l.recBufWriter, err = l.obtainFileAndWriter(l.recFilePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (l *trafficLogger) obtainFileAndWriter(path string) (afero.File, *bufio.Writer, error) {
 	if err := l.prepareLogDir(); err != nil {
 		return nil, nil, err
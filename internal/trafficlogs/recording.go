@@ -0,0 +1,127 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trafficlogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+)
+
+// sensitiveHeaders are never written to recordings.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Exchange is a recorded HTTP request together with the response received for it. A recording contains one JSON
+// encoded Exchange per line, in the order the responses were received.
+type Exchange struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is an HTTP request as written to a recording. Credential headers are removed and secrets in the
+// body are masked, see maskBody.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is an HTTP response as written to a recording. Credential headers are removed and secrets in the
+// body are masked, see maskBody.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+func (l *trafficLogger) recordRequest(id string, request *http.Request, body []byte) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.pendingRequests == nil {
+		l.pendingRequests = make(map[string]RecordedRequest)
+	}
+	l.pendingRequests[id] = RecordedRequest{
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Header: withoutSensitiveHeaders(request.Header),
+		Body:   maskBody(request.Header, body),
+	}
+}
+
+// recordResponse writes the exchange of the given response and its previously recorded request to the recording.
+func (l *trafficLogger) recordResponse(id string, response *http.Response, body []byte) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	request, found := l.pendingRequests[id]
+	if !found {
+		return fmt.Errorf("no request recorded")
+	}
+	delete(l.pendingRequests, id)
+
+	if err := l.openRecordingFile(); err != nil {
+		return fmt.Errorf("unable to open file for recording traffic: %w", err)
+	}
+
+	data, err := json.Marshal(Exchange{
+		Request: request,
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     withoutSensitiveHeaders(response.Header),
+			Body:       maskBody(response.Header, body),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := l.recBufWriter.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// discardRequest drops the recorded request of an exchange that failed without a response.
+func (l *trafficLogger) discardRequest(id string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.pendingRequests, id)
+}
+
+func withoutSensitiveHeaders(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	h := header.Clone()
+	for _, name := range sensitiveHeaders {
+		h.Del(name)
+	}
+	return h
+}
+
+// maskBody returns the body to record with the values of secret fields masked. JSON bodies and URL encoded forms (e.g.
+// OAuth token requests) are masked field by field. Other bodies, like extension archives or plain text error messages,
+// can not be masked and are recorded as they are.
+func maskBody(header http.Header, body []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type")); err == nil && mediaType == "application/x-www-form-urlencoded" {
+		return string(secret.MaskForm(body))
+	}
+	return string(secret.Mask(body))
+}
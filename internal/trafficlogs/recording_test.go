@@ -0,0 +1,112 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trafficlogs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// record records a single exchange of the given request and response and returns the content of the recording.
+func record(t *testing.T, request *http.Request, requestBody string, response *http.Response, responseBody string) string {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	logger := &trafficLogger{fs: fs, recFilePath: "recording.jsonl"}
+	logger.recordRequest("1", request, []byte(requestBody))
+	require.NoError(t, logger.recordResponse("1", response, []byte(responseBody)))
+	require.NoError(t, logger.Sync())
+
+	content, err := afero.ReadFile(fs, "recording.jsonl")
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestRecording_SensitiveHeadersAreNotRecorded(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "https://env.com/api", nil)
+	request.Header.Set("Authorization", "Api-Token request-credential")
+	request.Header.Set("Proxy-Authorization", "Basic proxy-credential")
+	request.Header.Set("Cookie", "session=request-cookie")
+	request.Header.Set("Accept", "application/json")
+	response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	response.Header.Set("Set-Cookie", "session=response-cookie")
+	response.Header.Set("Content-Type", "application/json")
+
+	recording := record(t, request, "", response, "{}")
+
+	for _, credential := range []string{"request-credential", "proxy-credential", "request-cookie", "response-cookie"} {
+		assert.NotContains(t, recording, credential)
+	}
+	assert.Contains(t, recording, "Accept")
+	assert.Contains(t, recording, "Content-Type")
+
+	// the headers of the original request are not modified
+	assert.Equal(t, "Api-Token request-credential", request.Header.Get("Authorization"))
+}
+
+func TestRecording_SecretsInBodiesAreMasked(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentType  string
+		requestBody  string
+		responseBody string
+	}{
+		{
+			name:         "JSON",
+			contentType:  "application/json",
+			requestBody:  `{"name": "a", "credentials": {"password": "request-secret"}}`,
+			responseBody: `[{"name": "a", "token": "response-secret"}]`,
+		},
+		{
+			name:         "URL encoded form",
+			contentType:  "application/x-www-form-urlencoded; charset=utf-8",
+			requestBody:  "grant_type=client_credentials&client_secret=request-secret",
+			responseBody: `{"access_token": "response-secret"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "https://env.com/api", strings.NewReader(tt.requestBody))
+			request.Header.Set("Content-Type", tt.contentType)
+			response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"application/json"}}}
+
+			recording := record(t, request, tt.requestBody, response, tt.responseBody)
+
+			assert.NotContains(t, recording, "request-secret")
+			assert.NotContains(t, recording, "response-secret")
+			assert.Contains(t, recording, "########")
+		})
+	}
+}
+
+func TestRecording_OtherBodiesAreRecordedUnmasked(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "https://env.com/api", nil)
+	response := &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{"Content-Type": []string{"text/plain"}}}
+
+	recording := record(t, request, "", response, "invalid request")
+
+	assert.Contains(t, recording, "invalid request")
+}
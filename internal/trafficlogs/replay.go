@@ -0,0 +1,189 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trafficlogs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+)
+
+// maxRecordLineSize is the maximum size of a single exchange in a recording
+const maxRecordLineSize = 64 * 1024 * 1024
+
+// syntheticTokenResponse is served for OAuth token requests, as they are not part of recordings.
+const syntheticTokenResponse = `{"access_token":"replayed-token","token_type":"Bearer","expires_in":3600}`
+
+type replayCtxKey struct{}
+
+// ContextWithReplay returns a copy of ctx signaling that all Dynatrace API requests are sent using the given replay
+// transport instead of the network.
+func ContextWithReplay(ctx context.Context, transport *ReplayTransport) context.Context {
+	return context.WithValue(ctx, replayCtxKey{}, transport)
+}
+
+// ReplayTransportFromContext returns the replay transport of the context, if requests are replayed.
+func ReplayTransportFromContext(ctx context.Context) (*ReplayTransport, bool) {
+	t, ok := ctx.Value(replayCtxKey{}).(*ReplayTransport)
+	return t, ok
+}
+
+// LoadRecording reads all exchanges of the recording at the given path.
+func LoadRecording(fs afero.Fs, path string) ([]Exchange, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	var exchanges []Exchange
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid exchange in line %d of recording %q: %w", line, path, err)
+		}
+		exchanges = append(exchanges, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording %q: %w", path, err)
+	}
+	return exchanges, nil
+}
+
+// ReplayTransport is an [http.RoundTripper] that serves the responses of a recording instead of sending requests.
+//
+// A request is answered with the first not yet served exchange with the same method, URL and masked body. If there is
+// none, the body and then the query are ignored as well, so that requests depending on masked values of previous
+// responses (e.g. page keys) can be served. Once all matching exchanges were served, the last one is served again.
+// OAuth token requests are answered with a synthetic token.
+type ReplayTransport struct {
+	exchanges []Exchange
+	served    []bool
+	lock      sync.Mutex
+}
+
+// NewReplayTransport creates a transport serving the given recorded exchanges.
+func NewReplayTransport(exchanges []Exchange) *ReplayTransport {
+	return &ReplayTransport{exchanges: exchanges, served: make([]bool, len(exchanges))}
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	matchers := []func(RecordedRequest) bool{
+		func(r RecordedRequest) bool {
+			return sameURL(r, req, true) && r.Body == string(secret.Mask(body))
+		},
+		func(r RecordedRequest) bool { return sameURL(r, req, true) },
+		func(r RecordedRequest) bool { return sameURL(r, req, false) },
+	}
+
+	lastServed := -1
+	for _, matches := range matchers {
+		for i, e := range t.exchanges {
+			if !matches(e.Request) {
+				continue
+			}
+			if !t.served[i] {
+				t.served[i] = true
+				return newResponse(req, e.Response), nil
+			}
+			lastServed = i
+		}
+		if lastServed >= 0 {
+			return newResponse(req, t.exchanges[lastServed].Response), nil
+		}
+	}
+
+	if isTokenRequest(req, body) {
+		return newResponse(req, RecordedResponse{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       syntheticTokenResponse,
+		}), nil
+	}
+
+	return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+}
+
+// sameURL compares the URL of the recorded request with the one of the given request. Query parameters are compared
+// regardless of their order, or not at all.
+func sameURL(recorded RecordedRequest, req *http.Request, withQuery bool) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	if u.Scheme != req.URL.Scheme || u.Host != req.URL.Host || u.Path != req.URL.Path {
+		return false
+	}
+	return !withQuery || u.Query().Encode() == req.URL.Query().Encode()
+}
+
+func isTokenRequest(req *http.Request, body []byte) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	form, err := url.ParseQuery(string(body))
+	return err == nil && form.Get("grant_type") == "client_credentials"
+}
+
+func newResponse(req *http.Request, r RecordedResponse) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,104 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trafficlogs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lib "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		rw.Header().Set("Set-Cookie", "session=secret")
+		_, _ = rw.Write([]byte(`{"path": "` + req.URL.Path + `", "received": ` + strings.TrimSpace(string(body)) + `, "token": "secret"}`))
+	}))
+	defer server.Close()
+
+	fs := afero.NewMemMapFs()
+	logger := &trafficLogger{fs: fs, reqFilePath: "request.log", respFilePath: "response.log", recFilePath: "recording.jsonl"}
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := lib.NewClient(u, server.Client(), lib.WithHTTPListener(&lib.HTTPListener{Callback: logger.LogToFiles}))
+	client.SetHeader("Authorization", "Api-Token secret")
+
+	resp, err := client.POST(t.Context(), "/api/a", strings.NewReader(`{"name": "a"}`), lib.RequestOptions{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, logger.Sync())
+
+	exchanges, err := LoadRecording(fs, "recording.jsonl")
+	require.NoError(t, err)
+	require.Len(t, exchanges, 1)
+	assert.Equal(t, http.MethodPost, exchanges[0].Request.Method)
+	assert.Empty(t, exchanges[0].Request.Header.Get("Authorization"), "credentials are not recorded")
+	assert.Empty(t, exchanges[0].Response.Header.Get("Set-Cookie"), "credentials are not recorded")
+	assert.NotContains(t, exchanges[0].Response.Body, "secret", "secrets in bodies are masked")
+
+	server.Close()
+	replayClient := lib.NewClient(u, &http.Client{Transport: NewReplayTransport(exchanges)})
+	resp, err = replayClient.POST(t.Context(), "/api/a", strings.NewReader(`{"name": "a"}`), lib.RequestOptions{})
+	require.NoError(t, err)
+	replayedBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"path": "/api/a", "received": {"name": "a"}, "token": "########"}`, string(replayedBody))
+}
+
+func TestReplayTransport_RoundTrip(t *testing.T) {
+	exchanges := []Exchange{
+		{Request: RecordedRequest{Method: http.MethodGet, URL: "https://env.com/api?b=2&a=1"}, Response: RecordedResponse{StatusCode: http.StatusOK, Body: "first"}},
+		{Request: RecordedRequest{Method: http.MethodGet, URL: "https://env.com/api?a=1&b=2"}, Response: RecordedResponse{StatusCode: http.StatusOK, Body: "second"}},
+		{Request: RecordedRequest{Method: http.MethodPost, URL: "https://env.com/api", Body: `{"name":"x"}`}, Response: RecordedResponse{StatusCode: http.StatusCreated, Body: "x"}},
+		{Request: RecordedRequest{Method: http.MethodPost, URL: "https://env.com/api", Body: `{"name":"y"}`}, Response: RecordedResponse{StatusCode: http.StatusCreated, Body: "y"}},
+	}
+	transport := NewReplayTransport(exchanges)
+
+	roundTrip := func(method, u, body string) string {
+		t.Helper()
+		req := httptest.NewRequest(method, u, strings.NewReader(body))
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, "first", roundTrip(http.MethodGet, "https://env.com/api?a=1&b=2", ""), "query parameters are compared regardless of their order")
+	assert.Equal(t, "second", roundTrip(http.MethodGet, "https://env.com/api?a=1&b=2", ""), "exchanges are served in order")
+	assert.Equal(t, "second", roundTrip(http.MethodGet, "https://env.com/api?a=1&b=2", ""), "the last exchange is served again")
+	assert.Equal(t, "second", roundTrip(http.MethodGet, "https://env.com/api?nextPageKey=masked", ""), "the query is ignored if nothing else matches")
+	assert.Equal(t, "y", roundTrip(http.MethodPost, "https://env.com/api", `{"name":"y"}`), "bodies are matched")
+	assert.Equal(t, "x", roundTrip(http.MethodPost, "https://env.com/api", `{"name":"z"}`))
+	assert.Contains(t, roundTrip(http.MethodPost, "https://sso.com/token", "grant_type=client_credentials"), "access_token")
+
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodDelete, "https://env.com/api", nil))
+	assert.ErrorContains(t, err, "no recorded response for DELETE https://env.com/api")
+}
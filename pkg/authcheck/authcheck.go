@@ -0,0 +1,262 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package authcheck determines the scopes required to deploy configurations and checks whether the credentials of an
+// environment are granted all of them, so that deployments don't fail halfway because of missing permissions.
+package authcheck
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// Credential is the kind of credential a scope is granted to.
+type Credential string
+
+const (
+	// Token is the access token used for classic APIs
+	Token Credential = "access token"
+	// Platform is the OAuth client or platform token used for platform APIs
+	Platform Credential = "platform credentials"
+)
+
+// Scope is a scope of a credential.
+type Scope struct {
+	Credential Credential
+	Name       string
+}
+
+// Requirements are the scopes required to deploy configurations, each with the config types requiring it.
+type Requirements map[Scope][]string
+
+func (r Requirements) add(credential Credential, requiredBy string, scopes ...string) {
+	for _, s := range scopes {
+		scope := Scope{Credential: credential, Name: s}
+		if !slices.Contains(r[scope], requiredBy) {
+			r[scope] = append(r[scope], requiredBy)
+		}
+	}
+}
+
+// RequiredScopes returns the scopes required to deploy the given configs to an environment using the given
+// authentication. Skipped configs are not deployed and don't require any scopes.
+func RequiredScopes(auth manifest.Auth, configs iter.Seq[config.Config]) Requirements {
+	r := Requirements{}
+	if auth.OAuth != nil {
+		// the classic environment URL of platform environments is looked up by an app function
+		r.add(Platform, "environment URL lookup", "app-engine:apps:run")
+	}
+
+	for c := range configs {
+		if c.Skip {
+			continue
+		}
+
+		requiredBy := c.Coordinate.Type
+		switch t := c.Type.(type) {
+		case config.ClassicApiType:
+			scopes, found := classicAPIScopes[t.Api]
+			if !found {
+				scopes = configAPIScopes
+			}
+			r.add(Token, requiredBy, scopes...)
+		case config.SettingsType:
+			// settings are deployed using the platform credentials, if there are any
+			if auth.HasPlatformAuth() {
+				r.add(Platform, requiredBy, "settings:schemas:read", "settings:objects:read", "settings:objects:write")
+			} else {
+				r.add(Token, requiredBy, "settings.read", "settings.write")
+			}
+		case config.AutomationType:
+			r.add(Platform, requiredBy, automationScopes(t.Resource)...)
+		case config.DocumentType:
+			r.add(Platform, requiredBy, "document:documents:read", "document:documents:write")
+			if t.Sharing != nil {
+				r.add(Platform, requiredBy, "document:direct-shares:read", "document:direct-shares:write", "document:direct-shares:delete",
+					"document:environment-shares:read", "document:environment-shares:write", "document:environment-shares:delete")
+			}
+		case config.BucketType:
+			r.add(Platform, requiredBy, "storage:bucket-definitions:read", "storage:bucket-definitions:write")
+		case config.ServiceLevelObjective:
+			r.add(Platform, requiredBy, "slo:slos:read", "slo:slos:write")
+		case config.Segment:
+			r.add(Platform, requiredBy, "storage:filter-segments:read", "storage:filter-segments:write")
+		case config.OpenPipelineType:
+			r.add(Platform, requiredBy, "openpipeline:configurations:read", "openpipeline:configurations:write")
+		case config.ExtensionV2Type:
+			r.add(Token, requiredBy, "extensions.read", "extensions.write", "extensionEnvironment.read", "extensionEnvironment.write")
+		case config.ExtensionV2MonitoringConfigurationType:
+			r.add(Token, requiredBy, "extensionConfigurations.read", "extensionConfigurations.write")
+		}
+	}
+
+	for s := range r {
+		slices.Sort(r[s])
+	}
+	return r
+}
+
+// classicAPIScopes are the token scopes required by classic APIs that aren't part of the configuration API. All other
+// classic APIs require the configuration API scopes.
+var classicAPIScopes = map[string][]string{
+	api.Slo:               {"slo.read", "slo.write"},
+	api.NetworkZone:       {"networkZones.read", "networkZones.write"},
+	api.CredentialVault:   {"credentialVault.read", "credentialVault.write"},
+	api.SyntheticLocation: {"ReadSyntheticData", "ExternalSyntheticIntegration"},
+	api.SyntheticMonitor:  {"ReadSyntheticData", "ExternalSyntheticIntegration"},
+}
+
+var configAPIScopes = []string{"ReadConfig", "WriteConfig"}
+
+func automationScopes(resource config.AutomationResource) []string {
+	switch resource {
+	case config.BusinessCalendar:
+		return []string{"automation:calendars:read", "automation:calendars:write"}
+	case config.SchedulingRule:
+		return []string{"automation:rules:read", "automation:rules:write"}
+	default:
+		return []string{"automation:workflows:read", "automation:workflows:write"}
+	}
+}
+
+// ScopeSource determines the scopes granted to the credentials of an environment.
+type ScopeSource interface {
+	// TokenScopes returns the scopes granted to the environment's access token.
+	TokenScopes(ctx context.Context) ([]string, error)
+	// PlatformScopes returns the scopes granted to the environment's platform credentials.
+	PlatformScopes(ctx context.Context) ([]string, error)
+}
+
+// MissingScope is a required scope that is not granted to the credential.
+type MissingScope struct {
+	Scope
+	// RequiredBy are the config types requiring the scope
+	RequiredBy []string
+}
+
+func (m MissingScope) String() string {
+	return fmt.Sprintf("%s (required by %s)", m.Name, strings.Join(m.RequiredBy, ", "))
+}
+
+// Result is the result of checking the scopes of a single environment.
+type Result struct {
+	Environment string
+	// Missing are the required scopes that are not granted, sorted by credential and name
+	Missing []MissingScope
+	// Unchecked lists the credentials whose scopes could not be determined, together with the reason
+	Unchecked map[Credential]string
+}
+
+// OK returns whether no required scope is known to be missing.
+func (r Result) OK() bool {
+	return len(r.Missing) == 0
+}
+
+func (r Result) String() string {
+	var sb strings.Builder
+	for _, credential := range []Credential{Token, Platform} {
+		var missing []string
+		for _, m := range r.Missing {
+			if m.Credential == credential {
+				missing = append(missing, m.String())
+			}
+		}
+		if len(missing) > 0 {
+			fmt.Fprintf(&sb, "\n  missing scopes of the %s of environment %q:\n    %s", credential, r.Environment, strings.Join(missing, "\n    "))
+		}
+	}
+	return strings.TrimPrefix(sb.String(), "\n")
+}
+
+// Check checks whether the credentials of the environment are granted all scopes required to deploy the given configs.
+// Credentials whose scopes can't be determined, e.g. platform tokens, are reported as unchecked instead of failing the
+// check.
+func Check(ctx context.Context, env manifest.EnvironmentDefinition, configs iter.Seq[config.Config], source ScopeSource) Result {
+	result := Result{Environment: env.Name, Unchecked: map[Credential]string{}}
+	required := RequiredScopes(env.Auth, configs)
+
+	granted := map[Credential]map[string]struct{}{}
+	for _, credential := range []Credential{Token, Platform} {
+		if !requiresCredential(required, credential) {
+			continue
+		}
+
+		scopes, reason := grantedScopes(ctx, env.Auth, credential, source)
+		if reason != "" {
+			result.Unchecked[credential] = reason
+			continue
+		}
+		granted[credential] = make(map[string]struct{}, len(scopes))
+		for _, s := range scopes {
+			granted[credential][s] = struct{}{}
+		}
+	}
+
+	for scope, requiredBy := range required {
+		g, checked := granted[scope.Credential]
+		if !checked {
+			continue
+		}
+		if _, found := g[scope.Name]; !found {
+			result.Missing = append(result.Missing, MissingScope{Scope: scope, RequiredBy: requiredBy})
+		}
+	}
+	slices.SortFunc(result.Missing, func(a, b MissingScope) int {
+		return cmp.Or(cmp.Compare(a.Credential, b.Credential), cmp.Compare(a.Name, b.Name))
+	})
+
+	return result
+}
+
+func requiresCredential(required Requirements, credential Credential) bool {
+	for s := range maps.Keys(required) {
+		if s.Credential == credential {
+			return true
+		}
+	}
+	return false
+}
+
+func grantedScopes(ctx context.Context, auth manifest.Auth, credential Credential, source ScopeSource) ([]string, string) {
+	var scopes []string
+	var err error
+	switch {
+	case credential == Token && auth.Token == nil:
+		return nil, "no access token configured"
+	case credential == Token:
+		scopes, err = source.TokenScopes(ctx)
+	case auth.PlatformToken != nil:
+		return nil, "scopes of platform tokens can't be determined"
+	case auth.OAuth == nil:
+		return nil, "no OAuth client or platform token configured"
+	default:
+		scopes, err = source.PlatformScopes(ctx)
+	}
+
+	if err != nil {
+		return nil, err.Error()
+	}
+	return scopes, ""
+}
@@ -0,0 +1,214 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package authcheck
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+type testScopeSource struct {
+	tokenScopes    []string
+	platformScopes []string
+	err            error
+}
+
+func (s testScopeSource) TokenScopes(context.Context) ([]string, error) {
+	return s.tokenScopes, s.err
+}
+
+func (s testScopeSource) PlatformScopes(context.Context) ([]string, error) {
+	return s.platformScopes, s.err
+}
+
+func newConfig(t config.Type, configType string) config.Config {
+	return config.Config{Type: t, Coordinate: coordinate.Coordinate{Project: "p", Type: configType, ConfigId: "c"}}
+}
+
+var (
+	tokenAuth = manifest.Auth{Token: &manifest.AuthSecret{}}
+	oAuth     = manifest.Auth{Token: &manifest.AuthSecret{}, OAuth: &manifest.OAuth{}}
+)
+
+func TestRequiredScopes(t *testing.T) {
+	configs := []config.Config{
+		newConfig(config.ClassicApiType{Api: "alerting-profile"}, "alerting-profile"),
+		newConfig(config.ClassicApiType{Api: "slo"}, "slo"),
+		newConfig(config.SettingsType{SchemaId: "builtin:tags.auto-tagging"}, "builtin:tags.auto-tagging"),
+		newConfig(config.DocumentType{}, "document"),
+	}
+
+	t.Run("settings use the token without platform credentials", func(t *testing.T) {
+		r := RequiredScopes(tokenAuth, slices.Values(configs))
+		assert.Equal(t, Requirements{
+			{Token, "ReadConfig"}:                  {"alerting-profile"},
+			{Token, "WriteConfig"}:                 {"alerting-profile"},
+			{Token, "slo.read"}:                    {"slo"},
+			{Token, "slo.write"}:                   {"slo"},
+			{Token, "settings.read"}:               {"builtin:tags.auto-tagging"},
+			{Token, "settings.write"}:              {"builtin:tags.auto-tagging"},
+			{Platform, "document:documents:read"}:  {"document"},
+			{Platform, "document:documents:write"}: {"document"},
+		}, r)
+	})
+
+	t.Run("settings use the platform credentials if there are any", func(t *testing.T) {
+		r := RequiredScopes(oAuth, slices.Values(configs))
+		assert.Contains(t, r, Scope{Platform, "settings:objects:write"})
+		assert.Contains(t, r, Scope{Platform, "app-engine:apps:run"})
+		assert.NotContains(t, r, Scope{Token, "settings.write"})
+	})
+
+	t.Run("skipped configs don't require scopes", func(t *testing.T) {
+		c := newConfig(config.BucketType{}, "bucket")
+		c.Skip = true
+		assert.Empty(t, RequiredScopes(tokenAuth, slices.Values([]config.Config{c})))
+	})
+
+	t.Run("shared documents require the sharing scopes", func(t *testing.T) {
+		r := RequiredScopes(tokenAuth, slices.Values([]config.Config{
+			newConfig(config.DocumentType{Sharing: &config.DocumentSharing{Environment: config.DocumentReadAccess}}, "document"),
+		}))
+		assert.Contains(t, r, Scope{Platform, "document:environment-shares:write"})
+		assert.Contains(t, r, Scope{Platform, "document:direct-shares:delete"})
+	})
+
+	t.Run("the credential vault requires the credential vault scopes", func(t *testing.T) {
+		r := RequiredScopes(tokenAuth, slices.Values([]config.Config{
+			newConfig(config.ClassicApiType{Api: "credential-vault"}, "credential-vault"),
+		}))
+		assert.Equal(t, Requirements{
+			{Token, "credentialVault.read"}:  {"credential-vault"},
+			{Token, "credentialVault.write"}: {"credential-vault"},
+		}, r)
+	})
+
+	t.Run("config types requiring a scope are listed once", func(t *testing.T) {
+		r := RequiredScopes(tokenAuth, slices.Values([]config.Config{
+			newConfig(config.ClassicApiType{Api: "management-zone"}, "management-zone"),
+			newConfig(config.ClassicApiType{Api: "alerting-profile"}, "alerting-profile"),
+			newConfig(config.ClassicApiType{Api: "alerting-profile"}, "alerting-profile"),
+		}))
+		assert.Equal(t, []string{"alerting-profile", "management-zone"}, r[Scope{Token, "WriteConfig"}])
+	})
+}
+
+func TestCheck(t *testing.T) {
+	configs := slices.Values([]config.Config{
+		newConfig(config.ClassicApiType{Api: "alerting-profile"}, "alerting-profile"),
+		newConfig(config.SettingsType{SchemaId: "builtin:tags.auto-tagging"}, "builtin:tags.auto-tagging"),
+		newConfig(config.DocumentType{}, "document"),
+	})
+
+	t.Run("all scopes granted", func(t *testing.T) {
+		env := manifest.EnvironmentDefinition{Name: "env", Auth: oAuth}
+		source := testScopeSource{
+			tokenScopes:    []string{"ReadConfig", "WriteConfig", "DataExport"},
+			platformScopes: []string{"app-engine:apps:run", "settings:schemas:read", "settings:objects:read", "settings:objects:write", "document:documents:read", "document:documents:write"},
+		}
+
+		result := Check(t.Context(), env, configs, source)
+		assert.True(t, result.OK())
+		assert.Empty(t, result.Unchecked)
+	})
+
+	t.Run("missing scopes are reported per credential", func(t *testing.T) {
+		env := manifest.EnvironmentDefinition{Name: "env", Auth: oAuth}
+		source := testScopeSource{
+			tokenScopes:    []string{"ReadConfig"},
+			platformScopes: []string{"app-engine:apps:run", "settings:schemas:read", "settings:objects:read", "document:documents:read"},
+		}
+
+		result := Check(t.Context(), env, configs, source)
+		assert.False(t, result.OK())
+		assert.Equal(t, []MissingScope{
+			{Scope: Scope{Token, "WriteConfig"}, RequiredBy: []string{"alerting-profile"}},
+			{Scope: Scope{Platform, "document:documents:write"}, RequiredBy: []string{"document"}},
+			{Scope: Scope{Platform, "settings:objects:write"}, RequiredBy: []string{"builtin:tags.auto-tagging"}},
+		}, result.Missing)
+		assert.Equal(t, `  missing scopes of the access token of environment "env":
+    WriteConfig (required by alerting-profile)
+  missing scopes of the platform credentials of environment "env":
+    document:documents:write (required by document)
+    settings:objects:write (required by builtin:tags.auto-tagging)`, result.String())
+	})
+
+	t.Run("credentials whose scopes can't be determined are not checked", func(t *testing.T) {
+		env := manifest.EnvironmentDefinition{Name: "env", Auth: oAuth}
+
+		result := Check(t.Context(), env, configs, testScopeSource{err: errors.New("lookup failed")})
+		assert.True(t, result.OK())
+		assert.Equal(t, map[Credential]string{Token: "lookup failed", Platform: "lookup failed"}, result.Unchecked)
+	})
+
+	t.Run("scopes of platform tokens are not checked", func(t *testing.T) {
+		env := manifest.EnvironmentDefinition{Name: "env", Auth: manifest.Auth{Token: &manifest.AuthSecret{}, PlatformToken: &manifest.AuthSecret{}}}
+
+		result := Check(t.Context(), env, configs, testScopeSource{tokenScopes: []string{"ReadConfig", "WriteConfig"}})
+		assert.True(t, result.OK())
+		assert.Contains(t, result.Unchecked, Platform)
+	})
+}
+
+type testAccessTokensClient struct {
+	scopes []string
+}
+
+func (c testAccessTokensClient) LookupScopes(context.Context, string) ([]string, error) {
+	return c.scopes, nil
+}
+
+func TestCheckEnvironments(t *testing.T) {
+	projects := []project.Project{{
+		Id: "p",
+		Configs: project.ConfigsPerTypePerEnvironments{
+			"ok":     {"alerting-profile": {newConfig(config.ClassicApiType{Api: "alerting-profile"}, "alerting-profile")}},
+			"failed": {"alerting-profile": {newConfig(config.ClassicApiType{Api: "alerting-profile"}, "alerting-profile")}},
+		},
+	}}
+	environments := manifest.Environments{
+		"ok":     {Name: "ok", Auth: tokenAuth},
+		"failed": {Name: "failed", Auth: tokenAuth},
+	}
+
+	t.Run("environments missing scopes fail the check", func(t *testing.T) {
+		err := CheckEnvironments(t.Context(), projects, environments, map[string]client.AccessTokensClient{
+			"ok":     testAccessTokensClient{scopes: []string{"ReadConfig", "WriteConfig"}},
+			"failed": testAccessTokensClient{scopes: []string{"ReadConfig"}},
+		})
+		assert.EqualError(t, err, "the credentials of 1 environment(s) are missing required scopes")
+	})
+
+	t.Run("environments without access tokens client are not checked", func(t *testing.T) {
+		err := CheckEnvironments(t.Context(), projects, environments, map[string]client.AccessTokensClient{
+			"ok": testAccessTokensClient{scopes: []string{"ReadConfig", "WriteConfig"}},
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package authcheck
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// CheckEnvironments checks whether the credentials of every environment are granted all scopes required to deploy the
+// projects' configurations to it. Access tokens are looked up using the client of their environment in accessTokens.
+// Missing scopes are logged per environment. Credentials whose scopes can't be determined are only warned about.
+func CheckEnvironments(ctx context.Context, projects []project.Project, environments manifest.Environments, accessTokens map[string]client.AccessTokensClient) error {
+	sorted := slices.SortedFunc(maps.Values(environments), func(a, b manifest.EnvironmentDefinition) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	var failed int
+	for _, env := range sorted {
+		result := Check(ctx, env, configsOfEnvironment(projects, env.Name), NewScopeSource(env, accessTokens[env.Name]))
+		for credential, reason := range result.Unchecked {
+			log.Warn("Could not check the scopes of the %s of environment %q: %s", credential, env.Name, reason)
+		}
+
+		if !result.OK() {
+			log.Error("%s", result)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("the credentials of %d environment(s) are missing required scopes", failed)
+	}
+	return nil
+}
+
+func configsOfEnvironment(projects []project.Project, environment string) func(yield func(config.Config) bool) {
+	return func(yield func(config.Config) bool) {
+		for _, p := range projects {
+			for c := range p.Configs[environment].AllConfigs {
+				if !yield(c) {
+					return
+				}
+			}
+		}
+	}
+}
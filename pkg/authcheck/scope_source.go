@@ -0,0 +1,57 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package authcheck
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/auth"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// environmentScopeSource determines the scopes of an environment's credentials by looking up its access token and by
+// requesting an OAuth token, whose response lists the scopes granted to the OAuth client.
+type environmentScopeSource struct {
+	auth         manifest.Auth
+	accessTokens client.AccessTokensClient
+}
+
+// NewScopeSource returns a ScopeSource for the credentials of the given environment. The access token is looked up
+// using the given client.
+func NewScopeSource(env manifest.EnvironmentDefinition, accessTokens client.AccessTokensClient) ScopeSource {
+	return environmentScopeSource{auth: env.Auth, accessTokens: accessTokens}
+}
+
+func (s environmentScopeSource) TokenScopes(ctx context.Context) ([]string, error) {
+	if s.auth.Token == nil || s.accessTokens == nil {
+		return nil, errors.New("no access token configured")
+	}
+	return s.accessTokens.LookupScopes(ctx, s.auth.Token.Value.Value())
+}
+
+func (s environmentScopeSource) PlatformScopes(ctx context.Context) ([]string, error) {
+	if s.auth.OAuth == nil {
+		return nil, errors.New("no OAuth client configured")
+	}
+	return auth.FetchOAuthScopes(ctx, auth.OauthCredentials{
+		ClientID:     s.auth.OAuth.ClientID.Value.Value(),
+		ClientSecret: s.auth.OAuth.ClientSecret.Value.Value(),
+		TokenURL:     s.auth.OAuth.GetTokenEndpointValue(),
+	})
+}
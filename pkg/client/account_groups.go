@@ -0,0 +1,93 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/clients/accounts"
+)
+
+type accountGroup struct {
+	id   string
+	name string
+}
+
+// accountGroupsClient looks up the groups of multiple accounts. The groups are only requested once and cached afterward.
+type accountGroupsClient struct {
+	// accountClients holds the clients of all accounts, keyed by the account UUID
+	accountClients map[string]*accounts.Client
+
+	once   sync.Once
+	groups []accountGroup
+	err    error
+}
+
+// NewAccountGroupsClient creates an AccountGroupsClient looking up the groups of all given accounts, keyed by their UUID.
+func NewAccountGroupsClient(accountClients map[string]*accounts.Client) AccountGroupsClient {
+	return &accountGroupsClient{accountClients: accountClients}
+}
+
+// GroupIDByName returns the ID of the group with the given name. It is an error if no or multiple groups with the name exist.
+func (c *accountGroupsClient) GroupIDByName(ctx context.Context, name string) (string, error) {
+	c.once.Do(func() {
+		c.groups, c.err = c.listGroups(ctx)
+	})
+	if c.err != nil {
+		return "", c.err
+	}
+
+	var ids []string
+	for _, g := range c.groups {
+		if g.name == name {
+			ids = append(ids, g.id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no group named %q found in the accounts of the manifest", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("multiple groups named %q found in the accounts of the manifest: %v", name, ids)
+	}
+}
+
+func (c *accountGroupsClient) listGroups(ctx context.Context) ([]accountGroup, error) {
+	var result []accountGroup
+	for accountUUID, accClient := range c.accountClients {
+		r, resp, err := accClient.GroupManagementAPI.GetGroups(ctx, accountUUID).Execute()
+		if resp != nil && resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list groups of account %q: %w", accountUUID, err)
+		}
+		if r == nil {
+			return nil, errors.New("no group response data received")
+		}
+
+		for _, g := range r.Items {
+			result = append(result, accountGroup{id: g.GetUuid(), name: g.Name})
+		}
+	}
+	return result, nil
+}
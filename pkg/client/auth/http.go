@@ -18,6 +18,8 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"golang.org/x/oauth2/clientcredentials"
 	"net/http"
@@ -52,6 +54,27 @@ func NewOAuthClient(ctx context.Context, oauthConfig OauthCredentials) *http.Cli
 	return config.Client(ctx)
 }
 
+// FetchOAuthScopes requests an access token using the client credentials and returns the scopes granted to it, as
+// listed by the token response.
+func FetchOAuthScopes(ctx context.Context, oauthConfig OauthCredentials) ([]string, error) {
+	config := clientcredentials.Config{
+		ClientID:     oauthConfig.ClientID,
+		ClientSecret: oauthConfig.ClientSecret,
+		TokenURL:     oauthConfig.TokenURL,
+		Scopes:       oauthConfig.Scopes,
+	}
+	token, err := config.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request OAuth token: %w", err)
+	}
+
+	scope, _ := token.Extra("scope").(string)
+	if scope == "" {
+		return nil, errors.New("token response doesn't list the granted scopes")
+	}
+	return strings.Fields(scope), nil
+}
+
 func isNewDynatraceTokenFormat(token string) bool {
 	return strings.HasPrefix(token, "dt0c01.") && strings.Count(token, ".") == 2
 }
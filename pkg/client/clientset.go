@@ -18,7 +18,9 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"runtime"
 	"time"
@@ -34,7 +36,9 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/segments"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/slo"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/supportarchive"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/cache"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/trafficlogs"
@@ -46,10 +50,14 @@ import (
 )
 
 var (
-	_ SettingsClient = (*dtclient.SettingsClient)(nil)
-	_ ConfigClient   = (*dtclient.ConfigClient)(nil)
-	_ SettingsClient = (*dtclient.DummySettingsClient)(nil)
-	_ ConfigClient   = (*dtclient.DummyConfigClient)(nil)
+	_ SettingsClient     = (*dtclient.SettingsClient)(nil)
+	_ ConfigClient       = (*dtclient.ConfigClient)(nil)
+	_ SettingsClient     = (*dtclient.DummySettingsClient)(nil)
+	_ ConfigClient       = (*dtclient.DummyConfigClient)(nil)
+	_ EntitiesClient     = (*dtclient.EntitiesClient)(nil)
+	_ ExtensionsClient   = (*dtclient.ExtensionsClient)(nil)
+	_ ExtensionsClient   = (*DummyExtensionsClient)(nil)
+	_ AccessTokensClient = (*dtclient.AccessTokensClient)(nil)
 )
 
 //go:generate mockgen -source=clientset.go -destination=client_mock.go -package=client ConfigClient
@@ -125,6 +133,10 @@ type SettingsClient interface {
 	// update the object.
 	Upsert(context.Context, dtclient.SettingsObject, dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error)
 
+	// UpsertBatch creates or updates all supplied objects of one schema like Upsert, but in a single request.
+	// The results are returned in the order of the supplied objects.
+	UpsertBatch(context.Context, []dtclient.SettingsObject) []dtclient.UpsertResult
+
 	// ListSchemas returns all schemas that the Dynatrace environment reports
 	ListSchemas(context.Context) (dtclient.SchemaList, error)
 
@@ -178,6 +190,7 @@ type DocumentClient interface {
 }
 
 type OpenPipelineClient interface {
+	Get(ctx context.Context, id string) (openpipeline.Response, error)
 	GetAll(ctx context.Context) ([]openpipeline.Response, error)
 	Update(ctx context.Context, id string, data []byte) (openpipeline.Response, error)
 }
@@ -198,6 +211,48 @@ type ServiceLevelObjectiveClient interface {
 	Delete(ctx context.Context, id string) (libAPI.Response, error)
 }
 
+// EntitiesClient reads monitored entities, e.g. to look up the display names of entity IDs.
+type EntitiesClient interface {
+	List(ctx context.Context, entitySelector string) ([]dtclient.Entity, error)
+}
+
+// ExtensionsClient manages Extensions 2.0 extensions and their monitoring configurations.
+type ExtensionsClient interface {
+	List(ctx context.Context) ([]dtclient.ExtensionVersion, error)
+	ListVersions(ctx context.Context, extensionName string) ([]dtclient.ExtensionVersion, error)
+	Upload(ctx context.Context, extensionPackage []byte) (dtclient.ExtensionVersion, error)
+	GetPackage(ctx context.Context, extensionName, version string) ([]byte, error)
+	GetActiveVersion(ctx context.Context, extensionName string) (string, bool, error)
+	EnsureActiveVersion(ctx context.Context, extensionName, version string) (bool, error)
+	ListMonitoringConfigurations(ctx context.Context, extensionName string) ([]dtclient.MonitoringConfiguration, error)
+	CreateMonitoringConfiguration(ctx context.Context, extensionName, scope string, value json.RawMessage) (string, error)
+	UpdateMonitoringConfiguration(ctx context.Context, extensionName, id string, value json.RawMessage) error
+}
+
+// DocumentSharingClient manages the shares and the owner of documents.
+type DocumentSharingClient interface {
+	ListDirectShares(ctx context.Context, documentID string) ([]dtclient.DirectShare, error)
+	CreateDirectShare(ctx context.Context, documentID, access string, recipients []dtclient.Recipient) error
+	DeleteDirectShare(ctx context.Context, shareID string) error
+	ListRecipients(ctx context.Context, shareID string) ([]dtclient.Recipient, error)
+	AddRecipients(ctx context.Context, shareID string, recipients []dtclient.Recipient) error
+	RemoveRecipients(ctx context.Context, shareID string, recipientIDs []string) error
+	ListEnvironmentShares(ctx context.Context, documentID string) ([]dtclient.EnvironmentShare, error)
+	CreateEnvironmentShare(ctx context.Context, documentID, access string) error
+	DeleteEnvironmentShare(ctx context.Context, shareID string) error
+	TransferOwner(ctx context.Context, documentID string, version int, newOwnerID string) error
+}
+
+// AccountGroupsClient looks up the groups of the accounts defined in the manifest, e.g. to resolve groups referenced by name.
+type AccountGroupsClient interface {
+	GroupIDByName(ctx context.Context, name string) (string, error)
+}
+
+// AccessTokensClient looks up the metadata of access tokens, e.g. to check whether a token has all required scopes.
+type AccessTokensClient interface {
+	LookupScopes(ctx context.Context, token string) ([]string, error)
+}
+
 var DefaultMonacoUserAgent = "Dynatrace Monitoring as Code/" + version.MonitoringAsCode + " " + (runtime.GOOS + " " + runtime.GOARCH)
 
 var DefaultRetryOptions = rest.RetryOptions{MaxRetries: 10, ShouldRetryFunc: rest.RetryIfNotSuccess}
@@ -214,11 +269,23 @@ type ClientSet struct {
 	OpenPipelineClient          OpenPipelineClient
 	SegmentClient               SegmentClient
 	ServiceLevelObjectiveClient ServiceLevelObjectiveClient
+	EntitiesClient              EntitiesClient
+	ExtensionsClient            ExtensionsClient
+	AccessTokensClient          AccessTokensClient
+	DocumentSharingClient       DocumentSharingClient
+	// AccountGroupsClient is not created with the ClientSet, as it requires account credentials. It is nil unless
+	// set explicitly.
+	AccountGroupsClient AccountGroupsClient
 }
 
 type ClientOptions struct {
 	CustomUserAgent string
 	CachingDisabled bool
+	// PersistentCachingDisabled disables reusing the listings persisted in the cache store of the context, so that
+	// all listings are fetched fresh from the environment.
+	PersistentCachingDisabled bool
+	// Transport holds optional HTTP transport settings applied to all clients. If nil, the default transport is used.
+	Transport *manifest.Transport
 }
 
 func (o ClientOptions) getUserAgentString() string {
@@ -263,6 +330,10 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		openPipelineClient          OpenPipelineClient
 		segmentClient               SegmentClient
 		serviceLevelObjectiveClient ServiceLevelObjectiveClient
+		entitiesClient              EntitiesClient
+		extensionsClient            ExtensionsClient
+		accessTokensClient          AccessTokensClient
+		documentSharingClient       DocumentSharingClient
 		err                         error
 	)
 	concurrentReqLimit := environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
@@ -280,75 +351,118 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		cFactory = cFactory.WithHTTPListener(&rest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles})
 	}
 
+	var transport http.RoundTripper
+	if opts.Transport != nil {
+		t, err := NewHTTPTransport(*opts.Transport)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply transport settings: %w", err)
+		}
+		transport = t
+		ctx = ContextWithTransport(ctx, transport)
+	}
+
+	if replay, ok := trafficlogs.ReplayTransportFromContext(ctx); ok {
+		transport = replay
+		ctx = ContextWithTransport(ctx, transport)
+	}
+
+	persistentSettingsCache, persistentConfigCache := persistentCacheOptions(ctx, url, opts.CachingDisabled || opts.PersistentCachingDisabled)
+
 	classicURL := url
-	if auth.OAuth != nil {
-		cFactory = cFactory.WithOAuthCredentials(
-			clientcredentials.Config{
-				ClientID:     auth.OAuth.ClientID.Value.Value(),
-				ClientSecret: auth.OAuth.ClientSecret.Value.Value(),
-				TokenURL:     auth.OAuth.GetTokenEndpointValue(),
-			}).WithPlatformURL(url)
-		client, err := cFactory.CreatePlatformClient(ctx)
+	if auth.HasPlatformAuth() {
+		var platformClient func() (*rest.Client, error)
+		if auth.OAuth != nil {
+			cFactory = cFactory.WithOAuthCredentials(
+				clientcredentials.Config{
+					ClientID:     auth.OAuth.ClientID.Value.Value(),
+					ClientSecret: auth.OAuth.ClientSecret.Value.Value(),
+					TokenURL:     auth.OAuth.GetTokenEndpointValue(),
+				}).WithPlatformURL(url)
+			platformClient = func() (*rest.Client, error) { return cFactory.CreatePlatformClient(ctx) }
+		} else {
+			httpClient := NewPlatformTokenHTTPClient(auth.PlatformToken.Value.Value(), transport)
+			platformClient = func() (*rest.Client, error) {
+				return createRestClient(ctx, url, httpClient, concurrentReqLimit, opts.getUserAgentString())
+			}
+		}
+
+		client, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
 
-		bucketClient, err = cFactory.BucketClientWithRetrySettings(ctx, time.Second, 5*time.Minute)
+		bucketRestClient, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
+		bucketClient = buckets.NewClient(bucketRestClient, buckets.WithRetrySettings(time.Second, 5*time.Minute))
 
-		autClient, err = cFactory.AutomationClient(ctx)
+		autRestClient, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
+		autClient = automation.NewClient(autRestClient)
 
-		documentClient, err = cFactory.DocumentClient(ctx)
+		documentRestClient, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
+		documentClient = documents.NewClient(documentRestClient)
+		documentSharingClient = dtclient.NewDocumentSharingClient(documentRestClient)
 
-		openPipelineClient, err = cFactory.OpenPipelineClient(ctx)
+		openPipelineRestClient, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
+		openPipelineClient = openpipeline.NewClient(openPipelineRestClient)
 
-		segmentClient, err = cFactory.SegmentsClient(ctx)
+		segmentRestClient, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
+		segmentClient = segments.NewClient(segmentRestClient)
 
-		serviceLevelObjectiveClient, err = cFactory.SLOClient(ctx)
+		sloRestClient, err := platformClient()
 		if err != nil {
 			return nil, err
 		}
+		serviceLevelObjectiveClient = slo.NewClient(sloRestClient)
 
-		settingsClient, err = dtclient.NewPlatformSettingsClient(client, dtclient.WithCachingDisabled(opts.CachingDisabled))
+		settingsClient, err = dtclient.NewPlatformSettingsClient(client, dtclient.WithCachingDisabled(opts.CachingDisabled), persistentSettingsCache)
 		if err != nil {
 			return nil, err
 		}
 
-		classicURL, err = transformPlatformUrlToClassic(ctx, url, auth.OAuth, client)
+		classicURL, err = metadata.GetDynatraceClassicURL(ctx, *client)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if auth.Token != nil {
-		cFactory = cFactory.WithAccessToken(auth.Token.Value.Value()).
-			WithClassicURL(classicURL)
-		client, err := cFactory.CreateClassicClient()
+		var client *rest.Client
+		if transport != nil {
+			client, err = createRestClient(ctx, classicURL, NewTokenBasedHTTPClient(auth.Token.Value.Value(), transport), concurrentReqLimit, opts.getUserAgentString())
+		} else {
+			cFactory = cFactory.WithAccessToken(auth.Token.Value.Value()).
+				WithClassicURL(classicURL)
+			client, err = cFactory.CreateClassicClient()
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		configClient, err = dtclient.NewClassicConfigClient(client, dtclient.WithCachingDisabledForConfigClient(opts.CachingDisabled))
+		configClient, err = dtclient.NewClassicConfigClient(client, dtclient.WithCachingDisabledForConfigClient(opts.CachingDisabled), persistentConfigCache)
 		if err != nil {
 			return nil, err
 		}
 
+		entitiesClient = dtclient.NewEntitiesClient(client)
+		extensionsClient = dtclient.NewExtensionsClient(client)
+		accessTokensClient = dtclient.NewAccessTokensClient(client)
+
 		if settingsClient == nil {
-			settingsClient, err = dtclient.NewClassicSettingsClient(client, dtclient.WithCachingDisabled(opts.CachingDisabled), dtclient.WithAutoServerVersion(ctx))
+			settingsClient, err = dtclient.NewClassicSettingsClient(client, dtclient.WithCachingDisabled(opts.CachingDisabled), persistentSettingsCache, dtclient.WithAutoServerVersion(ctx))
 			if err != nil {
 				return nil, err
 			}
@@ -364,14 +478,57 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		OpenPipelineClient:          openPipelineClient,
 		SegmentClient:               segmentClient,
 		ServiceLevelObjectiveClient: serviceLevelObjectiveClient,
+		EntitiesClient:              entitiesClient,
+		ExtensionsClient:            extensionsClient,
+		AccessTokensClient:          accessTokensClient,
+		DocumentSharingClient:       documentSharingClient,
 	}, nil
 }
 
-func transformPlatformUrlToClassic(ctx context.Context, url string, auth *manifest.OAuth, client *rest.Client) (string, error) {
-	classicUrl := url
-	if auth != nil && client != nil {
-		return metadata.GetDynatraceClassicURL(ctx, *client)
+// NewClassicRestClient creates a REST client for the classic APIs at the given URL, which authenticates with the given
+// access token and sends all requests using the given transport. It is configured like the clients of a ClientSet.
+func NewClassicRestClient(ctx context.Context, classicURL string, token string, transport http.RoundTripper) (*rest.Client, error) {
+	return createRestClient(ctx, classicURL, NewTokenBasedHTTPClient(token, transport), environment.GetEnvValueInt(environment.ConcurrentRequestsEnvKey), DefaultMonacoUserAgent)
+}
+
+// NewPlatformTokenRestClient creates a REST client for the platform APIs at the given URL, which authenticates with the
+// given platform token and sends all requests using the given transport, or the default transport if it is nil. It is
+// configured like the clients of a ClientSet.
+func NewPlatformTokenRestClient(ctx context.Context, platformURL string, token string, transport http.RoundTripper) (*rest.Client, error) {
+	return createRestClient(ctx, platformURL, NewPlatformTokenHTTPClient(token, transport), environment.GetEnvValueInt(environment.ConcurrentRequestsEnvKey), DefaultMonacoUserAgent)
+}
+
+// createRestClient creates a REST client equivalent to the ones created by the [clients.Factory], but sending all
+// requests using the given HTTP client.
+func createRestClient(ctx context.Context, u string, httpClient *http.Client, concurrentReqLimit int, userAgent string) (*rest.Client, error) {
+	parsedURL, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL %q: %w", u, err)
+	}
+
+	restOpts := []rest.Option{
+		rest.WithConcurrentRequestLimit(concurrentReqLimit),
+		rest.WithRateLimiter(),
+		rest.WithRetryOptions(&DefaultRetryOptions),
+	}
+	if supportarchive.IsEnabled(ctx) {
+		restOpts = append(restOpts, rest.WithHTTPListener(&rest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles}))
+	}
+
+	client := rest.NewClient(parsedURL, httpClient, restOpts...)
+	client.SetHeader("User-Agent", userAgent)
+	return client, nil
+}
+
+// persistentCacheOptions returns the options making the settings and config clients of the environment keep their
+// caches in the cache store of the context. Both options are nil if there is no store or caching is disabled.
+func persistentCacheOptions(ctx context.Context, environmentURL string, cachingDisabled bool) (func(*dtclient.SettingsClient), func(*dtclient.ConfigClient)) {
+	store, ok := cache.StoreFromContext(ctx)
+	if !ok || cachingDisabled {
+		return nil, nil
 	}
 
-	return classicUrl, nil
+	settingsCache := cache.NewPersistentCache[[]dtclient.DownloadSettingsObject](store, "settings@"+environmentURL)
+	configCache := cache.NewPersistentCache[[]dtclient.Value](store, "classic@"+environmentURL)
+	return dtclient.WithSettingsCache(settingsCache), dtclient.WithConfigCache(configCache)
 }
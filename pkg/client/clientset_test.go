@@ -25,9 +25,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/cache"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
@@ -113,3 +117,76 @@ func TestCreateClientSet(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateClientSet_PlatformToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer mock platform token", req.Header.Get("Authorization"))
+		_, _ = fmt.Fprintf(rw, `{"domain": "http://%s/api/test"}`, req.Host)
+	}))
+	defer server.Close()
+
+	clientSet, err := CreateClientSet(t.Context(), server.URL, manifest.Auth{
+		PlatformToken: &manifest.AuthSecret{Name: "platform-token-env-var", Value: "mock platform token"},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, clientSet.AutClient)
+	assert.NotNil(t, clientSet.BucketClient)
+	assert.NotNil(t, clientSet.DocumentClient)
+	assert.NotNil(t, clientSet.OpenPipelineClient)
+	assert.NotNil(t, clientSet.SegmentClient)
+	assert.NotNil(t, clientSet.ServiceLevelObjectiveClient)
+	assert.NotNil(t, clientSet.SettingsClient)
+	assert.Nil(t, clientSet.ConfigClient, "classic config client requires an API token")
+}
+
+func TestPersistentCacheOptions(t *testing.T) {
+	ctx := cache.ContextWithStore(t.Context(), cache.NewStore(afero.NewMemMapFs(), "cache", time.Hour))
+
+	t.Run("listings are reused from the store of the context", func(t *testing.T) {
+		settingsCache, configCache := persistentCacheOptions(ctx, "https://env", false)
+		assert.NotNil(t, settingsCache)
+		assert.NotNil(t, configCache)
+	})
+
+	t.Run("listings are not reused if persistent caching is disabled", func(t *testing.T) {
+		settingsCache, configCache := persistentCacheOptions(ctx, "https://env", true)
+		assert.Nil(t, settingsCache)
+		assert.Nil(t, configCache)
+	})
+
+	t.Run("listings are not reused without a store", func(t *testing.T) {
+		settingsCache, configCache := persistentCacheOptions(t.Context(), "https://env", false)
+		assert.Nil(t, settingsCache)
+		assert.Nil(t, configCache)
+	})
+}
+
+func TestNewRestClients(t *testing.T) {
+	var gotAuthorization, gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotAuthorization = req.Header.Get("Authorization")
+		gotUserAgent = req.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	t.Run("classic client", func(t *testing.T) {
+		restClient, err := NewClassicRestClient(t.Context(), server.URL, "token", http.DefaultTransport)
+		require.NoError(t, err)
+
+		_, err = restClient.GET(t.Context(), "/api/v1/config/clusterversion", rest.RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Api-Token token", gotAuthorization)
+		assert.Equal(t, DefaultMonacoUserAgent, gotUserAgent)
+	})
+
+	t.Run("platform token client", func(t *testing.T) {
+		restClient, err := NewPlatformTokenRestClient(t.Context(), server.URL, "platform-token", nil)
+		require.NoError(t, err)
+
+		_, err = restClient.GET(t.Context(), "/platform/metadata/v1/classic-environment-domain", rest.RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer platform-token", gotAuthorization)
+		assert.Equal(t, DefaultMonacoUserAgent, gotUserAgent)
+	})
+}
@@ -0,0 +1,61 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+const accessTokensLookupAPIPath = "/api/v2/apiTokens/lookup"
+
+// AccessTokensClient looks up the metadata of access tokens
+type AccessTokensClient struct {
+	client *corerest.Client
+}
+
+func NewAccessTokensClient(client *corerest.Client) *AccessTokensClient {
+	return &AccessTokensClient{client: client}
+}
+
+// LookupScopes returns the scopes granted to the given access token. The lookup is documented to be allowed for any
+// valid token, without requiring a specific scope. Should an environment reject it nonetheless, e.g. with 403, the error
+// is returned and callers like the scope check treat the token's scopes as undetermined.
+func (c *AccessTokensClient) LookupScopes(ctx context.Context, token string) ([]string, error) {
+	payload, err := json.Marshal(map[string]string{"token": token})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := coreapi.AsResponseOrError(c.client.POST(ctx, accessTokensLookupAPIPath, bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up access token: %w", err)
+	}
+
+	var parsed struct {
+		Scopes []string `json:"scopes"`
+	}
+	if err := json.Unmarshal(resp.Data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return parsed.Scopes, nil
+}
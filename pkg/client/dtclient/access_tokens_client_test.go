@@ -0,0 +1,54 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func TestAccessTokensClient_LookupScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, accessTokensLookupAPIPath, req.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"token": "dt0c01.abc.def"}, body)
+
+		_, _ = rw.Write([]byte(`{"id": "dt0c01.abc", "enabled": true, "scopes": ["ReadConfig", "settings.read"]}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c := NewAccessTokensClient(corerest.NewClient(u, server.Client()))
+
+	scopes, err := c.LookupScopes(t.Context(), "dt0c01.abc.def")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ReadConfig", "settings.read"}, scopes)
+}
@@ -25,7 +25,9 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"golang.org/x/exp/maps"
 
@@ -47,6 +49,9 @@ type ConfigClient struct {
 
 	// configCache caches config API values
 	configCache cache.Cache[[]Value]
+
+	// configCacheLock guards updates of cached values after the client modified configs
+	configCacheLock sync.Mutex
 }
 
 // WithRetrySettings sets the retry settings to be used by the ConfigClient
@@ -66,6 +71,13 @@ func WithCachingDisabledForConfigClient(disabled bool) func(client *ConfigClient
 	}
 }
 
+// WithConfigCache sets the cache used for config API values, e.g. to reuse them between runs.
+func WithConfigCache(c cache.Cache[[]Value]) func(client *ConfigClient) {
+	return func(d *ConfigClient) {
+		d.configCache = c
+	}
+}
+
 func NewClassicConfigClient(client *corerest.Client, opts ...func(dynatraceClient *ConfigClient)) (*ConfigClient, error) {
 	d := &ConfigClient{
 		client:        client,
@@ -117,11 +129,13 @@ func (d *ConfigClient) Delete(ctx context.Context, api api.API, id string) error
 	if err != nil {
 		if coreapi.IsNotFoundError(err) {
 			log.Debug("No config with id '%s' found to delete (HTTP 404 response)", id)
+			d.updateCachedValues(api, func(values []Value) []Value { return removeValue(values, id) })
 			return nil
 		}
 		return err
 	}
 
+	d.updateCachedValues(api, func(values []Value) []Value { return removeValue(values, id) })
 	return nil
 }
 
@@ -188,12 +202,21 @@ func (d *ConfigClient) upsertDynatraceObject(ctx context.Context, theApi api.API
 		}
 	}
 
-	if obj, err := doUpsert(); err == nil {
-		return obj, nil
-	} else {
+	obj, err := doUpsert()
+	if err != nil {
 		d.configCache.Delete(theApi.ID)
-		return doUpsert()
+		if obj, err = doUpsert(); err != nil {
+			return DynatraceEntity{}, err
+		}
 	}
+
+	if theApi.CheckEqualFunc != nil {
+		// values of these APIs are compared by custom fields, which can't be derived from the entity
+		d.configCache.Delete(theApi.ID)
+	} else if !theApi.SingleConfiguration {
+		d.updateCachedValues(theApi, func(values []Value) []Value { return upsertValue(values, Value{Id: obj.Id, Name: objectName}) })
+	}
+	return obj, nil
 }
 
 func (d *ConfigClient) UpsertByNonUniqueNameAndId(ctx context.Context, theApi api.API, entityId string, objectName string, payload []byte, duplicate bool) (entity DynatraceEntity, err error) {
@@ -581,11 +604,47 @@ func (d *ConfigClient) getExistingObjectId(ctx context.Context, objectName strin
 	return objID, nil
 }
 
-func (d *ConfigClient) List(ctx context.Context, theApi api.API) ([]Value, error) {
+// isCacheable returns whether the values of the API can be cached.
+func isCacheable(theApi api.API) bool {
 	// caching cannot be used for subPathAPI as well because there is potentially more than one config per api type/id to consider.
 	// the cache cannot deal with that
-	if (!theApi.NonUniqueName && !theApi.HasParent()) && // there is potentially more than one config per api type/id to consider
-		(theApi.ID != api.ApplicationWeb && theApi.ID != api.ApplicationMobile) { // there is no refresh mechanism for delete; outdated values can cause decreasing performance during delete (unnecessary retrying)
+	return (!theApi.NonUniqueName && !theApi.HasParent()) && // there is potentially more than one config per api type/id to consider
+		(theApi.ID != api.ApplicationWeb && theApi.ID != api.ApplicationMobile) // there is no refresh mechanism for delete; outdated values can cause decreasing performance during delete (unnecessary retrying)
+}
+
+// updateCachedValues applies the update to a copy of the cached values of the API, so that the cache doesn't need to be
+// invalidated by the client's own modifications. A copy is needed, as previously returned values may still be in use.
+func (d *ConfigClient) updateCachedValues(theApi api.API, update func([]Value) []Value) {
+	if !isCacheable(theApi) {
+		return
+	}
+
+	d.configCacheLock.Lock()
+	defer d.configCacheLock.Unlock()
+
+	if values, cached := d.configCache.Get(theApi.ID); cached {
+		d.configCache.Set(theApi.ID, update(slices.Clone(values)))
+	}
+}
+
+// upsertValue replaces the value with the same ID or appends it.
+func upsertValue(values []Value, value Value) []Value {
+	for i, v := range values {
+		if v.Id == value.Id {
+			values[i].Name = value.Name
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// removeValue removes the value with the given ID.
+func removeValue(values []Value, id string) []Value {
+	return slices.DeleteFunc(values, func(v Value) bool { return v.Id == id })
+}
+
+func (d *ConfigClient) List(ctx context.Context, theApi api.API) ([]Value, error) {
+	if isCacheable(theApi) {
 		if values, cached := d.configCache.Get(theApi.ID); cached {
 			return values, nil
 		}
@@ -924,3 +924,49 @@ func TestConfigClient_ClearCache(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, listCalledCount, 2)
 }
+
+func TestConfigClient_CacheIsUpdatedByModifications(t *testing.T) {
+	testApi := api.API{ID: "test", URLPath: "/test/api", PropertyNameOfGetAllResponse: api.StandardApiPropertyNameOfGetAllResponse}
+	listCalledCount := 0
+	var updated []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /test/api", func(w http.ResponseWriter, _ *http.Request) {
+		listCalledCount++
+		_, _ = w.Write([]byte(`{"values": [{"id": "42", "name": "MY CONFIG"}]}`))
+	})
+	mux.HandleFunc("POST /test/api", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "43", "name": "NEW CONFIG"}`))
+	})
+	mux.HandleFunc("PUT /test/api/{id}", func(w http.ResponseWriter, r *http.Request) {
+		updated = append(updated, r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /test/api/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClassicConfigClientForTesting(server.URL, server.Client())
+	require.NoError(t, err)
+
+	entity, err := client.UpsertByName(t.Context(), testApi, "NEW CONFIG", []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, "43", entity.Id)
+
+	// the created config is found in the cache and updated
+	_, err = client.UpsertByName(t.Context(), testApi, "NEW CONFIG", []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"43"}, updated)
+
+	require.NoError(t, client.Delete(t.Context(), testApi, "42"))
+
+	values, err := client.List(t.Context(), testApi)
+	require.NoError(t, err)
+	assert.Equal(t, []Value{{Id: "43", Name: "NEW CONFIG"}}, values)
+
+	assert.Equal(t, 1, listCalledCount, "all listings after the first one must be served from the cache")
+}
@@ -0,0 +1,247 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+const (
+	documentsAPIPath         = "/platform/document/v1/documents"
+	directSharesAPIPath      = "/platform/document/v1/direct-shares"
+	environmentSharesAPIPath = "/platform/document/v1/environment-shares"
+)
+
+// Recipient types of direct shares
+const (
+	RecipientTypeUser  = "user"
+	RecipientTypeGroup = "group"
+)
+
+// DirectShare shares a document with specific users and groups
+type DirectShare struct {
+	ID         string `json:"id"`
+	DocumentID string `json:"documentId"`
+	Access     string `json:"access"`
+}
+
+// Recipient is a user or group a document is directly shared with
+type Recipient struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// EnvironmentShare shares a document with all users of the environment
+type EnvironmentShare struct {
+	ID         string `json:"id"`
+	DocumentID string `json:"documentId"`
+	Access     string `json:"access"`
+}
+
+// DocumentSharingClient manages the shares and the owner of documents
+type DocumentSharingClient struct {
+	client *corerest.Client
+}
+
+func NewDocumentSharingClient(client *corerest.Client) *DocumentSharingClient {
+	return &DocumentSharingClient{client: client}
+}
+
+// ListDirectShares returns all direct shares of the document.
+func (c *DocumentSharingClient) ListDirectShares(ctx context.Context, documentID string) ([]DirectShare, error) {
+	var result []DirectShare
+	addToResult := func(body []byte) error {
+		var parsed struct {
+			DirectShares []DirectShare `json:"directShares"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result = append(result, parsed.DirectShares...)
+		return nil
+	}
+
+	if err := c.listDocumentPages(ctx, directSharesAPIPath, documentFilter(documentID), addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list direct shares of document %q: %w", documentID, err)
+	}
+	return result, nil
+}
+
+// CreateDirectShare shares the document with the given recipients.
+func (c *DocumentSharingClient) CreateDirectShare(ctx context.Context, documentID, access string, recipients []Recipient) error {
+	payload, err := json.Marshal(struct {
+		DocumentID string      `json:"documentId"`
+		Access     string      `json:"access"`
+		Recipients []Recipient `json:"recipients"`
+	}{documentID, access, recipients})
+	if err != nil {
+		return err
+	}
+
+	if _, err := coreapi.AsResponseOrError(c.client.POST(ctx, directSharesAPIPath, bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return fmt.Errorf("failed to create %s share of document %q: %w", access, documentID, err)
+	}
+	return nil
+}
+
+// DeleteDirectShare deletes the direct share and thereby revokes the access of all its recipients.
+func (c *DocumentSharingClient) DeleteDirectShare(ctx context.Context, shareID string) error {
+	if _, err := coreapi.AsResponseOrError(c.client.DELETE(ctx, directSharesAPIPath+"/"+url.PathEscape(shareID), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return fmt.Errorf("failed to delete direct share %q: %w", shareID, err)
+	}
+	return nil
+}
+
+// ListRecipients returns all recipients of the direct share.
+func (c *DocumentSharingClient) ListRecipients(ctx context.Context, shareID string) ([]Recipient, error) {
+	var result []Recipient
+	addToResult := func(body []byte) error {
+		var parsed struct {
+			Recipients []Recipient `json:"recipients"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result = append(result, parsed.Recipients...)
+		return nil
+	}
+
+	if err := c.listDocumentPages(ctx, recipientsPath(shareID), url.Values{}, addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list recipients of direct share %q: %w", shareID, err)
+	}
+	return result, nil
+}
+
+// AddRecipients adds the recipients to the direct share.
+func (c *DocumentSharingClient) AddRecipients(ctx context.Context, shareID string, recipients []Recipient) error {
+	payload, err := json.Marshal(map[string][]Recipient{"recipients": recipients})
+	if err != nil {
+		return err
+	}
+
+	if _, err := coreapi.AsResponseOrError(c.client.POST(ctx, recipientsPath(shareID)+"/add", bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return fmt.Errorf("failed to add recipients to direct share %q: %w", shareID, err)
+	}
+	return nil
+}
+
+// RemoveRecipients removes the recipients with the given IDs from the direct share.
+func (c *DocumentSharingClient) RemoveRecipients(ctx context.Context, shareID string, recipientIDs []string) error {
+	payload, err := json.Marshal(map[string][]string{"ids": recipientIDs})
+	if err != nil {
+		return err
+	}
+
+	if _, err := coreapi.AsResponseOrError(c.client.POST(ctx, recipientsPath(shareID)+"/remove", bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return fmt.Errorf("failed to remove recipients from direct share %q: %w", shareID, err)
+	}
+	return nil
+}
+
+// ListEnvironmentShares returns all environment shares of the document.
+func (c *DocumentSharingClient) ListEnvironmentShares(ctx context.Context, documentID string) ([]EnvironmentShare, error) {
+	var result []EnvironmentShare
+	addToResult := func(body []byte) error {
+		var parsed struct {
+			EnvironmentShares []EnvironmentShare `json:"environmentShares"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result = append(result, parsed.EnvironmentShares...)
+		return nil
+	}
+
+	if err := c.listDocumentPages(ctx, environmentSharesAPIPath, documentFilter(documentID), addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list environment shares of document %q: %w", documentID, err)
+	}
+	return result, nil
+}
+
+// CreateEnvironmentShare shares the document with all users of the environment.
+func (c *DocumentSharingClient) CreateEnvironmentShare(ctx context.Context, documentID, access string) error {
+	payload, err := json.Marshal(map[string]string{"documentId": documentID, "access": access})
+	if err != nil {
+		return err
+	}
+
+	if _, err := coreapi.AsResponseOrError(c.client.POST(ctx, environmentSharesAPIPath, bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return fmt.Errorf("failed to share document %q with the environment: %w", documentID, err)
+	}
+	return nil
+}
+
+// DeleteEnvironmentShare deletes the environment share.
+func (c *DocumentSharingClient) DeleteEnvironmentShare(ctx context.Context, shareID string) error {
+	if _, err := coreapi.AsResponseOrError(c.client.DELETE(ctx, environmentSharesAPIPath+"/"+url.PathEscape(shareID), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return fmt.Errorf("failed to delete environment share %q: %w", shareID, err)
+	}
+	return nil
+}
+
+// TransferOwner makes the given user the owner of the document. The version is the current version of the document,
+// used for optimistic locking.
+func (c *DocumentSharingClient) TransferOwner(ctx context.Context, documentID string, version int, newOwnerID string) error {
+	payload, err := json.Marshal(map[string]string{"newOwnerId": newOwnerID})
+	if err != nil {
+		return err
+	}
+
+	queryParams := url.Values{"optimistic-locking-version": []string{strconv.Itoa(version)}}
+	_, err = coreapi.AsResponseOrError(c.client.POST(ctx, documentsAPIPath+"/"+url.PathEscape(documentID)+":transfer-owner", bytes.NewReader(payload), corerest.RequestOptions{QueryParams: queryParams, CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	if err != nil {
+		return fmt.Errorf("failed to transfer ownership of document %q to %q: %w", documentID, newOwnerID, err)
+	}
+	return nil
+}
+
+// listDocumentPages requests all pages of a list endpoint of the document service. Unlike the classic APIs, the
+// document service expects the key of the next page in the 'page-key' query parameter, without any other parameters.
+func (c *DocumentSharingClient) listDocumentPages(ctx context.Context, endpoint string, queryParams url.Values, addToResult func(body []byte) error) error {
+	for {
+		resp, err := coreapi.AsResponseOrError(c.client.GET(ctx, endpoint, corerest.RequestOptions{QueryParams: queryParams, CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+		if err != nil {
+			return err
+		}
+
+		if err := addToResult(resp.Data); err != nil {
+			return err
+		}
+
+		nextPageKey, _ := getPaginationValues(resp.Data)
+		if nextPageKey == "" {
+			return nil
+		}
+		queryParams = url.Values{"page-key": []string{nextPageKey}}
+	}
+}
+
+func documentFilter(documentID string) url.Values {
+	return url.Values{"filter": []string{fmt.Sprintf("documentId=='%s'", documentID)}}
+}
+
+func recipientsPath(shareID string) string {
+	return directSharesAPIPath + "/" + url.PathEscape(shareID) + "/recipients"
+}
@@ -0,0 +1,131 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func newTestDocumentSharingClient(t *testing.T, handler http.HandlerFunc) *DocumentSharingClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return NewDocumentSharingClient(corerest.NewClient(u, server.Client()))
+}
+
+func TestDocumentSharingClient_ListDirectShares(t *testing.T) {
+	c := newTestDocumentSharingClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, directSharesAPIPath, req.URL.Path)
+		switch req.URL.Query().Get("page-key") {
+		case "":
+			assert.Equal(t, "documentId=='doc'", req.URL.Query().Get("filter"))
+			_, _ = rw.Write([]byte(`{"directShares": [{"id": "s1", "documentId": "doc", "access": "read"}], "nextPageKey": "next"}`))
+		case "next":
+			assert.Empty(t, req.URL.Query().Get("filter"))
+			_, _ = rw.Write([]byte(`{"directShares": [{"id": "s2", "documentId": "doc", "access": "read-write"}]}`))
+		default:
+			t.Fatalf("unexpected page key %q", req.URL.Query().Get("page-key"))
+		}
+	})
+
+	shares, err := c.ListDirectShares(t.Context(), "doc")
+	require.NoError(t, err)
+	assert.Equal(t, []DirectShare{{"s1", "doc", "read"}, {"s2", "doc", "read-write"}}, shares)
+}
+
+func TestDocumentSharingClient_CreateDirectShare(t *testing.T) {
+	c := newTestDocumentSharingClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, directSharesAPIPath, req.URL.Path)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string]any{
+			"documentId": "doc",
+			"access":     "read",
+			"recipients": []any{map[string]any{"id": "u1", "type": "user"}},
+		}, body)
+		rw.WriteHeader(http.StatusCreated)
+	})
+
+	err := c.CreateDirectShare(t.Context(), "doc", "read", []Recipient{{ID: "u1", Type: RecipientTypeUser}})
+	assert.NoError(t, err)
+}
+
+func TestDocumentSharingClient_RemoveRecipients(t *testing.T) {
+	c := newTestDocumentSharingClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, directSharesAPIPath+"/s1/recipients/remove", req.URL.Path)
+
+		var body map[string][]string
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string][]string{"ids": {"u1", "g1"}}, body)
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.RemoveRecipients(t.Context(), "s1", []string{"u1", "g1"})
+	assert.NoError(t, err)
+}
+
+func TestDocumentSharingClient_ListEnvironmentShares(t *testing.T) {
+	c := newTestDocumentSharingClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, environmentSharesAPIPath, req.URL.Path)
+		_, _ = rw.Write([]byte(`{"environmentShares": [{"id": "e1", "documentId": "doc", "access": "read"}]}`))
+	})
+
+	shares, err := c.ListEnvironmentShares(t.Context(), "doc")
+	require.NoError(t, err)
+	assert.Equal(t, []EnvironmentShare{{"e1", "doc", "read"}}, shares)
+}
+
+func TestDocumentSharingClient_TransferOwner(t *testing.T) {
+	c := newTestDocumentSharingClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, documentsAPIPath+"/doc:transfer-owner", req.URL.Path)
+		assert.Equal(t, "3", req.URL.Query().Get("optimistic-locking-version"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"newOwnerId": "u2"}, body)
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.TransferOwner(t.Context(), "doc", 3, "u2")
+	assert.NoError(t, err)
+}
+
+func TestDocumentSharingClient_ErrorIsReturned(t *testing.T) {
+	c := newTestDocumentSharingClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+	})
+
+	err := c.DeleteEnvironmentShare(t.Context(), "e1")
+	assert.ErrorContains(t, err, `failed to delete environment share "e1"`)
+}
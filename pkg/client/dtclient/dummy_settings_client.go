@@ -49,6 +49,14 @@ func (c *DummySettingsClient) Upsert(_ context.Context, obj SettingsObject, _ Up
 	}, nil
 }
 
+func (c *DummySettingsClient) UpsertBatch(ctx context.Context, objects []SettingsObject) []UpsertResult {
+	results := make([]UpsertResult, len(objects))
+	for i, obj := range objects {
+		results[i].Entity, results[i].Err = c.Upsert(ctx, obj, UpsertSettingsOptions{})
+	}
+	return results
+}
+
 func (c *DummySettingsClient) ListSchemas(_ context.Context) (SchemaList, error) {
 	return make(SchemaList, 0), nil
 }
@@ -0,0 +1,94 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+const entitiesAPIPath = "/api/v2/entities"
+
+// Entity is a monitored entity as returned by the entities API
+type Entity struct {
+	EntityId    string      `json:"entityId"`
+	Type        string      `json:"type"`
+	DisplayName string      `json:"displayName"`
+	Tags        []EntityTag `json:"tags,omitempty"`
+}
+
+type EntityTag struct {
+	StringRepresentation string `json:"stringRepresentation"`
+}
+
+// EntitiesClient reads monitored entities using the entities API
+type EntitiesClient struct {
+	client *corerest.Client
+}
+
+func NewEntitiesClient(client *corerest.Client) *EntitiesClient {
+	return &EntitiesClient{client: client}
+}
+
+// List returns all entities matching the given entity selector, including their tags
+func (c *EntitiesClient) List(ctx context.Context, entitySelector string) ([]Entity, error) {
+	queryParams := url.Values{}
+	queryParams.Add("entitySelector", entitySelector)
+	queryParams.Add("fields", "+tags")
+	queryParams.Add("pageSize", "500")
+
+	var result []Entity
+	addToResult := func(body []byte) (int, error) {
+		var parsed struct {
+			Entities []Entity `json:"entities"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result = append(result, parsed.Entities...)
+		return len(parsed.Entities), nil
+	}
+
+	if err := listPaginated(ctx, c.client, entitiesAPIPath, queryParams, "entities", addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list entities for selector %q: %w", entitySelector, err)
+	}
+	return result, nil
+}
+
+// EntityIdSelector returns an entity selector matching all entities of the given IDs
+func EntityIdSelector(ids ...string) string {
+	quoted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quoted = append(quoted, quoteSelectorValue(id))
+	}
+	return fmt.Sprintf("entityId(%s)", strings.Join(quoted, ","))
+}
+
+// EntityNameSelector returns an entity selector matching all entities of the given type with exactly the given name
+func EntityNameSelector(entityType, displayName string) string {
+	return fmt.Sprintf("type(%s),entityName.equals(%s)", quoteSelectorValue(entityType), quoteSelectorValue(displayName))
+}
+
+// quoteSelectorValue quotes a value of an entity selector, escaping the characters that are special in quoted values
+func quoteSelectorValue(v string) string {
+	return `"` + strings.NewReplacer(`~`, `~~`, `"`, `~"`).Replace(v) + `"`
+}
@@ -0,0 +1,61 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func TestEntitiesClient_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, entitiesAPIPath, req.URL.Path)
+		if req.URL.Query().Get("nextPageKey") == "" {
+			assert.Equal(t, `entityId("HOST-0000000000000001")`, req.URL.Query().Get("entitySelector"))
+			assert.Equal(t, "+tags", req.URL.Query().Get("fields"))
+			_, _ = rw.Write([]byte(`{"totalCount": 2, "nextPageKey": "page2", "entities": [{"entityId": "HOST-0000000000000001", "type": "HOST", "displayName": "host-a", "tags": [{"stringRepresentation": "env:prod"}]}]}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"totalCount": 2, "entities": [{"entityId": "HOST-0000000000000002", "type": "HOST", "displayName": "host-b"}]}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c := NewEntitiesClient(corerest.NewClient(u, server.Client()))
+
+	entities, err := c.List(t.Context(), EntityIdSelector("HOST-0000000000000001"))
+	require.NoError(t, err)
+	assert.Equal(t, []Entity{
+		{EntityId: "HOST-0000000000000001", Type: "HOST", DisplayName: "host-a", Tags: []EntityTag{{StringRepresentation: "env:prod"}}},
+		{EntityId: "HOST-0000000000000002", Type: "HOST", DisplayName: "host-b"},
+	}, entities)
+}
+
+func TestEntitySelectors(t *testing.T) {
+	assert.Equal(t, `entityId("HOST-1","HOST-2")`, EntityIdSelector("HOST-1", "HOST-2"))
+	assert.Equal(t, `type("HOST"),entityName.equals("my ~"host~" ~~1")`, EntityNameSelector("HOST", `my "host" ~1`))
+}
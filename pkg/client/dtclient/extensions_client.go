@@ -0,0 +1,244 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+const extensionsAPIPath = "/api/v2/extensions"
+
+// ExtensionVersion identifies a version of an Extensions 2.0 extension
+type ExtensionVersion struct {
+	ExtensionName string `json:"extensionName"`
+	Version       string `json:"version"`
+}
+
+// MonitoringConfiguration is a monitoring configuration of an Extensions 2.0 extension
+type MonitoringConfiguration struct {
+	ObjectId string          `json:"objectId"`
+	Scope    string          `json:"scope"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// ExtensionsClient manages Extensions 2.0 extensions and their monitoring configurations
+type ExtensionsClient struct {
+	client *corerest.Client
+}
+
+func NewExtensionsClient(client *corerest.Client) *ExtensionsClient {
+	return &ExtensionsClient{client: client}
+}
+
+// List returns all extensions uploaded to the environment. Each extension is returned once, with its latest version.
+func (c *ExtensionsClient) List(ctx context.Context) ([]ExtensionVersion, error) {
+	var result []ExtensionVersion
+	if err := listPaginated(ctx, c.client, extensionsAPIPath, url.Values{}, "extensions", addExtensionsToResult(&result)); err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+	return result, nil
+}
+
+// ListVersions returns all uploaded versions of the extension. No versions are returned if the extension doesn't exist.
+func (c *ExtensionsClient) ListVersions(ctx context.Context, extensionName string) ([]ExtensionVersion, error) {
+	var result []ExtensionVersion
+	err := listPaginated(ctx, c.client, extensionPath(extensionName), url.Values{}, extensionName, addExtensionsToResult(&result))
+	if err != nil {
+		if coreapi.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list versions of extension %q: %w", extensionName, err)
+	}
+	return result, nil
+}
+
+func addExtensionsToResult(result *[]ExtensionVersion) AddEntriesToResult {
+	return func(body []byte) (int, error) {
+		var parsed struct {
+			Extensions []ExtensionVersion `json:"extensions"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		*result = append(*result, parsed.Extensions...)
+		return len(parsed.Extensions), nil
+	}
+}
+
+// Upload uploads the given signed extension package and returns the extension version it contains.
+func (c *ExtensionsClient) Upload(ctx context.Context, extensionPackage []byte) (ExtensionVersion, error) {
+	buffer := new(bytes.Buffer)
+	multipartWriter := multipart.NewWriter(buffer)
+	formFileWriter, err := multipartWriter.CreateFormFile("file", "extension.zip")
+	if err != nil {
+		return ExtensionVersion{}, err
+	}
+	if _, err := formFileWriter.Write(extensionPackage); err != nil {
+		return ExtensionVersion{}, err
+	}
+	if err := multipartWriter.Close(); err != nil {
+		return ExtensionVersion{}, err
+	}
+
+	resp, err := coreapi.AsResponseOrError(c.client.POST(ctx, extensionsAPIPath, buffer, corerest.RequestOptions{ContentType: multipartWriter.FormDataContentType(), CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	if err != nil {
+		return ExtensionVersion{}, fmt.Errorf("failed to upload extension: %w", err)
+	}
+
+	var uploaded ExtensionVersion
+	if err := json.Unmarshal(resp.Data, &uploaded); err != nil {
+		return ExtensionVersion{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return uploaded, nil
+}
+
+// GetPackage downloads the signed package of the given extension version.
+func (c *ExtensionsClient) GetPackage(ctx context.Context, extensionName, version string) ([]byte, error) {
+	// the package is only returned instead of the version's details if it is explicitly accepted
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.client.BaseURL().JoinPath(extensionPath(extensionName), url.PathEscape(version)).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := coreapi.AsResponseOrError(c.client.Do(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download version %s of extension %q: %w", version, extensionName, err)
+	}
+	return resp.Data, nil
+}
+
+// GetActiveVersion returns the active version of the extension. If no version is active, false is returned.
+func (c *ExtensionsClient) GetActiveVersion(ctx context.Context, extensionName string) (string, bool, error) {
+	resp, err := coreapi.AsResponseOrError(c.client.GET(ctx, extensionPath(extensionName)+"/environmentConfiguration", corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	if err != nil {
+		if coreapi.IsNotFoundError(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get active version of extension %q: %w", extensionName, err)
+	}
+
+	var parsed struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(resp.Data, &parsed); err != nil {
+		return "", false, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return parsed.Version, parsed.Version != "", nil
+}
+
+// EnsureActiveVersion makes the given version the active version of the extension, replacing any other active version.
+// It returns whether the active version was changed.
+func (c *ExtensionsClient) EnsureActiveVersion(ctx context.Context, extensionName, version string) (bool, error) {
+	activeVersion, active, err := c.GetActiveVersion(ctx, extensionName)
+	if err != nil {
+		return false, err
+	}
+	if activeVersion == version {
+		return false, nil
+	}
+
+	payload, err := json.Marshal(map[string]string{"version": version})
+	if err != nil {
+		return false, err
+	}
+
+	// the initial activation is a POST, while changing the active version is a PUT
+	send := c.client.POST
+	if active {
+		send = c.client.PUT
+	}
+	if _, err := coreapi.AsResponseOrError(send(ctx, extensionPath(extensionName)+"/environmentConfiguration", bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})); err != nil {
+		return false, fmt.Errorf("failed to activate version %s of extension %q: %w", version, extensionName, err)
+	}
+	return true, nil
+}
+
+// ListMonitoringConfigurations returns all monitoring configurations of the extension.
+func (c *ExtensionsClient) ListMonitoringConfigurations(ctx context.Context, extensionName string) ([]MonitoringConfiguration, error) {
+	var result []MonitoringConfiguration
+	addToResult := func(body []byte) (int, error) {
+		var parsed struct {
+			Items []MonitoringConfiguration `json:"items"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result = append(result, parsed.Items...)
+		return len(parsed.Items), nil
+	}
+
+	if err := listPaginated(ctx, c.client, monitoringConfigurationsPath(extensionName), url.Values{}, extensionName, addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list monitoring configurations of extension %q: %w", extensionName, err)
+	}
+	return result, nil
+}
+
+// CreateMonitoringConfiguration creates a new monitoring configuration of the extension and returns its ID.
+func (c *ExtensionsClient) CreateMonitoringConfiguration(ctx context.Context, extensionName, scope string, value json.RawMessage) (string, error) {
+	payload, err := json.Marshal([]MonitoringConfiguration{{Scope: scope, Value: value}})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := coreapi.AsResponseOrError(c.client.POST(ctx, monitoringConfigurationsPath(extensionName), bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	if err != nil {
+		return "", fmt.Errorf("failed to create monitoring configuration of extension %q: %w", extensionName, err)
+	}
+
+	var parsed []struct {
+		ObjectId string `json:"objectId"`
+	}
+	if err := json.Unmarshal(resp.Data, &parsed); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(parsed) != 1 {
+		return "", fmt.Errorf("response contains %d elements instead of 1", len(parsed))
+	}
+	return parsed[0].ObjectId, nil
+}
+
+// UpdateMonitoringConfiguration replaces the value of an existing monitoring configuration of the extension.
+func (c *ExtensionsClient) UpdateMonitoringConfiguration(ctx context.Context, extensionName, id string, value json.RawMessage) error {
+	payload, err := json.Marshal(map[string]json.RawMessage{"value": value})
+	if err != nil {
+		return err
+	}
+
+	_, err = coreapi.AsResponseOrError(c.client.PUT(ctx, monitoringConfigurationsPath(extensionName)+"/"+url.PathEscape(id), bytes.NewReader(payload), corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	if err != nil {
+		return fmt.Errorf("failed to update monitoring configuration %q of extension %q: %w", id, extensionName, err)
+	}
+	return nil
+}
+
+func extensionPath(extensionName string) string {
+	return extensionsAPIPath + "/" + url.PathEscape(extensionName)
+}
+
+func monitoringConfigurationsPath(extensionName string) string {
+	return extensionPath(extensionName) + "/monitoringConfigurations"
+}
@@ -0,0 +1,180 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func newTestExtensionsClient(t *testing.T, handler http.HandlerFunc) *ExtensionsClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return NewExtensionsClient(corerest.NewClient(u, server.Client()))
+}
+
+func TestExtensionsClient_ListVersions(t *testing.T) {
+	c := newTestExtensionsClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case extensionsAPIPath + "/com.example.ext":
+			_, _ = rw.Write([]byte(`{"totalCount": 2, "extensions": [{"extensionName": "com.example.ext", "version": "1.0.0"}, {"extensionName": "com.example.ext", "version": "1.1.0"}]}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	versions, err := c.ListVersions(t.Context(), "com.example.ext")
+	require.NoError(t, err)
+	assert.Equal(t, []ExtensionVersion{{"com.example.ext", "1.0.0"}, {"com.example.ext", "1.1.0"}}, versions)
+
+	versions, err = c.ListVersions(t.Context(), "com.example.unknown")
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestExtensionsClient_Upload(t *testing.T) {
+	c := newTestExtensionsClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, extensionsAPIPath, req.URL.Path)
+
+		f, _, err := req.FormFile("file")
+		require.NoError(t, err)
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "package", string(content))
+
+		_, _ = rw.Write([]byte(`{"extensionName": "com.example.ext", "version": "1.0.0"}`))
+	})
+
+	uploaded, err := c.Upload(t.Context(), []byte("package"))
+	require.NoError(t, err)
+	assert.Equal(t, ExtensionVersion{"com.example.ext", "1.0.0"}, uploaded)
+}
+
+func TestExtensionsClient_GetPackage(t *testing.T) {
+	c := newTestExtensionsClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, extensionsAPIPath+"/com.example.ext/1.0.0", req.URL.Path)
+		assert.Equal(t, "application/octet-stream", req.Header.Get("Accept"))
+		_, _ = rw.Write([]byte("package"))
+	})
+
+	extensionPackage, err := c.GetPackage(t.Context(), "com.example.ext", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("package"), extensionPackage)
+}
+
+func TestExtensionsClient_EnsureActiveVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		activeVersion  string
+		expectedMethod string
+		expectChange   bool
+	}{
+		{
+			name:           "no version is active",
+			expectedMethod: http.MethodPost,
+			expectChange:   true,
+		},
+		{
+			name:           "other version is active",
+			activeVersion:  "0.9.0",
+			expectedMethod: http.MethodPut,
+			expectChange:   true,
+		},
+		{
+			name:          "version is already active",
+			activeVersion: "1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var activated bool
+			c := newTestExtensionsClient(t, func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, extensionsAPIPath+"/com.example.ext/environmentConfiguration", req.URL.Path)
+				if req.Method == http.MethodGet {
+					if tt.activeVersion == "" {
+						rw.WriteHeader(http.StatusNotFound)
+						return
+					}
+					_, _ = rw.Write([]byte(`{"version": "` + tt.activeVersion + `"}`))
+					return
+				}
+
+				assert.Equal(t, tt.expectedMethod, req.Method)
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				assert.Equal(t, map[string]string{"version": "1.0.0"}, body)
+				activated = true
+				_, _ = rw.Write([]byte(`{"version": "1.0.0"}`))
+			})
+
+			changed, err := c.EnsureActiveVersion(t.Context(), "com.example.ext", "1.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectChange, changed)
+			assert.Equal(t, tt.expectChange, activated)
+		})
+	}
+}
+
+func TestExtensionsClient_MonitoringConfigurations(t *testing.T) {
+	c := newTestExtensionsClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == extensionsAPIPath+"/com.example.ext/monitoringConfigurations":
+			_, _ = rw.Write([]byte(`{"totalCount": 1, "items": [{"objectId": "mc-1", "scope": "environment", "value": {"description": "a"}}]}`))
+		case req.Method == http.MethodPost && req.URL.Path == extensionsAPIPath+"/com.example.ext/monitoringConfigurations":
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `[{"objectId": "", "scope": "HOST-1", "value": {"description": "b"}}]`, string(body))
+			_, _ = rw.Write([]byte(`[{"objectId": "mc-2", "code": 200}]`))
+		case req.Method == http.MethodPut && req.URL.Path == extensionsAPIPath+"/com.example.ext/monitoringConfigurations/mc-1":
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"value": {"description": "c"}}`, string(body))
+			_, _ = rw.Write([]byte(`{"objectId": "mc-1", "code": 200}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	configs, err := c.ListMonitoringConfigurations(t.Context(), "com.example.ext")
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "mc-1", configs[0].ObjectId)
+	assert.Equal(t, "environment", configs[0].Scope)
+	assert.JSONEq(t, `{"description": "a"}`, string(configs[0].Value))
+
+	id, err := c.CreateMonitoringConfiguration(t.Context(), "com.example.ext", "HOST-1", json.RawMessage(`{"description": "b"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "mc-2", id)
+
+	err = c.UpdateMonitoringConfiguration(t.Context(), "com.example.ext", "mc-1", json.RawMessage(`{"description": "c"}`))
+	require.NoError(t, err)
+}
@@ -26,6 +26,8 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/exp/maps"
@@ -58,6 +60,8 @@ type DownloadSettingsObject struct {
 	ObjectId      string          `json:"objectId"`
 	Scope         string          `json:"scope"`
 	Value         json.RawMessage `json:"value"`
+	// Modified is the time of the last modification of the object in milliseconds since the epoch
+	Modified int64 `json:"modified"`
 	//Deprecated in the API used only as fallback replaced by ResourceContext
 	ModificationInfo *SettingsModificationInfo `json:"modificationInfo"`
 	ResourceContext  *SettingsResourceContext  `json:"resourceContext"`
@@ -144,11 +148,11 @@ const (
 )
 
 // defaultListSettingsFields  are the fields we are interested in when getting setting objects
-const defaultListSettingsFields = "objectId,value,externalId,schemaVersion,schemaId,scope,modificationInfo"
+const defaultListSettingsFields = "objectId,value,externalId,schemaVersion,schemaId,scope,modificationInfo,modified"
 
 // reducedListSettingsFields are the fields we are interested in when getting settings objects but don't care about the
 // actual value payload
-const reducedListSettingsFields = "objectId,externalId,schemaVersion,schemaId,scope,modificationInfo"
+const reducedListSettingsFields = "objectId,externalId,schemaVersion,schemaId,scope,modificationInfo,modified"
 const defaultPageSize = "500"
 
 // ListSettingsOptions are additional options for the ListSettings method
@@ -190,6 +194,12 @@ type SettingsClient struct {
 
 	// schemaCache caches schema constraints
 	schemaCache cache.Cache[Schema]
+
+	// settingsCacheLock guards updates of cached settings objects after the client modified them
+	settingsCacheLock sync.Mutex
+
+	// objectSchemas maps the IDs of all known settings objects to their schema, to update the cache after a deletion
+	objectSchemas sync.Map
 }
 
 type TypePermissions = string
@@ -256,6 +266,19 @@ type (
 		ObjectId string `json:"objectId"`
 	}
 
+	// batchPostResponse is the result of a single object of a post request. Error is only set if the object was rejected.
+	batchPostResponse struct {
+		Code     int             `json:"code"`
+		ObjectId string          `json:"objectId"`
+		Error    json.RawMessage `json:"error"`
+	}
+
+	// UpsertResult is the result of a single object of [SettingsClient.UpsertBatch].
+	UpsertResult struct {
+		Entity DynatraceEntity
+		Err    error
+	}
+
 	settingsRequest struct {
 		SchemaId      string  `json:"schemaId"`
 		ExternalId    string  `json:"externalId,omitempty"`
@@ -342,6 +365,13 @@ func WithCachingDisabled(disabled bool) func(client *SettingsClient) {
 	}
 }
 
+// WithSettingsCache sets the cache used for settings objects, e.g. to reuse them between runs.
+func WithSettingsCache(c cache.Cache[[]DownloadSettingsObject]) func(client *SettingsClient) {
+	return func(d *SettingsClient) {
+		d.settingsCache = c
+	}
+}
+
 // NewPlatformSettingsClient creates a new settings client to be used for platform enabled environments
 //
 //nolint:dupl
@@ -527,15 +557,170 @@ func (d *SettingsClient) handleUpsertUnsupportedVersion(ctx context.Context, obj
 //
 // Note: If the Dynatrace version of the remote system is <262, nothing will be performed and an error is returned.
 func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertOptions UpsertSettingsOptions) (result DynatraceEntity, err error) {
-	if !d.serverVersion.Invalid() && d.serverVersion.SmallerThan(version.Version{Major: 1, Minor: 262, Patch: 0}) {
+	if d.isUpsertUnsupported() {
 		return d.handleUpsertUnsupportedVersion(ctx, obj)
 	}
+	return d.upsert(ctx, obj, upsertOptions, true)
+}
+
+// upsert creates or updates the object as described in [SettingsClient.Upsert]. If retryStale is set and the object to
+// update was found in the cache, the upsert is retried once with the current settings objects if the update fails, as
+// the cached object may have been deleted in the meantime.
+func (d *SettingsClient) upsert(ctx context.Context, obj SettingsObject, upsertOptions UpsertSettingsOptions, retryStale bool) (DynatraceEntity, error) {
+	usedCache := d.isCached(obj.SchemaId)
+	request, err := d.buildUpsertRequest(ctx, obj, upsertOptions)
+	if err != nil {
+		return DynatraceEntity{}, err
+	}
+
+	payload, err := buildPostRequestPayload(ctx, []settingsRequest{request})
+	if err != nil {
+		return DynatraceEntity{}, fmt.Errorf("failed to build settings object: %w", err)
+	}
+
+	retrySetting := d.retrySettings.Normal
+	if upsertOptions.OverrideRetry != nil {
+		retrySetting = *upsertOptions.OverrideRetry
+	}
+
+	resp, err := SendWithRetryWithInitialTry(ctx, d.client.POST, d.settingsObjectAPIPath, corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}, payload, retrySetting)
+	if err != nil {
+		d.invalidateSettingsCache(obj.SchemaId)
+		if retryStale && usedCache && request.ObjectId != "" && isStaleObjectError(err) {
+			log.WithCtxFields(ctx).Debug("Failed to update cached object %q of schema %s, retrying with the current settings objects: %v", request.ObjectId, obj.SchemaId, err)
+			return d.upsert(ctx, obj, upsertOptions, false)
+		}
+		return DynatraceEntity{}, fmt.Errorf("failed to create or update settings object with externalId %s: %w", request.ExternalId, err)
+	}
+
+	entity, err := parsePostResponse(resp.Data)
+	if err != nil {
+		return DynatraceEntity{}, err
+	}
+	if upsertOptions.InsertAfter != nil {
+		// the position of the object in the cached order is unknown
+		d.objectSchemas.Store(entity.Id, obj.SchemaId)
+		d.invalidateSettingsCache(obj.SchemaId)
+	} else {
+		d.updateCachedObject(request, entity.Id, obj.Content)
+	}
+
+	if featureflags.AccessControlSettings.Enabled() && upsertOptions.AllUserPermission != nil {
+		permErr := d.modifyPermission(ctx, entity.Id, *upsertOptions.AllUserPermission)
+
+		if permErr != nil {
+			return DynatraceEntity{}, fmt.Errorf("failed to modify permissions of settings object with externalId %s: %w", request.ExternalId, permErr)
+		}
+	}
 
+	insertAfterForLogging := "<nil>"
+	if upsertOptions.InsertAfter != nil {
+		insertAfterForLogging = *upsertOptions.InsertAfter
+	}
+	log.WithCtxFields(ctx).Debug("Created/Updated object %s (schemaID: %s, Scope: %s, insertAfter: %s) with externalId %s", obj.Coordinate.ConfigId, obj.SchemaId, obj.Scope, insertAfterForLogging, request.ExternalId)
+	return entity, nil
+}
+
+// UpsertBatch creates or updates all given settings objects like [SettingsClient.Upsert], but sends them to the API in
+// a single request. Objects which need to be inserted at a specific position or whose permissions are modified can't be
+// part of a batch and need to be upserted individually.
+//
+// The results are returned in the order of the given objects. If the request as a whole fails, its error is returned
+// for every object.
+func (d *SettingsClient) UpsertBatch(ctx context.Context, objects []SettingsObject) []UpsertResult {
+	results := make([]UpsertResult, len(objects))
+
+	if d.isUpsertUnsupported() {
+		for i, obj := range objects {
+			results[i].Entity, results[i].Err = d.handleUpsertUnsupportedVersion(ctx, obj)
+		}
+		return results
+	}
+
+	usedCache := len(objects) > 0 && d.isCached(objects[0].SchemaId)
+
+	// indices maps the position of each request in the payload to the position of its object
+	var requests []settingsRequest
+	var indices []int
+	for i, obj := range objects {
+		request, err := d.buildUpsertRequest(ctx, obj, UpsertSettingsOptions{})
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		requests = append(requests, request)
+		indices = append(indices, i)
+	}
+	if len(requests) == 0 {
+		return results
+	}
+
+	setErr := func(err error) {
+		for _, i := range indices {
+			results[i].Err = err
+		}
+	}
+
+	payload, err := buildPostRequestPayload(ctx, requests)
+	if err != nil {
+		setErr(fmt.Errorf("failed to build settings objects: %w", err))
+		return results
+	}
+
+	resp, err := SendWithRetryWithInitialTry(ctx, d.client.POST, d.settingsObjectAPIPath, corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}, payload, d.retrySettings.Normal)
+
+	// if all objects are rejected, the API responds with an error, but still reports the result of every object
+	var body []byte
+	var request corerest.RequestInfo
+	if err == nil {
+		body, request = resp.Data, resp.Request
+	} else if apiErr := (coreapi.APIError{}); errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+		body, request = apiErr.Body, apiErr.Request
+	}
+
+	items, parseErr := parseBatchPostResponse(body, len(requests))
+	if parseErr != nil {
+		if err == nil {
+			err = parseErr
+		}
+		d.invalidateSettingsCache(objects[0].SchemaId)
+		setErr(fmt.Errorf("failed to create or update %d settings objects of schema %s: %w", len(requests), objects[0].SchemaId, err))
+		return results
+	}
+
+	for j, item := range items {
+		i := indices[j]
+		if item.Error != nil {
+			d.invalidateSettingsCache(objects[i].SchemaId)
+			if usedCache && requests[j].ObjectId != "" && isStaleObjectStatus(item.Code) {
+				log.WithFields(field.Coordinate(objects[i].Coordinate)).Debug("Failed to update cached object %q of schema %s as part of a batch, retrying with the current settings objects", requests[j].ObjectId, objects[i].SchemaId)
+				results[i].Entity, results[i].Err = d.upsert(ctx, objects[i], UpsertSettingsOptions{}, false)
+				continue
+			}
+			results[i].Err = fmt.Errorf("failed to create or update settings object with externalId %s: %w", requests[j].ExternalId, coreapi.APIError{StatusCode: item.Code, Body: item.Error, Request: request})
+			continue
+		}
+
+		results[i].Entity = DynatraceEntity{Id: item.ObjectId, Name: item.ObjectId}
+		d.updateCachedObject(requests[j], item.ObjectId, objects[i].Content)
+		log.WithFields(field.Coordinate(objects[i].Coordinate)).Debug("Created/Updated object %s (schemaID: %s, Scope: %s) with externalId %s as part of a batch", objects[i].Coordinate.ConfigId, objects[i].SchemaId, objects[i].Scope, requests[j].ExternalId)
+	}
+	return results
+}
+
+// isUpsertUnsupported returns whether the environment is too old to update settings objects.
+func (d *SettingsClient) isUpsertUnsupported() bool {
+	return !d.serverVersion.Invalid() && d.serverVersion.SmallerThan(version.Version{Major: 1, Minor: 262, Patch: 0})
+}
+
+// buildUpsertRequest builds the request creating or updating the given object, by finding the remote object to update
+// as described in [SettingsClient.Upsert].
+func (d *SettingsClient) buildUpsertRequest(ctx context.Context, obj SettingsObject, upsertOptions UpsertSettingsOptions) (settingsRequest, error) {
 	// The objectID of the object we want to update
 	remoteObjectId := ""
 
 	if matchingObject, found, err := d.findObjectWithMatchingConstraints(ctx, obj); err != nil {
-		return DynatraceEntity{}, err
+		return settingsRequest{}, err
 	} else if found {
 
 		var props []string
@@ -553,14 +738,14 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 	// This can be removed in a later release of monaco
 	legacyExternalID, err := d.generateExternalID(coordinate.Coordinate{Type: obj.Coordinate.Type, ConfigId: obj.Coordinate.ConfigId})
 	if err != nil {
-		return DynatraceEntity{}, fmt.Errorf("unable to generate external id: %w", err)
+		return settingsRequest{}, fmt.Errorf("unable to generate external id: %w", err)
 	}
 
 	settingsWithExternalID, err := d.List(ctx, obj.SchemaId, ListSettingsOptions{
 		Filter: func(object DownloadSettingsObject) bool { return object.ExternalId == legacyExternalID },
 	})
 	if err != nil {
-		return DynatraceEntity{}, err
+		return settingsRequest{}, err
 	}
 
 	if len(settingsWithExternalID) > 0 {
@@ -569,7 +754,7 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 
 	externalID, err := d.generateExternalID(obj.Coordinate)
 	if err != nil {
-		return DynatraceEntity{}, fmt.Errorf("unable to generate external id: %w", err)
+		return settingsRequest{}, fmt.Errorf("unable to generate external id: %w", err)
 	}
 
 	// If the server contains two configs, one with the origin-object-id and a second config with the externalID,
@@ -582,7 +767,7 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 		},
 	})
 	if err != nil {
-		return DynatraceEntity{}, err
+		return settingsRequest{}, err
 	}
 	if len(settings) == 1 {
 		remoteObjectId = settings[0].ObjectId
@@ -602,48 +787,27 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 
 	if schema, ok := d.schemaCache.Get(obj.SchemaId); ok {
 		if upsertOptions.InsertAfter != nil && !schema.Ordered {
-			return DynatraceEntity{}, fmt.Errorf("'%s' is not an ordered setting, hence 'insertAfter' is not supported for this type of setting object", obj.SchemaId)
+			return settingsRequest{}, fmt.Errorf("'%s' is not an ordered setting, hence 'insertAfter' is not supported for this type of setting object", obj.SchemaId)
 		}
 		if featureflags.AccessControlSettings.Enabled() && upsertOptions.AllUserPermission != nil && (schema.OwnerBasedAccessControl == nil || !*schema.OwnerBasedAccessControl) {
-			return DynatraceEntity{}, fmt.Errorf("schema '%s' does not have owner-based access control enabled, hence 'permissions' is not supported for this type of setting object'", obj.SchemaId)
+			return settingsRequest{}, fmt.Errorf("schema '%s' does not have owner-based access control enabled, hence 'permissions' is not supported for this type of setting object'", obj.SchemaId)
 		}
 	}
 
-	payload, err := buildPostRequestPayload(ctx, remoteObjectId, obj, externalID, upsertOptions.InsertAfter)
-	if err != nil {
-		return DynatraceEntity{}, fmt.Errorf("failed to build settings object: %w", err)
-	}
-
-	retrySetting := d.retrySettings.Normal
-	if upsertOptions.OverrideRetry != nil {
-		retrySetting = *upsertOptions.OverrideRetry
-	}
-
-	resp, err := SendWithRetryWithInitialTry(ctx, d.client.POST, d.settingsObjectAPIPath, corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}, payload, retrySetting)
-	if err != nil {
-		d.settingsCache.Delete(obj.SchemaId)
-		return DynatraceEntity{}, fmt.Errorf("failed to create or update settings object with externalId %s: %w", externalID, err)
-	}
-
-	entity, err := parsePostResponse(resp.Data)
-	if err != nil {
-		return DynatraceEntity{}, err
-	}
-
-	if featureflags.AccessControlSettings.Enabled() && upsertOptions.AllUserPermission != nil {
-		permErr := d.modifyPermission(ctx, entity.Id, *upsertOptions.AllUserPermission)
-
-		if permErr != nil {
-			return DynatraceEntity{}, fmt.Errorf("failed to modify permissions of settings object with externalId %s: %w", externalID, permErr)
-		}
+	var value any
+	if err := json.Unmarshal(obj.Content, &value); err != nil {
+		return settingsRequest{}, fmt.Errorf("failed to build settings object: failed to unmarshal rendered config: %w", err)
 	}
 
-	insertAfterForLogging := "<nil>"
-	if upsertOptions.InsertAfter != nil {
-		insertAfterForLogging = *upsertOptions.InsertAfter
-	}
-	log.WithCtxFields(ctx).Debug("Created/Updated object %s (schemaID: %s, Scope: %s, insertAfter: %s) with externalId %s", obj.Coordinate.ConfigId, obj.SchemaId, obj.Scope, insertAfterForLogging, externalID)
-	return entity, nil
+	return settingsRequest{
+		SchemaId:      obj.SchemaId,
+		ExternalId:    externalID,
+		Scope:         obj.Scope,
+		Value:         value,
+		SchemaVersion: obj.SchemaVersion,
+		ObjectId:      remoteObjectId,
+		InsertAfter:   insertAfterToPayloadValue(upsertOptions.InsertAfter),
+	}, nil
 }
 
 // modifyPermission creates, updates or deletes the all-user permission of a given settings object
@@ -807,30 +971,10 @@ func explodePath(targetPath string) []string {
 // buildPostRequestPayload builds the json that is required as body in the settings api.
 // POST Request body: https://www.dynatrace.com/support/help/dynatrace-api/environment-api/settings/objects/post-object#request-body-json-model
 //
-// To do this, we have to wrap the template in another object and send this object to the server.
-// Currently, we only encode one object into an array of objects, but we can optimize it to contain multiple elements to update.
+// The API accepts a list of objects, so several objects can be created or updated by a single request.
 // Note payload limitations: https://www.dynatrace.com/support/help/dynatrace-api/basics/access-limit#payload-limit
-func buildPostRequestPayload(ctx context.Context, remoteObjectId string, obj SettingsObject, externalID string, insertAfter *string) ([]byte, error) {
-	var value any
-	if err := json.Unmarshal(obj.Content, &value); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal rendered config: %w", err)
-	}
-
-	insertPosition := insertAfterToPayloadValue(insertAfter)
-
-	data := settingsRequest{
-		SchemaId:      obj.SchemaId,
-		ExternalId:    externalID,
-		Scope:         obj.Scope,
-		Value:         value,
-		SchemaVersion: obj.SchemaVersion,
-		ObjectId:      remoteObjectId,
-		InsertAfter:   insertPosition,
-	}
-
-	// Create json obj. We currently marshal everything into an array, but we can optimize it to include multiple objects in the
-	// future. Look up limits when imp
-	fullObj, err := json.Marshal([]interface{}{data})
+func buildPostRequestPayload(ctx context.Context, requests []settingsRequest) ([]byte, error) {
+	fullObj, err := json.Marshal(requests)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal full object: %w", err)
 	}
@@ -872,7 +1016,7 @@ func insertAfterToPayloadValue(insertAfter *string) *string {
 
 // parsePostResponse unmarshals and parses the settings response for the post request
 // The response is returned as an array for each element we send.
-// Since we only send one object at the moment, we simply use the first one.
+// [SettingsClient.Upsert] only sends one object, so we simply use the first one.
 func parsePostResponse(body []byte) (DynatraceEntity, error) {
 
 	var parsed []postResponse
@@ -894,9 +1038,27 @@ func parsePostResponse(body []byte) (DynatraceEntity, error) {
 	}, nil
 }
 
+// parseBatchPostResponse unmarshals the settings response for a post request of several objects. The response holds
+// the result of each object in the order of the request.
+func parseBatchPostResponse(body []byte, count int) ([]batchPostResponse, error) {
+	var parsed []batchPostResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w. Response was: %s", err, string(body))
+	}
+
+	if len(parsed) != count {
+		return nil, fmt.Errorf("response contains %d elements instead of %d", len(parsed), count)
+	}
+	return parsed, nil
+}
+
 func (d *SettingsClient) List(ctx context.Context, schemaId string, opts ListSettingsOptions) (res []DownloadSettingsObject, err error) {
-	if settings, cached := d.settingsCache.Get(schemaId); cached {
+	if settings, cached := d.cachedSettings(schemaId, opts.DiscardValue); cached {
 		log.WithCtxFields(ctx).Debug("Using cached settings for schema %s", schemaId)
+		// the cache may have been filled by another client, e.g. if it's persisted
+		for _, o := range settings {
+			d.objectSchemas.Store(o.ObjectId, schemaId)
+		}
 		return filter.FilterSlice(settings, opts.Filter), nil
 	}
 
@@ -931,11 +1093,112 @@ func (d *SettingsClient) List(ctx context.Context, schemaId string, opts ListSet
 		return nil, fmt.Errorf("failed to list settings of schema %q: %w", schemaId, err)
 	}
 
-	d.settingsCache.Set(schemaId, result)
+	d.settingsCache.Set(settingsCacheKey(schemaId, opts.DiscardValue), result)
+	for _, o := range result {
+		d.objectSchemas.Store(o.ObjectId, schemaId)
+	}
 
 	return filter.FilterSlice(result, opts.Filter), nil
 }
 
+// settingsCacheKey returns the key under which the settings objects of a schema are cached. Objects listed without
+// their value are cached separately, as they can't be used for requests needing the value.
+func settingsCacheKey(schemaId string, discardValue bool) string {
+	if discardValue {
+		return schemaId + "#withoutValue"
+	}
+	return schemaId
+}
+
+// cachedSettings returns the cached settings objects of the schema. Cached objects including their value are also
+// returned if the value is to be discarded.
+func (d *SettingsClient) cachedSettings(schemaId string, discardValue bool) ([]DownloadSettingsObject, bool) {
+	if settings, cached := d.settingsCache.Get(settingsCacheKey(schemaId, false)); cached || !discardValue {
+		return settings, cached
+	}
+	return d.settingsCache.Get(settingsCacheKey(schemaId, true))
+}
+
+// isCached returns whether settings objects of the schema are cached, either with or without their value.
+func (d *SettingsClient) isCached(schemaId string) bool {
+	_, cached := d.cachedSettings(schemaId, true)
+	return cached
+}
+
+// isStaleObjectError returns whether the error may be caused by updating an object that does not exist anymore.
+func isStaleObjectError(err error) bool {
+	var apiErr coreapi.APIError
+	return errors.As(err, &apiErr) && isStaleObjectStatus(apiErr.StatusCode)
+}
+
+// isStaleObjectStatus returns whether the status code of a failed upsert may be caused by updating an object that does
+// not exist anymore.
+func isStaleObjectStatus(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusBadRequest
+}
+
+// invalidateSettingsCache removes all cached settings objects of the schema.
+func (d *SettingsClient) invalidateSettingsCache(schemaId string) {
+	d.settingsCache.Delete(settingsCacheKey(schemaId, false))
+	d.settingsCache.Delete(settingsCacheKey(schemaId, true))
+}
+
+// updateCachedObject adds or replaces the object created or updated by the given request in the cached settings
+// objects of its schema, so that the cache doesn't need to be invalidated by the client's own modifications.
+func (d *SettingsClient) updateCachedObject(request settingsRequest, objectId string, value json.RawMessage) {
+	d.objectSchemas.Store(objectId, request.SchemaId)
+
+	d.updateCachedSettings(request.SchemaId, func(objects []DownloadSettingsObject, discardValue bool) []DownloadSettingsObject {
+		updated := DownloadSettingsObject{
+			ExternalId:    request.ExternalId,
+			SchemaVersion: request.SchemaVersion,
+			SchemaId:      request.SchemaId,
+			ObjectId:      objectId,
+			Scope:         request.Scope,
+			Value:         value,
+			Modified:      time.Now().UnixMilli(),
+		}
+		if discardValue {
+			updated.Value = nil
+		}
+
+		for i, o := range objects {
+			if o.ObjectId == objectId {
+				updated.ModificationInfo = o.ModificationInfo
+				objects[i] = updated
+				return objects
+			}
+		}
+		return append(objects, updated)
+	})
+}
+
+// removeCachedObject removes the deleted object from the cached settings objects of its schema.
+func (d *SettingsClient) removeCachedObject(objectId string) {
+	schemaId, known := d.objectSchemas.LoadAndDelete(objectId)
+	if !known {
+		return
+	}
+
+	d.updateCachedSettings(schemaId.(string), func(objects []DownloadSettingsObject, _ bool) []DownloadSettingsObject {
+		return slices.DeleteFunc(objects, func(o DownloadSettingsObject) bool { return o.ObjectId == objectId })
+	})
+}
+
+// updateCachedSettings applies the update to copies of all cached settings objects of the schema. Copies are needed,
+// as previously returned objects may still be in use.
+func (d *SettingsClient) updateCachedSettings(schemaId string, update func(objects []DownloadSettingsObject, discardValue bool) []DownloadSettingsObject) {
+	d.settingsCacheLock.Lock()
+	defer d.settingsCacheLock.Unlock()
+
+	for _, discardValue := range []bool{false, true} {
+		key := settingsCacheKey(schemaId, discardValue)
+		if objects, cached := d.settingsCache.Get(key); cached {
+			d.settingsCache.Set(key, update(slices.Clone(objects), discardValue))
+		}
+	}
+}
+
 func (d *SettingsClient) Get(ctx context.Context, objectId string) (res *DownloadSettingsObject, err error) {
 	resp, err := coreapi.AsResponseOrError(d.client.GET(ctx, d.settingsObjectAPIPath+"/"+objectId, corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
 	if err != nil {
@@ -955,11 +1218,13 @@ func (d *SettingsClient) Delete(ctx context.Context, objectID string) error {
 	if err != nil {
 		if coreapi.IsNotFoundError(err) {
 			log.Debug("No settings object with id '%s' found to delete (HTTP 404 response)", objectID)
+			d.removeCachedObject(objectID)
 			return nil
 		}
 		return err
 	}
 
+	d.removeCachedObject(objectID)
 	return nil
 }
 
@@ -28,12 +28,15 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/cache"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/pointer"
@@ -1449,6 +1452,98 @@ func TestUpsertSettingsRetries(t *testing.T) {
 	assert.Equal(t, numAPICalls, 3)
 }
 
+func TestUpsertBatch(t *testing.T) {
+	objects := []SettingsObject{
+		{Coordinate: coordinate.Coordinate{Project: "p", Type: "some:schema", ConfigId: "a"}, SchemaId: "some:schema", Scope: "environment", Content: []byte(`{"name":"a"}`)},
+		{Coordinate: coordinate.Coordinate{Project: "p", Type: "some:schema", ConfigId: "b"}, SchemaId: "some:schema", Scope: "environment", Content: []byte(`{"name":"b"}`)},
+		{Coordinate: coordinate.Coordinate{Project: "p", Type: "some:schema", ConfigId: "c"}, SchemaId: "some:schema", Scope: "environment", Content: []byte(`invalid`)},
+	}
+
+	tests := []struct {
+		name                string
+		postResponseCode    int
+		postResponseContent string
+		expectIDs           []string
+		expectErrors        []bool
+	}{
+		{
+			name:                "all objects are created",
+			postResponseCode:    http.StatusOK,
+			postResponseContent: `[{"code":200,"objectId":"id-a"},{"code":200,"objectId":"id-b"}]`,
+			expectIDs:           []string{"id-a", "id-b", ""},
+			expectErrors:        []bool{false, false, true},
+		},
+		{
+			name:                "rejected objects are reported individually",
+			postResponseCode:    http.StatusMultiStatus,
+			postResponseContent: `[{"code":200,"objectId":"id-a"},{"code":400,"error":{"code":400,"message":"invalid value"}}]`,
+			expectIDs:           []string{"id-a", "", ""},
+			expectErrors:        []bool{false, true, true},
+		},
+		{
+			name:                "all objects are rejected",
+			postResponseCode:    http.StatusBadRequest,
+			postResponseContent: `[{"code":400,"error":{"code":400,"message":"invalid value"}},{"code":400,"error":{"code":400,"message":"invalid value"}}]`,
+			expectIDs:           []string{"", "", ""},
+			expectErrors:        []bool{true, true, true},
+		},
+		{
+			name:                "error of the request is returned for all objects",
+			postResponseCode:    http.StatusUnauthorized,
+			postResponseContent: `{"error":{"code":401,"message":"unauthorized"}}`,
+			expectIDs:           []string{"", "", ""},
+			expectErrors:        []bool{true, true, true},
+		},
+		{
+			name:                "response with a wrong number of results is an error for all objects",
+			postResponseCode:    http.StatusOK,
+			postResponseContent: `[{"code":200,"objectId":"id-a"}]`,
+			expectIDs:           []string{"", "", ""},
+			expectErrors:        []bool{true, true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted []settingsRequest
+			server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodGet {
+					rw.WriteHeader(http.StatusOK)
+					_, _ = rw.Write([]byte(`{"items":[]}`))
+					return
+				}
+
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&posted))
+				rw.WriteHeader(tt.postResponseCode)
+				_, _ = rw.Write([]byte(tt.postResponseContent))
+			}))
+			defer server.Close()
+
+			serverURL, err := url.Parse(server.URL)
+			require.NoError(t, err)
+			restClient := corerest.NewClient(serverURL, server.Client())
+
+			client, err := NewClassicSettingsClient(restClient,
+				WithRetrySettings(testRetrySettings),
+				WithExternalIDGenerator(idutils.GenerateExternalIDForSettingsObject))
+			require.NoError(t, err)
+
+			results := client.UpsertBatch(t.Context(), objects)
+
+			require.Len(t, results, len(objects))
+			for i, r := range results {
+				assert.Equal(t, tt.expectIDs[i], r.Entity.Id, "object %d", i)
+				assert.Equal(t, tt.expectErrors[i], r.Err != nil, "object %d: %v", i, r.Err)
+			}
+
+			require.Len(t, posted, 2, "objects which can't be built must not be sent")
+			assert.Equal(t, "some:schema", posted[0].SchemaId)
+			assert.NotEmpty(t, posted[0].ExternalId)
+			assert.NotEqual(t, posted[0].ExternalId, posted[1].ExternalId)
+		})
+	}
+}
+
 func TestUpsertSettingsFromCache(t *testing.T) {
 	numAPIGetCalls := 0
 	numAPIPostCalls := 0
@@ -3159,3 +3254,269 @@ func TestSettingsClient_ClearCache_OnSettingsCache(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, settingsCalledCount, 2)
 }
+
+func TestSettingsClient_CacheIsUpdatedByModifications(t *testing.T) {
+	const testSchema = "builtin:test"
+	listCalledCount := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsSchemaAPIPathClassic+"/"+testSchema, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"schemaId": "builtin:test"}`))
+	})
+	mux.HandleFunc("GET "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		listCalledCount++
+		_, _ = w.Write([]byte(`{"items": [{"objectId": "obj-1", "externalId": "ext-1", "schemaId": "builtin:test", "scope": "environment", "value": {"name": "one"}}]}`))
+	})
+	mux.HandleFunc("POST "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"code": 200, "objectId": "obj-2"}]`))
+	})
+	mux.HandleFunc("DELETE "+settingsObjectAPIPathClassic+"/obj-1", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c, err := NewClassicSettingsClient(corerest.NewClient(serverURL, server.Client()), WithRetrySettings(testRetrySettings))
+	require.NoError(t, err)
+
+	objects, err := c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+
+	entity, err := c.Upsert(t.Context(), SettingsObject{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: testSchema, ConfigId: "config"},
+		SchemaId:   testSchema,
+		Scope:      "environment",
+		Content:    []byte(`{"name": "two"}`),
+	}, UpsertSettingsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "obj-2", entity.Id)
+
+	objects, err = c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, "obj-2", objects[1].ObjectId)
+	assert.Equal(t, "environment", objects[1].Scope)
+	assert.JSONEq(t, `{"name": "two"}`, string(objects[1].Value))
+
+	require.NoError(t, c.Delete(t.Context(), "obj-1"))
+
+	objects, err = c.List(t.Context(), testSchema, ListSettingsOptions{DiscardValue: true})
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "obj-2", objects[0].ObjectId)
+
+	assert.Equal(t, 1, listCalledCount, "all listings after the first one must be served from the cache")
+}
+
+func TestSettingsClient_ListingsWithoutValueAreCachedSeparately(t *testing.T) {
+	const testSchema = "builtin:test"
+	var requestedFields []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, r *http.Request) {
+		requestedFields = append(requestedFields, r.URL.Query().Get("fields"))
+		_, _ = w.Write([]byte(`{"items": [{"objectId": "obj-1", "schemaId": "builtin:test", "scope": "environment"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c, err := NewClassicSettingsClient(corerest.NewClient(serverURL, server.Client()))
+	require.NoError(t, err)
+
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{DiscardValue: true})
+	require.NoError(t, err)
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{DiscardValue: true})
+	require.NoError(t, err)
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{reducedListSettingsFields, defaultListSettingsFields}, requestedFields)
+}
+
+func TestSettingsClient_WithSettingsCache(t *testing.T) {
+	const testSchema = "builtin:test"
+	listCalledCount := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		listCalledCount++
+		_, _ = w.Write([]byte(`{"items": [{"objectId": "obj-1", "schemaId": "builtin:test", "scope": "environment"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	store := cache.NewStore(afero.NewMemMapFs(), "cache", time.Hour)
+	newClient := func() *SettingsClient {
+		c, err := NewClassicSettingsClient(corerest.NewClient(serverURL, server.Client()),
+			WithSettingsCache(cache.NewPersistentCache[[]DownloadSettingsObject](store, server.URL)))
+		require.NoError(t, err)
+		return c
+	}
+
+	first := newClient()
+	_, err = first.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	first.ClearCache()
+
+	objects, err := newClient().List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, 1, listCalledCount, "second client must reuse the persisted listing")
+}
+
+func TestSettingsClient_DeletedObjectIsRemovedFromPersistedCache(t *testing.T) {
+	const testSchema = "builtin:test"
+	listCalledCount := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		listCalledCount++
+		_, _ = w.Write([]byte(`{"items": [{"objectId": "obj-1", "schemaId": "builtin:test", "scope": "environment"}, {"objectId": "obj-2", "schemaId": "builtin:test", "scope": "environment"}]}`))
+	})
+	mux.HandleFunc("DELETE "+settingsObjectAPIPathClassic+"/obj-1", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	store := cache.NewStore(afero.NewMemMapFs(), "cache", time.Hour)
+	newClient := func() *SettingsClient {
+		c, err := NewClassicSettingsClient(corerest.NewClient(serverURL, server.Client()),
+			WithSettingsCache(cache.NewPersistentCache[[]DownloadSettingsObject](store, server.URL)))
+		require.NoError(t, err)
+		return c
+	}
+
+	first := newClient()
+	_, err = first.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	first.ClearCache()
+
+	c := newClient()
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.Delete(t.Context(), "obj-1"))
+
+	objects, err := c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "obj-2", objects[0].ObjectId)
+	assert.Equal(t, 1, listCalledCount)
+}
+
+func TestSettingsClient_UpsertIsRetriedIfCachedObjectIsStale(t *testing.T) {
+	const testSchema = "builtin:test"
+	listCalledCount := 0
+	var postedObjectIds []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsSchemaAPIPathClassic+"/"+testSchema, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"schemaId": "builtin:test"}`))
+	})
+	mux.HandleFunc("GET "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		listCalledCount++
+		if listCalledCount == 1 {
+			_, _ = w.Write([]byte(`{"items": [{"objectId": "obj-1", "schemaId": "builtin:test", "scope": "environment", "value": {}}]}`))
+			return
+		}
+		// the object was deleted after it was cached
+		_, _ = w.Write([]byte(`{"items": []}`))
+	})
+	mux.HandleFunc("POST "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, r *http.Request) {
+		var requests []settingsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&requests))
+		require.Len(t, requests, 1)
+		postedObjectIds = append(postedObjectIds, requests[0].ObjectId)
+
+		if requests[0].ObjectId != "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`[{"code": 404, "error": {"message": "not found"}}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"code": 200, "objectId": "obj-2"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c, err := NewClassicSettingsClient(corerest.NewClient(serverURL, server.Client()), WithRetrySettings(testRetrySettings))
+	require.NoError(t, err)
+
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+
+	entity, err := c.Upsert(t.Context(), SettingsObject{
+		Coordinate:     coordinate.Coordinate{Project: "project", Type: testSchema, ConfigId: "config"},
+		SchemaId:       testSchema,
+		Scope:          "environment",
+		Content:        []byte(`{}`),
+		OriginObjectId: "obj-1",
+	}, UpsertSettingsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "obj-2", entity.Id)
+	require.NotEmpty(t, postedObjectIds)
+	assert.Equal(t, "obj-1", postedObjectIds[0], "the cached object is updated first")
+	assert.Equal(t, "", postedObjectIds[len(postedObjectIds)-1], "the retry must not use the stale object")
+	assert.Equal(t, 2, listCalledCount)
+}
+
+func TestSettingsClient_UpsertWithInsertAfterInvalidatesCache(t *testing.T) {
+	const testSchema = "builtin:test"
+	listCalledCount := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsSchemaAPIPathClassic+"/"+testSchema, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"schemaId": "builtin:test", "ordered": true}`))
+	})
+	mux.HandleFunc("GET "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		listCalledCount++
+		_, _ = w.Write([]byte(`{"items": [{"objectId": "obj-1", "schemaId": "builtin:test", "scope": "environment", "value": {}}]}`))
+	})
+	mux.HandleFunc("POST "+settingsObjectAPIPathClassic, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"code": 200, "objectId": "obj-2"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c, err := NewClassicSettingsClient(corerest.NewClient(serverURL, server.Client()), WithRetrySettings(testRetrySettings))
+	require.NoError(t, err)
+
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+
+	_, err = c.Upsert(t.Context(), SettingsObject{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: testSchema, ConfigId: "config"},
+		SchemaId:   testSchema,
+		Scope:      "environment",
+		Content:    []byte(`{"name": "two"}`),
+	}, UpsertSettingsOptions{InsertAfter: pointer.Pointer(InsertPositionFront)})
+	require.NoError(t, err)
+
+	_, err = c.List(t.Context(), testSchema, ListSettingsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, listCalledCount, "the cached order is unknown after inserting at a position")
+}
@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -40,6 +42,9 @@ var DummyClientSet = ClientSet{
 	OpenPipelineClient:          &DummyOpenPipelineClient{},
 	SegmentClient:               &DummySegmentClient{},
 	ServiceLevelObjectiveClient: &DummyServiceLevelObjectClient{},
+	ExtensionsClient:            &DummyExtensionsClient{},
+	DocumentSharingClient:       &DummyDocumentSharingClient{},
+	AccountGroupsClient:         &DummyAccountGroupsClient{},
 }
 
 var _ AutomationClient = (*DummyAutomationClient)(nil)
@@ -94,9 +99,10 @@ func (d *DummyBucketClient) Delete(ctx context.Context, bucketName string) (api.
 	panic("unimplemented")
 }
 
-// Get implements BucketClient.
+// Get returns an error, as the deployed buckets are not fetched. Destructive changes to buckets are therefore reported
+// as unchecked.
 func (d *DummyBucketClient) Get(ctx context.Context, bucketName string) (api.Response, error) {
-	panic("unimplemented")
+	return api.Response{}, errors.New("the deployed bucket is not fetched")
 }
 
 // List implements BucketClient.
@@ -117,6 +123,25 @@ func (d *DummyBucketClient) Upsert(ctx context.Context, bucketName string, data
 	}, nil
 }
 
+var _ BucketClient = (*DryRunBucketClient)(nil)
+
+// DryRunBucketClient fetches the deployed buckets read-only, so that dry-runs can check changes to them. All changes
+// are only simulated, like in the DummyBucketClient.
+type DryRunBucketClient struct {
+	DummyBucketClient
+	deployed BucketClient
+}
+
+// NewDryRunBucketClient returns a DryRunBucketClient fetching the deployed buckets with the given client.
+func NewDryRunBucketClient(deployed BucketClient) *DryRunBucketClient {
+	return &DryRunBucketClient{deployed: deployed}
+}
+
+// Get returns the deployed bucket.
+func (d *DryRunBucketClient) Get(ctx context.Context, bucketName string) (api.Response, error) {
+	return d.deployed.Get(ctx, bucketName)
+}
+
 var _ DocumentClient = (*DummyDocumentClient)(nil)
 
 type DummyDocumentClient struct{}
@@ -150,6 +175,12 @@ var _ OpenPipelineClient = (*DummyOpenPipelineClient)(nil)
 
 type DummyOpenPipelineClient struct{}
 
+// Get implements OpenPipelineClient. Partial configurations are merged without the deployed configuration in dry-runs,
+// so it is not used for deployments.
+func (c *DummyOpenPipelineClient) Get(_ context.Context, id string) (openpipeline.Response, error) {
+	return openpipeline.Response{Data: []byte(fmt.Sprintf(`{"id":%q}`, id))}, nil
+}
+
 // GetAll implements OpenPipelineClient.
 func (c *DummyOpenPipelineClient) GetAll(ctx context.Context) ([]api.Response, error) {
 	panic("unimplemented")
@@ -202,3 +233,94 @@ func (c *DummyServiceLevelObjectClient) Create(_ context.Context, _ []byte) (api
 func (c *DummyServiceLevelObjectClient) Delete(_ context.Context, _ string) (api.Response, error) {
 	return api.Response{}, nil
 }
+
+type DummyExtensionsClient struct{}
+
+func (c *DummyExtensionsClient) List(_ context.Context) ([]dtclient.ExtensionVersion, error) {
+	return nil, nil
+}
+
+func (c *DummyExtensionsClient) ListVersions(_ context.Context, _ string) ([]dtclient.ExtensionVersion, error) {
+	return nil, nil
+}
+
+func (c *DummyExtensionsClient) Upload(_ context.Context, _ []byte) (dtclient.ExtensionVersion, error) {
+	return dtclient.ExtensionVersion{}, nil
+}
+
+func (c *DummyExtensionsClient) GetPackage(_ context.Context, _, _ string) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *DummyExtensionsClient) GetActiveVersion(_ context.Context, _ string) (string, bool, error) {
+	return "", false, nil
+}
+
+func (c *DummyExtensionsClient) EnsureActiveVersion(_ context.Context, _, _ string) (bool, error) {
+	return true, nil
+}
+
+func (c *DummyExtensionsClient) ListMonitoringConfigurations(_ context.Context, _ string) ([]dtclient.MonitoringConfiguration, error) {
+	return nil, nil
+}
+
+func (c *DummyExtensionsClient) CreateMonitoringConfiguration(_ context.Context, _, _ string, _ json.RawMessage) (string, error) {
+	return "", nil
+}
+
+func (c *DummyExtensionsClient) UpdateMonitoringConfiguration(_ context.Context, _, _ string, _ json.RawMessage) error {
+	return nil
+}
+
+var _ DocumentSharingClient = (*DummyDocumentSharingClient)(nil)
+
+type DummyDocumentSharingClient struct{}
+
+func (c *DummyDocumentSharingClient) ListDirectShares(_ context.Context, _ string) ([]dtclient.DirectShare, error) {
+	return nil, nil
+}
+
+func (c *DummyDocumentSharingClient) CreateDirectShare(_ context.Context, _, _ string, _ []dtclient.Recipient) error {
+	return nil
+}
+
+func (c *DummyDocumentSharingClient) DeleteDirectShare(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *DummyDocumentSharingClient) ListRecipients(_ context.Context, _ string) ([]dtclient.Recipient, error) {
+	return nil, nil
+}
+
+func (c *DummyDocumentSharingClient) AddRecipients(_ context.Context, _ string, _ []dtclient.Recipient) error {
+	return nil
+}
+
+func (c *DummyDocumentSharingClient) RemoveRecipients(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+
+func (c *DummyDocumentSharingClient) ListEnvironmentShares(_ context.Context, _ string) ([]dtclient.EnvironmentShare, error) {
+	return nil, nil
+}
+
+func (c *DummyDocumentSharingClient) CreateEnvironmentShare(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (c *DummyDocumentSharingClient) DeleteEnvironmentShare(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *DummyDocumentSharingClient) TransferOwner(_ context.Context, _ string, _ int, _ string) error {
+	return nil
+}
+
+var _ AccountGroupsClient = (*DummyAccountGroupsClient)(nil)
+
+// DummyAccountGroupsClient resolves every group name to a placeholder ID, as group names can't be verified offline.
+type DummyAccountGroupsClient struct{}
+
+func (c *DummyAccountGroupsClient) GroupIDByName(_ context.Context, name string) (string, error) {
+	return "dummy-group-" + name, nil
+}
@@ -73,6 +73,10 @@ func (TestServiceLevelObjectiveClient) Delete(ctx context.Context, id string) (a
 
 type TestOpenPipelineClient struct{}
 
+func (TestOpenPipelineClient) Get(ctx context.Context, id string) (openpipeline.Response, error) {
+	return api.Response{}, fmt.Errorf("unimplemented")
+}
+
 func (TestOpenPipelineClient) GetAll(ctx context.Context) ([]openpipeline.Response, error) {
 	return []api.Response{}, fmt.Errorf("unimplemented")
 }
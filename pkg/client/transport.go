@@ -0,0 +1,104 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// NewHTTPTransport creates a new [http.Transport] based on the default transport, applying the given transport settings.
+func NewHTTPTransport(t manifest.Transport) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if t.ProxyURL != nil {
+		proxyURL, err := url.Parse(t.ProxyURL.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", t.ProxyURL.Value, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: t.InsecureSkipVerify, // #nosec G402 -- explicitly configured by the user, a warning is logged when loading the manifest
+	}
+
+	if t.CACertificatePath != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(t.CACertificate) {
+			return nil, fmt.Errorf("no valid PEM encoded certificates found in %q", t.CACertificatePath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if t.ClientCertificatePath != "" {
+		cert, err := tls.X509KeyPair(t.ClientCertificate, []byte(t.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// ContextWithTransport returns a copy of ctx that makes OAuth based HTTP clients created with it, including the
+// requests to fetch OAuth tokens, send all requests using the given transport.
+func ContextWithTransport(ctx context.Context, transport http.RoundTripper) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+}
+
+// NewTokenBasedHTTPClient creates an [http.Client] authenticating all requests with the given API token and sending
+// them using the given transport.
+func NewTokenBasedHTTPClient(token string, transport http.RoundTripper) *http.Client {
+	return &http.Client{Transport: &tokenAuthTransport{base: transport, authorization: "Api-Token " + token}}
+}
+
+// NewPlatformTokenHTTPClient creates an [http.Client] authenticating all requests with the given platform token as
+// bearer token and sending them using the given transport. If transport is nil, [http.DefaultTransport] is used.
+func NewPlatformTokenHTTPClient(token string, transport http.RoundTripper) *http.Client {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{Transport: &tokenAuthTransport{base: transport, authorization: "Bearer " + token}}
+}
+
+// tokenAuthTransport adds the given authorization header to every request
+type tokenAuthTransport struct {
+	base          http.RoundTripper
+	authorization string
+}
+
+func (t *tokenAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", t.authorization)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,115 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func versionHandler(t *testing.T, requests *atomic.Int32) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "Api-Token mock token", req.Header.Get("Authorization"))
+		_, _ = rw.Write([]byte(`{"version" : "1.300.0.20240101"}`))
+	}
+}
+
+func TestCreateClientSetWithOptions_Transport(t *testing.T) {
+	tokenAuth := manifest.Auth{Token: &manifest.AuthSecret{Value: "mock token"}}
+
+	t.Run("requests are sent through the configured proxy", func(t *testing.T) {
+		var requests atomic.Int32
+		proxy := httptest.NewServer(versionHandler(t, &requests))
+		defer proxy.Close()
+
+		_, err := CreateClientSetWithOptions(t.Context(), "http://tenant.invalid", tokenAuth, ClientOptions{
+			Transport: &manifest.Transport{ProxyURL: &manifest.URLDefinition{Value: proxy.URL}},
+		})
+		require.NoError(t, err)
+		assert.Positive(t, requests.Load())
+	})
+
+	t.Run("custom CA is trusted", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewTLSServer(versionHandler(t, &requests))
+		defer server.Close()
+
+		_, err := CreateClientSetWithOptions(t.Context(), server.URL, tokenAuth, ClientOptions{
+			Transport: &manifest.Transport{
+				CACertificatePath: "ca.pem",
+				CACertificate:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+			},
+		})
+		require.NoError(t, err)
+		assert.Positive(t, requests.Load())
+	})
+
+	t.Run("untrusted certificates are rejected", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewTLSServer(versionHandler(t, &requests))
+		defer server.Close()
+
+		_, err := CreateClientSetWithOptions(t.Context(), server.URL, tokenAuth, ClientOptions{Transport: &manifest.Transport{}})
+		require.NoError(t, err, "failing to query the server version is not an error")
+		assert.Zero(t, requests.Load())
+	})
+
+	t.Run("certificate verification can be skipped", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewTLSServer(versionHandler(t, &requests))
+		defer server.Close()
+
+		_, err := CreateClientSetWithOptions(t.Context(), server.URL, tokenAuth, ClientOptions{
+			Transport: &manifest.Transport{InsecureSkipVerify: true},
+		})
+		require.NoError(t, err)
+		assert.Positive(t, requests.Load())
+	})
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	t.Run("fails for empty CA bundle", func(t *testing.T) {
+		_, err := NewHTTPTransport(manifest.Transport{CACertificatePath: "ca.pem"})
+		assert.ErrorContains(t, err, "no valid PEM encoded certificates")
+	})
+
+	t.Run("fails for CA bundle without certificates", func(t *testing.T) {
+		_, err := NewHTTPTransport(manifest.Transport{CACertificatePath: "ca.pem", CACertificate: []byte("not a certificate")})
+		assert.ErrorContains(t, err, "no valid PEM encoded certificates")
+	})
+
+	t.Run("fails for invalid client certificate", func(t *testing.T) {
+		_, err := NewHTTPTransport(manifest.Transport{
+			ClientCertificatePath: "cert.pem",
+			ClientCertificate:     []byte("not a certificate"),
+			ClientKeyPath:         "cert.key",
+			ClientKey:             "not a key",
+		})
+		assert.ErrorContains(t, err, "failed to load client certificate")
+	})
+}
@@ -18,6 +18,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -27,9 +28,11 @@ import (
 	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	fileParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	labelParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/label"
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	variableParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
@@ -93,6 +96,14 @@ type Config struct {
 
 	// OriginObjectId is the DT object ID of the object when it was downloaded from an environment
 	OriginObjectId string
+
+	// OriginExternalId is the external ID of the object when it was downloaded from an environment. It is not persisted
+	// and only used to match downloaded objects to configurations deployed by monaco.
+	OriginExternalId string
+
+	// OriginModified is the time the object was last modified in the environment it was downloaded from, if known.
+	// It is not persisted and only used to filter downloaded objects.
+	OriginModified time.Time
 }
 
 func (c *Config) Render(properties map[string]interface{}) (string, error) {
@@ -100,6 +111,11 @@ func (c *Config) Render(properties map[string]interface{}) (string, error) {
 		return "", nil
 	}
 
+	// extension packages are binary archives, which are neither templated nor JSON
+	if _, ok := c.Type.(ExtensionV2Type); ok {
+		return c.Template.Content()
+	}
+
 	var templatePath string // include path in errors if we know it
 	if t, ok := c.Template.(*template.FileBasedTemplate); ok {
 		templatePath = t.FilePath()
@@ -148,6 +164,8 @@ var DefaultParameterParsers = map[string]parameter.ParameterSerDe{
 	compoundParam.CompoundParameterType:       compoundParam.CompoundParameterSerde,
 	listParam.ListParameterType:               listParam.ListParameterSerde,
 	fileParam.FileParameterType:               fileParam.FileParameterSerde,
+	variableParam.VariableParameterType:       variableParam.VariableParameterSerde,
+	labelParam.LabelParameterType:             labelParam.LabelParameterSerde,
 }
 
 func (c *Config) References() []coordinate.Coordinate {
@@ -0,0 +1,116 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entityids
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+)
+
+// lookupBatchSize is the number of entity IDs looked up in a single request, to keep the entity selector short.
+const lookupBatchSize = 100
+
+// Annotate looks up all monitored entity IDs extracted into parameters of the given configs and returns their
+// annotations. IDs of entities that do not exist are annotated with their type only.
+func Annotate(ctx context.Context, lister EntityLister, configsPerType map[string][]config.Config) (Annotations, error) {
+	usedBy := make(map[string][]string)
+	for _, cfgs := range configsPerType {
+		for _, c := range cfgs {
+			for _, id := range extractedIDs(c) {
+				if meIDPattern.MatchString(id) && !slices.Contains(usedBy[id], c.Coordinate.String()) {
+					usedBy[id] = append(usedBy[id], c.Coordinate.String())
+				}
+			}
+		}
+	}
+	if len(usedBy) == 0 {
+		return nil, nil
+	}
+
+	ids := slices.Sorted(maps.Keys(usedBy))
+	annotations := make(Annotations, len(ids))
+	for _, id := range ids {
+		entityType, _, _ := strings.Cut(id, "-")
+		slices.Sort(usedBy[id])
+		annotations[id] = Annotation{Type: entityType, UsedBy: usedBy[id]}
+	}
+
+	for batch := range slices.Chunk(ids, lookupBatchSize) {
+		entities, err := lister.List(ctx, dtclient.EntityIdSelector(batch...))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entities {
+			a, found := annotations[e.EntityId]
+			if !found {
+				continue
+			}
+			a.Type = e.Type
+			a.DisplayName = e.DisplayName
+			a.Tags = tagStrings(e.Tags)
+			annotations[e.EntityId] = a
+		}
+	}
+
+	if missing := countMissing(annotations); missing > 0 {
+		log.Warn("%d of %d extracted entity IDs do not exist in the environment and are annotated without display name", missing, len(annotations))
+	}
+	return annotations, nil
+}
+
+func tagStrings(tags []dtclient.EntityTag) []string {
+	var result []string
+	for _, t := range tags {
+		result = append(result, t.StringRepresentation)
+	}
+	slices.Sort(result)
+	return result
+}
+
+func countMissing(annotations Annotations) int {
+	missing := 0
+	for _, a := range annotations {
+		if a.DisplayName == "" {
+			missing++
+		}
+	}
+	return missing
+}
+
+// Merge adds the given annotations to a. The configs using an entity annotated in both are combined.
+func (a Annotations) Merge(other Annotations) {
+	for id, o := range other {
+		existing, found := a[id]
+		if !found {
+			a[id] = o
+			continue
+		}
+		for _, c := range o.UsedBy {
+			if !slices.Contains(existing.UsedBy, c) {
+				existing.UsedBy = append(existing.UsedBy, c)
+			}
+		}
+		slices.Sort(existing.UsedBy)
+		a[id] = existing
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package entityids annotates the monitored entity IDs extracted into parameters on download with human-readable
+// information, like their display names. The annotations are stored in a sidecar file in the project folder and allow
+// to look up the IDs of the same entities in another environment on deployment.
+package entityids
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+// FileName is the name of the sidecar file written to the project folder.
+const FileName = "ids.yaml"
+
+// KeyEntityIDs is the top-level key of the sidecar file. Config loaders use it to detect and skip the file.
+const KeyEntityIDs = "entityIds"
+
+// extractedIDsParameter is the name of the value parameter the IDs are extracted into on download.
+const extractedIDsParameter = "extractedIDs"
+
+// meIDPattern matches a complete monitored entity ID, like 'HOST-0123456789ABCDEF'.
+var meIDPattern = regexp.MustCompile(`^[A-Z][A-Z_]*-[A-F0-9]{16}$`)
+
+// Annotation holds the human-readable information about an entity.
+type Annotation struct {
+	Type        string   `yaml:"type"`
+	DisplayName string   `yaml:"displayName,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	// UsedBy lists the coordinates of the configs referencing the entity.
+	UsedBy []string `yaml:"usedBy,omitempty"`
+}
+
+// Annotations maps entity IDs to their annotation.
+type Annotations map[string]Annotation
+
+type file struct {
+	EntityIDs Annotations `yaml:"entityIds"`
+}
+
+// EntityLister lists monitored entities matching an entity selector.
+type EntityLister interface {
+	List(ctx context.Context, entitySelector string) ([]dtclient.Entity, error)
+}
+
+// IsEntityIDsFile returns whether the given top-level content of a YAML file is the content of an annotations file.
+func IsEntityIDsFile(content map[string]any) bool {
+	return content[KeyEntityIDs] != nil
+}
+
+// Write writes the annotations to the sidecar file in the given project folder.
+func Write(fs afero.Fs, projectFolder string, annotations Annotations) error {
+	data, err := yaml.Marshal(file{EntityIDs: annotations})
+	if err != nil {
+		return fmt.Errorf("failed to marshal entity ID annotations: %w", err)
+	}
+	p := filepath.Join(projectFolder, FileName)
+	if err := afero.WriteFile(fs, p, data, 0644); err != nil {
+		return fmt.Errorf("failed to write entity ID annotations to %q: %w", p, err)
+	}
+	return nil
+}
+
+// Load reads the annotations from the sidecar file in the given project folder. If there is no such file, it returns
+// nil annotations.
+func Load(fs afero.Fs, projectFolder string) (Annotations, error) {
+	p := filepath.Join(projectFolder, FileName)
+	if exists, err := afero.Exists(fs, p); err != nil || !exists {
+		return nil, err
+	}
+	data, err := afero.ReadFile(fs, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entity ID annotations from %q: %w", p, err)
+	}
+	var f file
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse entity ID annotations from %q: %w", p, err)
+	}
+	return f.EntityIDs, nil
+}
+
+// extractedIDs returns the IDs extracted into the given config's parameter, by their parameter key. The values of
+// downloaded configs are of type map[string]string, while loaded ones are maps parsed from YAML.
+func extractedIDs(c config.Config) map[string]string {
+	p, ok := c.Parameters[extractedIDsParameter].(*value.ValueParameter)
+	if !ok {
+		return nil
+	}
+
+	ids := make(map[string]string)
+	switch v := p.Value.(type) {
+	case map[string]string:
+		for k, id := range v {
+			ids[k] = id
+		}
+	case map[string]any:
+		for k, id := range v {
+			if s, ok := id.(string); ok {
+				ids[k] = s
+			}
+		}
+	case map[any]any:
+		for k, id := range v {
+			key, keyOk := k.(string)
+			s, ok := id.(string)
+			if keyOk && ok {
+				ids[key] = s
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,121 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entityids
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+// listerFunc lists entities by calling itself.
+type listerFunc func(entitySelector string) ([]dtclient.Entity, error)
+
+func (f listerFunc) List(_ context.Context, entitySelector string) ([]dtclient.Entity, error) {
+	return f(entitySelector)
+}
+
+func newConfig(id string, extractedIDs any) config.Config {
+	return config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "builtin:test", ConfigId: id},
+		Parameters: config.Parameters{extractedIDsParameter: value.New(extractedIDs)},
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	configs := map[string][]config.Config{
+		"builtin:test": {
+			newConfig("a", map[string]string{"id_1": "HOST-0000000000000001", "id_2": "0b9b4a8e-6f2c-4d0a-9a3f-8b7c6d5e4f3a"}),
+			newConfig("b", map[string]string{"id_1": "HOST-0000000000000001", "id_2": "SERVICE-0000000000000002"}),
+		},
+	}
+
+	var selectors []string
+	lister := listerFunc(func(entitySelector string) ([]dtclient.Entity, error) {
+		selectors = append(selectors, entitySelector)
+		return []dtclient.Entity{{EntityId: "HOST-0000000000000001", Type: "HOST", DisplayName: "my-host", Tags: []dtclient.EntityTag{{StringRepresentation: "team:b"}, {StringRepresentation: "env:prod"}}}}, nil
+	})
+
+	annotations, err := Annotate(t.Context(), lister, configs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`entityId("HOST-0000000000000001","SERVICE-0000000000000002")`}, selectors, "UUIDs are not looked up")
+	assert.Equal(t, Annotations{
+		"HOST-0000000000000001":    {Type: "HOST", DisplayName: "my-host", Tags: []string{"env:prod", "team:b"}, UsedBy: []string{"project:builtin:test:a", "project:builtin:test:b"}},
+		"SERVICE-0000000000000002": {Type: "SERVICE", UsedBy: []string{"project:builtin:test:b"}},
+	}, annotations)
+}
+
+func TestResolve(t *testing.T) {
+	annotations := Annotations{
+		"HOST-0000000000000001":    {Type: "HOST", DisplayName: "my-host", Tags: []string{"env:prod"}},
+		"SERVICE-0000000000000002": {Type: "SERVICE", DisplayName: "unknown"},
+	}
+	lister := listerFunc(func(entitySelector string) ([]dtclient.Entity, error) {
+		if entitySelector == dtclient.EntityNameSelector("HOST", "my-host") {
+			return []dtclient.Entity{
+				{EntityId: "HOST-00000000000000AA", Tags: []dtclient.EntityTag{{StringRepresentation: "env:dev"}}},
+				{EntityId: "HOST-00000000000000BB", Tags: []dtclient.EntityTag{{StringRepresentation: "env:prod"}}},
+			}, nil
+		}
+		return nil, nil
+	})
+
+	// loaded parameters hold maps parsed from YAML
+	c := newConfig("a", map[any]any{"id_1": "HOST-0000000000000001", "id_2": "SERVICE-0000000000000002"})
+	err := Resolve(t.Context(), lister, annotations, map[string][]config.Config{"builtin:test": {c}})
+	require.NoError(t, err)
+
+	assert.Equal(t, value.New(map[string]string{"id_1": "HOST-00000000000000BB", "id_2": "SERVICE-0000000000000002"}), c.Parameters[extractedIDsParameter])
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	annotations := Annotations{"HOST-0000000000000001": {Type: "HOST", DisplayName: "my-host", UsedBy: []string{"project:builtin:test:a"}}}
+
+	require.NoError(t, Write(fs, "project", annotations))
+	loaded, err := Load(fs, "project")
+	require.NoError(t, err)
+	assert.Equal(t, annotations, loaded)
+
+	loaded, err = Load(fs, "other-project")
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+}
+
+func TestAnnotations_Merge(t *testing.T) {
+	a := Annotations{"HOST-0000000000000001": {Type: "HOST", UsedBy: []string{"project:t:b"}}}
+	a.Merge(Annotations{
+		"HOST-0000000000000001": {Type: "HOST", UsedBy: []string{"project:t:a", "project:t:b"}},
+		"HOST-0000000000000002": {Type: "HOST"},
+	})
+
+	assert.Equal(t, Annotations{
+		"HOST-0000000000000001": {Type: "HOST", UsedBy: []string{"project:t:a", "project:t:b"}},
+		"HOST-0000000000000002": {Type: "HOST"},
+	}, a)
+}
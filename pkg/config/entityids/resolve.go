@@ -0,0 +1,102 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entityids
+
+import (
+	"context"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+// Resolve replaces the annotated entity IDs extracted into parameters of the given configs with the IDs of the
+// entities of the same type and display name in the environment of the lister. If tags are annotated, they are used to
+// choose between several entities of the same name. IDs that can not be resolved unambiguously are kept.
+// It modifies the given configs.
+func Resolve(ctx context.Context, lister EntityLister, annotations Annotations, configsPerType map[string][]config.Config) error {
+	resolved := make(map[string]string)
+	for _, cfgs := range configsPerType {
+		for _, c := range cfgs {
+			ids := extractedIDs(c)
+			if len(ids) == 0 {
+				continue
+			}
+
+			changed := false
+			for key, id := range ids {
+				newID, found := resolved[id]
+				if !found {
+					var err error
+					if newID, err = resolveID(ctx, lister, id, annotations); err != nil {
+						return err
+					}
+					resolved[id] = newID
+				}
+				if newID != id {
+					log.WithFields(field.Coordinate(c.Coordinate)).Debug("Resolved entity ID %q to %q", id, newID)
+					ids[key] = newID
+					changed = true
+				}
+			}
+			if changed {
+				c.Parameters[extractedIDsParameter] = value.New(ids)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveID returns the ID of the entity matching the annotation of the given ID, or the given ID if it is not
+// annotated or can not be resolved unambiguously.
+func resolveID(ctx context.Context, lister EntityLister, id string, annotations Annotations) (string, error) {
+	a, found := annotations[id]
+	if !found || a.DisplayName == "" {
+		return id, nil
+	}
+
+	entities, err := lister.List(ctx, dtclient.EntityNameSelector(a.Type, a.DisplayName))
+	if err != nil {
+		return "", err
+	}
+	if len(entities) > 1 && len(a.Tags) > 0 {
+		entities = slices.DeleteFunc(entities, func(e dtclient.Entity) bool { return !hasAllTags(e, a.Tags) })
+	}
+
+	switch len(entities) {
+	case 1:
+		return entities[0].EntityId, nil
+	case 0:
+		log.Warn("No entity of type %q named %q found, keeping entity ID %q", a.Type, a.DisplayName, id)
+	default:
+		log.Warn("%d entities of type %q named %q found, keeping entity ID %q", len(entities), a.Type, a.DisplayName, id)
+	}
+	return id, nil
+}
+
+func hasAllTags(e dtclient.Entity, tags []string) bool {
+	entityTags := tagStrings(e.Tags)
+	for _, t := range tags {
+		if !slices.Contains(entityTags, t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lookup implements parameters whose value is looked up by name in values defined in the manifest for the
+// environment, like manifest variables and environment labels.
+package lookup
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// Kind describes a type of lookup parameter and the values its parameters are looked up in.
+type Kind struct {
+	// Type is the type of the parameters used in config files
+	Type string
+
+	// Noun names a single value in errors, e.g. "variable"
+	Noun string
+
+	// Values returns the values defined for the environment the parameter is parsed for
+	Values func(context parameter.ParameterParserContext) map[string]string
+
+	// Undefined describes where a value was not found in errors, e.g. "in the manifest for this environment"
+	Undefined string
+}
+
+// Parameter is a parameter which loads its value by name from values defined in the manifest for the environment.
+type Parameter struct {
+	// Type of the parameter
+	Type string
+
+	// Name of the referenced value
+	Name string
+
+	// Value holds the value for the environment this parameter has been parsed for
+	Value string
+}
+
+// this forces the compiler to check if Parameter implements parameter.Parameter
+var _ parameter.Parameter = (*Parameter)(nil)
+
+func (p *Parameter) GetType() string {
+	return p.Type
+}
+
+func (p *Parameter) GetReferences() []parameter.ParameterReference {
+	// lookup parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *Parameter) ResolveValue(_ parameter.ResolveContext) (interface{}, error) {
+	return template.EscapeSpecialCharactersInValue(p.Value, template.FullStringEscapeFunction)
+}
+
+// New returns a parameter of this kind with the given name and value.
+func (k Kind) New(name string, value string) *Parameter {
+	return &Parameter{
+		Type:  k.Type,
+		Name:  name,
+		Value: value,
+	}
+}
+
+// Serde returns the serializer and deserializer of parameters of this kind.
+func (k Kind) Serde() parameter.ParameterSerDe {
+	return parameter.ParameterSerDe{
+		Serializer:   k.write,
+		Deserializer: k.parse,
+	}
+}
+
+// parse parses a parameter of this kind from a given context.
+// It requires a `name` field that matches a value defined for the current environment.
+func (k Kind) parse(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	n, ok := context.Value["name"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `name`")
+	}
+	name := strings.ToString(n)
+
+	value, found := k.Values(context)[name]
+	if !found {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("%s `%s` is not defined %s", k.Noun, name, k.Undefined))
+	}
+
+	return k.New(name, value), nil
+}
+
+func (k Kind) write(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	p, ok := context.Parameter.(*Parameter)
+
+	if !ok || p.Type != k.Type {
+		return nil, parameter.NewParameterWriterError(context, fmt.Sprintf("unexpected type. parameter is not of type `%s`", k.Type))
+	}
+
+	return map[string]interface{}{
+		"name": p.Name,
+	}, nil
+}
@@ -0,0 +1,85 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lookup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+var testKind = Kind{
+	Type:      "test",
+	Noun:      "value",
+	Values:    func(context parameter.ParameterParserContext) map[string]string { return context.Variables },
+	Undefined: "for this environment",
+}
+
+func TestParse(t *testing.T) {
+	param, err := testKind.parse(parameter.ParameterParserContext{
+		Value:     map[string]interface{}{"name": "region"},
+		Variables: map[string]string{"region": "eu"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &Parameter{Type: "test", Name: "region", Value: "eu"}, param)
+	assert.Equal(t, "test", param.GetType())
+	assert.Empty(t, param.GetReferences())
+}
+
+func TestParse_MissingName(t *testing.T) {
+	_, err := testKind.parse(parameter.ParameterParserContext{
+		Value:     map[string]interface{}{"wrong": "region"},
+		Variables: map[string]string{"region": "eu"},
+	})
+
+	assert.ErrorContains(t, err, "missing property `name`")
+}
+
+func TestParse_UndefinedValue(t *testing.T) {
+	_, err := testKind.parse(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"name": "region"},
+	})
+
+	assert.ErrorContains(t, err, "value `region` is not defined for this environment")
+}
+
+func TestResolveValue(t *testing.T) {
+	val, err := testKind.New("host", `hooks."slack".com`).ResolveValue(parameter.ResolveContext{})
+
+	require.NoError(t, err)
+	assert.Equal(t, `hooks.\"slack\".com`, val)
+}
+
+func TestWrite(t *testing.T) {
+	result, err := testKind.write(parameter.ParameterWriterContext{Parameter: testKind.New("region", "eu")})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "region"}, result)
+}
+
+func TestWrite_OtherKind(t *testing.T) {
+	other := Kind{Type: "other"}
+	_, err := testKind.write(parameter.ParameterWriterContext{Parameter: other.New("region", "eu")})
+
+	assert.ErrorContains(t, err, "parameter is not of type `test`")
+}
@@ -0,0 +1,41 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package label
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/internal/lookup"
+)
+
+// LabelParameterType specifies the type of the parameter used in config files
+const LabelParameterType = "label"
+
+var kind = lookup.Kind{
+	Type:      LabelParameterType,
+	Noun:      "label",
+	Values:    func(context parameter.ParameterParserContext) map[string]string { return context.Labels },
+	Undefined: "on this environment",
+}
+
+var LabelParameterSerde = kind.Serde()
+
+// LabelParameter defines a parameter which loads its value from a label of the environment defined in the manifest.
+type LabelParameter = lookup.Parameter
+
+func New(name string, value string) *LabelParameter {
+	return kind.New(name, value)
+}
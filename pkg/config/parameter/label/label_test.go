@@ -0,0 +1,54 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package label
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+func TestParseLabelParameter(t *testing.T) {
+	param, err := LabelParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value:  map[string]interface{}{"name": "tier"},
+		Labels: map[string]string{"tier": "prod"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, New("tier", "prod"), param)
+	assert.Equal(t, LabelParameterType, param.GetType())
+}
+
+func TestParseLabelParameter_UndefinedLabel(t *testing.T) {
+	_, err := LabelParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"name": "tier"},
+	})
+
+	assert.ErrorContains(t, err, "label `tier` is not defined on this environment")
+}
+
+func TestWriteLabelParameter(t *testing.T) {
+	result, err := LabelParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: New("tier", "prod")})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "tier"}, result)
+}
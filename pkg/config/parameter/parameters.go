@@ -91,6 +91,10 @@ type ParameterParserContext struct {
 	Fs               afero.Fs
 	Value            map[string]interface {
 	}
+	// Variables holds the resolved manifest variables available to the environment the parameter is parsed for
+	Variables map[string]string
+	// Labels holds the labels of the environment the parameter is parsed for
+	Labels map[string]string
 }
 
 type ParameterParserError struct {
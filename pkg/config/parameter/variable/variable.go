@@ -0,0 +1,42 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variable
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/internal/lookup"
+)
+
+// VariableParameterType specifies the type of the parameter used in config files
+const VariableParameterType = "variable"
+
+var kind = lookup.Kind{
+	Type:      VariableParameterType,
+	Noun:      "variable",
+	Values:    func(context parameter.ParameterParserContext) map[string]string { return context.Variables },
+	Undefined: "in the manifest for this environment",
+}
+
+var VariableParameterSerde = kind.Serde()
+
+// VariableParameter defines a parameter which loads its value from a variable defined in the manifest.
+// Variables are defined per environment, so the value is looked up when the parameter is parsed for an environment.
+type VariableParameter = lookup.Parameter
+
+func New(name string, value string) *VariableParameter {
+	return kind.New(name, value)
+}
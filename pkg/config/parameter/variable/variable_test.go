@@ -0,0 +1,54 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+func TestParseVariableParameter(t *testing.T) {
+	param, err := VariableParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value:     map[string]interface{}{"name": "region"},
+		Variables: map[string]string{"region": "eu"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, New("region", "eu"), param)
+	assert.Equal(t, VariableParameterType, param.GetType())
+}
+
+func TestParseVariableParameter_UndefinedVariable(t *testing.T) {
+	_, err := VariableParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"name": "region"},
+	})
+
+	assert.ErrorContains(t, err, "variable `region` is not defined in the manifest for this environment")
+}
+
+func TestWriteVariableParameter(t *testing.T) {
+	result, err := VariableParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: New("region", "eu")})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "region"}, result)
+}
@@ -26,6 +26,9 @@ const (
 	OpenPipelineTypeID      TypeID = "openpipeline"
 	SegmentID               TypeID = "segment"
 	ServiceLevelObjectiveID TypeID = "slo-v2"
+	ExtensionV2TypeID       TypeID = "extension-v2"
+
+	ExtensionV2MonitoringConfigurationTypeID TypeID = "extension-v2-monitoring-configuration"
 )
 
 var _ Type = SettingsType{}
@@ -93,7 +96,16 @@ func (AutomationType) ID() TypeID {
 
 var _ Type = BucketType{}
 
-type BucketType struct{}
+// BucketType represents a Grail bucket. As changes to a bucket may delete the data stored in it, like decreasing its
+// retention or deleting it, such changes are only made if they are allowed explicitly.
+type BucketType struct {
+	// AllowDestructive allows changes to the bucket which delete data, like decreasing its retention, changing its
+	// table or deleting it.
+	AllowDestructive bool
+
+	// Protected prevents the bucket from being deleted by delete or purge, even if destructive changes are allowed.
+	Protected bool
+}
 
 func (BucketType) ID() TypeID {
 	return BucketTypeID
@@ -108,9 +120,52 @@ type DocumentType struct {
 
 	// Private indicates if a document is private, otherwise by default it is visible to other users.
 	Private bool
+
+	// Sharing defines the owner and shares of the document. If nil, the sharing of the document is not managed.
+	Sharing *DocumentSharing
+}
+
+// DocumentSharing defines the owner of a document and with whom it is shared.
+// Shares which exist on the document but are not defined here are removed on deployment.
+type DocumentSharing struct {
+	// Owner is the ID of the user owning the document. If empty, the owner is not changed.
+	Owner string
+
+	// Environment is the access granted to all users of the environment via a share link. If empty, the document is
+	// not shared with the environment.
+	Environment DocumentAccess
+
+	// Users are the users the document is directly shared with.
+	Users []DocumentShare
+
+	// Groups are the groups the document is directly shared with.
+	Groups []DocumentShare
 }
 
-// DocumentKind defines the type of document. Currently, it can be a dashboard or a notebook.
+// DocumentShare is a direct share of a document with a user or group.
+type DocumentShare struct {
+	// ID is the ID of the user or group.
+	ID string
+
+	// Name is the name of the account group, used if the ID is not known. It is resolved using the accounts of the manifest.
+	Name string
+
+	// Access is the access granted to the user or group.
+	Access DocumentAccess
+}
+
+// DocumentAccess defines the access granted by a document share.
+type DocumentAccess = string
+
+const (
+	DocumentReadAccess      DocumentAccess = "read"
+	DocumentReadWriteAccess DocumentAccess = "read-write"
+)
+
+var KnownDocumentAccess = []DocumentAccess{DocumentReadAccess, DocumentReadWriteAccess}
+
+// DocumentKind defines the type of document. Unless documents of any type are enabled, it must be one of the
+// KnownDocumentKinds.
 type DocumentKind string
 
 const (
@@ -135,6 +190,37 @@ var _ Type = OpenPipelineType{}
 type OpenPipelineType struct {
 	// Kind indicates the type of OpenPipeline.
 	Kind string
+	// Partial is set if the config only defines a part of the OpenPipeline configuration of its kind. All configs of
+	// a kind are merged into one configuration before they are deployed.
+	Partial *OpenPipelinePartial
+}
+
+// OpenPipelinePartialType is the part of an OpenPipeline configuration a partial config defines.
+type OpenPipelinePartialType string
+
+const (
+	OpenPipelinePipelinePartial     OpenPipelinePartialType = "pipeline"
+	OpenPipelineProcessorPartial    OpenPipelinePartialType = "processor"
+	OpenPipelineEndpointPartial     OpenPipelinePartialType = "endpoint"
+	OpenPipelineRoutingEntryPartial OpenPipelinePartialType = "routing-entry"
+)
+
+// KnownOpenPipelinePartialTypes lists all partial types in the order they are merged into an OpenPipeline configuration.
+// Pipelines are merged first, so that processors can be added to pipelines defined by other partial configs.
+var KnownOpenPipelinePartialTypes = []OpenPipelinePartialType{
+	OpenPipelinePipelinePartial,
+	OpenPipelineProcessorPartial,
+	OpenPipelineEndpointPartial,
+	OpenPipelineRoutingEntryPartial,
+}
+
+// OpenPipelinePartial defines which part of an OpenPipeline configuration a config defines.
+type OpenPipelinePartial struct {
+	Type OpenPipelinePartialType
+	// Pipeline is the ID of the pipeline a processor is part of. Only set for processors.
+	Pipeline string
+	// Stage is the stage of the pipeline a processor is part of, e.g. 'processing'. Only set for processors.
+	Stage string
 }
 
 func (OpenPipelineType) ID() TypeID {
@@ -154,3 +240,25 @@ type ServiceLevelObjective struct{}
 func (ServiceLevelObjective) ID() TypeID {
 	return ServiceLevelObjectiveID
 }
+
+var _ Type = ExtensionV2Type{}
+
+// ExtensionV2Type represents an Extensions 2.0 extension. Its template is the signed extension package, which is
+// uploaded and activated in the version it contains.
+type ExtensionV2Type struct{}
+
+func (ExtensionV2Type) ID() TypeID {
+	return ExtensionV2TypeID
+}
+
+var _ Type = ExtensionV2MonitoringConfigurationType{}
+
+// ExtensionV2MonitoringConfigurationType represents a monitoring configuration of an Extensions 2.0 extension.
+type ExtensionV2MonitoringConfigurationType struct {
+	// Extension is the name of the extension the monitoring configuration is for.
+	Extension string
+}
+
+func (ExtensionV2MonitoringConfigurationType) ID() TypeID {
+	return ExtensionV2MonitoringConfigurationTypeID
+}
@@ -0,0 +1,83 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// BucketProtection decides which Grail buckets may be deleted by Configs and All.
+type BucketProtection = bucket.Protection
+
+// LoadBucketConfigs loads the bucket configs of all projects of the manifest, which define the BucketProtection of each
+// environment. Configs of other types are not loaded. Without the bucket configs it is unknown which buckets are
+// protected, so failing to load them is an error.
+func LoadBucketConfigs(ctx context.Context, fs afero.Fs, workingDir string, m manifest.Manifest) ([]project.Project, error) {
+	if len(m.Projects) == 0 {
+		return nil, nil
+	}
+
+	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      workingDir,
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+		ConfigTypes:     []config.TypeID{config.BucketTypeID},
+	}, nil)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to load the bucket configs to check which Grail buckets may be deleted: %w", errors.Join(errs...))
+	}
+	return projects, nil
+}
+
+// NewBucketProtection creates the BucketProtection of the given environment from the bucket configs of the projects.
+// If allowDestructive is set, all buckets whose config is not protected may be deleted.
+func NewBucketProtection(projects []project.Project, environment string, allowDestructive bool) BucketProtection {
+	p := BucketProtection{AllowDestructive: allowDestructive, Configs: make(map[string]config.BucketType)}
+	for _, proj := range projects {
+		for c := range proj.Configs[environment].AllConfigs {
+			t, ok := c.Type.(config.BucketType)
+			if !ok {
+				continue
+			}
+
+			bucketName := c.OriginObjectId
+			if bucketName == "" {
+				bucketName = idutils.GenerateBucketName(c.Coordinate)
+			}
+			p.Configs[bucketName] = t
+		}
+	}
+	return p
+}
+
+// NewContextWithBucketProtection returns a context in which Configs and All only delete the Grail buckets allowed by
+// the given BucketProtection. Without BucketProtection, no buckets are deleted.
+func NewContextWithBucketProtection(ctx context.Context, p BucketProtection) context.Context {
+	return bucket.NewContextWithProtection(ctx, p)
+}
@@ -0,0 +1,135 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delete_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func TestNewBucketProtection(t *testing.T) {
+	projects := []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": {
+					"bucket": {
+						{
+							Coordinate: coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "generated"},
+							Type:       config.BucketType{Protected: true},
+						},
+						{
+							Coordinate:     coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "downloaded"},
+							Type:           config.BucketType{AllowDestructive: true},
+							OriginObjectId: "origin_bucket",
+						},
+					},
+					"builtin:tags.auto-tagging": {
+						{
+							Coordinate: coordinate.Coordinate{Project: "project", Type: "builtin:tags.auto-tagging", ConfigId: "tag"},
+							Type:       config.SettingsType{SchemaId: "builtin:tags.auto-tagging"},
+						},
+					},
+				},
+				"other-env": {
+					"bucket": {
+						{
+							Coordinate: coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "other"},
+							Type:       config.BucketType{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := delete.NewBucketProtection(projects, "env", true)
+
+	assert.Equal(t, delete.BucketProtection{
+		AllowDestructive: true,
+		Configs: map[string]config.BucketType{
+			"project_generated": {Protected: true},
+			"origin_bucket":     {AllowDestructive: true},
+		},
+	}, got)
+}
+
+func TestLoadBucketConfigs(t *testing.T) {
+	t.Setenv(featureflags.BucketSafety.EnvName(), "true")
+
+	m := manifest.Manifest{
+		Projects:     manifest.ProjectDefinitionByProjectID{"project": {Name: "project", Path: "project"}},
+		Environments: manifest.Environments{"env": {Name: "env", Group: "group"}},
+	}
+
+	t.Run("only bucket configs are loaded", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		// the settings config can't be loaded, as its environment variable is not set
+		require.NoError(t, afero.WriteFile(fs, "project/configs.yaml", []byte(`
+configs:
+- id: bucket
+  config:
+    template: bucket.json
+  type:
+    bucket:
+      protected: true
+- id: tag
+  config:
+    template: tag.json
+    parameters:
+      name:
+        type: environment
+        name: UNDEFINED_ENV_VAR_OF_BUCKET_TEST
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+`), 0644))
+		require.NoError(t, afero.WriteFile(fs, "project/bucket.json", []byte(`{}`), 0644))
+
+		projects, err := delete.LoadBucketConfigs(t.Context(), fs, ".", m)
+		require.NoError(t, err)
+		assert.Equal(t, delete.BucketProtection{Configs: map[string]config.BucketType{"project_bucket": {Protected: true}}}, delete.NewBucketProtection(projects, "env", false))
+	})
+
+	t.Run("invalid bucket configs fail", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "project/configs.yaml", []byte(`
+configs:
+- id: bucket
+  config:
+    template: missing.json
+  type:
+    bucket: {}
+`), 0644))
+
+		_, err := delete.LoadBucketConfigs(t.Context(), fs, ".", m)
+		assert.ErrorContains(t, err, "failed to load the bucket configs")
+	})
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
 )
@@ -506,7 +507,7 @@ func TestDeleteBuckets(t *testing.T) {
 				},
 			},
 		}
-		errs := delete.Configs(t.Context(), client.ClientSet{BucketClient: c}, entriesToDelete)
+		errs := delete.Configs(delete.NewContextWithBucketProtection(t.Context(), delete.BucketProtection{AllowDestructive: true}), client.ClientSet{BucketClient: c}, entriesToDelete)
 		assert.Empty(t, errs, "errors should be empty")
 	})
 
@@ -532,7 +533,7 @@ func TestDeleteBuckets(t *testing.T) {
 				},
 			},
 		}
-		errs := delete.Configs(t.Context(), client.ClientSet{BucketClient: c}, entriesToDelete)
+		errs := delete.Configs(delete.NewContextWithBucketProtection(t.Context(), delete.BucketProtection{AllowDestructive: true}), client.ClientSet{BucketClient: c}, entriesToDelete)
 		assert.Empty(t, errs, "errors should be empty")
 	})
 
@@ -558,7 +559,7 @@ func TestDeleteBuckets(t *testing.T) {
 				},
 			},
 		}
-		err := delete.Configs(t.Context(), client.ClientSet{BucketClient: c}, entriesToDelete)
+		err := delete.Configs(delete.NewContextWithBucketProtection(t.Context(), delete.BucketProtection{AllowDestructive: true}), client.ClientSet{BucketClient: c}, entriesToDelete)
 		assert.Error(t, err, "there should be one delete error")
 	})
 
@@ -606,10 +607,72 @@ func TestDeleteBuckets(t *testing.T) {
 				},
 			},
 		}
-		errs := delete.Configs(t.Context(), client.ClientSet{BucketClient: c}, entriesToDelete)
+		errs := delete.Configs(delete.NewContextWithBucketProtection(t.Context(), delete.BucketProtection{AllowDestructive: true}), client.ClientSet{BucketClient: c}, entriesToDelete)
 		assert.Empty(t, errs, "errors should be empty")
 	})
 
+	t.Run("refuses to delete buckets without allowed destructive changes", func(t *testing.T) {
+		t.Setenv(featureflags.BucketSafety.EnvName(), "true")
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Fail(t, "unexpected HTTP call")
+		}))
+		defer server.Close()
+
+		u, _ := url.Parse(server.URL)
+		c := buckets.NewClient(rest.NewClient(u, server.Client()))
+
+		entriesToDelete := delete.DeleteEntries{
+			"bucket": {
+				{
+					Type:       "bucket",
+					Project:    "project",
+					Identifier: "id1",
+				},
+			},
+		}
+
+		err := delete.Configs(t.Context(), client.ClientSet{BucketClient: c}, entriesToDelete)
+		assert.Error(t, err, "deleting without protection should fail")
+
+		protection := delete.BucketProtection{Configs: map[string]config.BucketType{"project_id1": {}}}
+		err = delete.Configs(delete.NewContextWithBucketProtection(t.Context(), protection), client.ClientSet{BucketClient: c}, entriesToDelete)
+		assert.Error(t, err, "deleting a bucket whose config does not allow destructive changes should fail")
+
+		protection = delete.BucketProtection{AllowDestructive: true, Configs: map[string]config.BucketType{"project_id1": {AllowDestructive: true, Protected: true}}}
+		err = delete.Configs(delete.NewContextWithBucketProtection(t.Context(), protection), client.ClientSet{BucketClient: c}, entriesToDelete)
+		assert.Error(t, err, "deleting a protected bucket should fail")
+	})
+
+	t.Run("deletes buckets whose config allows destructive changes", func(t *testing.T) {
+		t.Setenv(featureflags.BucketSafety.EnvName(), "true")
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodDelete && strings.HasSuffix(req.URL.Path, "/project_id1") {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			assert.Fail(t, "unexpected HTTP call")
+		}))
+		defer server.Close()
+
+		u, _ := url.Parse(server.URL)
+		c := buckets.NewClient(rest.NewClient(u, server.Client()))
+
+		entriesToDelete := delete.DeleteEntries{
+			"bucket": {
+				{
+					Type:       "bucket",
+					Project:    "project",
+					Identifier: "id1",
+				},
+			},
+		}
+
+		protection := delete.BucketProtection{Configs: map[string]config.BucketType{"project_id1": {AllowDestructive: true}}}
+		err := delete.Configs(delete.NewContextWithBucketProtection(t.Context(), protection), client.ClientSet{BucketClient: c}, entriesToDelete)
+		assert.NoError(t, err)
+	})
 }
 
 func TestSplitConfigsForDeletion(t *testing.T) {
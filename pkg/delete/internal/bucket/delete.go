@@ -25,9 +25,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/buckettools"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
 )
 
@@ -36,6 +38,47 @@ type client interface {
 	List(ctx context.Context) (buckets.ListResponse, error)
 }
 
+// Protection decides which buckets may be deleted. Buckets of protected configs are never deleted. All other buckets
+// are only deleted if destructive changes are allowed, either for all buckets or by the config of the bucket.
+type Protection struct {
+	// AllowDestructive allows deleting all buckets that are not protected
+	AllowDestructive bool
+	// Configs holds the types of all known bucket configs, keyed by bucket name
+	Configs map[string]config.BucketType
+}
+
+// refusal returns the reason why the bucket must not be deleted, or an empty string if it may be deleted.
+func (p Protection) refusal(bucketName string) string {
+	t, found := p.Configs[bucketName]
+	switch {
+	case t.Protected:
+		return "its config is protected"
+	case p.AllowDestructive || t.AllowDestructive:
+		return ""
+	case found:
+		return "its config does not set 'allowDestructive: true'"
+	default:
+		return "it is not part of any loaded config allowing destructive changes"
+	}
+}
+
+type ctxKeyProtection struct{}
+
+// NewContextWithProtection returns a context in which buckets are only deleted if allowed by the given Protection.
+// Without Protection, no buckets are deleted.
+func NewContextWithProtection(ctx context.Context, p Protection) context.Context {
+	return context.WithValue(ctx, ctxKeyProtection{}, p)
+}
+
+// deletionRefusal returns the reason why the bucket must not be deleted, or an empty string if it may be deleted.
+func deletionRefusal(ctx context.Context, bucketName string) string {
+	if !featureflags.BucketSafety.Enabled() {
+		return ""
+	}
+	p, _ := ctx.Value(ctxKeyProtection{}).(Protection)
+	return p.refusal(bucketName)
+}
+
 func Delete(ctx context.Context, c client, entries []pointer.DeletePointer) error {
 	logger := log.WithCtxFields(ctx).WithFields(field.Type("bucket"))
 	logger.Info(`Deleting %d config(s) of type "bucket"...`, len(entries))
@@ -50,6 +93,12 @@ func Delete(ctx context.Context, c client, entries []pointer.DeletePointer) erro
 			bucketName = idutils.GenerateBucketName(e.AsCoordinate())
 		}
 
+		if reason := deletionRefusal(ctx, bucketName); reason != "" {
+			logger.Error("Refused to delete Grail Bucket %q as %s. Use '--allow-destructive-changes' to delete buckets that are not protected.", bucketName, reason)
+			deleteErrs++
+			continue
+		}
+
 		logger.Debug("Deleting bucket: %s.", e, bucketName)
 		_, err := c.Delete(ctx, bucketName)
 		if err != nil {
@@ -109,6 +158,11 @@ func DeleteAll(ctx context.Context, c client) error {
 			continue
 		}
 
+		if reason := deletionRefusal(ctx, bucketName.BucketName); reason != "" {
+			logger.Warn("Skipped deletion of bucket %q as %s.", bucketName.BucketName, reason)
+			continue
+		}
+
 		_, err := c.Delete(ctx, bucketName.BucketName)
 		if err != nil {
 			var apiErr api.APIError
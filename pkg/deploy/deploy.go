@@ -41,6 +41,8 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/dryrun"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extension"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/openpipeline"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/segment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
@@ -59,6 +61,9 @@ type DeployConfigsOptions struct {
 	// DryRun states that the deployment shall just run in dry-run mode, meaning
 	// that actual deployment of the configuration to a tenant will be skipped
 	DryRun bool
+	// AllowDestructiveChanges states that changes deleting data of Grail buckets, like decreasing their retention, are
+	// deployed even if the configs of the buckets do not allow them
+	AllowDestructiveChanges bool
 }
 
 var (
@@ -87,6 +92,13 @@ func DeployForAllEnvironments(ctx context.Context, projects []project.Project, e
 
 	reporter := report.GetReporterFromContextOrDiscard(ctx)
 
+	if opts.AllowDestructiveChanges {
+		ctx = bucket.NewContextAllowingDestructiveChanges(ctx)
+	}
+	if opts.DryRun {
+		ctx = dryrun.NewContext(ctx)
+	}
+
 	envNames := environmentClients.Names()
 	g := graph.New(projects, envNames)
 	envConfigs, err := getSortedEnvConfigs(g, envNames)
@@ -133,7 +145,56 @@ func Deploy(ctx context.Context, clientSet *client.ClientSet, projects []project
 	defer clearCaches(clientSet)
 	log.WithCtxFields(ctx).Info("Deploying configurations to environment %q...", environment)
 
-	return deployComponents(ctx, sortedConfigs, clientSet)
+	clientSet = withSettingsBatching(clientSet)
+	if !featureflags.OpenPipelinePartials.Enabled() {
+		return deployComponents(ctx, sortedConfigs, clientSet, nil)
+	}
+
+	merger := openpipeline.NewMerger()
+	errCount := 0
+	var deploymentErrs deployErrors.DeploymentErrors
+	if errors.As(deployComponents(ctx, sortedConfigs, clientSet, merger), &deploymentErrs) {
+		errCount += deploymentErrs.ErrorCount
+	}
+
+	errCount += deployMergedOpenPipelines(ctx, clientSet.OpenPipelineClient, merger)
+	if errCount > 0 {
+		return deployErrors.DeploymentErrors{ErrorCount: errCount}
+	}
+	return nil
+}
+
+// deployMergedOpenPipelines deploys the merged openpipeline configs collected during the deployment of all other
+// configs, reports the result for every merged config and returns the number of failed configs.
+func deployMergedOpenPipelines(ctx context.Context, client openpipeline.MergingClient, merger *openpipeline.Merger) int {
+	errCount := 0
+	for _, r := range merger.Deploy(ctx, client) {
+		ctx := context.WithValue(ctx, log.CtxKeyCoord{}, r.Config.Coordinate)
+		if r.Err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(r.Err)).Error("Deployment failed - Monaco Error: %v", r.Err)
+			report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(r.Config.Coordinate, report.StateError, nil, r.Err)
+			errCount++
+			continue
+		}
+
+		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(r.Config.Coordinate, report.StateSuccess, nil, nil)
+		log.WithCtxFields(ctx).WithFields(field.StatusDeployed()).Info("Deployment successful")
+	}
+	return errCount
+}
+
+// withSettingsBatching returns a copy of the client set whose settings client sends Settings 2.0 objects of the same
+// schema which are deployed at the same time in batches. Batching is opt-in: it is only enabled if the batch size is
+// set to at least 2 via MONACO_SETTINGS_BATCH_SIZE.
+func withSettingsBatching(clientSet *client.ClientSet) *client.ClientSet {
+	batchSize := environment.GetEnvValueIntLog(environment.SettingsBatchSizeEnvKey)
+	if batchSize < 2 {
+		return clientSet
+	}
+
+	batching := *clientSet
+	batching.SettingsClient = setting.NewBatchingClient(clientSet.SettingsClient, batchSize)
+	return &batching
 }
 
 // getSortedEnvConfigs sorts the config graphs and checks for certain errors like cyclic dependencies
@@ -149,7 +210,9 @@ func getSortedEnvConfigs(g graph.ConfigGraphPerEnvironment, envNames []string) (
 	return envConfigs, nil
 }
 
-func deployComponents(ctx context.Context, components []graph.SortedComponent, clientset *client.ClientSet) error {
+// deployComponents deploys all components in parallel. If an openpipeline merger is given, openpipeline configs are
+// only added to it and need to be deployed by it afterward.
+func deployComponents(ctx context.Context, components []graph.SortedComponent, clientset *client.ClientSet, openPipelineMerger *openpipeline.Merger) error {
 	log.WithCtxFields(ctx).Info("Deploying %d independent configuration sets in parallel...", len(components))
 	errCount := 0
 	errChan := make(chan error, len(components))
@@ -157,7 +220,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, c
 	// Iterate over components and launch a goroutine for each component deployment.
 	for i := range components {
 		go func(ctx context.Context, component graph.SortedComponent) {
-			errChan <- deployGraph(ctx, component.Graph, clientset)
+			errChan <- deployGraph(ctx, component.Graph, clientset, openPipelineMerger)
 		}(context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i)), components[i])
 	}
 
@@ -180,7 +243,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, c
 	return nil
 }
 
-func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clientset *client.ClientSet) error {
+func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clientset *client.ClientSet, openPipelineMerger *openpipeline.Merger) error {
 	g := simple.NewDirectedGraph()
 	gonum.Copy(g, configGraph)
 	resolvedEntities := entities.New()
@@ -195,7 +258,7 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients
 			time.Sleep(api.NewAPIs()[node.Config.Coordinate.Type].DeployWaitDuration)
 
 			go func(ctx context.Context, node graph.ConfigNode) {
-				errChan <- deployNode(ctx, node, configGraph, clientset, resolvedEntities)
+				errChan <- deployNode(ctx, node, configGraph, clientset, openPipelineMerger, resolvedEntities)
 			}(context.WithValue(ctx, log.CtxKeyCoord{}, node.Config.Coordinate), node)
 		}
 
@@ -221,9 +284,9 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients
 	return nil
 }
 
-func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.ConfigGraph, clientset *client.ClientSet, resolvedEntities *entities.EntityMap) error {
+func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.ConfigGraph, clientset *client.ClientSet, openPipelineMerger *openpipeline.Merger, resolvedEntities *entities.EntityMap) error {
 	ctx = report.NewContextWithDetailer(ctx, report.NewDefaultDetailer())
-	resolvedEntity, err := deployConfig(ctx, n.Config, clientset, resolvedEntities)
+	resolvedEntity, err := deployConfig(ctx, n.Config, clientset, openPipelineMerger, resolvedEntities)
 	details := report.GetDetailerFromContextOrDiscard(ctx).GetAll()
 
 	if err != nil {
@@ -242,6 +305,12 @@ func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.Confi
 	}
 
 	resolvedEntities.Put(resolvedEntity)
+	if _, ok := n.Config.Type.(config.OpenPipelineType); ok && openPipelineMerger != nil {
+		// the result is only known and reported after the merged configuration of the kind is deployed
+		log.WithCtxFields(ctx).Debug("Deployment deferred until all openpipeline configs are merged")
+		return nil
+	}
+
 	report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateSuccess, details, nil)
 	log.WithCtxFields(ctx).WithFields(field.StatusDeployed()).Info("Deployment successful")
 	return nil
@@ -291,7 +360,7 @@ func (e ErrUnknownConfigType) Error() string {
 	return fmt.Sprintf("unknown config type (ID: %q)", e.configType)
 }
 
-func deployConfig(ctx context.Context, c *config.Config, clientset *client.ClientSet, resolvedEntities config.EntityLookup) (entities.ResolvedEntity, error) {
+func deployConfig(ctx context.Context, c *config.Config, clientset *client.ClientSet, openPipelineMerger *openpipeline.Merger, resolvedEntities config.EntityLookup) (entities.ResolvedEntity, error) {
 	if concurrentDeploymentsLimiter != nil {
 		concurrentDeploymentsLimiter.Acquire()
 		defer concurrentDeploymentsLimiter.Release()
@@ -320,7 +389,7 @@ func deployConfig(ctx context.Context, c *config.Config, clientset *client.Clien
 	log.WithCtxFields(ctx).WithFields(field.StatusDeploying()).Info("Deploying config")
 	var resolvedEntity entities.ResolvedEntity
 	var deployErr error
-	switch c.Type.(type) {
+	switch t := c.Type.(type) {
 	case config.SettingsType:
 		resolvedEntity, deployErr = setting.Deploy(ctx, clientset.SettingsClient, properties, renderedConfig, c)
 
@@ -335,6 +404,12 @@ func deployConfig(ctx context.Context, c *config.Config, clientset *client.Clien
 
 	case config.DocumentType:
 		resolvedEntity, deployErr = document.Deploy(ctx, clientset.DocumentClient, properties, renderedConfig, c)
+		if deployErr != nil || t.Sharing == nil || !featureflags.DocumentSharing.Enabled() {
+			break
+		}
+
+		documentID, _ := resolvedEntity.Properties[config.IdParameter].(string)
+		deployErr = document.DeploySharing(ctx, clientset.DocumentClient, clientset.DocumentSharingClient, clientset.AccountGroupsClient, documentID, *t.Sharing, c)
 
 	case config.OpenPipelineType:
 		if !featureflags.OpenPipeline.Enabled() {
@@ -342,6 +417,11 @@ func deployConfig(ctx context.Context, c *config.Config, clientset *client.Clien
 			break
 		}
 
+		if openPipelineMerger != nil {
+			resolvedEntity, deployErr = openPipelineMerger.Add(c, properties, renderedConfig)
+			break
+		}
+
 		resolvedEntity, deployErr = openpipeline.Deploy(ctx, clientset.OpenPipelineClient, properties, renderedConfig, c)
 
 	case config.Segment:
@@ -360,6 +440,22 @@ func deployConfig(ctx context.Context, c *config.Config, clientset *client.Clien
 
 		resolvedEntity, deployErr = slo.Deploy(ctx, clientset.ServiceLevelObjectiveClient, properties, renderedConfig, c)
 
+	case config.ExtensionV2Type:
+		if !featureflags.ExtensionsV2.Enabled() {
+			deployErr = ErrUnknownConfigType{configType: c.Type.ID()}
+			break
+		}
+
+		resolvedEntity, deployErr = extension.Deploy(ctx, clientset.ExtensionsClient, properties, renderedConfig, c)
+
+	case config.ExtensionV2MonitoringConfigurationType:
+		if !featureflags.ExtensionsV2.Enabled() {
+			deployErr = ErrUnknownConfigType{configType: c.Type.ID()}
+			break
+		}
+
+		resolvedEntity, deployErr = extension.DeployMonitoringConfiguration(ctx, clientset.ExtensionsClient, properties, renderedConfig, c)
+
 	default:
 		deployErr = ErrUnknownConfigType{configType: c.Type.ID()}
 	}
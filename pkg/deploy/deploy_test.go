@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -41,6 +42,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
@@ -1417,6 +1419,10 @@ type StubOpenPipelineClient struct {
 	UpdateStub func() (openpipeline.Response, error)
 }
 
+func (c *StubOpenPipelineClient) Get(ctx context.Context, id string) (openpipeline.Response, error) {
+	return coreapi.Response{}, nil
+}
+
 func (c *StubOpenPipelineClient) GetAll(ctx context.Context) ([]openpipeline.Response, error) {
 	return []coreapi.Response{}, nil
 }
@@ -1425,6 +1431,88 @@ func (c *StubOpenPipelineClient) Update(ctx context.Context, id string, data []b
 	return c.UpdateStub()
 }
 
+type mergingOpenPipelineClient struct {
+	StubOpenPipelineClient
+	deployed string
+	updated  map[string]string
+}
+
+func (c *mergingOpenPipelineClient) Get(_ context.Context, id string) (openpipeline.Response, error) {
+	return coreapi.Response{Data: []byte(c.deployed)}, nil
+}
+
+func (c *mergingOpenPipelineClient) Update(_ context.Context, id string, data []byte) (openpipeline.Response, error) {
+	c.updated[id] = string(data)
+	return coreapi.Response{}, nil
+}
+
+func TestDeployMergesPartialOpenPipelineConfigs(t *testing.T) {
+	t.Setenv(featureflags.OpenPipelinePartials.EnvName(), "true")
+
+	partialConfig := func(project, configID, payload string) config.Config {
+		return config.Config{
+			Type:        config.OpenPipelineType{Kind: "logs", Partial: &config.OpenPipelinePartial{Type: config.OpenPipelineRoutingEntryPartial}},
+			Environment: "env",
+			Coordinate:  coordinate.Coordinate{Project: project, Type: "openpipeline", ConfigId: configID},
+			Template:    template.NewInMemoryTemplate(configID, payload),
+			Parameters:  config.Parameters{},
+		}
+	}
+
+	projectWith := func(c config.Config) project.Project {
+		return project.Project{
+			Id:      c.Coordinate.Project,
+			Configs: project.ConfigsPerTypePerEnvironments{"env": project.ConfigsPerType{"openpipeline": {c}}},
+		}
+	}
+
+	t.Run("partial configs of multiple projects are merged into the deployed configuration", func(t *testing.T) {
+		opClient := &mergingOpenPipelineClient{deployed: `{"id":"logs","routing":{"entries":[{"note":"existing"}]}}`, updated: map[string]string{}}
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{OpenPipelineClient: opClient},
+		}
+
+		projects := []project.Project{
+			projectWith(partialConfig("team-b", "entry", `{"note":"b"}`)),
+			projectWith(partialConfig("team-a", "entry", `{"note":"a"}`)),
+		}
+
+		err := deploy.DeployForAllEnvironments(t.Context(), projects, clients, deploy.DeployConfigsOptions{})
+		require.NoError(t, err)
+		require.Len(t, opClient.updated, 1)
+		assert.JSONEq(t, `{"id":"logs","routing":{"entries":[{"note":"existing"},{"note":"a"},{"note":"b"}]}}`, opClient.updated["logs"])
+	})
+
+	t.Run("partial configs defining the same routing entry fail", func(t *testing.T) {
+		opClient := &mergingOpenPipelineClient{deployed: `{"id":"logs"}`, updated: map[string]string{}}
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{OpenPipelineClient: opClient},
+		}
+
+		projects := []project.Project{
+			projectWith(partialConfig("team-a", "entry", `{"note":"same"}`)),
+			projectWith(partialConfig("team-b", "entry", `{"note":"same"}`)),
+		}
+
+		err := deploy.DeployForAllEnvironments(t.Context(), projects, clients, deploy.DeployConfigsOptions{})
+		var envErrs errors.EnvironmentDeploymentErrors
+		require.ErrorAs(t, err, &envErrs)
+		assert.Equal(t, []error{errors.DeploymentErrors{ErrorCount: 2}}, envErrs["env"])
+		assert.Empty(t, opClient.updated)
+	})
+
+	t.Run("processors of pipelines that are only deployed can be merged in dry-runs", func(t *testing.T) {
+		processor := partialConfig("team-a", "processor", `{"id":"proc"}`)
+		processor.Type = config.OpenPipelineType{Kind: "logs", Partial: &config.OpenPipelinePartial{Type: config.OpenPipelineProcessorPartial, Pipeline: "remote", Stage: "processing"}}
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.DummyClientSet,
+		}
+
+		err := deploy.DeployForAllEnvironments(t.Context(), []project.Project{projectWith(processor)}, clients, deploy.DeployConfigsOptions{DryRun: true})
+		assert.NoError(t, err)
+	})
+}
+
 func TestLogResponseErrors(t *testing.T) {
 	projects := []project.Project{
 		{
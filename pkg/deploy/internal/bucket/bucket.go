@@ -18,25 +18,47 @@ package bucket
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/dryrun"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
 type Client interface {
+	Get(ctx context.Context, bucketName string) (buckets.Response, error)
 	Upsert(ctx context.Context, bucketName string, data []byte) (buckets.Response, error)
 }
 
+type ctxKeyDestructiveChangesAllowed struct{}
+
+// NewContextAllowingDestructiveChanges returns a context in which destructive changes are deployed to all buckets,
+// regardless of whether their configs allow them.
+func NewContextAllowingDestructiveChanges(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyDestructiveChangesAllowed{}, true)
+}
+
+func destructiveChangesAllowed(ctx context.Context, c *config.Config) bool {
+	if t, ok := c.Type.(config.BucketType); ok && t.AllowDestructive {
+		return true
+	}
+	allowed, _ := ctx.Value(ctxKeyDestructiveChangesAllowed{}).(bool)
+	return allowed
+}
+
 func Deploy(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
 	var bucketName string
 
@@ -48,6 +70,13 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 
 	// create new context to carry logger
 	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
+
+	if featureflags.BucketSafety.Enabled() {
+		if err := checkDestructiveChanges(ctx, client, bucketName, renderedConfig, c); err != nil {
+			return entities.ResolvedEntity{}, err
+		}
+	}
+
 	_, err := client.Upsert(ctx, bucketName, []byte(renderedConfig))
 	if err != nil {
 		var apiErr api.APIError
@@ -66,3 +95,69 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		Properties: properties,
 	}, nil
 }
+
+// checkDestructiveChanges compares the bucket to the deployed bucket, and returns an error if the changes would delete
+// data but are not allowed. Allowed destructive changes are reported as warnings.
+// Dry-runs only fetch the deployed bucket if credentials for the environment are available. If it can't be fetched,
+// it is reported as a warning that the changes could not be checked.
+func checkDestructiveChanges(ctx context.Context, client Client, bucketName string, renderedConfig string, c *config.Config) error {
+	resp, err := client.Get(ctx, bucketName)
+	if err != nil {
+		var apiErr api.APIError
+		if errors.As(err, &apiErr) && api.IsNotFoundError(apiErr) {
+			return nil
+		}
+		if dryrun.FromContext(ctx) {
+			if !destructiveChangesAllowed(ctx, c) {
+				msg := fmt.Sprintf("Bucket '%s' is not checked for destructive changes in the dry-run: %v", bucketName, err)
+				log.WithCtxFields(ctx).Warn("%s", msg)
+				report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeWarn, Message: msg})
+			}
+			return nil
+		}
+		return deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to get bucket '%s' to check for destructive changes", bucketName)).WithError(err)
+	}
+
+	changes, err := destructiveChanges(resp.Data, []byte(renderedConfig))
+	if err != nil {
+		return deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to check bucket '%s' for destructive changes", bucketName)).WithError(err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Destructive changes to bucket '%s': %s", bucketName, strings.Join(changes, ", "))
+	if destructiveChangesAllowed(ctx, c) {
+		log.WithCtxFields(ctx).Warn("%s", msg)
+		report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeWarn, Message: msg})
+		return nil
+	}
+
+	report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: msg})
+	return deployErrors.NewConfigDeployErr(c, msg+" - set 'allowDestructive: true' for the config or use '--allow-destructive-changes' to deploy them")
+}
+
+// destructiveChanges returns the changes from the deployed bucket to the given bucket which delete data.
+func destructiveChanges(deployed []byte, bucket []byte) ([]string, error) {
+	type bucketDefinition struct {
+		Table         string `json:"table"`
+		RetentionDays *int   `json:"retentionDays"`
+	}
+
+	var current, wanted bucketDefinition
+	if err := json.Unmarshal(deployed, &current); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal deployed bucket: %w", err)
+	}
+	if err := json.Unmarshal(bucket, &wanted); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bucket: %w", err)
+	}
+
+	var changes []string
+	if current.RetentionDays != nil && wanted.RetentionDays != nil && *wanted.RetentionDays < *current.RetentionDays {
+		changes = append(changes, fmt.Sprintf("retention decreased from %d to %d days", *current.RetentionDays, *wanted.RetentionDays))
+	}
+	if current.Table != "" && wanted.Table != "" && wanted.Table != current.Table {
+		changes = append(changes, fmt.Sprintf("table changed from '%s' to '%s'", current.Table, wanted.Table))
+	}
+	return changes, nil
+}
@@ -21,18 +21,23 @@ package bucket_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/dryrun"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
 type assertAndRespond func(t *testing.T, bucketName string, data []byte) (buckets.Response, error)
@@ -40,6 +45,20 @@ type assertAndRespond func(t *testing.T, bucketName string, data []byte) (bucket
 type testClient struct {
 	t                    *testing.T
 	assertAndRespondFunc assertAndRespond
+	// deployed is the data of the bucket returned by Get. If not set, the bucket does not exist.
+	deployed []byte
+	// getErr is returned by Get if set.
+	getErr error
+}
+
+func (c testClient) Get(_ context.Context, _ string) (buckets.Response, error) {
+	if c.getErr != nil {
+		return buckets.Response{}, c.getErr
+	}
+	if c.deployed == nil {
+		return buckets.Response{}, api.APIError{StatusCode: http.StatusNotFound}
+	}
+	return buckets.Response{StatusCode: http.StatusOK, Data: c.deployed}, nil
 }
 
 func (c testClient) Upsert(_ context.Context, bucketName string, data []byte) (buckets.Response, error) {
@@ -151,8 +170,8 @@ func TestDeploy(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			c := testClient{
-				t,
-				tt.assertAndRespond,
+				t:                    t,
+				assertAndRespondFunc: tt.assertAndRespond,
 			}
 
 			props, errs := tt.givenConfig.ResolveParameterValues(entities.New())
@@ -170,3 +189,149 @@ func TestDeploy(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploy_DestructiveChanges(t *testing.T) {
+	t.Setenv(featureflags.BucketSafety.EnvName(), "true")
+
+	deployed := []byte(`{"bucketName": "proj_my-bucket", "table": "logs", "retentionDays": 35}`)
+
+	tests := []struct {
+		name          string
+		givenTemplate string
+		givenType     config.BucketType
+		givenCtx      func(ctx context.Context) context.Context
+		wantUpsert    bool
+	}{
+		{
+			name:          "increasing the retention is deployed",
+			givenTemplate: `{"table": "logs", "retentionDays": 60}`,
+			wantUpsert:    true,
+		},
+		{
+			name:          "decreasing the retention is refused",
+			givenTemplate: `{"table": "logs", "retentionDays": 10}`,
+		},
+		{
+			name:          "changing the table is refused",
+			givenTemplate: `{"table": "events", "retentionDays": 35}`,
+		},
+		{
+			name:          "decreasing the retention is deployed if allowed by the config",
+			givenTemplate: `{"table": "logs", "retentionDays": 10}`,
+			givenType:     config.BucketType{AllowDestructive: true},
+			wantUpsert:    true,
+		},
+		{
+			name:          "decreasing the retention is deployed if allowed by the context",
+			givenTemplate: `{"table": "logs", "retentionDays": 10}`,
+			givenCtx:      bucket.NewContextAllowingDestructiveChanges,
+			wantUpsert:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upserted := false
+			c := testClient{
+				t: t,
+				assertAndRespondFunc: func(t *testing.T, bucketName string, data []byte) (buckets.Response, error) {
+					upserted = true
+					return buckets.Response{StatusCode: http.StatusOK, Data: data}, nil
+				},
+				deployed: deployed,
+			}
+
+			ctx := t.Context()
+			if tt.givenCtx != nil {
+				ctx = tt.givenCtx(ctx)
+			}
+
+			givenConfig := config.Config{
+				Template:   template.NewInMemoryTemplate("path/file.json", tt.givenTemplate),
+				Coordinate: coordinate.Coordinate{Project: "proj", Type: "bucket", ConfigId: "my-bucket"},
+				Type:       tt.givenType,
+				Parameters: config.Parameters{},
+			}
+
+			_, err := bucket.Deploy(ctx, c, parameter.Properties{}, tt.givenTemplate, &givenConfig)
+			if tt.wantUpsert {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "Destructive changes to bucket 'proj_my-bucket'")
+			}
+			assert.Equal(t, tt.wantUpsert, upserted)
+		})
+	}
+
+	t.Run("destructive changes are refused in dry-runs if the deployed bucket is fetched", func(t *testing.T) {
+		c := testClient{
+			t: t,
+			assertAndRespondFunc: func(t *testing.T, bucketName string, data []byte) (buckets.Response, error) {
+				return buckets.Response{StatusCode: http.StatusOK, Data: data}, nil
+			},
+			deployed: deployed,
+		}
+
+		detailer := report.NewDefaultDetailer()
+		ctx := report.NewContextWithDetailer(dryrun.NewContext(t.Context()), detailer)
+		givenConfig := config.Config{
+			Template:   template.NewInMemoryTemplate("path/file.json", "{}"),
+			Coordinate: coordinate.Coordinate{Project: "proj", Type: "bucket", ConfigId: "my-bucket"},
+			Type:       config.BucketType{},
+			Parameters: config.Parameters{},
+		}
+
+		_, err := bucket.Deploy(ctx, c, parameter.Properties{}, `{"table": "logs", "retentionDays": 10}`, &givenConfig)
+		require.ErrorContains(t, err, "Destructive changes to bucket 'proj_my-bucket'")
+		assert.Equal(t, []report.Detail{{
+			Type:    report.DetailTypeError,
+			Message: "Destructive changes to bucket 'proj_my-bucket': retention decreased from 35 to 10 days",
+		}}, detailer.GetAll())
+	})
+
+	t.Run("buckets that are not fetched in dry-runs are reported as unchecked", func(t *testing.T) {
+		c := testClient{
+			t: t,
+			assertAndRespondFunc: func(t *testing.T, bucketName string, data []byte) (buckets.Response, error) {
+				return buckets.Response{StatusCode: http.StatusOK, Data: data}, nil
+			},
+			getErr: errors.New("the deployed bucket is not fetched"),
+		}
+
+		detailer := report.NewDefaultDetailer()
+		ctx := report.NewContextWithDetailer(dryrun.NewContext(t.Context()), detailer)
+		givenConfig := config.Config{
+			Template:   template.NewInMemoryTemplate("path/file.json", "{}"),
+			Coordinate: coordinate.Coordinate{Project: "proj", Type: "bucket", ConfigId: "my-bucket"},
+			Type:       config.BucketType{},
+			Parameters: config.Parameters{},
+		}
+
+		_, err := bucket.Deploy(ctx, c, parameter.Properties{}, `{"table": "logs", "retentionDays": 10}`, &givenConfig)
+		require.NoError(t, err)
+		assert.Equal(t, []report.Detail{{
+			Type:    report.DetailTypeWarn,
+			Message: "Bucket 'proj_my-bucket' is not checked for destructive changes in the dry-run: the deployed bucket is not fetched",
+		}}, detailer.GetAll())
+	})
+
+	t.Run("new buckets are deployed", func(t *testing.T) {
+		upserted := false
+		c := testClient{
+			t: t,
+			assertAndRespondFunc: func(t *testing.T, bucketName string, data []byte) (buckets.Response, error) {
+				upserted = true
+				return buckets.Response{StatusCode: http.StatusOK, Data: data}, nil
+			},
+		}
+
+		givenConfig := config.Config{
+			Template:   template.NewInMemoryTemplate("path/file.json", "{}"),
+			Coordinate: coordinate.Coordinate{Project: "proj", Type: "bucket", ConfigId: "my-bucket"},
+			Type:       config.BucketType{},
+		}
+
+		_, err := bucket.Deploy(t.Context(), c, parameter.Properties{}, `{"table": "logs", "retentionDays": 10}`, &givenConfig)
+		require.NoError(t, err)
+		assert.True(t, upserted)
+	})
+}
@@ -146,9 +146,10 @@ func getDocumentAttributesFromConfigType(t config.Type) (doctype string, private
 		return "", false, fmt.Errorf("expected document config type but found %v", t)
 	}
 
+	// kinds without a mapping are types of app-specific documents, which are deployed with the type as is
 	kind, f := documentMapping[documentType.Kind]
 	if !f {
-		return "", false, nil
+		kind = string(documentType.Kind)
 	}
 
 	return kind, documentType.Private, nil
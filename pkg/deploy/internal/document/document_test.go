@@ -217,6 +217,22 @@ func TestDeploy_ConfigWithoutOriginObjectId(t *testing.T) {
 	})
 }
 
+func TestGetDocumentAttributesFromConfigType(t *testing.T) {
+	t.Run("known kinds are mapped to their document type", func(t *testing.T) {
+		docType, private, err := getDocumentAttributesFromConfigType(config.DocumentType{Kind: config.NotebookKind, Private: true})
+		require.NoError(t, err)
+		assert.Equal(t, documents.Notebook, docType)
+		assert.True(t, private)
+	})
+
+	t.Run("other kinds are used as document type", func(t *testing.T) {
+		docType, private, err := getDocumentAttributesFromConfigType(config.DocumentType{Kind: "my.app:custom", Private: true})
+		require.NoError(t, err)
+		assert.Equal(t, "my.app:custom", docType)
+		assert.True(t, private)
+	})
+}
+
 func runDeployTest(t *testing.T, client Client, c *config.Config) (entities.ResolvedEntity, error) {
 	parameters, errs := c.ResolveParameterValues(entities.New())
 	require.Empty(t, errs)
@@ -0,0 +1,197 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+)
+
+type SharingClient interface {
+	ListDirectShares(ctx context.Context, documentID string) ([]dtclient.DirectShare, error)
+	CreateDirectShare(ctx context.Context, documentID, access string, recipients []dtclient.Recipient) error
+	DeleteDirectShare(ctx context.Context, shareID string) error
+	ListRecipients(ctx context.Context, shareID string) ([]dtclient.Recipient, error)
+	AddRecipients(ctx context.Context, shareID string, recipients []dtclient.Recipient) error
+	RemoveRecipients(ctx context.Context, shareID string, recipientIDs []string) error
+	ListEnvironmentShares(ctx context.Context, documentID string) ([]dtclient.EnvironmentShare, error)
+	CreateEnvironmentShare(ctx context.Context, documentID, access string) error
+	DeleteEnvironmentShare(ctx context.Context, shareID string) error
+	TransferOwner(ctx context.Context, documentID string, version int, newOwnerID string) error
+}
+
+type GroupsClient interface {
+	GroupIDByName(ctx context.Context, name string) (string, error)
+}
+
+// DeploySharing makes the owner and the shares of the deployed document match the given sharing. Shares existing on
+// the document but not part of the sharing are removed.
+// The owner is changed last, as transferring the ownership may revoke the permission to manage the shares.
+func DeploySharing(ctx context.Context, documentClient Client, sharingClient SharingClient, groupsClient GroupsClient, documentID string, sharing config.DocumentSharing, c *config.Config) error {
+	recipients, err := recipientsByAccess(ctx, groupsClient, sharing)
+	if err != nil {
+		return deployErrors.NewConfigDeployErr(c, "failed to resolve recipients of document shares").WithError(err)
+	}
+
+	if err := deployEnvironmentShare(ctx, sharingClient, documentID, sharing.Environment); err != nil {
+		return deployErrors.NewConfigDeployErr(c, "failed to deploy environment share of document").WithError(err)
+	}
+
+	if err := deployDirectShares(ctx, sharingClient, documentID, recipients); err != nil {
+		return deployErrors.NewConfigDeployErr(c, "failed to deploy direct shares of document").WithError(err)
+	}
+
+	if sharing.Owner == "" {
+		return nil
+	}
+
+	current, err := documentClient.Get(ctx, documentID)
+	if err != nil {
+		return deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to get document '%s'", documentID)).WithError(err)
+	}
+	if current.Owner == sharing.Owner {
+		return nil
+	}
+
+	log.WithCtxFields(ctx).Debug("Transferring ownership of document %q from %q to %q", documentID, current.Owner, sharing.Owner)
+	if err := sharingClient.TransferOwner(ctx, documentID, current.Version, sharing.Owner); err != nil {
+		return deployErrors.NewConfigDeployErr(c, "failed to deploy owner of document").WithError(err)
+	}
+	return nil
+}
+
+// recipientsByAccess groups the users and groups the document is shared with by the access they are granted.
+// Groups referenced by name are resolved to their ID.
+func recipientsByAccess(ctx context.Context, groupsClient GroupsClient, sharing config.DocumentSharing) (map[config.DocumentAccess][]dtclient.Recipient, error) {
+	result := make(map[config.DocumentAccess][]dtclient.Recipient)
+
+	for _, u := range sharing.Users {
+		result[u.Access] = append(result[u.Access], dtclient.Recipient{ID: u.ID, Type: dtclient.RecipientTypeUser})
+	}
+
+	for _, g := range sharing.Groups {
+		id := g.ID
+		if id == "" {
+			if groupsClient == nil {
+				return nil, fmt.Errorf("group %q is referenced by name, but no accounts to look it up are defined in the manifest", g.Name)
+			}
+
+			var err error
+			if id, err = groupsClient.GroupIDByName(ctx, g.Name); err != nil {
+				return nil, err
+			}
+		}
+		result[g.Access] = append(result[g.Access], dtclient.Recipient{ID: id, Type: dtclient.RecipientTypeGroup})
+	}
+
+	return result, nil
+}
+
+func deployEnvironmentShare(ctx context.Context, client SharingClient, documentID string, access config.DocumentAccess) error {
+	existing, err := client.ListEnvironmentShares(ctx, documentID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, s := range existing {
+		if s.Access == access && !found {
+			found = true
+			continue
+		}
+
+		if err := client.DeleteEnvironmentShare(ctx, s.ID); err != nil {
+			return err
+		}
+	}
+
+	if found || access == "" {
+		return nil
+	}
+	return client.CreateEnvironmentShare(ctx, documentID, access)
+}
+
+func deployDirectShares(ctx context.Context, client SharingClient, documentID string, recipients map[config.DocumentAccess][]dtclient.Recipient) error {
+	existing, err := client.ListDirectShares(ctx, documentID)
+	if err != nil {
+		return err
+	}
+
+	deployed := make(map[config.DocumentAccess]bool)
+	for _, s := range existing {
+		wanted, ok := recipients[s.Access]
+		if !ok || deployed[s.Access] {
+			if err := client.DeleteDirectShare(ctx, s.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := updateRecipients(ctx, client, s.ID, wanted); err != nil {
+			return err
+		}
+		deployed[s.Access] = true
+	}
+
+	// shares are created in a fixed order to keep deployments reproducible
+	for _, access := range config.KnownDocumentAccess {
+		if wanted, ok := recipients[access]; ok && !deployed[access] {
+			if err := client.CreateDirectShare(ctx, documentID, access, wanted); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func updateRecipients(ctx context.Context, client SharingClient, shareID string, wanted []dtclient.Recipient) error {
+	current, err := client.ListRecipients(ctx, shareID)
+	if err != nil {
+		return err
+	}
+
+	var toAdd []dtclient.Recipient
+	for _, r := range wanted {
+		if !slices.ContainsFunc(current, func(c dtclient.Recipient) bool { return c.ID == r.ID }) {
+			toAdd = append(toAdd, r)
+		}
+	}
+
+	var toRemove []string
+	for _, r := range current {
+		if !slices.ContainsFunc(wanted, func(w dtclient.Recipient) bool { return w.ID == r.ID }) {
+			toRemove = append(toRemove, r.ID)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		if err := client.AddRecipients(ctx, shareID, toAdd); err != nil {
+			return err
+		}
+	}
+
+	if len(toRemove) > 0 {
+		return client.RemoveRecipients(ctx, shareID, toRemove)
+	}
+	return nil
+}
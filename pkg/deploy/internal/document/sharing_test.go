@@ -0,0 +1,224 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
+)
+
+// testSharingClient keeps the shares of a single document in memory
+type testSharingClient struct {
+	directShares      []dtclient.DirectShare
+	recipients        map[string][]dtclient.Recipient
+	environmentShares []dtclient.EnvironmentShare
+	owner             string
+	transferVersion   int
+	nextID            int
+}
+
+func (c *testSharingClient) id() string {
+	c.nextID++
+	return fmt.Sprintf("share-%d", c.nextID)
+}
+
+func (c *testSharingClient) ListDirectShares(_ context.Context, _ string) ([]dtclient.DirectShare, error) {
+	return slices.Clone(c.directShares), nil
+}
+
+func (c *testSharingClient) CreateDirectShare(_ context.Context, documentID, access string, recipients []dtclient.Recipient) error {
+	id := c.id()
+	c.directShares = append(c.directShares, dtclient.DirectShare{ID: id, DocumentID: documentID, Access: access})
+	c.recipients[id] = recipients
+	return nil
+}
+
+func (c *testSharingClient) DeleteDirectShare(_ context.Context, shareID string) error {
+	c.directShares = slices.DeleteFunc(c.directShares, func(s dtclient.DirectShare) bool { return s.ID == shareID })
+	delete(c.recipients, shareID)
+	return nil
+}
+
+func (c *testSharingClient) ListRecipients(_ context.Context, shareID string) ([]dtclient.Recipient, error) {
+	return slices.Clone(c.recipients[shareID]), nil
+}
+
+func (c *testSharingClient) AddRecipients(_ context.Context, shareID string, recipients []dtclient.Recipient) error {
+	c.recipients[shareID] = append(c.recipients[shareID], recipients...)
+	return nil
+}
+
+func (c *testSharingClient) RemoveRecipients(_ context.Context, shareID string, recipientIDs []string) error {
+	c.recipients[shareID] = slices.DeleteFunc(c.recipients[shareID], func(r dtclient.Recipient) bool { return slices.Contains(recipientIDs, r.ID) })
+	return nil
+}
+
+func (c *testSharingClient) ListEnvironmentShares(_ context.Context, _ string) ([]dtclient.EnvironmentShare, error) {
+	return slices.Clone(c.environmentShares), nil
+}
+
+func (c *testSharingClient) CreateEnvironmentShare(_ context.Context, documentID, access string) error {
+	c.environmentShares = append(c.environmentShares, dtclient.EnvironmentShare{ID: c.id(), DocumentID: documentID, Access: access})
+	return nil
+}
+
+func (c *testSharingClient) DeleteEnvironmentShare(_ context.Context, shareID string) error {
+	c.environmentShares = slices.DeleteFunc(c.environmentShares, func(s dtclient.EnvironmentShare) bool { return s.ID == shareID })
+	return nil
+}
+
+func (c *testSharingClient) TransferOwner(_ context.Context, _ string, version int, newOwnerID string) error {
+	c.owner = newOwnerID
+	c.transferVersion = version
+	return nil
+}
+
+// testDocumentClient only supports getting the metadata of a document
+type testDocumentClient struct {
+	owner   string
+	version int
+}
+
+func (c testDocumentClient) Get(_ context.Context, id string) (documents.Response, error) {
+	return documents.Response{Metadata: documents.Metadata{ID: id, Owner: c.owner, Version: c.version}}, nil
+}
+
+func (c testDocumentClient) List(_ context.Context, _ string) (documents.ListResponse, error) {
+	panic("unimplemented")
+}
+
+func (c testDocumentClient) Create(_ context.Context, _ string, _ bool, _ string, _ []byte, _ documents.DocumentType) (api.Response, error) {
+	panic("unimplemented")
+}
+
+func (c testDocumentClient) Update(_ context.Context, _ string, _ string, _ bool, _ []byte, _ documents.DocumentType) (api.Response, error) {
+	panic("unimplemented")
+}
+
+type testGroupsClient map[string]string
+
+func (c testGroupsClient) GroupIDByName(_ context.Context, name string) (string, error) {
+	if id, ok := c[name]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("no group named %q", name)
+}
+
+var sharedDocumentConfig = &config.Config{
+	Coordinate: coordinate.Coordinate{Project: "proj", Type: "document", ConfigId: "shared"},
+}
+
+func TestDeploySharing_CreatesShares(t *testing.T) {
+	sharingClient := &testSharingClient{recipients: map[string][]dtclient.Recipient{}}
+	sharing := config.DocumentSharing{
+		Environment: config.DocumentReadAccess,
+		Users:       []config.DocumentShare{{ID: "user-1", Access: config.DocumentReadAccess}},
+		Groups: []config.DocumentShare{
+			{ID: "group-1", Access: config.DocumentReadWriteAccess},
+			{Name: "Admins", Access: config.DocumentReadAccess},
+		},
+	}
+
+	err := document.DeploySharing(t.Context(), testDocumentClient{}, sharingClient, testGroupsClient{"Admins": "group-admins"}, "doc", sharing, sharedDocumentConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []dtclient.EnvironmentShare{{ID: "share-1", DocumentID: "doc", Access: "read"}}, sharingClient.environmentShares)
+	assert.Equal(t, []dtclient.DirectShare{
+		{ID: "share-2", DocumentID: "doc", Access: "read"},
+		{ID: "share-3", DocumentID: "doc", Access: "read-write"},
+	}, sharingClient.directShares)
+	assert.Equal(t, map[string][]dtclient.Recipient{
+		"share-2": {{ID: "user-1", Type: "user"}, {ID: "group-admins", Type: "group"}},
+		"share-3": {{ID: "group-1", Type: "group"}},
+	}, sharingClient.recipients)
+	assert.Empty(t, sharingClient.owner, "owner must not be changed if not defined")
+}
+
+func TestDeploySharing_UpdatesExistingShares(t *testing.T) {
+	sharingClient := &testSharingClient{
+		nextID: 10,
+		directShares: []dtclient.DirectShare{
+			{ID: "read", DocumentID: "doc", Access: "read"},
+			{ID: "read-write", DocumentID: "doc", Access: "read-write"},
+		},
+		recipients: map[string][]dtclient.Recipient{
+			"read":       {{ID: "user-1", Type: "user"}, {ID: "user-2", Type: "user"}},
+			"read-write": {{ID: "user-3", Type: "user"}},
+		},
+		environmentShares: []dtclient.EnvironmentShare{{ID: "env", DocumentID: "doc", Access: "read-write"}},
+	}
+	sharing := config.DocumentSharing{
+		Users: []config.DocumentShare{
+			{ID: "user-1", Access: config.DocumentReadAccess},
+			{ID: "user-4", Access: config.DocumentReadAccess},
+		},
+	}
+
+	err := document.DeploySharing(t.Context(), testDocumentClient{}, sharingClient, nil, "doc", sharing, sharedDocumentConfig)
+	require.NoError(t, err)
+
+	assert.Empty(t, sharingClient.environmentShares)
+	assert.Equal(t, []dtclient.DirectShare{{ID: "read", DocumentID: "doc", Access: "read"}}, sharingClient.directShares)
+	assert.Equal(t, map[string][]dtclient.Recipient{
+		"read": {{ID: "user-1", Type: "user"}, {ID: "user-4", Type: "user"}},
+	}, sharingClient.recipients)
+}
+
+func TestDeploySharing_TransfersOwner(t *testing.T) {
+	t.Run("owner is transferred", func(t *testing.T) {
+		sharingClient := &testSharingClient{recipients: map[string][]dtclient.Recipient{}}
+
+		err := document.DeploySharing(t.Context(), testDocumentClient{owner: "user-1", version: 4}, sharingClient, nil, "doc", config.DocumentSharing{Owner: "user-2"}, sharedDocumentConfig)
+		require.NoError(t, err)
+		assert.Equal(t, "user-2", sharingClient.owner)
+		assert.Equal(t, 4, sharingClient.transferVersion)
+	})
+
+	t.Run("owner is not transferred if unchanged", func(t *testing.T) {
+		sharingClient := &testSharingClient{recipients: map[string][]dtclient.Recipient{}}
+
+		err := document.DeploySharing(t.Context(), testDocumentClient{owner: "user-2"}, sharingClient, nil, "doc", config.DocumentSharing{Owner: "user-2"}, sharedDocumentConfig)
+		require.NoError(t, err)
+		assert.Empty(t, sharingClient.owner)
+	})
+}
+
+func TestDeploySharing_GroupNamesRequireAccounts(t *testing.T) {
+	sharingClient := &testSharingClient{recipients: map[string][]dtclient.Recipient{}}
+	sharing := config.DocumentSharing{Groups: []config.DocumentShare{{Name: "Admins", Access: config.DocumentReadAccess}}}
+
+	err := document.DeploySharing(t.Context(), testDocumentClient{}, sharingClient, nil, "doc", sharing, sharedDocumentConfig)
+	assert.ErrorContains(t, err, `group "Admins" is referenced by name, but no accounts`)
+
+	err = document.DeploySharing(t.Context(), testDocumentClient{}, sharingClient, testGroupsClient{}, "doc", sharing, sharedDocumentConfig)
+	assert.ErrorContains(t, err, `no group named "Admins"`)
+	assert.Empty(t, sharingClient.directShares, "no shares must be changed if recipients can't be resolved")
+}
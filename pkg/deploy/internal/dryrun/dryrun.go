@@ -0,0 +1,34 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dryrun marks the context of deployments that only run in dry-run mode, in which the clients don't send any
+// requests and the deployed configurations are unknown.
+package dryrun
+
+import "context"
+
+type ctxKeyDryRun struct{}
+
+// NewContext returns a context of a dry-run deployment.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyDryRun{}, true)
+}
+
+// FromContext returns whether the context is the context of a dry-run deployment.
+func FromContext(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(ctxKeyDryRun{}).(bool)
+	return dryRun
+}
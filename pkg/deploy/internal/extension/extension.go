@@ -0,0 +1,135 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+)
+
+// VersionParameter is the property of a deployed extension holding the version it was activated in. Monitoring
+// configurations reference it, as their value has to name the extension's version.
+const VersionParameter = "version"
+
+type deployExtensionClient interface {
+	ListVersions(ctx context.Context, extensionName string) ([]dtclient.ExtensionVersion, error)
+	Upload(ctx context.Context, extensionPackage []byte) (dtclient.ExtensionVersion, error)
+	EnsureActiveVersion(ctx context.Context, extensionName, version string) (bool, error)
+}
+
+// Deploy uploads the signed extension package of the config, unless the version it contains already exists, and
+// ensures that this version is the active version of the extension.
+func Deploy(ctx context.Context, client deployExtensionClient, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
+	// uploading packages takes considerably longer than other requests, as they are verified by the environment
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	extensionPackage := []byte(renderedConfig)
+	ext, err := ReadExtensionVersion(extensionPackage)
+	if err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, "failed to read extension package").WithError(err)
+	}
+
+	versions, err := client.ListVersions(ctx, ext.ExtensionName)
+	if err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to list versions of extension %q", ext.ExtensionName)).WithError(err)
+	}
+
+	if slices.Contains(versions, ext) {
+		log.WithCtxFields(ctx).Debug("Version %s of extension %q already exists", ext.Version, ext.ExtensionName)
+	} else if _, err := client.Upload(ctx, extensionPackage); err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to upload version %s of extension %q", ext.Version, ext.ExtensionName)).WithError(err)
+	}
+
+	changed, err := client.EnsureActiveVersion(ctx, ext.ExtensionName, ext.Version)
+	if err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to activate version %s of extension %q", ext.Version, ext.ExtensionName)).WithError(err)
+	}
+	if changed {
+		log.WithCtxFields(ctx).Info("Activated version %s of extension %q", ext.Version, ext.ExtensionName)
+	}
+
+	properties[config.IdParameter] = ext.ExtensionName
+	if _, found := properties[config.NameParameter]; !found {
+		properties[config.NameParameter] = ext.ExtensionName
+	}
+	properties[VersionParameter] = ext.Version
+
+	return entities.ResolvedEntity{
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}, nil
+}
+
+// ReadExtensionVersion reads the name and version of the extension contained in the given signed extension package.
+// Signed packages are archives containing the actual extension archive 'extension.zip' and its signature.
+func ReadExtensionVersion(extensionPackage []byte) (dtclient.ExtensionVersion, error) {
+	extensionArchive, err := readFileFromZip(extensionPackage, "extension.zip")
+	if err != nil {
+		return dtclient.ExtensionVersion{}, fmt.Errorf("invalid signed extension package: %w", err)
+	}
+
+	extensionYAML, err := readFileFromZip(extensionArchive, "extension.yaml")
+	if err != nil {
+		return dtclient.ExtensionVersion{}, fmt.Errorf("invalid extension archive: %w", err)
+	}
+
+	var ext struct {
+		Name    string `yaml:"name"`
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(extensionYAML, &ext); err != nil {
+		return dtclient.ExtensionVersion{}, fmt.Errorf("invalid extension.yaml: %w", err)
+	}
+	if ext.Name == "" || ext.Version == "" {
+		return dtclient.ExtensionVersion{}, errors.New("extension.yaml is missing the extension's name or version")
+	}
+
+	return dtclient.ExtensionVersion{ExtensionName: ext.Name, Version: ext.Version}, nil
+}
+
+func readFileFromZip(archive []byte, name string) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := r.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
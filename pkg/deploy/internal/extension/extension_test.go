@@ -0,0 +1,139 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extension"
+)
+
+type testExtensionClient struct {
+	versions  []dtclient.ExtensionVersion
+	uploaded  [][]byte
+	activated []dtclient.ExtensionVersion
+}
+
+func (c *testExtensionClient) ListVersions(_ context.Context, _ string) ([]dtclient.ExtensionVersion, error) {
+	return c.versions, nil
+}
+
+func (c *testExtensionClient) Upload(_ context.Context, extensionPackage []byte) (dtclient.ExtensionVersion, error) {
+	c.uploaded = append(c.uploaded, extensionPackage)
+	return dtclient.ExtensionVersion{}, nil
+}
+
+func (c *testExtensionClient) EnsureActiveVersion(_ context.Context, extensionName, version string) (bool, error) {
+	c.activated = append(c.activated, dtclient.ExtensionVersion{ExtensionName: extensionName, Version: version})
+	return true, nil
+}
+
+func createZip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func createSignedPackage(t *testing.T, extensionYAML string) []byte {
+	return createZip(t, map[string][]byte{
+		"extension.zip":     createZip(t, map[string][]byte{"extension.yaml": []byte(extensionYAML)}),
+		"extension.zip.sig": []byte("signature"),
+	})
+}
+
+func newExtensionConfig(extensionPackage []byte) config.Config {
+	return config.Config{
+		Template:   template.NewInMemoryTemplate("extension.zip", string(extensionPackage)),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: string(config.ExtensionV2TypeID), ConfigId: "ext"},
+		Type:       config.ExtensionV2Type{},
+	}
+}
+
+func TestDeploy(t *testing.T) {
+	extensionPackage := createSignedPackage(t, "name: com.example.ext\nversion: 1.2.0\nminDynatraceVersion: '1.280'\n")
+
+	t.Run("uploads and activates a new version", func(t *testing.T) {
+		client := &testExtensionClient{versions: []dtclient.ExtensionVersion{{ExtensionName: "com.example.ext", Version: "1.1.0"}}}
+		c := newExtensionConfig(extensionPackage)
+
+		resolved, err := extension.Deploy(t.Context(), client, parameter.Properties{}, string(extensionPackage), &c)
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]byte{extensionPackage}, client.uploaded)
+		assert.Equal(t, []dtclient.ExtensionVersion{{ExtensionName: "com.example.ext", Version: "1.2.0"}}, client.activated)
+		assert.Equal(t, parameter.Properties{
+			config.IdParameter:         "com.example.ext",
+			config.NameParameter:       "com.example.ext",
+			extension.VersionParameter: "1.2.0",
+		}, resolved.Properties)
+	})
+
+	t.Run("existing version is only activated", func(t *testing.T) {
+		client := &testExtensionClient{versions: []dtclient.ExtensionVersion{{ExtensionName: "com.example.ext", Version: "1.2.0"}}}
+		c := newExtensionConfig(extensionPackage)
+
+		resolved, err := extension.Deploy(t.Context(), client, parameter.Properties{config.NameParameter: "my extension"}, string(extensionPackage), &c)
+		require.NoError(t, err)
+
+		assert.Empty(t, client.uploaded)
+		assert.Equal(t, []dtclient.ExtensionVersion{{ExtensionName: "com.example.ext", Version: "1.2.0"}}, client.activated)
+		assert.Equal(t, "my extension", resolved.Properties[config.NameParameter])
+	})
+
+	t.Run("invalid package fails", func(t *testing.T) {
+		client := &testExtensionClient{}
+		c := newExtensionConfig([]byte("not a zip"))
+
+		_, err := extension.Deploy(t.Context(), client, parameter.Properties{}, "not a zip", &c)
+		assert.Error(t, err)
+		assert.Empty(t, client.uploaded)
+		assert.Empty(t, client.activated)
+	})
+}
+
+func TestReadExtensionVersion(t *testing.T) {
+	ext, err := extension.ReadExtensionVersion(createSignedPackage(t, "name: com.example.ext\nversion: 1.2.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, dtclient.ExtensionVersion{ExtensionName: "com.example.ext", Version: "1.2.0"}, ext)
+
+	_, err = extension.ReadExtensionVersion(createSignedPackage(t, "name: com.example.ext\n"))
+	assert.ErrorContains(t, err, "version")
+
+	_, err = extension.ReadExtensionVersion(createZip(t, map[string][]byte{"extension.yaml": []byte("name: com.example.ext\nversion: 1.2.0\n")}))
+	assert.ErrorContains(t, err, "signed extension package")
+}
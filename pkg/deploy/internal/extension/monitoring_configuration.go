@@ -0,0 +1,141 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+)
+
+type deployMonitoringConfigurationClient interface {
+	ListMonitoringConfigurations(ctx context.Context, extensionName string) ([]dtclient.MonitoringConfiguration, error)
+	CreateMonitoringConfiguration(ctx context.Context, extensionName, scope string, value json.RawMessage) (string, error)
+	UpdateMonitoringConfiguration(ctx context.Context, extensionName, id string, value json.RawMessage) error
+}
+
+// DeployMonitoringConfiguration creates or updates a monitoring configuration of an extension. As monitoring
+// configurations can't be identified by an external ID, an existing configuration of the same scope and description
+// is updated if the config doesn't have an origin object ID.
+func DeployMonitoringConfiguration(ctx context.Context, client deployMonitoringConfigurationClient, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	t, ok := c.Type.(config.ExtensionV2MonitoringConfigurationType)
+	if !ok {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.ExtensionV2MonitoringConfigurationTypeID, c.Type.ID()))
+	}
+
+	scope, ok := properties[config.ScopeParameter].(string)
+	if !ok || scope == "" {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, "scope of monitoring configuration must be a non-empty string")
+	}
+
+	value := json.RawMessage(renderedConfig)
+	if !json.Valid(value) {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, "monitoring configuration is not valid JSON")
+	}
+
+	//Strategy 1 when OriginObjectId is set we update the object
+	if c.OriginObjectId != "" {
+		err := client.UpdateMonitoringConfiguration(ctx, t.Extension, c.OriginObjectId, value)
+		if err == nil {
+			return createResolveEntity(c.OriginObjectId, properties, c), nil
+		}
+
+		if !api.IsNotFoundError(err) {
+			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to deploy monitoring configuration: %s", c.OriginObjectId)).WithError(err)
+		}
+	}
+
+	//Strategy 2 is to try to find a match with the same scope and description and update it
+	matchID, match, err := findMatchOnRemote(ctx, client, t.Extension, scope, value)
+	if err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("error finding monitoring configuration of extension %q", t.Extension)).WithError(err)
+	}
+
+	if match {
+		if err := client.UpdateMonitoringConfiguration(ctx, t.Extension, matchID, value); err != nil {
+			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to update monitoring configuration: %s", matchID)).WithError(err)
+		}
+		return createResolveEntity(matchID, properties, c), nil
+	}
+
+	//Strategy 3 is to create a new monitoring configuration
+	id, err := client.CreateMonitoringConfiguration(ctx, t.Extension, scope, value)
+	if err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to create monitoring configuration of extension %q", t.Extension)).WithError(err)
+	}
+
+	return createResolveEntity(id, properties, c), nil
+}
+
+func findMatchOnRemote(ctx context.Context, client deployMonitoringConfigurationClient, extensionName, scope string, value json.RawMessage) (string, bool, error) {
+	description, err := getDescription(value)
+	if err != nil {
+		return "", false, err
+	}
+	if description == "" {
+		return "", false, nil
+	}
+
+	existing, err := client.ListMonitoringConfigurations(ctx, extensionName)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, mc := range existing {
+		if mc.Scope != scope {
+			continue
+		}
+		// invalid remote values can't match, they are skipped instead of failing the deployment
+		if d, err := getDescription(mc.Value); err == nil && d == description {
+			return mc.ObjectId, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func getDescription(value json.RawMessage) (string, error) {
+	var v struct {
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return "", fmt.Errorf("failed to unmarshal monitoring configuration: %w", err)
+	}
+	return v.Description, nil
+}
+
+func createResolveEntity(id string, properties parameter.Properties, c *config.Config) entities.ResolvedEntity {
+	properties[config.IdParameter] = id
+	return entities.ResolvedEntity{
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extension"
+)
+
+type testMonitoringConfigurationClient struct {
+	existing  []dtclient.MonitoringConfiguration
+	updateErr error
+	created   []string
+	updated   []string
+}
+
+func (c *testMonitoringConfigurationClient) ListMonitoringConfigurations(_ context.Context, _ string) ([]dtclient.MonitoringConfiguration, error) {
+	return c.existing, nil
+}
+
+func (c *testMonitoringConfigurationClient) CreateMonitoringConfiguration(_ context.Context, _, scope string, _ json.RawMessage) (string, error) {
+	c.created = append(c.created, scope)
+	return "new-id", nil
+}
+
+func (c *testMonitoringConfigurationClient) UpdateMonitoringConfiguration(_ context.Context, _, id string, _ json.RawMessage) error {
+	if c.updateErr != nil {
+		return c.updateErr
+	}
+	c.updated = append(c.updated, id)
+	return nil
+}
+
+func TestDeployMonitoringConfiguration(t *testing.T) {
+	const payload = `{"description": "my config", "version": "1.2.0", "enabled": true}`
+	existing := []dtclient.MonitoringConfiguration{
+		{ObjectId: "other-scope", Scope: "HOST-1", Value: json.RawMessage(`{"description": "my config"}`)},
+		{ObjectId: "match", Scope: "environment", Value: json.RawMessage(`{"description": "my config"}`)},
+	}
+
+	tests := []struct {
+		name            string
+		originObjectId  string
+		client          *testMonitoringConfigurationClient
+		expectedID      string
+		expectedCreated []string
+		expectedUpdated []string
+	}{
+		{
+			name:            "origin object ID is updated",
+			originObjectId:  "origin",
+			client:          &testMonitoringConfigurationClient{existing: existing},
+			expectedID:      "origin",
+			expectedUpdated: []string{"origin"},
+		},
+		{
+			name:            "configuration of same scope and description is updated",
+			client:          &testMonitoringConfigurationClient{existing: existing},
+			expectedID:      "match",
+			expectedUpdated: []string{"match"},
+		},
+		{
+			name:            "configuration is created if origin object doesn't exist and nothing matches",
+			originObjectId:  "origin",
+			client:          &testMonitoringConfigurationClient{updateErr: api.APIError{StatusCode: http.StatusNotFound}},
+			expectedID:      "new-id",
+			expectedCreated: []string{"environment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.Config{
+				Template:       template.NewInMemoryTemplate("mc.json", payload),
+				Coordinate:     coordinate.Coordinate{Project: "project", Type: string(config.ExtensionV2MonitoringConfigurationTypeID), ConfigId: "mc"},
+				Type:           config.ExtensionV2MonitoringConfigurationType{Extension: "com.example.ext"},
+				OriginObjectId: tt.originObjectId,
+			}
+
+			resolved, err := extension.DeployMonitoringConfiguration(t.Context(), tt.client, parameter.Properties{config.ScopeParameter: "environment"}, payload, &c)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedID, resolved.Properties[config.IdParameter])
+			assert.Equal(t, tt.expectedCreated, tt.client.created)
+			assert.Equal(t, tt.expectedUpdated, tt.client.updated)
+		})
+	}
+}
+
+func TestDeployMonitoringConfiguration_RequiresScope(t *testing.T) {
+	c := config.Config{
+		Template:   template.NewInMemoryTemplate("mc.json", "{}"),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: string(config.ExtensionV2MonitoringConfigurationTypeID), ConfigId: "mc"},
+		Type:       config.ExtensionV2MonitoringConfigurationType{Extension: "com.example.ext"},
+	}
+
+	_, err := extension.DeployMonitoringConfiguration(t.Context(), &testMonitoringConfigurationClient{}, parameter.Properties{}, "{}", &c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openpipeline
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/dryrun"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/openpipeline/partial"
+)
+
+type MergingClient interface {
+	Client
+	Get(ctx context.Context, id string) (openpipeline.Response, error)
+}
+
+// Merger collects the rendered openpipeline configs of an environment, so that all configs of the same kind can be
+// merged into one configuration and deployed at once.
+type Merger struct {
+	mu    sync.Mutex
+	kinds map[string]*kindConfigs
+}
+
+type kindConfigs struct {
+	configs []*config.Config
+	// base is the rendered config defining the whole configuration of the kind, if any
+	base  *string
+	parts []partial.Part
+}
+
+// MergeResult is the result of deploying the merged configuration a config is part of.
+type MergeResult struct {
+	Config *config.Config
+	Err    error
+}
+
+func NewMerger() *Merger {
+	return &Merger{kinds: make(map[string]*kindConfigs)}
+}
+
+// Add adds the rendered config to the configs of its kind and returns the entity the config resolves to.
+// The config is only deployed by Deploy, so configs referencing it are deployed before it.
+func (m *Merger) Add(c *config.Config, properties parameter.Properties, renderedConfig string) (entities.ResolvedEntity, error) {
+	t, ok := c.Type.(config.OpenPipelineType)
+	if !ok {
+		return entities.ResolvedEntity{}, fmt.Errorf("expected openpipeline config type but found %v", c.Type)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, found := m.kinds[t.Kind]
+	if !found {
+		k = &kindConfigs{}
+		m.kinds[t.Kind] = k
+	}
+
+	if t.Partial == nil && k.base != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("another config already defines the whole openpipeline configuration of kind '%s'", t.Kind))
+	}
+
+	k.configs = append(k.configs, c)
+	if t.Partial == nil {
+		k.base = &renderedConfig
+	} else {
+		k.parts = append(k.parts, partial.Part{Coordinate: c.Coordinate, Partial: *t.Partial, Payload: []byte(renderedConfig)})
+	}
+
+	return createResolvedEntity(t.Kind, c.Coordinate, properties), nil
+}
+
+// Deploy merges the configs of each kind and deploys the merged configurations. If no config defines the whole
+// configuration of a kind, the partial configs are merged into the configuration currently deployed, or into an empty
+// configuration in dry-runs.
+// The result of each kind is returned for every config of the kind.
+func (m *Merger) Deploy(ctx context.Context, client MergingClient) []MergeResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var results []MergeResult
+	for _, kind := range slices.Sorted(maps.Keys(m.kinds)) {
+		k := m.kinds[kind]
+		err := deployMerged(ctx, client, kind, k)
+		for _, c := range k.configs {
+			var deployErr error
+			if err != nil {
+				deployErr = deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to deploy merged openpipeline configuration of kind '%s'", kind)).WithError(err)
+			}
+			results = append(results, MergeResult{Config: c, Err: deployErr})
+		}
+	}
+	return results
+}
+
+func deployMerged(ctx context.Context, client MergingClient, kind string, k *kindConfigs) error {
+	//create new context to carry logger
+	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var merged []byte
+	var err error
+	switch {
+	case k.base != nil:
+		merged, err = partial.Merge([]byte(*k.base), k.parts)
+	case dryrun.FromContext(ctx):
+		// the deployed configuration is unknown in dry-runs, so checks depending on it, e.g. whether the pipelines of
+		// processors exist, are skipped
+		merged, err = partial.MergeWithoutBase(k.parts)
+	default:
+		resp, getErr := client.Get(ctx, kind)
+		if getErr != nil {
+			return fmt.Errorf("failed to get deployed configuration: %w", getErr)
+		}
+		merged, err = partial.Merge(resp.Data, k.parts)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.WithCtxFields(ctx).Debug("Deploying openpipeline configuration of kind %q merged from %d configs", kind, len(k.configs))
+	if _, err := client.Update(ctx, kind, merged); err != nil {
+		return fmt.Errorf("failed to update openpipeline object of kind '%s': %w", kind, err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package setting
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+)
+
+// batchDelay is the time objects are collected before a batch is sent
+const batchDelay = 50 * time.Millisecond
+
+// BatchingClient is a [client.SettingsClient] collecting objects that are upserted concurrently, and sending all
+// objects of the same schema in a single request. Objects which are inserted at a specific position, modify
+// permissions or need custom retries are upserted individually.
+//
+// As independent configurations are deployed concurrently, this cuts the number of requests without any change to the
+// deployment itself: every Upsert call still returns the result of its own object.
+type BatchingClient struct {
+	client.SettingsClient
+
+	// maxSize is the maximum number of objects per request. Full batches are sent immediately.
+	maxSize int
+	// delay is the time objects are collected, starting with the first object of a batch
+	delay time.Duration
+
+	lock sync.Mutex
+	// pending holds the batches that are still collecting objects by schema ID
+	pending map[string]*batch
+}
+
+type batch struct {
+	// ctxs holds the context of each object
+	ctxs    []context.Context
+	objects []dtclient.SettingsObject
+	results []chan dtclient.UpsertResult
+	timer   *time.Timer
+}
+
+// NewBatchingClient creates a BatchingClient upserting objects using the given client, sending at most maxSize objects
+// per request.
+func NewBatchingClient(c client.SettingsClient, maxSize int) *BatchingClient {
+	return &BatchingClient{
+		SettingsClient: c,
+		maxSize:        maxSize,
+		delay:          batchDelay,
+		pending:        make(map[string]*batch),
+	}
+}
+
+func (c *BatchingClient) Upsert(ctx context.Context, obj dtclient.SettingsObject, upsertOptions dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+	if upsertOptions.InsertAfter != nil || upsertOptions.AllUserPermission != nil || upsertOptions.OverrideRetry != nil {
+		return c.SettingsClient.Upsert(ctx, obj, upsertOptions)
+	}
+
+	result := make(chan dtclient.UpsertResult, 1)
+	c.add(ctx, obj, result)
+	select {
+	case r := <-result:
+		return r.Entity, r.Err
+	case <-ctx.Done():
+		return dtclient.DynatraceEntity{}, ctx.Err()
+	}
+}
+
+// add adds the object to the pending batch of its schema, starting a new batch if there is none.
+func (c *BatchingClient) add(ctx context.Context, obj dtclient.SettingsObject, result chan dtclient.UpsertResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	b, found := c.pending[obj.SchemaId]
+	if !found {
+		b = &batch{}
+		b.timer = time.AfterFunc(c.delay, func() { c.flush(obj.SchemaId, b) })
+		c.pending[obj.SchemaId] = b
+	}
+
+	b.ctxs = append(b.ctxs, ctx)
+	b.objects = append(b.objects, obj)
+	b.results = append(b.results, result)
+
+	if len(b.objects) >= c.maxSize {
+		b.timer.Stop()
+		delete(c.pending, obj.SchemaId)
+		go c.send(b)
+	}
+}
+
+// flush sends the batch once its delay has passed, unless it was already sent because it was full.
+func (c *BatchingClient) flush(schemaID string, b *batch) {
+	c.lock.Lock()
+	if c.pending[schemaID] != b {
+		c.lock.Unlock()
+		return
+	}
+	delete(c.pending, schemaID)
+	c.lock.Unlock()
+
+	c.send(b)
+}
+
+// send sends all objects of the batch whose context is not done yet. The request is only cancelled once the contexts of
+// all objects are done, so that a single caller can not cancel the upsert of the other objects.
+func (c *BatchingClient) send(b *batch) {
+	var ctxs []context.Context
+	var objects []dtclient.SettingsObject
+	var results []chan dtclient.UpsertResult
+	for i, ctx := range b.ctxs {
+		if err := ctx.Err(); err != nil {
+			b.results[i] <- dtclient.UpsertResult{Err: err}
+			continue
+		}
+		ctxs = append(ctxs, ctx)
+		objects = append(objects, b.objects[i])
+		results = append(results, b.results[i])
+	}
+	if len(objects) == 0 {
+		return
+	}
+
+	ctx, cancel := batchContext(ctxs)
+	defer cancel()
+
+	if len(objects) == 1 {
+		entity, err := c.SettingsClient.Upsert(ctxs[0], objects[0], dtclient.UpsertSettingsOptions{})
+		results[0] <- dtclient.UpsertResult{Entity: entity, Err: err}
+		return
+	}
+
+	for i, r := range c.SettingsClient.UpsertBatch(ctx, objects) {
+		if r.Err != nil {
+			log.WithCtxFields(ctxs[i]).WithFields(field.Error(r.Err)).Debug("Failed to upsert object as part of a batch of %d objects of schema %s: %v", len(objects), objects[i].SchemaId, r.Err)
+		} else {
+			log.WithCtxFields(ctxs[i]).Debug("Upserted object %s as part of a batch of %d objects of schema %s", r.Entity.Id, len(objects), objects[i].SchemaId)
+		}
+		results[i] <- r
+	}
+}
+
+// batchContext returns a context keeping the values of the first of the given contexts, which is cancelled once all
+// given contexts are done.
+func batchContext(ctxs []context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctxs[0]))
+
+	var remaining atomic.Int32
+	remaining.Store(int32(len(ctxs)))
+	stops := make([]func() bool, 0, len(ctxs))
+	for _, c := range ctxs {
+		stops = append(stops, context.AfterFunc(c, func() {
+			if remaining.Add(-1) == 0 {
+				cancel()
+			}
+		}))
+	}
+
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancel()
+	}
+}
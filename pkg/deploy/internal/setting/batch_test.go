@@ -0,0 +1,181 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package setting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+func settingsObject(schemaID string, configID string) dtclient.SettingsObject {
+	return dtclient.SettingsObject{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: schemaID, ConfigId: configID},
+		SchemaId:   schemaID,
+		Content:    []byte("{}"),
+	}
+}
+
+// upsertConcurrently upserts all objects concurrently and returns the results in the order of the objects
+func upsertConcurrently(t *testing.T, c client.SettingsClient, objects []dtclient.SettingsObject) []dtclient.UpsertResult {
+	results := make([]dtclient.UpsertResult, len(objects))
+	wg := sync.WaitGroup{}
+	for i, obj := range objects {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Entity, results[i].Err = c.Upsert(t.Context(), obj, dtclient.UpsertSettingsOptions{})
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func TestBatchingClient_BatchesObjectsOfTheSameSchema(t *testing.T) {
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().UpsertBatch(gomock.Any(), gomock.Len(3)).DoAndReturn(func(_ any, objects []dtclient.SettingsObject) []dtclient.UpsertResult {
+		results := make([]dtclient.UpsertResult, len(objects))
+		for i, obj := range objects {
+			if obj.Coordinate.ConfigId == "failing" {
+				results[i].Err = errors.New("rejected")
+				continue
+			}
+			results[i].Entity = dtclient.DynatraceEntity{Id: "id-" + obj.Coordinate.ConfigId}
+		}
+		return results
+	})
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "id-other"}, nil)
+
+	b := NewBatchingClient(c, 10)
+	b.delay = 100 * time.Millisecond
+
+	results := upsertConcurrently(t, b, []dtclient.SettingsObject{
+		settingsObject("builtin:a", "first"),
+		settingsObject("builtin:a", "second"),
+		settingsObject("builtin:b", "other"),
+		settingsObject("builtin:a", "failing"),
+	})
+
+	assert.Equal(t, "id-first", results[0].Entity.Id)
+	assert.Equal(t, "id-second", results[1].Entity.Id)
+	assert.Equal(t, "id-other", results[2].Entity.Id, "single objects are upserted individually")
+	assert.Error(t, results[3].Err)
+	assert.NoError(t, results[0].Err)
+}
+
+func TestBatchingClient_SendsFullBatchesImmediately(t *testing.T) {
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().UpsertBatch(gomock.Any(), gomock.Len(2)).Times(2).DoAndReturn(func(_ any, objects []dtclient.SettingsObject) []dtclient.UpsertResult {
+		return make([]dtclient.UpsertResult, len(objects))
+	})
+
+	b := NewBatchingClient(c, 2)
+	b.delay = time.Hour
+
+	var objects []dtclient.SettingsObject
+	for i := range 4 {
+		objects = append(objects, settingsObject("builtin:a", fmt.Sprint(i)))
+	}
+	for _, r := range upsertConcurrently(t, b, objects) {
+		assert.NoError(t, r.Err)
+	}
+}
+
+func TestBatchingClient_UpsertsObjectsWithOptionsIndividually(t *testing.T) {
+	opts := dtclient.UpsertSettingsOptions{InsertAfter: pointer.Pointer(dtclient.InsertPositionFront)}
+	obj := settingsObject("builtin:a", "positioned")
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Upsert(gomock.Any(), obj, opts).Return(dtclient.DynatraceEntity{Id: "id"}, nil)
+
+	b := NewBatchingClient(c, 10)
+	b.delay = time.Hour
+
+	entity, err := b.Upsert(t.Context(), obj, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "id", entity.Id)
+}
+
+func TestBatchingClient_CancellingOneObjectDoesNotCancelTheBatch(t *testing.T) {
+	first, cancelFirst := context.WithCancel(t.Context())
+	defer cancelFirst()
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().UpsertBatch(gomock.Any(), gomock.Len(2)).DoAndReturn(func(ctx context.Context, objects []dtclient.SettingsObject) []dtclient.UpsertResult {
+		cancelFirst()
+		assert.NoError(t, ctx.Err(), "the batch is still sent for the second object")
+		return make([]dtclient.UpsertResult, len(objects))
+	})
+
+	b := NewBatchingClient(c, 2)
+	b.delay = time.Hour
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = b.Upsert(first, settingsObject("builtin:a", "first"), dtclient.UpsertSettingsOptions{})
+	}()
+	_, err := b.Upsert(t.Context(), settingsObject("builtin:a", "second"), dtclient.UpsertSettingsOptions{})
+	assert.NoError(t, err)
+	wg.Wait()
+}
+
+func TestBatchingClient_ObjectsOfDoneContextsAreNotSent(t *testing.T) {
+	cancelled, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Upsert(gomock.Any(), settingsObject("builtin:a", "second"), dtclient.UpsertSettingsOptions{}).Return(dtclient.DynatraceEntity{Id: "id"}, nil)
+
+	b := NewBatchingClient(c, 2)
+	b.delay = time.Hour
+
+	result := make(chan dtclient.UpsertResult, 1)
+	b.add(cancelled, settingsObject("builtin:a", "first"), result)
+	entity, err := b.Upsert(t.Context(), settingsObject("builtin:a", "second"), dtclient.UpsertSettingsOptions{})
+	assert.Equal(t, "id", entity.Id)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, (<-result).Err, context.Canceled)
+}
+
+func TestBatchContext(t *testing.T) {
+	first, cancelFirst := context.WithCancel(t.Context())
+	second, cancelSecond := context.WithCancel(t.Context())
+
+	ctx, cancel := batchContext([]context.Context{first, second})
+	defer cancel()
+
+	cancelFirst()
+	assert.NoError(t, ctx.Err(), "the batch context is not done while any context is not done")
+
+	cancelSecond()
+	assert.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, time.Millisecond, "the batch context is done once all contexts are done")
+}
@@ -0,0 +1,246 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+)
+
+// classicObject is an object of a classic config API.
+type classicObject struct {
+	ID    string         `json:"id"`
+	Value map[string]any `json:"value"`
+}
+
+// classicIDProperties are the properties holding the ID of objects of APIs not using "id".
+var classicIDProperties = map[string]string{
+	api.SyntheticLocation:                    "entityId",
+	api.SyntheticMonitor:                     "entityId",
+	api.KeyUserActionsWeb:                    "meIdentifier",
+	api.KeyUserActionsMobile:                 "name",
+	api.UserActionAndSessionPropertiesMobile: "key",
+}
+
+// classicListProperties are the properties listing all objects of APIs whose list response differs from their
+// PropertyNameOfGetAllResponse.
+var classicListProperties = map[string]string{
+	api.SyntheticLocation:                    "locations",
+	api.SyntheticMonitor:                     "monitors",
+	api.UserActionAndSessionPropertiesMobile: "userActionProperties",
+}
+
+// classicIDPrefixes are the prefixes of generated IDs of APIs creating monitored entities, as their IDs are not UUIDs.
+var classicIDPrefixes = map[string]string{
+	api.SyntheticLocation: "SYNTHETIC_LOCATION-",
+	api.SyntheticMonitor:  "SYNTHETIC_TEST-",
+	api.ApplicationWeb:    "APPLICATION-",
+	api.KeyUserActionsWeb: "APPLICATION_METHOD-",
+}
+
+var (
+	classicAPIs     []api.API
+	classicAPIsOnce sync.Once
+)
+
+// findClassicAPI returns the classic API with the given URL path. Path segments of parent objects ({SCOPE}) match any
+// segment.
+func findClassicAPI(urlPath string) (api.API, bool) {
+	classicAPIsOnce.Do(func() {
+		for _, a := range api.NewAPIs() {
+			classicAPIs = append(classicAPIs, a)
+		}
+		slices.SortFunc(classicAPIs, func(a, b api.API) int { return strings.Compare(a.ID, b.ID) })
+	})
+
+	segments := strings.Split(urlPath, "/")
+	for _, a := range classicAPIs {
+		if matchesTemplate(strings.Split(a.URLPath, "/"), segments) {
+			return a, true
+		}
+	}
+	return api.API{}, false
+}
+
+func matchesTemplate(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, t := range template {
+		if t != segments[i] && (t != "{SCOPE}" || segments[i] == "") {
+			return false
+		}
+	}
+	return true
+}
+
+// handleClassic handles all requests to classic config APIs. Requests to the URL path of an API list, create or (for
+// single configurations) update its objects, requests to sub-paths read, update or delete single objects.
+func (s *Server) handleClassic(rw http.ResponseWriter, req *http.Request) {
+	p := path.Clean(req.URL.Path)
+	if a, ok := findClassicAPI(p); ok {
+		s.handleClassicCollection(rw, req, a, p)
+		return
+	}
+	if a, ok := findClassicAPI(path.Dir(p)); ok && !a.SingleConfiguration {
+		s.handleClassicObject(rw, req, a, path.Dir(p), path.Base(p))
+		return
+	}
+	writeError(rw, http.StatusNotFound, "unknown API %s %s", req.Method, req.URL.Path)
+}
+
+func (s *Server) handleClassicCollection(rw http.ResponseWriter, req *http.Request, a api.API, collection string) {
+	if a.SingleConfiguration {
+		switch req.Method {
+		case http.MethodGet:
+			s.getClassicObject(rw, collection, "")
+		case http.MethodPut:
+			s.putClassicObject(rw, req, a, collection, "")
+		default:
+			writeError(rw, http.StatusMethodNotAllowed, "method %s is not allowed for %s", req.Method, req.URL.Path)
+		}
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		s.listClassicObjects(rw, a, collection)
+	case http.MethodPost:
+		s.createClassicObject(rw, req, a, collection, "")
+	default:
+		writeError(rw, http.StatusMethodNotAllowed, "method %s is not allowed for %s", req.Method, req.URL.Path)
+	}
+}
+
+func (s *Server) handleClassicObject(rw http.ResponseWriter, req *http.Request, a api.API, collection string, id string) {
+	switch req.Method {
+	case http.MethodGet:
+		s.getClassicObject(rw, collection, id)
+	case http.MethodPut:
+		s.putClassicObject(rw, req, a, collection, id)
+	case http.MethodPost:
+		// some APIs (e.g. mobile key user actions) are created using their name as part of the path
+		s.createClassicObject(rw, req, a, collection, id)
+	case http.MethodDelete:
+		s.deleteClassicObject(rw, collection, id)
+	default:
+		writeError(rw, http.StatusMethodNotAllowed, "method %s is not allowed for %s", req.Method, req.URL.Path)
+	}
+}
+
+func (s *Server) listClassicObjects(rw http.ResponseWriter, a api.API, collection string) {
+	values := make([]map[string]any, 0, len(s.state.Classic[collection]))
+	for _, o := range s.state.Classic[collection] {
+		values = append(values, o.Value)
+	}
+
+	// this API returns a plain list instead of an object
+	if a.ID == api.AwsCredentials {
+		writeJSON(rw, http.StatusOK, values)
+		return
+	}
+
+	property := a.PropertyNameOfGetAllResponse
+	if p, ok := classicListProperties[a.ID]; ok {
+		property = p
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{property: values, "totalCount": len(values)})
+}
+
+func (s *Server) getClassicObject(rw http.ResponseWriter, collection string, id string) {
+	i := s.state.findClassicObject(collection, id)
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "object %q not found", id)
+		return
+	}
+	writeJSON(rw, http.StatusOK, s.state.Classic[collection][i].Value)
+}
+
+func (s *Server) createClassicObject(rw http.ResponseWriter, req *http.Request, a api.API, collection string, id string) {
+	value, ok := readJSONObject(rw, req)
+	if !ok {
+		return
+	}
+
+	idProperty := classicIDProperty(a)
+	if id == "" && idProperty == "name" {
+		id, _ = value["name"].(string)
+	}
+	if id == "" {
+		id = newID(classicIDPrefixes[a.ID])
+	}
+	if s.state.findClassicObject(collection, id) >= 0 {
+		writeError(rw, http.StatusBadRequest, "object %q already exists", id)
+		return
+	}
+
+	value[idProperty] = id
+	s.state.Classic[collection] = append(s.state.Classic[collection], classicObject{ID: id, Value: value})
+
+	if idProperty == "entityId" {
+		writeJSON(rw, http.StatusCreated, map[string]any{"entityId": id})
+		return
+	}
+	writeJSON(rw, http.StatusCreated, map[string]any{"id": id, "name": value["name"]})
+}
+
+// putClassicObject updates an object, or creates it with the given ID if it does not exist yet.
+func (s *Server) putClassicObject(rw http.ResponseWriter, req *http.Request, a api.API, collection string, id string) {
+	value, ok := readJSONObject(rw, req)
+	if !ok {
+		return
+	}
+
+	if id != "" {
+		value[classicIDProperty(a)] = id
+	}
+
+	if i := s.state.findClassicObject(collection, id); i >= 0 {
+		s.state.Classic[collection][i].Value = value
+		rw.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.state.Classic[collection] = append(s.state.Classic[collection], classicObject{ID: id, Value: value})
+	if a.SingleConfiguration {
+		rw.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(rw, http.StatusCreated, map[string]any{"id": id, "name": value["name"]})
+}
+
+func (s *Server) deleteClassicObject(rw http.ResponseWriter, collection string, id string) {
+	i := s.state.findClassicObject(collection, id)
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "object %q not found", id)
+		return
+	}
+	s.state.Classic[collection] = slices.Delete(s.state.Classic[collection], i, i+1)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func classicIDProperty(a api.API) string {
+	if p, ok := classicIDProperties[a.ID]; ok {
+		return p
+	}
+	return "id"
+}
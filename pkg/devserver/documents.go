@@ -0,0 +1,240 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	documentsPath      = "/platform/document/v1/documents"
+	documentsTrashPath = "/platform/document/v1/trash/documents"
+
+	// owner is the owner of all platform objects, as the server does not know any users
+	owner = "dev-server"
+
+	maxDocumentSize = 32 << 20
+)
+
+// document is a stored document. Deleted documents are kept in the trash until they are deleted from it.
+type document struct {
+	ID               string `json:"id"`
+	ExternalID       string `json:"externalId,omitempty"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	IsPrivate        bool   `json:"isPrivate"`
+	Version          int    `json:"version"`
+	Content          []byte `json:"content"`
+	LastModifiedTime string `json:"lastModifiedTime"`
+	Trashed          bool   `json:"trashed,omitempty"`
+}
+
+func (d document) metadata() map[string]any {
+	return map[string]any{
+		"id":         d.ID,
+		"externalId": d.ExternalID,
+		"name":       d.Name,
+		"type":       d.Type,
+		"version":    d.Version,
+		"isPrivate":  d.IsPrivate,
+		"owner":      owner,
+		"actor":      owner,
+		"modificationInfo": map[string]any{
+			"lastModifiedBy":   owner,
+			"lastModifiedTime": d.LastModifiedTime,
+		},
+	}
+}
+
+func (s *Server) registerDocuments() {
+	s.mux.HandleFunc("GET "+documentsPath, s.handleListDocuments)
+	s.mux.HandleFunc("POST "+documentsPath, s.handleCreateDocument)
+	s.mux.HandleFunc("GET "+documentsPath+"/{id}", s.handleGetDocument)
+	s.mux.HandleFunc("PATCH "+documentsPath+"/{id}", s.handlePatchDocument)
+	s.mux.HandleFunc("DELETE "+documentsPath+"/{id}", s.handleDeleteDocument)
+	s.mux.HandleFunc("DELETE "+documentsTrashPath+"/{id}", s.handleDeleteTrashedDocument)
+}
+
+// handleListDocuments lists all documents matching the filter expression. All documents are returned in a single page,
+// which clients only recognize as the last one if the next page key is explicitly null.
+func (s *Server) handleListDocuments(rw http.ResponseWriter, req *http.Request) {
+	f, err := parseFilter(req.URL.Query().Get("filter"))
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "invalid filter: %v", err)
+		return
+	}
+
+	docs := make([]map[string]any, 0)
+	for _, d := range s.state.Documents {
+		if md := d.metadata(); !d.Trashed && f.matches(md) {
+			docs = append(docs, md)
+		}
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{"documents": docs, "totalCount": len(docs), "nextPageKey": nil})
+}
+
+func (s *Server) handleCreateDocument(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseMultipartForm(maxDocumentSize); err != nil {
+		writeError(rw, http.StatusBadRequest, "invalid multipart form: %v", err)
+		return
+	}
+
+	d := document{ID: newID(""), Version: 1}
+	if err := updateDocument(&d, req); err != nil {
+		writeError(rw, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if d.Name == "" || d.Type == "" {
+		writeError(rw, http.StatusBadRequest, "name and type are required")
+		return
+	}
+
+	d.ExternalID = req.FormValue("externalId")
+	if d.ExternalID != "" && slices.ContainsFunc(s.state.Documents, func(o document) bool { return !o.Trashed && o.ExternalID == d.ExternalID }) {
+		writeError(rw, http.StatusConflict, "a document with externalId %q already exists", d.ExternalID)
+		return
+	}
+
+	s.state.Documents = append(s.state.Documents, d)
+	writeJSON(rw, http.StatusCreated, d.metadata())
+}
+
+// handleGetDocument returns the metadata and content of a document as multipart form.
+func (s *Server) handleGetDocument(rw http.ResponseWriter, req *http.Request) {
+	i, ok := s.findDocument(rw, req)
+	if !ok {
+		return
+	}
+	d := s.state.Documents[i]
+
+	md, err := json.Marshal(d.metadata())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	w := multipart.NewWriter(rw)
+	rw.Header().Set("Content-Type", w.FormDataContentType())
+	rw.WriteHeader(http.StatusOK)
+	_ = w.WriteField("metadata", string(md))
+	if part, err := w.CreateFormFile("content", d.Name); err == nil {
+		_, _ = part.Write(d.Content)
+	}
+	_ = w.Close()
+}
+
+// handlePatchDocument updates all fields of the document that are part of the form.
+func (s *Server) handlePatchDocument(rw http.ResponseWriter, req *http.Request) {
+	i, ok := s.findDocument(rw, req)
+	if !ok || !checkVersion(rw, req, strconv.Itoa(s.state.Documents[i].Version)) {
+		return
+	}
+
+	if err := req.ParseMultipartForm(maxDocumentSize); err != nil {
+		writeError(rw, http.StatusBadRequest, "invalid multipart form: %v", err)
+		return
+	}
+
+	d := s.state.Documents[i]
+	if err := updateDocument(&d, req); err != nil {
+		writeError(rw, http.StatusBadRequest, "%v", err)
+		return
+	}
+	d.Version++
+	s.state.Documents[i] = d
+
+	writeJSON(rw, http.StatusOK, map[string]any{"documentMetadata": d.metadata()})
+}
+
+// handleDeleteDocument moves the document to the trash.
+func (s *Server) handleDeleteDocument(rw http.ResponseWriter, req *http.Request) {
+	i, ok := s.findDocument(rw, req)
+	if !ok || !checkVersion(rw, req, strconv.Itoa(s.state.Documents[i].Version)) {
+		return
+	}
+	s.state.Documents[i].Trashed = true
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeleteTrashedDocument(rw http.ResponseWriter, req *http.Request) {
+	i := slices.IndexFunc(s.state.Documents, func(d document) bool { return d.ID == req.PathValue("id") && d.Trashed })
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "document %q not found in trash", req.PathValue("id"))
+		return
+	}
+	s.state.Documents = slices.Delete(s.state.Documents, i, i+1)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// findDocument returns the index of the document with the ID of the request path. If there is none, an error is
+// written to the response.
+func (s *Server) findDocument(rw http.ResponseWriter, req *http.Request) (int, bool) {
+	i := slices.IndexFunc(s.state.Documents, func(d document) bool { return d.ID == req.PathValue("id") && !d.Trashed })
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "document %q not found", req.PathValue("id"))
+		return 0, false
+	}
+	return i, true
+}
+
+// updateDocument sets all fields of the document that are part of the parsed multipart form of the request.
+func updateDocument(d *document, req *http.Request) error {
+	form := req.MultipartForm
+	if v, ok := form.Value["name"]; ok {
+		d.Name = v[0]
+	}
+	if v, ok := form.Value["type"]; ok {
+		d.Type = v[0]
+	}
+	if v, ok := form.Value["isPrivate"]; ok {
+		isPrivate, err := strconv.ParseBool(v[0])
+		if err != nil {
+			return fmt.Errorf("isPrivate is not a boolean: %w", err)
+		}
+		d.IsPrivate = isPrivate
+	}
+	if files := form.File["content"]; len(files) > 0 {
+		f, err := files[0].Open()
+		if err != nil {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+		defer f.Close()
+		if d.Content, err = io.ReadAll(f); err != nil {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+	}
+	d.LastModifiedTime = time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	return nil
+}
+
+// checkVersion verifies the optimistic locking version of the request, if there is one. If it does not match the
+// current version, a conflict is written to the response.
+func checkVersion(rw http.ResponseWriter, req *http.Request, current string) bool {
+	v := req.URL.Query().Get("optimistic-locking-version")
+	if v == "" || v == current {
+		return true
+	}
+	writeError(rw, http.StatusConflict, "optimistic locking version %s does not match current version %s", v, current)
+	return false
+}
@@ -0,0 +1,68 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocuments_RoundTrip(t *testing.T) {
+	s, err := New()
+	require.NoError(t, err)
+	c := newClientSet(t, s).DocumentClient
+
+	created, err := c.Create(context.TODO(), "my dashboard", false, "monaco-external-id", []byte(`{"tiles":[]}`), documents.Dashboard)
+	require.NoError(t, err)
+	md, err := documents.UnmarshallMetadata(created.Data)
+	require.NoError(t, err)
+	assert.Equal(t, "monaco-external-id", md.ExternalID)
+
+	_, err = c.Create(context.TODO(), "duplicate", false, "monaco-external-id", []byte(`{}`), documents.Dashboard)
+	assert.Error(t, err, "documents with the same external ID must be rejected")
+
+	_, err = c.Update(context.TODO(), md.ID, "renamed", true, []byte(`{"tiles":[{}]}`), documents.Dashboard)
+	require.NoError(t, err)
+
+	doc, err := c.Get(context.TODO(), md.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", doc.Name)
+	assert.True(t, doc.IsPrivate)
+	assert.Greater(t, doc.Version, md.Version)
+	assert.JSONEq(t, `{"tiles":[{}]}`, string(doc.Data))
+
+	list, err := c.List(context.TODO(), "type=='dashboard' and externalId=='monaco-external-id'")
+	require.NoError(t, err)
+	require.Len(t, list.Responses, 1)
+	assert.Equal(t, md.ID, list.Responses[0].ID)
+
+	list, err = c.List(context.TODO(), "type=='notebook'")
+	require.NoError(t, err)
+	assert.Empty(t, list.Responses)
+
+	_, err = c.Delete(context.TODO(), md.ID)
+	require.NoError(t, err)
+	_, err = c.Get(context.TODO(), md.ID)
+	assert.Error(t, err)
+	assert.Empty(t, s.state.Documents, "deleted documents must be removed from the trash")
+}
@@ -0,0 +1,161 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// filter is a parsed filter expression of the document API, e.g. "type=='dashboard' and name contains 'a'". It is a
+// disjunction of conjunctions of comparisons; parentheses are not supported.
+type filter [][]comparison
+
+type comparison struct {
+	field    string
+	operator string
+	value    string
+}
+
+var filterOperators = []string{"==", "!=", ">=", "<=", "=", ">", "<", "contains", "starts-with"}
+
+// parseFilter parses a filter expression. An empty expression matches everything.
+func parseFilter(expression string) (filter, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	var f filter
+	var conjunction []comparison
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete comparison %q", strings.Join(tokens, " "))
+		}
+		c := comparison{field: tokens[0], operator: tokens[1], value: tokens[2]}
+		if !slices.Contains(filterOperators, c.operator) {
+			return nil, fmt.Errorf("unknown operator %q", c.operator)
+		}
+		conjunction = append(conjunction, c)
+		tokens = tokens[3:]
+
+		if len(tokens) == 0 {
+			break
+		}
+		switch strings.ToLower(tokens[0]) {
+		case "and":
+		case "or":
+			f = append(f, conjunction)
+			conjunction = nil
+		default:
+			return nil, fmt.Errorf("expected 'and' or 'or', but got %q", tokens[0])
+		}
+		tokens = tokens[1:]
+		if len(tokens) == 0 {
+			return nil, errors.New("expression ends with a logical operator")
+		}
+	}
+	if len(conjunction) > 0 {
+		f = append(f, conjunction)
+	}
+	return f, nil
+}
+
+// tokenize splits an expression into field names, operators, logical operators and (unquoted) values.
+func tokenize(expression string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expression); {
+		switch c := expression[i]; {
+		case c == ' ':
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expression[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, expression[i+1:i+1+end])
+			i += end + 2
+		case strings.ContainsRune("=!<>", rune(c)):
+			j := i + 1
+			if j < len(expression) && expression[j] == '=' {
+				j++
+			}
+			tokens = append(tokens, expression[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(expression) && !strings.ContainsRune(" =!<>'\"", rune(expression[j])) {
+				j++
+			}
+			tokens = append(tokens, expression[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// matches returns whether the given metadata matches the filter. Fields of nested objects are separated by ".".
+func (f filter) matches(metadata map[string]any) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, conjunction := range f {
+		if matchesAll(conjunction, metadata) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAll(comparisons []comparison, metadata map[string]any) bool {
+	for _, c := range comparisons {
+		if !c.matches(metadata) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c comparison) matches(metadata map[string]any) bool {
+	var actual string
+	if v := lookup(metadata, strings.ReplaceAll(c.field, ".", "/")); v != nil {
+		actual = fmt.Sprint(v)
+	}
+
+	// values are compared as strings, which is correct for the ISO timestamps used by the API
+	switch c.operator {
+	case "==", "=":
+		return actual == c.value
+	case "!=":
+		return actual != c.value
+	case ">":
+		return actual > c.value
+	case ">=":
+		return actual >= c.value
+	case "<":
+		return actual < c.value
+	case "<=":
+		return actual <= c.value
+	case "contains":
+		return strings.Contains(actual, c.value)
+	case "starts-with":
+		return strings.HasPrefix(actual, c.value)
+	}
+	return false
+}
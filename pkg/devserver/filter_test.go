@@ -0,0 +1,71 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	metadata := map[string]any{
+		"type":             "dashboard",
+		"name":             "My dashboard",
+		"modificationInfo": map[string]any{"lastModifiedTime": "2025-01-02T00:00:00.000Z"},
+	}
+
+	tests := []struct {
+		expression string
+		matches    bool
+	}{
+		{"", true},
+		{"type=='dashboard'", true},
+		{"type == \"notebook\"", false},
+		{"type='notebook' or type='dashboard'", true},
+		{"type=='dashboard' and name contains 'board'", true},
+		{"type=='dashboard' and name starts-with 'Other'", false},
+		{"modificationInfo.lastModifiedTime>'2025-01-01T00:00:00.000Z'", true},
+		{"modificationInfo.lastModifiedTime<='2025-01-01T00:00:00.000Z'", false},
+		{"owner!='someone'", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			f, err := parseFilter(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, f.matches(metadata))
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, expression := range []string{
+		"type==",
+		"type=='dashboard' and",
+		"type=='dashboard' xor name=='a'",
+		"type like 'a'",
+		"type=='unterminated",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := parseFilter(expression)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// resourceType describes a platform resource with a JSON API of the usual shape: objects are listed and created using
+// the path of the resource, and read, updated and deleted using the path of the object.
+type resourceType struct {
+	path string
+	// idProperty is the property holding the ID of objects
+	idProperty string
+	// listProperty is the property of the list response holding the objects
+	listProperty string
+	// requireID is set for resources whose ID must be part of the payload on creation. For all other resources, an ID
+	// is generated unless the payload contains one.
+	requireID bool
+	// versioned resources are updated and deleted with optimistic locking, their version increases with every update
+	versioned bool
+	// stringVersion is set for resources whose version is a string
+	stringVersion bool
+	// defaults are added to all objects not defining them
+	defaults map[string]any
+	// countProperty is the property of the list response holding the total count of objects
+	countProperty string
+}
+
+var resourceTypes = []resourceType{
+	{path: "/platform/automation/v1/workflows", idProperty: "id", listProperty: "results", countProperty: "count"},
+	{path: "/platform/automation/v1/business-calendars", idProperty: "id", listProperty: "results", countProperty: "count"},
+	{path: "/platform/automation/v1/scheduling-rules", idProperty: "id", listProperty: "results", countProperty: "count"},
+	{
+		path:          "/platform/storage/management/v1/bucket-definitions",
+		idProperty:    "bucketName",
+		listProperty:  "buckets",
+		countProperty: "totalCount",
+		requireID:     true,
+		versioned:     true,
+		// buckets become active immediately, so that clients waiting for them don't have to
+		defaults: map[string]any{"status": "active"},
+	},
+	{
+		path:          "/platform/storage/filter-segments/v1/filter-segments",
+		idProperty:    "uid",
+		listProperty:  "filterSegments",
+		countProperty: "totalCount",
+		versioned:     true,
+		defaults:      map[string]any{"owner": owner},
+	},
+	{
+		path:          "/platform/slo/v1/slos",
+		idProperty:    "id",
+		listProperty:  "slos",
+		countProperty: "totalCount",
+		versioned:     true,
+		stringVersion: true,
+	},
+}
+
+func (s *Server) registerResources() {
+	for _, t := range resourceTypes {
+		s.mux.HandleFunc("GET "+t.path, func(rw http.ResponseWriter, req *http.Request) { s.listResources(rw, req, t) })
+		s.mux.HandleFunc("POST "+t.path, func(rw http.ResponseWriter, req *http.Request) { s.createResource(rw, req, t) })
+		s.mux.HandleFunc("GET "+t.path+"/{id}", func(rw http.ResponseWriter, req *http.Request) { s.getResource(rw, req, t) })
+		s.mux.HandleFunc("PUT "+t.path+"/{id}", func(rw http.ResponseWriter, req *http.Request) { s.updateResource(rw, req, t) })
+		s.mux.HandleFunc("DELETE "+t.path+"/{id}", func(rw http.ResponseWriter, req *http.Request) { s.deleteResource(rw, req, t) })
+	}
+}
+
+// listResources lists all objects of the resource. Objects are skipped up to the offset query parameter, if given.
+func (s *Server) listResources(rw http.ResponseWriter, req *http.Request, t resourceType) {
+	objects := s.state.Resources[t.path]
+	offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+	if offset > len(objects) {
+		offset = len(objects)
+	}
+
+	page := make([]map[string]any, 0, len(objects)-offset)
+	page = append(page, objects[offset:]...)
+	writeJSON(rw, http.StatusOK, map[string]any{t.listProperty: page, t.countProperty: len(objects)})
+}
+
+func (s *Server) createResource(rw http.ResponseWriter, req *http.Request, t resourceType) {
+	object, ok := readJSONObject(rw, req)
+	if !ok {
+		return
+	}
+
+	id, _ := object[t.idProperty].(string)
+	if id == "" {
+		if t.requireID {
+			writeError(rw, http.StatusBadRequest, "%s is required", t.idProperty)
+			return
+		}
+		id = newID("")
+	}
+	if s.state.findResource(t, id) >= 0 {
+		writeError(rw, http.StatusConflict, "%s %q already exists", t.idProperty, id)
+		return
+	}
+
+	object[t.idProperty] = id
+	for k, v := range t.defaults {
+		if _, ok := object[k]; !ok {
+			object[k] = v
+		}
+	}
+	if t.versioned {
+		object["version"] = t.version(1)
+	}
+
+	s.state.Resources[t.path] = append(s.state.Resources[t.path], object)
+	writeJSON(rw, http.StatusCreated, object)
+}
+
+func (s *Server) getResource(rw http.ResponseWriter, req *http.Request, t resourceType) {
+	i, ok := s.findResource(rw, req, t)
+	if !ok {
+		return
+	}
+	writeJSON(rw, http.StatusOK, s.state.Resources[t.path][i])
+}
+
+// updateResource replaces an object. Properties of the defaults which are not part of the payload are kept.
+func (s *Server) updateResource(rw http.ResponseWriter, req *http.Request, t resourceType) {
+	i, ok := s.findResource(rw, req, t)
+	if !ok {
+		return
+	}
+	current := s.state.Resources[t.path][i]
+	if t.versioned && !checkVersion(rw, req, fmt.Sprint(current["version"])) {
+		return
+	}
+
+	object, ok := readJSONObject(rw, req)
+	if !ok {
+		return
+	}
+
+	object[t.idProperty] = current[t.idProperty]
+	for k := range t.defaults {
+		if _, ok := object[k]; !ok {
+			object[k] = current[k]
+		}
+	}
+	if t.versioned {
+		v, _ := strconv.Atoi(fmt.Sprint(current["version"]))
+		object["version"] = t.version(v + 1)
+	}
+
+	s.state.Resources[t.path][i] = object
+	writeJSON(rw, http.StatusOK, object)
+}
+
+func (s *Server) deleteResource(rw http.ResponseWriter, req *http.Request, t resourceType) {
+	i, ok := s.findResource(rw, req, t)
+	if !ok {
+		return
+	}
+	if t.versioned && !checkVersion(rw, req, fmt.Sprint(s.state.Resources[t.path][i]["version"])) {
+		return
+	}
+	s.state.Resources[t.path] = slices.Delete(s.state.Resources[t.path], i, i+1)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// findResource returns the index of the object with the ID of the request path. If there is none, an error is written
+// to the response.
+func (s *Server) findResource(rw http.ResponseWriter, req *http.Request, t resourceType) (int, bool) {
+	i := s.state.findResource(t, req.PathValue("id"))
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "%s %q not found", t.idProperty, req.PathValue("id"))
+		return 0, false
+	}
+	return i, true
+}
+
+func (s *state) findResource(t resourceType, id string) int {
+	return slices.IndexFunc(s.Resources[t.path], func(o map[string]any) bool { return o[t.idProperty] == id })
+}
+
+func (t resourceType) version(v int) any {
+	if t.stringVersion {
+		return strconv.Itoa(v)
+	}
+	return v
+}
@@ -0,0 +1,243 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package devserver implements a stateful fake of the Dynatrace APIs used by monaco. It can be used to develop and test
+// configurations locally: deploying, downloading and deleting configurations against it behaves like against a real
+// environment, without requiring one.
+//
+// The server emulates the classic config APIs, Settings 2.0 (including external IDs, ordering and unique-key
+// constraints), documents, automations, buckets, segments and SLOs. All objects are kept in memory and can optionally be
+// persisted to a file.
+//
+// Any token is accepted. OAuth clients retrieve their tokens from TokenPath, and the classic environment URL reported to
+// platform clients is the URL of the server itself.
+package devserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+)
+
+const (
+	// TokenPath is the path of the OAuth token endpoint of the server.
+	TokenPath = "/sso/oauth2/token"
+
+	// DefaultVersion is the Dynatrace version reported by the server if no other version is configured.
+	DefaultVersion = "1.310.0.20250101-000000"
+
+	classicEnvironmentDomainPath = "/platform/metadata/v1/classic-environment-domain"
+	versionPath                  = "/api/v1/config/clusterversion"
+	entitiesPath                 = "/api/v2/entities"
+)
+
+// Server is an [http.Handler] emulating a Dynatrace environment.
+type Server struct {
+	mux     *http.ServeMux
+	version string
+
+	// lock guards the state; a single lock is used as the server is not meant to be fast, but consistent
+	lock  sync.Mutex
+	state *state
+
+	fs          afero.Fs
+	storagePath string
+	schemas     []Schema
+}
+
+// WithStorageFile persists the state of the server to the given file. If the file exists, the state is loaded from it
+// when the server is created, and it is rewritten after every modification.
+func WithStorageFile(fs afero.Fs, path string) func(*Server) {
+	return func(s *Server) {
+		s.fs = fs
+		s.storagePath = path
+	}
+}
+
+// WithSchemas defines Settings 2.0 schemas. Objects of schemas that are not defined are accepted as well, but their
+// schema is neither ordered nor has any unique-key constraints.
+func WithSchemas(schemas ...Schema) func(*Server) {
+	return func(s *Server) {
+		s.schemas = append(s.schemas, schemas...)
+	}
+}
+
+// WithVersion sets the Dynatrace version reported by the server, in the format MAJOR.MINOR.PATCH.DATE.
+func WithVersion(version string) func(*Server) {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// New creates a server with an empty state, or the state persisted in its storage file.
+func New(opts ...func(*Server)) (*Server, error) {
+	s := &Server{
+		mux:     http.NewServeMux(),
+		version: DefaultVersion,
+		state:   newState(),
+	}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	if s.storagePath != "" {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	// schemas given as options take precedence over persisted ones
+	for _, schema := range s.schemas {
+		s.state.Schemas[schema.SchemaID] = schema
+	}
+
+	s.mux.HandleFunc("POST "+TokenPath, s.handleToken)
+	s.mux.HandleFunc("GET "+classicEnvironmentDomainPath, s.handleClassicEnvironmentDomain)
+	s.mux.HandleFunc("GET "+versionPath, s.handleVersion)
+	s.mux.HandleFunc("GET "+entitiesPath, s.handleEntities)
+	s.registerSettings()
+	s.registerDocuments()
+	s.registerResources()
+	s.mux.HandleFunc("/", s.handleClassic)
+
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != TokenPath && req.Header.Get("Authorization") == "" {
+		writeError(rw, http.StatusUnauthorized, "missing authorization")
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	rec := &statusRecorder{ResponseWriter: rw}
+	s.mux.ServeHTTP(rec, req)
+
+	if req.Method != http.MethodGet && rec.status < 300 {
+		if err := s.save(); err != nil {
+			log.Error("Failed to persist state of dev server: %v", err)
+		}
+	}
+}
+
+func (s *Server) handleToken(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, map[string]any{
+		"access_token": "dev-server-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// handleClassicEnvironmentDomain reports the server itself as the classic environment, so that platform and classic
+// clients of the same environment share the same state.
+func (s *Server) handleClassicEnvironmentDomain(rw http.ResponseWriter, req *http.Request) {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	writeJSON(rw, http.StatusOK, map[string]string{"domain": scheme + "://" + req.Host})
+}
+
+func (s *Server) handleVersion(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, map[string]string{"version": s.version})
+}
+
+// handleEntities lists monitored entities. As the server does not monitor anything, there are none.
+func (s *Server) handleEntities(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, map[string]any{"entities": []any{}, "totalCount": 0})
+}
+
+func (s *Server) load() error {
+	data, err := afero.ReadFile(s.fs, s.storagePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state of dev server from %q: %w", s.storagePath, err)
+	}
+
+	loaded := newState()
+	if err := json.Unmarshal(data, loaded); err != nil {
+		return fmt.Errorf("failed to parse state of dev server from %q: %w", s.storagePath, err)
+	}
+	loaded.init()
+	s.state = loaded
+	return nil
+}
+
+func (s *Server) save() error {
+	if s.storagePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(s.fs, s.storagePath, data, 0644)
+}
+
+// statusRecorder remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func writeJSON(rw http.ResponseWriter, status int, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, "%s", err)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write(data)
+}
+
+// writeError writes an error in the format of the Dynatrace APIs.
+func writeError(rw http.ResponseWriter, status int, format string, args ...any) {
+	data, _ := json.Marshal(map[string]any{
+		"error": map[string]any{
+			"code":    status,
+			"message": fmt.Sprintf(format, args...),
+		},
+	})
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write(data)
+}
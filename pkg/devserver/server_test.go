@@ -0,0 +1,167 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func authorized(req *http.Request) *http.Request {
+	req.Header.Set("Authorization", "Api-Token token")
+	return req
+}
+
+func jsonBody(body string) io.Reader {
+	return strings.NewReader(body)
+}
+
+// newClientSet starts the server and creates the clients monaco uses for an environment with token and platform auth.
+func newClientSet(t *testing.T, s *Server) *client.ClientSet {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	clients, err := client.CreateClientSet(context.TODO(), srv.URL, manifest.Auth{
+		Token:         &manifest.AuthSecret{Value: secret.MaskedString("token")},
+		PlatformToken: &manifest.AuthSecret{Value: secret.MaskedString("platform-token")},
+	})
+	require.NoError(t, err)
+	return clients
+}
+
+func TestServer_RequiresAuthorization(t *testing.T) {
+	s, err := New()
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, versionPath, nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, TokenPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_ReportsVersion(t *testing.T) {
+	s, err := New(WithVersion("1.300.1.20240101-000000"))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodGet, versionPath, nil)))
+	assert.JSONEq(t, `{"version":"1.300.1.20240101-000000"}`, rec.Body.String())
+}
+
+func TestServer_ClassicConfigRoundTrip(t *testing.T) {
+	s, err := New()
+	require.NoError(t, err)
+	c := newClientSet(t, s).ConfigClient
+	a := api.NewAPIs()[api.AlertingProfile]
+
+	created, err := c.UpsertByName(context.TODO(), a, "profile", []byte(`{"name":"profile","rules":[]}`))
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Id)
+
+	updated, err := c.UpsertByName(context.TODO(), a, "profile", []byte(`{"name":"profile","rules":[{}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, created.Id, updated.Id)
+
+	values, err := c.List(context.TODO(), a)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, "profile", values[0].Name)
+
+	data, err := c.Get(context.TODO(), a, created.Id)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"`+created.Id+`","name":"profile","rules":[{}]}`, string(data))
+
+	require.NoError(t, c.Delete(context.TODO(), a, created.Id))
+	c.ClearCache()
+	values, err = c.List(context.TODO(), a)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestServer_ResourcesRoundTrip(t *testing.T) {
+	s, err := New()
+	require.NoError(t, err)
+	c := newClientSet(t, s).BucketClient
+
+	_, err = c.Upsert(context.TODO(), "my_bucket", []byte(`{"bucketName":"my_bucket","table":"logs","retentionDays":35}`))
+	require.NoError(t, err)
+	_, err = c.Upsert(context.TODO(), "my_bucket", []byte(`{"bucketName":"my_bucket","table":"logs","retentionDays":40}`))
+	require.NoError(t, err)
+
+	resp, err := c.Get(context.TODO(), "my_bucket")
+	require.NoError(t, err)
+	var bucket map[string]any
+	require.NoError(t, json.Unmarshal(resp.Data, &bucket))
+	assert.Equal(t, float64(40), bucket["retentionDays"])
+	assert.Equal(t, float64(2), bucket["version"])
+	assert.Equal(t, "active", bucket["status"])
+
+	_, err = c.Delete(context.TODO(), "my_bucket")
+	require.NoError(t, err)
+	list, err := c.List(context.TODO())
+	require.NoError(t, err)
+	assert.Empty(t, list.All())
+}
+
+func TestServer_PersistsState(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s, err := New(WithStorageFile(fs, "state.json"), WithSchemas(Schema{SchemaID: "builtin:ordered", Ordered: true}))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, settingsPathClassic+"/objects",
+		jsonBody(`[{"schemaId":"builtin:ordered","scope":"environment","value":{"name":"a"}}]`))))
+	require.Equal(t, http.StatusOK, rec.Code)
+	exists, err := afero.Exists(fs, "state.json")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	restarted, err := New(WithStorageFile(fs, "state.json"))
+	require.NoError(t, err)
+	require.Len(t, restarted.state.Settings, 1)
+	assert.Equal(t, s.state.Settings[0].ObjectID, restarted.state.Settings[0].ObjectID)
+	assert.JSONEq(t, `{"name":"a"}`, string(restarted.state.Settings[0].Value))
+	assert.True(t, restarted.schema("builtin:ordered").Ordered)
+}
+
+func TestNew_FailsOnInvalidStorageFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "state.json", []byte("not json"), 0644))
+
+	_, err := New(WithStorageFile(fs, "state.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,484 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	settingsPathClassic  = "/api/v2/settings"
+	settingsPathPlatform = "/platform/classic/environment-api/v2/settings"
+
+	defaultSettingsPageSize = 100
+	maxSettingsPageSize     = 500
+)
+
+// Schema defines the properties of a Settings 2.0 schema relevant for deploying objects of it.
+type Schema struct {
+	SchemaID string `json:"schemaId"`
+	// Ordered schemas keep their objects in a user-defined order, objects can be inserted after other objects.
+	Ordered bool `json:"ordered,omitempty"`
+	// UniqueProperties are sets of properties whose combined values must be unique in the scope of an object.
+	// Properties of nested objects are separated by "/".
+	UniqueProperties [][]string `json:"uniqueProperties,omitempty"`
+	// OwnerBasedAccessControl allows granting permissions for the objects of the schema to all users.
+	OwnerBasedAccessControl bool `json:"ownerBasedAccessControl,omitempty"`
+}
+
+// settingsObject is a stored Settings 2.0 object.
+type settingsObject struct {
+	ObjectID      string          `json:"objectId"`
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion,omitempty"`
+	Scope         string          `json:"scope"`
+	ExternalID    string          `json:"externalId,omitempty"`
+	Value         json.RawMessage `json:"value"`
+	Modified      int64           `json:"modified"`
+}
+
+// settingsRequest is a single object of the payload of a POST request.
+type settingsRequest struct {
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Scope         string          `json:"scope"`
+	ExternalID    string          `json:"externalId"`
+	ObjectID      string          `json:"objectId"`
+	InsertAfter   *string         `json:"insertAfter"`
+	Value         json.RawMessage `json:"value"`
+}
+
+// settingsError is the error of a single object of a POST request.
+type settingsError struct {
+	code    int
+	message string
+}
+
+func (s *Server) registerSettings() {
+	for _, prefix := range []string{settingsPathClassic, settingsPathPlatform} {
+		s.mux.HandleFunc("GET "+prefix+"/schemas", s.handleListSchemas)
+		s.mux.HandleFunc("GET "+prefix+"/schemas/{schemaId}", s.handleGetSchema)
+		s.mux.HandleFunc("GET "+prefix+"/objects", s.handleListSettings)
+		s.mux.HandleFunc("POST "+prefix+"/objects", s.handlePostSettings)
+		s.mux.HandleFunc("GET "+prefix+"/objects/{objectId}", s.handleGetSettingsObject)
+		s.mux.HandleFunc("DELETE "+prefix+"/objects/{objectId}", s.handleDeleteSettingsObject)
+	}
+
+	// permissions are only available for platform clients
+	s.mux.HandleFunc("POST "+settingsPathPlatform+"/objects/{objectId}/permissions", s.handlePutPermission)
+	s.mux.HandleFunc("GET "+settingsPathPlatform+"/objects/{objectId}/permissions/all-users", s.handleGetPermission)
+	s.mux.HandleFunc("PUT "+settingsPathPlatform+"/objects/{objectId}/permissions/all-users", s.handlePutPermission)
+	s.mux.HandleFunc("DELETE "+settingsPathPlatform+"/objects/{objectId}/permissions/all-users", s.handleDeletePermission)
+}
+
+// schema returns the defined schema with the given ID. Undefined schemas are neither ordered nor have constraints.
+func (s *Server) schema(schemaID string) Schema {
+	if schema, ok := s.state.Schemas[schemaID]; ok {
+		return schema
+	}
+	return Schema{SchemaID: schemaID}
+}
+
+// handleListSchemas lists all defined schemas, and the schemas of all stored objects.
+func (s *Server) handleListSchemas(rw http.ResponseWriter, _ *http.Request) {
+	ids := make(map[string]struct{})
+	for id := range s.state.Schemas {
+		ids[id] = struct{}{}
+	}
+	for _, o := range s.state.Settings {
+		ids[o.SchemaID] = struct{}{}
+	}
+
+	items := make([]map[string]any, 0, len(ids))
+	for id := range ids {
+		schema := s.schema(id)
+		items = append(items, map[string]any{"schemaId": id, "ordered": schema.Ordered})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i]["schemaId"].(string) < items[j]["schemaId"].(string) })
+
+	writeJSON(rw, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+}
+
+func (s *Server) handleGetSchema(rw http.ResponseWriter, req *http.Request) {
+	schema := s.schema(req.PathValue("schemaId"))
+
+	constraints := make([]map[string]any, 0, len(schema.UniqueProperties))
+	for _, properties := range schema.UniqueProperties {
+		constraints = append(constraints, map[string]any{"type": "UNIQUE", "uniqueProperties": properties})
+	}
+
+	writeJSON(rw, http.StatusOK, map[string]any{
+		"schemaId":                schema.SchemaID,
+		"ordered":                 schema.Ordered,
+		"schemaConstraints":       constraints,
+		"ownerBasedAccessControl": schema.OwnerBasedAccessControl,
+	})
+}
+
+// handleListSettings lists the objects of the requested schemas and scopes. Like the real API, the next page key is
+// the only query parameter of requests for further pages, hence it encodes all other parameters.
+func (s *Server) handleListSettings(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	if key := query.Get("nextPageKey"); key != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(key)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "invalid next page key")
+			return
+		}
+		if query, err = url.ParseQuery(string(decoded)); err != nil {
+			writeError(rw, http.StatusBadRequest, "invalid next page key")
+			return
+		}
+	}
+
+	pageSize := defaultSettingsPageSize
+	if v := query.Get("pageSize"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 || size > maxSettingsPageSize {
+			writeError(rw, http.StatusBadRequest, "pageSize must be between 1 and %d", maxSettingsPageSize)
+			return
+		}
+		pageSize = size
+	}
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	schemaIDs := splitList(query.Get("schemaIds"))
+	scopes := splitList(query.Get("scopes"))
+	var matching []settingsObject
+	for _, o := range s.state.Settings {
+		if (len(schemaIDs) == 0 || slices.Contains(schemaIDs, o.SchemaID)) && (len(scopes) == 0 || slices.Contains(scopes, o.Scope)) {
+			matching = append(matching, o)
+		}
+	}
+
+	fields := splitList(query.Get("fields"))
+	items := make([]map[string]any, 0, pageSize)
+	for i := offset; i < len(matching) && i < offset+pageSize; i++ {
+		items = append(items, s.settingsListItem(matching[i], fields))
+	}
+
+	response := map[string]any{"items": items, "totalCount": len(matching), "pageSize": pageSize}
+	if offset+pageSize < len(matching) {
+		query.Set("offset", strconv.Itoa(offset+pageSize))
+		response["nextPageKey"] = base64.RawURLEncoding.EncodeToString([]byte(query.Encode()))
+	}
+	writeJSON(rw, http.StatusOK, response)
+}
+
+// settingsListItem returns the given fields of the object. If no fields are given, all fields are returned.
+func (s *Server) settingsListItem(o settingsObject, fields []string) map[string]any {
+	item := map[string]any{
+		"objectId":      o.ObjectID,
+		"schemaId":      o.SchemaID,
+		"schemaVersion": o.SchemaVersion,
+		"scope":         o.Scope,
+		"externalId":    o.ExternalID,
+		"value":         o.Value,
+		"modified":      o.Modified,
+		"resourceContext": map[string]any{
+			"operations":            []string{"read", "write", "delete"},
+			"modifications:movable": s.schema(o.SchemaID).Ordered,
+		},
+	}
+	if len(fields) == 0 {
+		return item
+	}
+
+	for k := range item {
+		// the resource context is returned as part of the modification info
+		if k != "objectId" && !slices.Contains(fields, k) && (k != "resourceContext" || !slices.Contains(fields, "modificationInfo")) {
+			delete(item, k)
+		}
+	}
+	return item
+}
+
+func (s *Server) handleGetSettingsObject(rw http.ResponseWriter, req *http.Request) {
+	i := s.state.findSettingsObject(req.PathValue("objectId"))
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "settings object %q not found", req.PathValue("objectId"))
+		return
+	}
+	writeJSON(rw, http.StatusOK, s.settingsListItem(s.state.Settings[i], nil))
+}
+
+func (s *Server) handleDeleteSettingsObject(rw http.ResponseWriter, req *http.Request) {
+	id := req.PathValue("objectId")
+	i := s.state.findSettingsObject(id)
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "settings object %q not found", id)
+		return
+	}
+	s.state.Settings = slices.Delete(s.state.Settings, i, i+1)
+	delete(s.state.Permissions, id)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handlePostSettings creates or updates all objects of the payload. Each object is handled on its own, and the
+// response contains the result of each object. Like the real API, the response status is 200 if all objects
+// succeeded, 400 if all of them failed, and 207 otherwise.
+func (s *Server) handlePostSettings(rw http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "failed to read body: %v", err)
+		return
+	}
+
+	var requests []settingsRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		writeError(rw, http.StatusBadRequest, "body is not a list of settings objects: %v", err)
+		return
+	}
+
+	results := make([]map[string]any, len(requests))
+	failed := 0
+	for i, r := range requests {
+		objectID, sErr := s.upsertSettingsObject(r)
+		if sErr != nil {
+			failed++
+			results[i] = map[string]any{
+				"code":  sErr.code,
+				"error": map[string]any{"code": sErr.code, "message": sErr.message},
+			}
+			continue
+		}
+		results[i] = map[string]any{"code": http.StatusOK, "objectId": objectID}
+	}
+
+	switch {
+	case failed == 0:
+		writeJSON(rw, http.StatusOK, results)
+	case failed == len(requests):
+		writeJSON(rw, http.StatusBadRequest, results)
+	default:
+		writeJSON(rw, http.StatusMultiStatus, results)
+	}
+}
+
+// upsertSettingsObject creates or updates a single object. The object to update is identified by its object ID, or
+// its external ID.
+func (s *Server) upsertSettingsObject(r settingsRequest) (string, *settingsError) {
+	if r.SchemaID == "" || r.Scope == "" {
+		return "", &settingsError{http.StatusBadRequest, "schemaId and scope are required"}
+	}
+	var value map[string]any
+	if err := json.Unmarshal(r.Value, &value); err != nil || value == nil {
+		return "", &settingsError{http.StatusBadRequest, "value is not a JSON object"}
+	}
+
+	schema := s.schema(r.SchemaID)
+	if r.InsertAfter != nil && !schema.Ordered {
+		return "", &settingsError{http.StatusBadRequest, fmt.Sprintf("schema %q is not ordered, hence insertAfter is not supported", r.SchemaID)}
+	}
+
+	target := -1
+	if r.ObjectID != "" {
+		if target = s.state.findSettingsObject(r.ObjectID); target < 0 {
+			return "", &settingsError{http.StatusNotFound, fmt.Sprintf("settings object %q not found", r.ObjectID)}
+		}
+	}
+	if r.ExternalID != "" {
+		for i, o := range s.state.Settings {
+			if o.SchemaID != r.SchemaID || o.ExternalID != r.ExternalID || i == target {
+				continue
+			}
+			if target >= 0 {
+				return "", &settingsError{http.StatusBadRequest, fmt.Sprintf("externalId %q is already used by object %q", r.ExternalID, o.ObjectID)}
+			}
+			target = i
+		}
+	}
+
+	scope := r.Scope
+	if target >= 0 {
+		// the scope of existing objects can not be changed
+		scope = s.state.Settings[target].Scope
+	}
+	if err := s.checkUniqueConstraints(schema, scope, value, target); err != nil {
+		return "", err
+	}
+
+	o := settingsObject{
+		ObjectID:      newID(""),
+		SchemaID:      r.SchemaID,
+		SchemaVersion: r.SchemaVersion,
+		Scope:         scope,
+		ExternalID:    r.ExternalID,
+		Value:         r.Value,
+		Modified:      time.Now().UnixMilli(),
+	}
+
+	if target < 0 {
+		position, err := s.insertPosition(o, r.InsertAfter)
+		if err != nil {
+			return "", err
+		}
+		s.state.Settings = slices.Insert(s.state.Settings, position, o)
+		return o.ObjectID, nil
+	}
+
+	o.ObjectID = s.state.Settings[target].ObjectID
+	if o.ExternalID == "" {
+		o.ExternalID = s.state.Settings[target].ExternalID
+	}
+	if r.InsertAfter == nil {
+		s.state.Settings[target] = o
+		return o.ObjectID, nil
+	}
+
+	// moved objects are removed first, so that their new position is computed without them
+	previous := slices.Clone(s.state.Settings)
+	s.state.Settings = slices.Delete(s.state.Settings, target, target+1)
+	position, err := s.insertPosition(o, r.InsertAfter)
+	if err != nil {
+		s.state.Settings = previous
+		return "", err
+	}
+	s.state.Settings = slices.Insert(s.state.Settings, position, o)
+	return o.ObjectID, nil
+}
+
+// checkUniqueConstraints returns an error if an object other than the updated one has the same values for any set of
+// unique properties of the schema.
+func (s *Server) checkUniqueConstraints(schema Schema, scope string, value map[string]any, updated int) *settingsError {
+	for _, properties := range schema.UniqueProperties {
+		for i, o := range s.state.Settings {
+			if i == updated || o.SchemaID != schema.SchemaID || o.Scope != scope {
+				continue
+			}
+
+			var other map[string]any
+			if err := json.Unmarshal(o.Value, &other); err != nil {
+				continue
+			}
+			if sameValues(properties, value, other) {
+				return &settingsError{http.StatusBadRequest, fmt.Sprintf("unique constraint %v violated by object %q", properties, o.ObjectID)}
+			}
+		}
+	}
+	return nil
+}
+
+func sameValues(properties []string, a, b map[string]any) bool {
+	for _, p := range properties {
+		v1, v2 := lookup(a, p), lookup(b, p)
+		if v1 == nil || v2 == nil || !reflect.DeepEqual(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the value of a property. Properties of nested objects are separated by "/".
+func lookup(m map[string]any, property string) any {
+	var current any = m
+	for _, key := range strings.Split(property, "/") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = obj[key]
+	}
+	return current
+}
+
+// insertPosition returns the index at which the object is inserted into the stored objects. Objects are added to the
+// back of their schema and scope, unless they are inserted after another object, or to the front if insertAfter is
+// empty.
+func (s *Server) insertPosition(o settingsObject, insertAfter *string) (int, *settingsError) {
+	if insertAfter == nil {
+		return len(s.state.Settings), nil
+	}
+
+	if *insertAfter == "" {
+		for i, other := range s.state.Settings {
+			if other.SchemaID == o.SchemaID && other.Scope == o.Scope {
+				return i, nil
+			}
+		}
+		return len(s.state.Settings), nil
+	}
+
+	i := s.state.findSettingsObject(*insertAfter)
+	if i < 0 || s.state.Settings[i].SchemaID != o.SchemaID || s.state.Settings[i].Scope != o.Scope {
+		return 0, &settingsError{http.StatusBadRequest, fmt.Sprintf("object %q to insert after does not exist in the same schema and scope", *insertAfter)}
+	}
+	return i + 1, nil
+}
+
+func (s *Server) handleGetPermission(rw http.ResponseWriter, req *http.Request) {
+	permission, ok := s.state.Permissions[req.PathValue("objectId")]
+	if !ok {
+		writeError(rw, http.StatusNotFound, "no permissions for all users defined")
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(permission)
+}
+
+func (s *Server) handlePutPermission(rw http.ResponseWriter, req *http.Request) {
+	id := req.PathValue("objectId")
+	i := s.state.findSettingsObject(id)
+	if i < 0 {
+		writeError(rw, http.StatusNotFound, "settings object %q not found", id)
+		return
+	}
+	if !s.schema(s.state.Settings[i].SchemaID).OwnerBasedAccessControl {
+		writeError(rw, http.StatusBadRequest, "schema %q does not support owner-based access control", s.state.Settings[i].SchemaID)
+		return
+	}
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil || !json.Valid(data) {
+		writeError(rw, http.StatusBadRequest, "body is not valid JSON")
+		return
+	}
+	s.state.Permissions[id] = data
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeletePermission(rw http.ResponseWriter, req *http.Request) {
+	id := req.PathValue("objectId")
+	if _, ok := s.state.Permissions[id]; !ok {
+		writeError(rw, http.StatusNotFound, "no permissions for all users defined")
+		return
+	}
+	delete(s.state.Permissions, id)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *state) findSettingsObject(objectID string) int {
+	return slices.IndexFunc(s.Settings, func(o settingsObject) bool { return o.ObjectID == objectID })
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
@@ -0,0 +1,158 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+const (
+	orderedSchema = "builtin:ordered"
+	uniqueSchema  = "builtin:unique"
+)
+
+func newSettingsClient(t *testing.T) *dtclient.SettingsClient {
+	t.Helper()
+	s, err := New(WithSchemas(
+		Schema{SchemaID: orderedSchema, Ordered: true},
+		Schema{SchemaID: uniqueSchema, UniqueProperties: [][]string{{"name"}}},
+	))
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	c, err := dtclient.NewPlatformSettingsClientForTesting(srv.URL, client.NewPlatformTokenHTTPClient("token", nil), dtclient.WithCachingDisabled(true))
+	require.NoError(t, err)
+	return c
+}
+
+func upsertObject(configID string, schemaID string, value string) dtclient.SettingsObject {
+	return dtclient.SettingsObject{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: schemaID, ConfigId: configID},
+		SchemaId:   schemaID,
+		Scope:      "environment",
+		Content:    []byte(value),
+	}
+}
+
+func objectIDs(t *testing.T, c *dtclient.SettingsClient, schemaID string) []string {
+	t.Helper()
+	objects, err := c.List(context.TODO(), schemaID, dtclient.ListSettingsOptions{})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(objects))
+	for _, o := range objects {
+		ids = append(ids, o.ObjectId)
+	}
+	return ids
+}
+
+func TestSettings_UpsertIsIdempotentByExternalID(t *testing.T) {
+	c := newSettingsClient(t)
+
+	created, err := c.Upsert(context.TODO(), upsertObject("a", "builtin:any", `{"name":"a"}`), dtclient.UpsertSettingsOptions{})
+	require.NoError(t, err)
+	updated, err := c.Upsert(context.TODO(), upsertObject("a", "builtin:any", `{"name":"b"}`), dtclient.UpsertSettingsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, created.Id, updated.Id)
+
+	o, err := c.Get(context.TODO(), created.Id)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"b"}`, string(o.Value))
+	assert.NotEmpty(t, o.ExternalId)
+
+	require.NoError(t, c.Delete(context.TODO(), created.Id))
+	_, err = c.Get(context.TODO(), created.Id)
+	assert.Error(t, err)
+}
+
+func TestSettings_InsertAfter(t *testing.T) {
+	c := newSettingsClient(t)
+
+	a, err := c.Upsert(context.TODO(), upsertObject("a", orderedSchema, `{}`), dtclient.UpsertSettingsOptions{})
+	require.NoError(t, err)
+	front := dtclient.InsertPositionFront
+	b, err := c.Upsert(context.TODO(), upsertObject("b", orderedSchema, `{}`), dtclient.UpsertSettingsOptions{InsertAfter: &front})
+	require.NoError(t, err)
+	d, err := c.Upsert(context.TODO(), upsertObject("d", orderedSchema, `{}`), dtclient.UpsertSettingsOptions{})
+	require.NoError(t, err)
+	c2, err := c.Upsert(context.TODO(), upsertObject("c", orderedSchema, `{}`), dtclient.UpsertSettingsOptions{InsertAfter: &a.Id})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{b.Id, a.Id, c2.Id, d.Id}, objectIDs(t, c, orderedSchema))
+}
+
+func TestSettings_InsertAfterIsRejectedForUnorderedSchemas(t *testing.T) {
+	s, err := New()
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, settingsPathClassic+"/objects",
+		jsonBody(`[{"schemaId":"builtin:any","scope":"environment","insertAfter":"","value":{}}]`))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSettings_UniqueConstraintsAreEnforced(t *testing.T) {
+	s, err := New(WithSchemas(Schema{SchemaID: uniqueSchema, UniqueProperties: [][]string{{"name"}}}))
+	require.NoError(t, err)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, settingsPathClassic+"/objects", jsonBody(body))))
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, post(`[{"schemaId":"builtin:unique","scope":"environment","value":{"name":"a"}}]`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`[{"schemaId":"builtin:unique","scope":"environment","value":{"name":"a"}}]`).Code)
+	assert.Equal(t, http.StatusOK, post(`[{"schemaId":"builtin:unique","scope":"other","value":{"name":"a"}}]`).Code)
+
+	rec := post(`[{"schemaId":"builtin:unique","scope":"environment","value":{"name":"b"}},{"schemaId":"builtin:unique","scope":"environment","value":{"name":"a"}}]`)
+	assert.Equal(t, http.StatusMultiStatus, rec.Code)
+	var results []struct {
+		Code     int    `json:"code"`
+		ObjectID string `json:"objectId"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, http.StatusOK, results[0].Code)
+	assert.NotEmpty(t, results[0].ObjectID)
+	assert.Equal(t, http.StatusBadRequest, results[1].Code)
+}
+
+func TestSettings_UpsertFindsObjectsWithMatchingUniqueProperties(t *testing.T) {
+	c := newSettingsClient(t)
+
+	a, err := c.Upsert(context.TODO(), upsertObject("a", uniqueSchema, `{"name":"x","enabled":true}`), dtclient.UpsertSettingsOptions{})
+	require.NoError(t, err)
+	b, err := c.Upsert(context.TODO(), upsertObject("b", uniqueSchema, `{"name":"x","enabled":false}`), dtclient.UpsertSettingsOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, a.Id, b.Id)
+}
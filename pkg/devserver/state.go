@@ -0,0 +1,107 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// state holds all objects of the server. It is persisted as JSON, hence all fields are exported.
+type state struct {
+	// Classic holds the objects of classic config APIs per URL path of the API, with the IDs of parent objects applied.
+	Classic map[string][]classicObject `json:"classic"`
+
+	// Schemas holds the defined Settings 2.0 schemas by their ID.
+	Schemas map[string]Schema `json:"schemas"`
+
+	// Settings holds all Settings 2.0 objects. The objects of ordered schemas are kept in their order.
+	Settings []settingsObject `json:"settings"`
+
+	// Permissions holds the permissions of all users per settings object ID.
+	Permissions map[string]json.RawMessage `json:"permissions"`
+
+	Documents []document `json:"documents"`
+
+	// Resources holds the objects of platform resources (e.g. workflows, buckets) per URL path of the resource.
+	Resources map[string][]map[string]any `json:"resources"`
+}
+
+func newState() *state {
+	s := &state{}
+	s.init()
+	return s
+}
+
+// init initializes all fields that are missing, e.g. after loading a persisted state.
+func (s *state) init() {
+	if s.Classic == nil {
+		s.Classic = make(map[string][]classicObject)
+	}
+	if s.Schemas == nil {
+		s.Schemas = make(map[string]Schema)
+	}
+	if s.Permissions == nil {
+		s.Permissions = make(map[string]json.RawMessage)
+	}
+	if s.Resources == nil {
+		s.Resources = make(map[string][]map[string]any)
+	}
+}
+
+func (s *state) findClassicObject(collection string, id string) int {
+	for i, o := range s.Classic[collection] {
+		if o.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// readJSONObject reads the JSON object in the body of the request. If the body is not a JSON object, an error is
+// written to the response.
+func readJSONObject(rw http.ResponseWriter, req *http.Request) (map[string]any, bool) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "failed to read body: %v", err)
+		return nil, false
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil || value == nil {
+		writeError(rw, http.StatusBadRequest, "body is not a JSON object")
+		return nil, false
+	}
+	return value, true
+}
+
+// newID generates an ID. If a prefix is given, the ID has the format of monitored entity IDs, otherwise it is a UUID.
+func newID(prefix string) string {
+	if prefix == "" {
+		return uuid.NewString()
+	}
+
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return prefix + strings.ToUpper(hex.EncodeToString(b))
+}
@@ -118,6 +118,7 @@ func Download(ctx context.Context, cl client.AutomationClient, projectName strin
 				},
 				Parameters:     params,
 				OriginObjectId: obj.ID,
+				OriginModified: lastModifiedTime(obj.Data),
 			}
 			configs = append(configs, c)
 		}
@@ -126,6 +127,20 @@ func Download(ctx context.Context, cl client.AutomationClient, projectName strin
 	return configsPerType, nil
 }
 
+// lastModifiedTime returns the time of the last modification stored in the 'modificationInfo' of an automation
+// resource, or the zero time if it is not available.
+func lastModifiedTime(data []byte) time.Time {
+	var resource struct {
+		ModificationInfo struct {
+			LastModifiedTime time.Time `json:"lastModifiedTime"`
+		} `json:"modificationInfo"`
+	}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return time.Time{}
+	}
+	return resource.ModificationInfo.LastModifiedTime
+}
+
 func escapeJinjaTemplates(src []byte) ([]byte, error) {
 	var prettyJSON bytes.Buffer
 	err := json.Indent(&prettyJSON, src, "", "\t")
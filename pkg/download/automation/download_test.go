@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -196,3 +197,11 @@ func Test_createTemplateFromRawJSON(t *testing.T) {
 		})
 	}
 }
+
+func Test_lastModifiedTime(t *testing.T) {
+	data := []byte(`{"id": "42", "modificationInfo": {"lastModifiedBy": "user", "lastModifiedTime": "2023-04-20T13:56:03.165746Z"}}`)
+	assert.Equal(t, time.Date(2023, 4, 20, 13, 56, 3, 165746000, time.UTC), lastModifiedTime(data))
+
+	assert.True(t, lastModifiedTime([]byte(`{"id": "42"}`)).IsZero())
+	assert.True(t, lastModifiedTime([]byte(`not json`)).IsZero())
+}
@@ -0,0 +1,106 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package canonical brings the JSON templates of downloaded configurations into a canonical form, so that downloading
+// unchanged objects again results in identical templates.
+package canonical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// volatileProperties are top-level properties of all types that change without the configuration itself changing,
+// e.g. when an object was last modified.
+var volatileProperties = []string{"modificationInfo", "lastModified", "lastModifiedBy", "lastModifiedTime", "updateToken"}
+
+// volatilePropertiesByAPI are additional volatile top-level properties of classic config APIs.
+var volatilePropertiesByAPI = map[string][]string{
+	// changes depending on whether a monitor was last updated in the UI or via the API
+	api.SyntheticMonitor: {"createdFrom"},
+}
+
+// CanonicalizeTemplates removes volatile properties from the JSON templates of all given configs and formats them with
+// sorted object keys and a fixed indentation. The order of array elements is kept. Templates that are not valid JSON
+// are kept as they are. It modifies the given configsPerType map.
+func CanonicalizeTemplates(configsPerType project.ConfigsPerType) (project.ConfigsPerType, error) {
+	for _, cfgs := range configsPerType {
+		for _, c := range cfgs {
+			content, err := c.Template.Content()
+			if err != nil {
+				return nil, fmt.Errorf("failed to canonicalize template of %s: %w", c.Coordinate, err)
+			}
+
+			canonical, err := canonicalize(content, volatilePropertiesOf(c.Type))
+			if err != nil {
+				log.WithFields(field.Coordinate(c.Coordinate), field.Error(err)).Debug("Keeping template of %s as it is: %s", c.Coordinate, err)
+				continue
+			}
+			if err := c.Template.UpdateContent(canonical); err != nil {
+				return nil, fmt.Errorf("failed to canonicalize template of %s: %w", c.Coordinate, err)
+			}
+		}
+	}
+	return configsPerType, nil
+}
+
+func volatilePropertiesOf(t config.Type) []string {
+	if classic, ok := t.(config.ClassicApiType); ok {
+		return slices.Concat(volatileProperties, volatilePropertiesByAPI[classic.Api])
+	}
+	return volatileProperties
+}
+
+// canonicalize parses the JSON content, removes the given top-level properties and serializes it again. Template
+// actions are masked while doing so, and numbers are kept exactly as they were.
+func canonicalize(content string, removedProperties []string) (string, error) {
+	masker := template.NewActionMasker()
+
+	dec := json.NewDecoder(bytes.NewBufferString(masker.Mask(content)))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("template is not valid JSON: %w", err)
+	}
+	if dec.More() {
+		return "", fmt.Errorf("template is not valid JSON: unexpected content after top-level value")
+	}
+
+	if obj, ok := v.(map[string]any); ok {
+		for _, p := range removedProperties {
+			delete(obj, p)
+		}
+	}
+
+	// encoding/json writes object keys in sorted order
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", fmt.Errorf("failed to serialize template: %w", err)
+	}
+	return masker.Unmask(buf.String()), nil
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canonical
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func newConfig(typ config.Type, content string) config.Config {
+	return config.Config{
+		Template:   template.NewInMemoryTemplate("id", content),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: string(typ.ID()), ConfigId: "id"},
+		Type:       typ,
+	}
+}
+
+func content(t *testing.T, c config.Config) string {
+	t.Helper()
+	s, err := c.Template.Content()
+	require.NoError(t, err)
+	return s
+}
+
+func TestCanonicalizeTemplates(t *testing.T) {
+	configs := project.ConfigsPerType{
+		"builtin:test":       {newConfig(config.SettingsType{SchemaId: "builtin:test"}, `{"b": [3, 1.50, {"d": 1, "c": "<a>"}], "a": "{{.name}}", "modificationInfo": {"lastModifiedTime": "2025-01-01"}}`)},
+		api.SyntheticMonitor: {newConfig(config.ClassicApiType{Api: api.SyntheticMonitor}, `{"name": "{{.name}}", "createdFrom": "GUI", "frequencyMin": {{ .frequency }}}`)},
+		"invalid":            {newConfig(config.SettingsType{SchemaId: "invalid"}, `{"a": `)},
+	}
+
+	result, err := CanonicalizeTemplates(configs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"a\": \"{{.name}}\",\n  \"b\": [\n    3,\n    1.50,\n    {\n      \"c\": \"<a>\",\n      \"d\": 1\n    }\n  ]\n}\n", content(t, result["builtin:test"][0]))
+	assert.Equal(t, "{\n  \"frequencyMin\": {{ .frequency }},\n  \"name\": \"{{.name}}\"\n}\n", content(t, result[api.SyntheticMonitor][0]))
+	assert.Equal(t, `{"a": `, content(t, result["invalid"][0]), "invalid templates are kept")
+}
+
+func TestCanonicalizeTemplates_IsIdempotent(t *testing.T) {
+	configs := project.ConfigsPerType{"builtin:test": {newConfig(config.SettingsType{SchemaId: "builtin:test"}, `{"z": {"y": [1, 2]}, "x": null}`)}}
+
+	once, err := CanonicalizeTemplates(configs)
+	require.NoError(t, err)
+	first := content(t, once["builtin:test"][0])
+
+	twice, err := CanonicalizeTemplates(once)
+	require.NoError(t, err)
+	assert.Equal(t, first, content(t, twice["builtin:test"][0]))
+}
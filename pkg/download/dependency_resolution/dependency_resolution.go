@@ -55,6 +55,11 @@ func resolve(configs project.ConfigsPerType) error {
 	// currently a simple brute force approach
 	for _, configs := range configs {
 		for i := range configs {
+			// extension packages are binary archives that can't contain references
+			if configs[i].Coordinate.Type == string(config.ExtensionV2TypeID) {
+				continue
+			}
+
 			wg.Add(1)
 
 			configToBeUpdated := &configs[i]
@@ -106,6 +111,11 @@ func collectConfigsById(configs project.ConfigsPerType) map[string]config.Config
 			if conf.Coordinate.Type == string(config.OpenPipelineTypeID) {
 				continue
 			}
+			// extension packages are not referenced by ID, but by their name, which is likely to occur in other
+			// configs without meaning to reference the extension, e.g. in monitoring configurations
+			if conf.Coordinate.Type == string(config.ExtensionV2TypeID) {
+				continue
+			}
 			configsById[conf.Coordinate.ConfigId] = conf
 			if conf.OriginObjectId != "" {
 				// resolve Settings references by Object ID as well
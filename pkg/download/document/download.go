@@ -20,11 +20,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
@@ -39,18 +43,51 @@ var documentMapping = map[string]config.DocumentKind{
 	documents.Launchpad: config.LaunchpadKind,
 }
 
-func Download(ctx context.Context, client client.DocumentClient, projectName string) (project.ConfigsPerType, error) {
-	// due to the current test setup, the types must be downloaded in order. This should be changed eventually
-	var typesToDownload = []documents.DocumentType{
-		documents.Dashboard,
-		documents.Notebook,
-		documents.Launchpad,
-	}
+// Options restrict the downloaded documents.
+type Options struct {
+	// ModifiedSince restricts the download to documents modified after this time. If zero, all documents are downloaded.
+	ModifiedSince time.Time
+	// IncludeTypes restricts the download to documents of these types. If empty, documents of all types are downloaded.
+	// Only used if documents of any kind are enabled.
+	IncludeTypes []string
+	// ExcludeTypes are the types of documents that are not downloaded. Only used if documents of any kind are enabled.
+	ExcludeTypes []string
+	// IncludeOwner defines that the owner of each document is downloaded together with its shares. As owners are user
+	// IDs of the environment downloaded from, deploying them transfers the ownership of each document to that user,
+	// which fails on environments where the user doesn't exist. Only used if a sharing client is given.
+	IncludeOwner bool
+}
 
+// Download downloads all documents that are not ready-made by an app. Unless documents of any kind are enabled, only
+// documents of the known kinds are downloaded. If a sharingClient is given, the shares of each document are downloaded
+// as well, and its owner if requested by the options.
+func Download(ctx context.Context, client client.DocumentClient, sharingClient client.DocumentSharingClient, projectName string, opts Options) (project.ConfigsPerType, error) {
 	var allConfigs []config.Config
-	for _, docKind := range typesToDownload {
-		configs := downloadDocumentsOfType(ctx, client, projectName, docKind)
-		allConfigs = append(allConfigs, configs...)
+	switch {
+	case !featureflags.AnyDocumentKind.Enabled():
+		// due to the current test setup, the types must be downloaded in order. This should be changed eventually
+		var typesToDownload = []documents.DocumentType{
+			documents.Dashboard,
+			documents.Notebook,
+			documents.Launchpad,
+		}
+
+		for _, docKind := range typesToDownload {
+			configs := downloadDocuments(ctx, client, sharingClient, opts.IncludeOwner, projectName, listFilter(docKind, opts.ModifiedSince), fmt.Sprintf("of type '%s'", docKind), nil)
+			allConfigs = append(allConfigs, configs...)
+		}
+
+	case len(opts.IncludeTypes) > 0:
+		for _, docType := range opts.IncludeTypes {
+			if slices.Contains(opts.ExcludeTypes, docType) {
+				continue
+			}
+			configs := downloadDocuments(ctx, client, sharingClient, opts.IncludeOwner, projectName, listFilter(docType, opts.ModifiedSince), fmt.Sprintf("of type '%s'", docType), nil)
+			allConfigs = append(allConfigs, configs...)
+		}
+
+	default:
+		allConfigs = downloadDocuments(ctx, client, sharingClient, opts.IncludeOwner, projectName, modifiedSinceFilter(opts.ModifiedSince), "of all types", opts.ExcludeTypes)
 	}
 
 	return project.ConfigsPerType{
@@ -58,12 +95,14 @@ func Download(ctx context.Context, client client.DocumentClient, projectName str
 	}, nil
 }
 
-func downloadDocumentsOfType(ctx context.Context, client client.DocumentClient, projectName string, documentType string) []config.Config {
-	log.WithFields(field.Type("document")).Debug("Downloading documents of type '%s'", documentType)
+// downloadDocuments downloads all documents matching the filter, except the ones of the excluded types. The description
+// of the documents is only used for logging.
+func downloadDocuments(ctx context.Context, client client.DocumentClient, sharingClient client.DocumentSharingClient, includeOwner bool, projectName string, filter string, description string, excludedTypes []string) []config.Config {
+	log.WithFields(field.Type("document")).Debug("Downloading documents %s", description)
 
-	listResponse, err := client.List(ctx, fmt.Sprintf("type=='%s'", documentType))
+	listResponse, err := client.List(ctx, filter)
 	if err != nil {
-		log.WithFields(field.Type("document"), field.Error(err)).Error("Failed to list all documents of type '%s': %v", documentType, err)
+		log.WithFields(field.Type("document"), field.Error(err)).Error("Failed to list all documents %s: %v", description, err)
 		return nil
 	}
 
@@ -75,24 +114,47 @@ func downloadDocumentsOfType(ctx context.Context, client client.DocumentClient,
 			continue
 		}
 
-		config, err := convertDocumentResponse(ctx, client, projectName, response)
+		if slices.Contains(excludedTypes, response.Type) {
+			continue
+		}
+
+		config, err := convertDocumentResponse(ctx, client, sharingClient, includeOwner, projectName, response)
 		if err != nil {
-			log.WithFields(field.Type("document"), field.Error(err)).Error("Failed to convert document '%s' of type '%s': %v", response.ID, documentType, err)
+			log.WithFields(field.Type("document"), field.Error(err)).Error("Failed to convert document '%s' of type '%s': %v", response.ID, response.Type, err)
 			continue
 		}
 		configs = append(configs, config)
 	}
 
-	log.WithFields(field.Type("document")).Debug("Downloaded %d documents of type '%s'", len(configs), documentType)
+	log.WithFields(field.Type("document")).Debug("Downloaded %d documents %s", len(configs), description)
 
 	return configs
 }
 
+// listFilter returns the filter expression to list all documents of the given type. The modification time is only
+// available when listing documents, so documents are filtered by it here instead of after downloading them.
+func listFilter(documentType string, modifiedSince time.Time) string {
+	filter := fmt.Sprintf("type=='%s'", documentType)
+	if !modifiedSince.IsZero() {
+		filter += " and " + modifiedSinceFilter(modifiedSince)
+	}
+	return filter
+}
+
+// modifiedSinceFilter returns the filter expression to list all documents modified after the given time. If the time is
+// zero, the expression is empty and all documents are listed.
+func modifiedSinceFilter(modifiedSince time.Time) string {
+	if modifiedSince.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("modificationInfo.lastModifiedTime>'%s'", modifiedSince.UTC().Format("2006-01-02T15:04:05.000Z"))
+}
+
 func isReadyMadeByAnApp(metadata documents.Metadata) bool {
 	return (metadata.OriginAppID != nil) && (len(*metadata.OriginAppID) > 0)
 }
 
-func convertDocumentResponse(ctx context.Context, client client.DocumentClient, projectName string, response documents.Response) (config.Config, error) {
+func convertDocumentResponse(ctx context.Context, client client.DocumentClient, sharingClient client.DocumentSharingClient, includeOwner bool, projectName string, response documents.Response) (config.Config, error) {
 	documentType, err := validateDocumentType(response.Type)
 	if err != nil {
 		return config.Config{}, err
@@ -114,6 +176,17 @@ func convertDocumentResponse(ctx context.Context, client client.DocumentClient,
 		return config.Config{}, fmt.Errorf("failed to create template: %w", err)
 	}
 
+	if sharingClient != nil {
+		sharing, err := downloadSharing(ctx, sharingClient, documentResponse.ID)
+		if err != nil {
+			return config.Config{}, fmt.Errorf("failed to download sharing: %w", err)
+		}
+		if includeOwner {
+			sharing.Owner = documentResponse.Owner
+		}
+		documentType.Sharing = &sharing
+	}
+
 	return config.Config{
 		Template: template,
 		Coordinate: coordinate.Coordinate{
@@ -121,12 +194,51 @@ func convertDocumentResponse(ctx context.Context, client client.DocumentClient,
 			Type:     string(config.DocumentTypeID),
 			ConfigId: documentResponse.ID,
 		},
-		Type:           documentType,
-		Parameters:     params,
-		OriginObjectId: documentResponse.ID,
+		Type:             documentType,
+		Parameters:       params,
+		OriginObjectId:   documentResponse.ID,
+		OriginExternalId: documentResponse.ExternalID,
 	}, nil
 }
 
+// downloadSharing returns the environment and direct shares of the document.
+func downloadSharing(ctx context.Context, sharingClient client.DocumentSharingClient, documentID string) (config.DocumentSharing, error) {
+	var sharing config.DocumentSharing
+
+	environmentShares, err := sharingClient.ListEnvironmentShares(ctx, documentID)
+	if err != nil {
+		return config.DocumentSharing{}, err
+	}
+	for _, s := range environmentShares {
+		// if the document is shared more than once with the environment, the broadest access is effective
+		if sharing.Environment != config.DocumentReadWriteAccess {
+			sharing.Environment = s.Access
+		}
+	}
+
+	directShares, err := sharingClient.ListDirectShares(ctx, documentID)
+	if err != nil {
+		return config.DocumentSharing{}, err
+	}
+	for _, s := range directShares {
+		recipients, err := sharingClient.ListRecipients(ctx, s.ID)
+		if err != nil {
+			return config.DocumentSharing{}, err
+		}
+
+		for _, r := range recipients {
+			share := config.DocumentShare{ID: r.ID, Access: s.Access}
+			if r.Type == dtclient.RecipientTypeGroup {
+				sharing.Groups = append(sharing.Groups, share)
+			} else {
+				sharing.Users = append(sharing.Users, share)
+			}
+		}
+	}
+
+	return sharing, nil
+}
+
 func createTemplateFromResponse(response documents.Response) (template.Template, error) {
 	var data map[string]interface{}
 	err := json.Unmarshal(response.Data, &data)
@@ -145,7 +257,10 @@ func createTemplateFromResponse(response documents.Response) (template.Template,
 func validateDocumentType(documentType string) (config.DocumentType, error) {
 	kind, f := documentMapping[documentType]
 	if !f {
-		return config.DocumentType{}, fmt.Errorf("unsupported document type: %s", documentType)
+		if !featureflags.AnyDocumentKind.Enabled() {
+			return config.DocumentType{}, fmt.Errorf("unsupported document type: %s", documentType)
+		}
+		kind = config.DocumentKind(documentType)
 	}
 
 	return config.DocumentType{Kind: kind}, nil
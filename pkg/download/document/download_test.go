@@ -19,17 +19,24 @@
 package document
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
@@ -224,7 +231,7 @@ func TestDownloader_Download(t *testing.T) {
 		defer server.Close()
 
 		documentClient := documents.NewClient(rest.NewClient(server.URL(), server.Client()))
-		result, err := Download(t.Context(), documentClient, "project")
+		result, err := Download(t.Context(), documentClient, nil, "project", Options{})
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
 
@@ -249,7 +256,7 @@ func TestDownloader_Download(t *testing.T) {
 		defer server.Close()
 
 		documentClient := documents.NewClient(rest.NewClient(server.URL(), server.FaultyClient()))
-		result, err := Download(t.Context(), documentClient, "project")
+		result, err := Download(t.Context(), documentClient, nil, "project", Options{})
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
 		assert.True(t, true)
@@ -354,7 +361,7 @@ func TestDownloader_Download(t *testing.T) {
 		defer server.Close()
 
 		documentClient := documents.NewClient(rest.NewClient(server.URL(), server.Client()))
-		result, err := Download(t.Context(), documentClient, "project")
+		result, err := Download(t.Context(), documentClient, nil, "project", Options{})
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
 
@@ -371,3 +378,151 @@ func TestDownloader_Download(t *testing.T) {
 	})
 
 }
+
+func TestListFilter(t *testing.T) {
+	assert.Equal(t, "type=='dashboard'", listFilter(documents.Dashboard, time.Time{}))
+
+	since := time.Date(2025, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	assert.Equal(t, "type=='notebook' and modificationInfo.lastModifiedTime>'2025-03-01T11:30:00.000Z'", listFilter(documents.Notebook, since))
+}
+
+type testSharingClient struct {
+	client.DummyDocumentSharingClient
+	environmentShares []dtclient.EnvironmentShare
+	directShares      []dtclient.DirectShare
+	recipients        map[string][]dtclient.Recipient
+}
+
+func (c testSharingClient) ListEnvironmentShares(_ context.Context, _ string) ([]dtclient.EnvironmentShare, error) {
+	return c.environmentShares, nil
+}
+
+func (c testSharingClient) ListDirectShares(_ context.Context, _ string) ([]dtclient.DirectShare, error) {
+	return c.directShares, nil
+}
+
+func (c testSharingClient) ListRecipients(_ context.Context, shareID string) ([]dtclient.Recipient, error) {
+	return c.recipients[shareID], nil
+}
+
+func TestDownloadSharing(t *testing.T) {
+	sharingClient := testSharingClient{
+		environmentShares: []dtclient.EnvironmentShare{
+			{ID: "e1", Access: config.DocumentReadWriteAccess},
+			{ID: "e2", Access: config.DocumentReadAccess},
+		},
+		directShares: []dtclient.DirectShare{
+			{ID: "d1", Access: config.DocumentReadAccess},
+			{ID: "d2", Access: config.DocumentReadWriteAccess},
+		},
+		recipients: map[string][]dtclient.Recipient{
+			"d1": {{ID: "user-1", Type: dtclient.RecipientTypeUser}, {ID: "group-1", Type: dtclient.RecipientTypeGroup}},
+			"d2": {{ID: "user-2", Type: dtclient.RecipientTypeUser}},
+		},
+	}
+
+	sharing, err := downloadSharing(t.Context(), &sharingClient, "doc")
+	require.NoError(t, err)
+	assert.Equal(t, config.DocumentSharing{
+		Environment: config.DocumentReadWriteAccess,
+		Users: []config.DocumentShare{
+			{ID: "user-1", Access: config.DocumentReadAccess},
+			{ID: "user-2", Access: config.DocumentReadWriteAccess},
+		},
+		Groups: []config.DocumentShare{{ID: "group-1", Access: config.DocumentReadAccess}},
+	}, sharing)
+}
+
+// testDocumentClient lists the given documents, ignoring the filter
+type testDocumentClient struct {
+	client.DummyDocumentClient
+	documents []documents.Metadata
+	filters   []string
+}
+
+func (c *testDocumentClient) List(_ context.Context, filter string) (documents.ListResponse, error) {
+	c.filters = append(c.filters, filter)
+	var result documents.ListResponse
+	for _, md := range c.documents {
+		result.Responses = append(result.Responses, documents.Response{Metadata: md})
+	}
+	return result, nil
+}
+
+func (c *testDocumentClient) Get(_ context.Context, id string) (documents.Response, error) {
+	for _, md := range c.documents {
+		if md.ID == id {
+			return documents.Response{Metadata: md, Response: api.Response{Data: []byte(`{}`)}}, nil
+		}
+	}
+	return documents.Response{}, fmt.Errorf("document %q not found", id)
+}
+
+func TestDownload_AnyDocumentKind(t *testing.T) {
+	t.Setenv(featureflags.AnyDocumentKind.EnvName(), "true")
+
+	newClient := func() *testDocumentClient {
+		return &testDocumentClient{documents: []documents.Metadata{
+			{ID: "dashboard-id", Name: "dashboard", Type: documents.Dashboard},
+			{ID: "custom-id", Name: "custom", Type: "my.app:custom"},
+			{ID: "other-id", Name: "other", Type: "my.app:other"},
+		}}
+	}
+
+	t.Run("all types are downloaded except excluded ones", func(t *testing.T) {
+		c := newClient()
+		result, err := Download(t.Context(), c, nil, "project", Options{ExcludeTypes: []string{"my.app:other"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{""}, c.filters)
+		require.Len(t, result["document"], 2)
+		assert.Equal(t, config.DocumentType{Kind: config.DashboardKind}, result["document"][0].Type)
+		assert.Equal(t, config.DocumentType{Kind: "my.app:custom"}, result["document"][1].Type)
+	})
+
+	t.Run("only included types are listed", func(t *testing.T) {
+		c := newClient()
+		_, err := Download(t.Context(), c, nil, "project", Options{
+			IncludeTypes:  []string{"my.app:custom", "my.app:other"},
+			ExcludeTypes:  []string{"my.app:other"},
+			ModifiedSince: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"type=='my.app:custom' and modificationInfo.lastModifiedTime>'2025-03-01T00:00:00.000Z'"}, c.filters)
+	})
+}
+
+func TestDownload_UnknownKindsAreSkippedWithoutFeatureFlag(t *testing.T) {
+	t.Setenv(featureflags.AnyDocumentKind.EnvName(), "false")
+
+	c := &testDocumentClient{documents: []documents.Metadata{{ID: "custom-id", Name: "custom", Type: "my.app:custom"}}}
+	result, err := Download(t.Context(), c, nil, "project", Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"type=='dashboard'", "type=='notebook'", "type=='launchpad'"}, c.filters)
+	assert.Empty(t, result["document"])
+}
+
+func TestDownload_OwnerIsOnlyDownloadedIfRequested(t *testing.T) {
+	t.Setenv(featureflags.AnyDocumentKind.EnvName(), "true")
+
+	c := &testDocumentClient{documents: []documents.Metadata{{ID: "dashboard-id", Name: "dashboard", Type: documents.Dashboard, Owner: "user-1"}}}
+	sharingClient := &testSharingClient{environmentShares: []dtclient.EnvironmentShare{{ID: "e1", Access: config.DocumentReadAccess}}}
+
+	t.Run("owner is not downloaded by default", func(t *testing.T) {
+		result, err := Download(t.Context(), c, sharingClient, "project", Options{})
+		require.NoError(t, err)
+
+		require.Len(t, result["document"], 1)
+		assert.Equal(t, &config.DocumentSharing{Environment: config.DocumentReadAccess}, result["document"][0].Type.(config.DocumentType).Sharing)
+	})
+
+	t.Run("owner is downloaded if requested", func(t *testing.T) {
+		result, err := Download(t.Context(), c, sharingClient, "project", Options{IncludeOwner: true})
+		require.NoError(t, err)
+
+		require.Len(t, result["document"], 1)
+		assert.Equal(t, &config.DocumentSharing{Owner: "user-1", Environment: config.DocumentReadAccess}, result["document"][0].Type.(config.DocumentType).Sharing)
+	})
+}
@@ -26,26 +26,53 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account"
+	accountwriter "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/persistence/writer"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/writer"
 )
 
+// accountsProjectName is the project the account management resources are written to, with a folder per account.
+const accountsProjectName = "accounts"
+
+// AccountResources are the account management resources downloaded from an account of the manifest.
+type AccountResources struct {
+	Account   manifest.Account
+	Resources account.Resources
+}
+
 type WriterContext struct {
-	EnvironmentUrl  manifest.URLDefinition
-	ProjectToWrite  project.Project
-	Auth            manifest.Auth
-	OutputFolder    string
-	ForceOverwrite  bool
+	EnvironmentUrl manifest.URLDefinition
+	ProjectToWrite project.Project
+	Auth           manifest.Auth
+	// Environments are written to the manifest if the project was downloaded from several environments. If empty, the
+	// manifest contains a single environment named after the project, using EnvironmentUrl and Auth.
+	Environments manifest.Environments
+	// EntityIDAnnotations are written to a sidecar file in the project folder. If empty, no such file is written.
+	EntityIDAnnotations entityids.Annotations
+	// Accounts are written to an additional project next to the downloaded configurations and added to the manifest.
+	// If empty, no such project is written.
+	Accounts       []AccountResources
+	OutputFolder   string
+	ForceOverwrite bool
+	// Stable defines that the written file names do not depend on the time of the download. The output folder defaults
+	// to 'download', and an existing manifest and project folder are replaced.
+	Stable          bool
 	timestampString string
 }
 
 func (c WriterContext) GetOutputFolderFilePath() string {
-	if c.OutputFolder == "" {
+	switch {
+	case c.OutputFolder != "":
+		return c.OutputFolder
+	case c.Stable:
+		return "download"
+	default:
 		return filepath.Clean(fmt.Sprintf("download_%s/", c.timestampString))
 	}
-	return c.OutputFolder
 }
 
 // WriteToDisk writes all projects to the disk
@@ -59,7 +86,10 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 	log.Debug("Preparing downloaded data for persisting")
 
 	manifestFileName := getManifestFileName(fs, writerContext)
-	projectFolderName := getProjectFolderName(fs, writerContext)
+	projectFolderName := getProjectFolderName(fs, writerContext, writerContext.ProjectToWrite.Id)
+	if err := removeStaleProjectFolder(fs, writerContext, projectFolderName); err != nil {
+		return err
+	}
 
 	projectDefinition := manifest.ProjectDefinitionByProjectID{
 		writerContext.ProjectToWrite.Id: {
@@ -68,16 +98,40 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 		},
 	}
 
-	manifest := manifest.Manifest{
-		Projects: projectDefinition,
-		Environments: map[string]manifest.EnvironmentDefinition{
+	var accounts map[string]manifest.Account
+	var accountsFolderName string
+	if len(writerContext.Accounts) > 0 {
+		if writerContext.ProjectToWrite.Id == accountsProjectName {
+			return fmt.Errorf("project name %q is reserved for the account management resources", accountsProjectName)
+		}
+		accountsFolderName = getProjectFolderName(fs, writerContext, accountsProjectName)
+		if err := removeStaleProjectFolder(fs, writerContext, accountsFolderName); err != nil {
+			return err
+		}
+		projectDefinition[accountsProjectName] = manifest.ProjectDefinition{Name: accountsProjectName, Path: accountsFolderName}
+
+		accounts = make(map[string]manifest.Account, len(writerContext.Accounts))
+		for _, a := range writerContext.Accounts {
+			accounts[a.Account.Name] = a.Account
+		}
+	}
+
+	environments := writerContext.Environments
+	if len(environments) == 0 {
+		environments = manifest.Environments{
 			writerContext.ProjectToWrite.Id: {
 				Name:  writerContext.ProjectToWrite.Id,
 				URL:   writerContext.EnvironmentUrl,
 				Group: "default",
 				Auth:  writerContext.Auth,
 			},
-		},
+		}
+	}
+
+	manifest := manifest.Manifest{
+		Projects:     projectDefinition,
+		Environments: environments,
+		Accounts:     accounts,
 	}
 
 	outputFolder := writerContext.GetOutputFolderFilePath()
@@ -95,10 +149,39 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 		return fmt.Errorf("failed to persist downloaded configurations")
 	}
 
+	if len(writerContext.EntityIDAnnotations) > 0 {
+		if err := entityids.Write(fs, filepath.Join(outputFolder, projectFolderName), writerContext.EntityIDAnnotations); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range writerContext.Accounts {
+		err := accountwriter.Write(accountwriter.Context{
+			Fs:            fs,
+			OutputFolder:  outputFolder,
+			ProjectFolder: filepath.Join(accountsFolderName, a.Account.Name),
+		}, a.Resources)
+		if err != nil {
+			return fmt.Errorf("failed to persist resources of account %q: %w", a.Account.Name, err)
+		}
+	}
+
 	log.WithFields(field.F("outputFolder", outputFolder)).Info("Downloaded configurations written to '%s'", outputFolder)
 	return nil
 }
 
+// removeStaleProjectFolder removes an existing project folder when writing stable downloads, so that the files of
+// objects that no longer exist do not remain in the project.
+func removeStaleProjectFolder(fs afero.Fs, writerContext WriterContext, projectFolderName string) error {
+	if !writerContext.Stable {
+		return nil
+	}
+	if err := fs.RemoveAll(filepath.Join(writerContext.GetOutputFolderFilePath(), projectFolderName)); err != nil {
+		return fmt.Errorf("failed to replace existing project folder: %w", err)
+	}
+	return nil
+}
+
 func getManifestFileName(fs afero.Fs, writerContext WriterContext) string {
 	manifestFileName := "manifest.yaml"
 	outputFolder := writerContext.GetOutputFolderFilePath()
@@ -107,7 +190,7 @@ func getManifestFileName(fs afero.Fs, writerContext WriterContext) string {
 		return manifestFileName
 	}
 
-	if writerContext.ForceOverwrite {
+	if writerContext.ForceOverwrite || writerContext.Stable {
 		log.WithFields(field.F("outputFolder", outputFolder), field.F("manifestFile", "manifest.yaml")).Info("Overwriting existing manifest.yaml in download target folder.")
 		return manifestFileName
 	}
@@ -117,20 +200,20 @@ func getManifestFileName(fs afero.Fs, writerContext WriterContext) string {
 	return manifestFileName
 }
 
-func getProjectFolderName(fs afero.Fs, writerContext WriterContext) string {
-	projectFolderName := writerContext.ProjectToWrite.Id
+func getProjectFolderName(fs afero.Fs, writerContext WriterContext, projectID string) string {
+	projectFolderName := projectID
 	outputFolder := writerContext.GetOutputFolderFilePath()
-	defaultProjectFolderPath := filepath.Join(outputFolder, writerContext.ProjectToWrite.Id)
+	defaultProjectFolderPath := filepath.Join(outputFolder, projectID)
 	if exists, _ := afero.Exists(fs, defaultProjectFolderPath); !exists {
-		return writerContext.ProjectToWrite.Id
+		return projectID
 	}
 
-	if writerContext.ForceOverwrite {
+	if writerContext.ForceOverwrite || writerContext.Stable {
 		log.WithFields(field.F("outputFolder", outputFolder), field.F("projectFolder", projectFolderName)).Info("Overwriting existing project folder named %q in %q.", projectFolderName, outputFolder)
 		return projectFolderName
 	}
 
-	projectFolderName = fmt.Sprintf("%s_%s", writerContext.ProjectToWrite.Id, writerContext.timestampString)
-	log.WithFields(field.F("outputFolder", outputFolder), field.F("projectFolder", projectFolderName)).Warn("A project folder named %q already exists in %q, creating %q instead.", writerContext.ProjectToWrite.Id, outputFolder, projectFolderName)
+	projectFolderName = fmt.Sprintf("%s_%s", projectID, writerContext.timestampString)
+	log.WithFields(field.F("outputFolder", outputFolder), field.F("projectFolder", projectFolderName)).Warn("A project folder named %q already exists in %q, creating %q instead.", projectID, outputFolder, projectFolderName)
 	return projectFolderName
 }
@@ -17,18 +17,25 @@
 package download
 
 import (
+	"maps"
 	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account"
+	accountloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/persistence/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
@@ -328,6 +335,151 @@ func TestWriteToDisk_OverwritesManifestIfForced(t *testing.T) {
 
 }
 
+func TestWriteToDisk_WritesAllEnvironmentsToManifest(t *testing.T) {
+	newConfig := func(env string) config.Config {
+		return config.Config{
+			Type:        config.ClassicApiType{Api: "test-api"},
+			Template:    template.NewInMemoryTemplate("template", "{}"),
+			Coordinate:  coordinate.Coordinate{Project: "test-project", Type: "test-api", ConfigId: "config"},
+			Group:       env,
+			Environment: env,
+			Parameters:  config.Parameters{"name": value.New("test-config")},
+		}
+	}
+	newEnvironment := func(name string) manifest.EnvironmentDefinition {
+		return manifest.EnvironmentDefinition{
+			Name:  name,
+			Group: name,
+			URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: name + ".url.com"},
+			Auth:  manifest.Auth{Token: &manifest.AuthSecret{Name: "TOKEN_" + name}},
+		}
+	}
+
+	fs := emptyTestFs()
+	err := writeToDisk(fs, WriterContext{
+		ProjectToWrite: project.Project{
+			Id: "test-project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev":  {"test-api": {newConfig("dev")}},
+				"prod": {"test-api": {newConfig("prod")}},
+			},
+		},
+		Environments: manifest.Environments{
+			"dev":  newEnvironment("dev"),
+			"prod": newEnvironment("prod"),
+		},
+		OutputFolder: "test-output",
+	})
+	require.NoError(t, err)
+
+	writtenManifest, err := afero.ReadFile(fs, "test-output/manifest.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(writtenManifest), "- name: dev\n    url:\n      value: dev.url.com")
+	assert.Contains(t, string(writtenManifest), "- name: prod\n    url:\n      value: prod.url.com")
+	assert.NotContains(t, string(writtenManifest), "name: default", "the default group of single environment downloads is not written")
+}
+
+func TestWriteToDisk_WritesEntityIDAnnotationsToProjectFolder(t *testing.T) {
+	fs := emptyTestFs()
+	err := writeToDisk(fs, WriterContext{
+		ProjectToWrite: project.Project{
+			Id: "test-project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"test-project": {"test-api": {{
+					Type:        config.ClassicApiType{Api: "test-api"},
+					Template:    template.NewInMemoryTemplate("template", "{}"),
+					Coordinate:  coordinate.Coordinate{Project: "test-project", Type: "test-api", ConfigId: "config"},
+					Environment: "test-project",
+					Parameters:  config.Parameters{"name": value.New("test-config")},
+				}}},
+			},
+		},
+		EntityIDAnnotations: entityids.Annotations{"HOST-0000000000000001": {Type: "HOST", DisplayName: "my-host"}},
+		OutputFolder:        "test-output",
+	})
+	require.NoError(t, err)
+
+	annotations, err := entityids.Load(fs, "test-output/test-project")
+	require.NoError(t, err)
+	assert.Equal(t, entityids.Annotations{"HOST-0000000000000001": {Type: "HOST", DisplayName: "my-host"}}, annotations)
+}
+
+func TestWriteToDisk_WritesAccountsAsAdditionalProject(t *testing.T) {
+	t.Setenv("ACCOUNT_CLIENT_ID", "client-id")
+	t.Setenv("ACCOUNT_CLIENT_SECRET", "client-secret")
+
+	fs := emptyTestFs()
+	err := writeToDisk(fs, WriterContext{
+		ProjectToWrite: project.Project{
+			Id: "test-project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"test-project": {"test-api": {{
+					Type:        config.ClassicApiType{Api: "test-api"},
+					Template:    template.NewInMemoryTemplate("template", "{}"),
+					Coordinate:  coordinate.Coordinate{Project: "test-project", Type: "test-api", ConfigId: "config"},
+					Environment: "test-project",
+					Parameters:  config.Parameters{"name": value.New("test-config")},
+				}}},
+			},
+		},
+		EnvironmentUrl: manifest.URLDefinition{Type: manifest.ValueURLType, Value: "https://example.com"},
+		Auth:           manifest.Auth{Token: &manifest.AuthSecret{Name: "TOKEN_VAR"}},
+		Accounts: []AccountResources{{
+			Account: manifest.Account{
+				Name:        "my-account",
+				AccountUUID: uuid.MustParse("8f9935ee-2068-455d-85ce-47447f19d5d5"),
+				OAuth:       manifest.OAuth{ClientID: manifest.AuthSecret{Name: "ACCOUNT_CLIENT_ID"}, ClientSecret: manifest.AuthSecret{Name: "ACCOUNT_CLIENT_SECRET"}},
+			},
+			Resources: account.Resources{Policies: map[string]account.Policy{
+				"my-policy": {ID: "my-policy", Name: "My Policy", Level: account.PolicyLevelAccount{Type: "account"}, Policy: "ALLOW automation:workflows:read;"},
+			}},
+		}},
+		OutputFolder: "test-output",
+	})
+	require.NoError(t, err)
+
+	t.Setenv("TOKEN_VAR", "token")
+	m, errs := manifestloader.Load(&manifestloader.Context{Fs: fs, ManifestPath: "test-output/manifest.yaml"})
+	require.Empty(t, errs)
+	assert.Equal(t, []string{"my-account"}, slices.Collect(maps.Keys(m.Accounts)))
+	assert.Equal(t, manifest.ProjectDefinition{Name: "accounts", Path: "accounts"}, m.Projects["accounts"])
+
+	resources, err := accountloader.LoadResources(fs, "test-output", manifest.ProjectDefinitionByProjectID{"accounts": m.Projects["accounts"]})
+	require.NoError(t, err)
+	assert.Contains(t, resources.Policies, "my-policy")
+}
+
+func TestWriteToDisk_StableReplacesExistingProject(t *testing.T) {
+	fs := testFsWithWithExistingManifest("download")
+	require.NoError(t, afero.WriteFile(fs, "download/test-project/test-api/stale.json", []byte("{}"), 0644))
+
+	err := writeToDisk(fs, WriterContext{
+		ProjectToWrite: project.Project{
+			Id: "test-project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"test-project": {"test-api": {{
+					Type:        config.ClassicApiType{Api: "test-api"},
+					Template:    template.NewInMemoryTemplate("template", "{}"),
+					Coordinate:  coordinate.Coordinate{Project: "test-project", Type: "test-api", ConfigId: "config"},
+					Environment: "test-project",
+					Parameters:  config.Parameters{"name": value.New("test-config")},
+				}}},
+			},
+		},
+		Stable:          true,
+		timestampString: "TESTING_TIME",
+	})
+	require.NoError(t, err)
+
+	files, err := afero.Glob(fs, "download/*")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"download/manifest.yaml", "download/test-project"}, files, "no timestamped files are written")
+
+	exists, err := afero.Exists(fs, "download/test-project/test-api/stale.json")
+	require.NoError(t, err)
+	assert.False(t, exists, "the existing project folder is replaced")
+}
+
 func emptyTestFs() afero.Fs {
 	return afero.NewMemMapFs()
 }
@@ -0,0 +1,140 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/internal/templatetools"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// versionParameter is the parameter of monitoring configurations referencing the version of their extension.
+const versionParameter = "extensionVersion"
+
+type DownloadExtensionsClient interface {
+	List(ctx context.Context) ([]dtclient.ExtensionVersion, error)
+	GetActiveVersion(ctx context.Context, extensionName string) (string, bool, error)
+	GetPackage(ctx context.Context, extensionName, version string) ([]byte, error)
+	ListMonitoringConfigurations(ctx context.Context, extensionName string) ([]dtclient.MonitoringConfiguration, error)
+}
+
+// Download downloads the active version of every extension, together with the extension's monitoring configurations.
+// Extensions without an active version are skipped, as they are not in use.
+func Download(ctx context.Context, client DownloadExtensionsClient, projectName string) (project.ConfigsPerType, error) {
+	extensions, err := client.List(ctx)
+	if err != nil {
+		log.WithFields(field.Type(config.ExtensionV2TypeID), field.Error(err)).Error("Failed to fetch the list of existing extensions: %v", err)
+		// error is ignored
+		return nil, nil
+	}
+
+	result := project.ConfigsPerType{}
+	for _, ext := range extensions {
+		version, active, err := client.GetActiveVersion(ctx, ext.ExtensionName)
+		if err != nil {
+			log.WithFields(field.Type(config.ExtensionV2TypeID), field.Error(err)).Error("Failed to get the active version of extension %q: %v", ext.ExtensionName, err)
+			continue
+		}
+		if !active {
+			log.WithFields(field.Type(config.ExtensionV2TypeID)).Debug("Skipping extension %q, as no version of it is active", ext.ExtensionName)
+			continue
+		}
+
+		extensionPackage, err := client.GetPackage(ctx, ext.ExtensionName, version)
+		if err != nil {
+			log.WithFields(field.Type(config.ExtensionV2TypeID), field.Error(err)).Error("Failed to download extension %q: %v", ext.ExtensionName, err)
+			continue
+		}
+		extensionConfig := createExtensionConfig(projectName, ext.ExtensionName, extensionPackage)
+		result[string(config.ExtensionV2TypeID)] = append(result[string(config.ExtensionV2TypeID)], extensionConfig)
+
+		monitoringConfigurations, err := client.ListMonitoringConfigurations(ctx, ext.ExtensionName)
+		if err != nil {
+			log.WithFields(field.Type(config.ExtensionV2MonitoringConfigurationTypeID), field.Error(err)).Error("Failed to fetch the monitoring configurations of extension %q: %v", ext.ExtensionName, err)
+			continue
+		}
+		for _, mc := range monitoringConfigurations {
+			c, err := createMonitoringConfigurationConfig(projectName, extensionConfig.Coordinate, ext.ExtensionName, version, mc)
+			if err != nil {
+				log.WithFields(field.Type(config.ExtensionV2MonitoringConfigurationTypeID), field.Error(err)).Error("Failed to convert monitoring configuration %q of extension %q: %v", mc.ObjectId, ext.ExtensionName, err)
+				continue
+			}
+			result[string(config.ExtensionV2MonitoringConfigurationTypeID)] = append(result[string(config.ExtensionV2MonitoringConfigurationTypeID)], c)
+		}
+	}
+
+	return result, nil
+}
+
+func createExtensionConfig(projectName, extensionName string, extensionPackage []byte) config.Config {
+	return config.Config{
+		Template: template.NewInMemoryTemplate(extensionName, string(extensionPackage)),
+		Coordinate: coordinate.Coordinate{
+			Project:  projectName,
+			Type:     string(config.ExtensionV2TypeID),
+			ConfigId: extensionName,
+		},
+		OriginObjectId: extensionName,
+		Type:           config.ExtensionV2Type{},
+		Parameters:     make(config.Parameters),
+	}
+}
+
+// createMonitoringConfigurationConfig creates the config of a monitoring configuration. If the configuration is for
+// the active version of the extension, its version is replaced with a reference to the extension's config, so that it
+// is deployed after the extension and follows version updates of it.
+func createMonitoringConfigurationConfig(projectName string, extensionCoordinate coordinate.Coordinate, extensionName, activeVersion string, mc dtclient.MonitoringConfiguration) (config.Config, error) {
+	jsonObj, err := templatetools.NewJSONObject(mc.Value)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	parameters := config.Parameters{
+		config.ScopeParameter: value.New(mc.Scope),
+	}
+	if v, ok := jsonObj.Get("version").(string); ok && v == activeVersion {
+		jsonObj.ParameterizeAttributeWith("version", versionParameter)
+		parameters[versionParameter] = reference.NewWithCoordinate(extensionCoordinate, "version")
+	}
+
+	jsonRaw, err := jsonObj.ToJSON(true)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return config.Config{
+		Template: template.NewInMemoryTemplate(mc.ObjectId, string(jsonRaw)),
+		Coordinate: coordinate.Coordinate{
+			Project:  projectName,
+			Type:     string(config.ExtensionV2MonitoringConfigurationTypeID),
+			ConfigId: mc.ObjectId,
+		},
+		OriginObjectId: mc.ObjectId,
+		Type:           config.ExtensionV2MonitoringConfigurationType{Extension: extensionName},
+		Parameters:     parameters,
+	}, nil
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+type testClient struct {
+	activeVersions map[string]string
+	mcs            map[string][]dtclient.MonitoringConfiguration
+}
+
+func (c testClient) List(_ context.Context) ([]dtclient.ExtensionVersion, error) {
+	return []dtclient.ExtensionVersion{{ExtensionName: "com.example.active", Version: "2.0.0"}, {ExtensionName: "com.example.inactive", Version: "1.0.0"}}, nil
+}
+
+func (c testClient) GetActiveVersion(_ context.Context, extensionName string) (string, bool, error) {
+	v, ok := c.activeVersions[extensionName]
+	return v, ok, nil
+}
+
+func (c testClient) GetPackage(_ context.Context, extensionName, version string) ([]byte, error) {
+	return []byte(extensionName + "@" + version), nil
+}
+
+func (c testClient) ListMonitoringConfigurations(_ context.Context, extensionName string) ([]dtclient.MonitoringConfiguration, error) {
+	return c.mcs[extensionName], nil
+}
+
+func TestDownload(t *testing.T) {
+	client := testClient{
+		activeVersions: map[string]string{"com.example.active": "1.5.0"},
+		mcs: map[string][]dtclient.MonitoringConfiguration{
+			"com.example.active": {
+				{ObjectId: "mc-1", Scope: "environment", Value: json.RawMessage(`{"description": "current", "version": "1.5.0"}`)},
+				{ObjectId: "mc-2", Scope: "HOST-1", Value: json.RawMessage(`{"description": "outdated", "version": "1.0.0"}`)},
+			},
+			"com.example.inactive": {
+				{ObjectId: "mc-3", Scope: "environment", Value: json.RawMessage(`{"description": "inactive", "version": "1.0.0"}`)},
+			},
+		},
+	}
+
+	result, err := Download(t.Context(), client, "project")
+	require.NoError(t, err)
+
+	extensions := result[string(config.ExtensionV2TypeID)]
+	require.Len(t, extensions, 1, "only the active extension is downloaded")
+	extensionCoordinate := coordinate.Coordinate{Project: "project", Type: string(config.ExtensionV2TypeID), ConfigId: "com.example.active"}
+	assert.Equal(t, extensionCoordinate, extensions[0].Coordinate)
+	assert.Equal(t, "com.example.active", extensions[0].OriginObjectId)
+	content, err := extensions[0].Template.Content()
+	require.NoError(t, err)
+	assert.Equal(t, "com.example.active@1.5.0", content, "the active version is downloaded")
+
+	mcs := result[string(config.ExtensionV2MonitoringConfigurationTypeID)]
+	require.Len(t, mcs, 2)
+
+	assert.Equal(t, "mc-1", mcs[0].OriginObjectId)
+	assert.Equal(t, config.ExtensionV2MonitoringConfigurationType{Extension: "com.example.active"}, mcs[0].Type)
+	assert.Equal(t, value.New("environment"), mcs[0].Parameters[config.ScopeParameter])
+	assert.Equal(t, reference.NewWithCoordinate(extensionCoordinate, "version"), mcs[0].Parameters[versionParameter])
+	content, err = mcs[0].Template.Content()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"description": "current", "version": "{{.extensionVersion}}"}`, content)
+
+	assert.Equal(t, "mc-2", mcs[1].OriginObjectId)
+	assert.Equal(t, value.New("HOST-1"), mcs[1].Parameters[config.ScopeParameter])
+	assert.NotContains(t, mcs[1].Parameters, versionParameter, "versions other than the active version are kept")
+	content, err = mcs[1].Template.Content()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"description": "outdated", "version": "1.0.0"}`, content)
+}
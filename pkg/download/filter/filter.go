@@ -0,0 +1,506 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package filter implements user-defined filters for downloaded configurations. Filters are defined in a YAML file
+// per classic API and settings schema, and are applied in addition to the built-in download filters:
+//
+//	apis:
+//	  dashboard:
+//	    include:
+//	      - owner: "^.*@my-team\\.com$"
+//	settings:
+//	  builtin:alerting.profile:
+//	    exclude:
+//	      - name: "^Test"
+//	      - scope: "^HOST-"
+//	        json:
+//	          - path: "severityRules[0].severityLevel"
+//	            matches: "^AVAILABILITY$"
+//
+// An object is kept if it matches any of the include rules of its type (or its type has no include rules), and does not
+// match any of the exclude rules. A rule matches if all of its conditions match. Objects of types without filters are
+// always kept. Settings objects have no owner, so the 'owner' condition is only supported for classic APIs.
+//
+// Settings objects keep their order: if an object another one is inserted after is discarded, the kept object is
+// inserted after the predecessor of the discarded one instead.
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+type fileDefinition struct {
+	APIs     map[string]typeDefinition `yaml:"apis"`
+	Settings map[string]typeDefinition `yaml:"settings"`
+}
+
+type typeDefinition struct {
+	Include []ruleDefinition `yaml:"include"`
+	Exclude []ruleDefinition `yaml:"exclude"`
+}
+
+type ruleDefinition struct {
+	// Name is a regular expression matched against the name of the object.
+	Name string `yaml:"name"`
+	// Owner is a regular expression matched against the owner of the object.
+	Owner string `yaml:"owner"`
+	// Scope is a regular expression matched against the scope of a settings object.
+	Scope string `yaml:"scope"`
+	// ModifiedAfter and ModifiedBefore are RFC 3339 timestamps the last modification of the object is compared to.
+	ModifiedAfter  string `yaml:"modifiedAfter"`
+	ModifiedBefore string `yaml:"modifiedBefore"`
+	// JSON contains predicates on the payload of the object.
+	JSON []jsonDefinition `yaml:"json"`
+}
+
+type jsonDefinition struct {
+	// Path to a property of the payload, e.g. 'dashboardMetadata.tags[0]'.
+	Path string `yaml:"path"`
+	// Matches is a regular expression matched against the value of the property. Values that are not strings are
+	// matched in their JSON representation.
+	Matches string `yaml:"matches"`
+	// Exists defines whether the property needs to be present.
+	Exists *bool `yaml:"exists"`
+}
+
+// Filters holds the user-defined filters per classic API and settings schema. A nil *Filters keeps all objects.
+type Filters struct {
+	apis     map[string]typeFilter
+	settings map[string]typeFilter
+}
+
+type typeFilter struct {
+	include, exclude []rule
+}
+
+type rule struct {
+	name, owner, scope            *regexp.Regexp
+	modifiedAfter, modifiedBefore time.Time
+	json                          []jsonPredicate
+}
+
+type jsonPredicate struct {
+	path    []any
+	matches *regexp.Regexp
+	exists  *bool
+}
+
+// Load reads and parses the filter file at the given path.
+func Load(fs afero.Fs, path string) (*Filters, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read filter file %q: %w", path, err)
+	}
+
+	filters, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter file %q: %w", path, err)
+	}
+	return filters, nil
+}
+
+func parse(data []byte) (*Filters, error) {
+	var definition fileDefinition
+	if err := yaml.UnmarshalStrict(data, &definition); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	filters := &Filters{
+		apis:     make(map[string]typeFilter, len(definition.APIs)),
+		settings: make(map[string]typeFilter, len(definition.Settings)),
+	}
+
+	knownAPIs := api.NewAPIs()
+	for apiID, d := range definition.APIs {
+		if !knownAPIs.Contains(apiID) {
+			errs = append(errs, fmt.Errorf("apis: unknown classic API %q", apiID))
+			continue
+		}
+		f, err := parseTypeFilter("apis."+apiID, d, true)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		filters.apis[apiID] = f
+	}
+
+	for schemaID, d := range definition.Settings {
+		f, err := parseTypeFilter("settings."+schemaID, d, false)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		filters.settings[schemaID] = f
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return filters, nil
+}
+
+func parseTypeFilter(location string, d typeDefinition, supportsOwner bool) (typeFilter, error) {
+	var errs []error
+	parseRules := func(kind string, definitions []ruleDefinition) []rule {
+		rules := make([]rule, 0, len(definitions))
+		for i, rd := range definitions {
+			if rd.Owner != "" && !supportsOwner {
+				errs = append(errs, fmt.Errorf("%s.%s[%d]: 'owner' is not supported, as settings objects have no owner", location, kind, i))
+				continue
+			}
+			r, err := parseRule(rd)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s[%d]: %w", location, kind, i, err))
+				continue
+			}
+			rules = append(rules, r)
+		}
+		return rules
+	}
+
+	f := typeFilter{
+		include: parseRules("include", d.Include),
+		exclude: parseRules("exclude", d.Exclude),
+	}
+	return f, errors.Join(errs...)
+}
+
+func parseRule(d ruleDefinition) (rule, error) {
+	if d.Name == "" && d.Owner == "" && d.Scope == "" && d.ModifiedAfter == "" && d.ModifiedBefore == "" && len(d.JSON) == 0 {
+		return rule{}, errors.New("rule has no conditions")
+	}
+
+	var r rule
+	var errs []error
+	compile := func(property, expr string) *regexp.Regexp {
+		if expr == "" {
+			return nil
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid regular expression for %q: %w", property, err))
+		}
+		return re
+	}
+	parseTime := func(property, timestamp string) time.Time {
+		if timestamp == "" {
+			return time.Time{}
+		}
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid RFC 3339 timestamp for %q: %w", property, err))
+		}
+		return t
+	}
+
+	r.name = compile("name", d.Name)
+	r.owner = compile("owner", d.Owner)
+	r.scope = compile("scope", d.Scope)
+	r.modifiedAfter = parseTime("modifiedAfter", d.ModifiedAfter)
+	r.modifiedBefore = parseTime("modifiedBefore", d.ModifiedBefore)
+
+	for i, jd := range d.JSON {
+		if jd.Matches == "" && jd.Exists == nil {
+			errs = append(errs, fmt.Errorf("json[%d]: either 'matches' or 'exists' needs to be defined", i))
+			continue
+		}
+		path, err := parsePath(jd.Path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("json[%d]: %w", i, err))
+			continue
+		}
+		r.json = append(r.json, jsonPredicate{
+			path:    path,
+			matches: compile(fmt.Sprintf("json[%d].matches", i), jd.Matches),
+			exists:  jd.Exists,
+		})
+	}
+
+	return r, errors.Join(errs...)
+}
+
+// parsePath splits a path like 'a.b[0].c' into its object keys (strings) and array indices (ints).
+func parsePath(path string) ([]any, error) {
+	if path == "" {
+		return nil, errors.New("path must not be empty")
+	}
+
+	var elements []any
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key == "" && rest == "" {
+			return nil, fmt.Errorf("invalid path %q: empty segment", path)
+		}
+		if key != "" {
+			elements = append(elements, key)
+		}
+		for rest != "" {
+			index, after, found := strings.Cut(rest, "]")
+			if !found {
+				return nil, fmt.Errorf("invalid path %q: missing ']'", path)
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, index)
+			}
+			elements = append(elements, i)
+			if after != "" && !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid path %q: unexpected %q after index", path, after)
+			}
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return elements, nil
+}
+
+// Apply returns the configurations that are kept by the filters. Discarded configurations are logged.
+func (f *Filters) Apply(configs project.ConfigsPerType) project.ConfigsPerType {
+	if f == nil {
+		return configs
+	}
+	return Select(configs, f.Keep)
+}
+
+// Select returns the configurations for which keep returns true. Discarded configurations are logged with the reason
+// returned by keep. Kept settings objects inserted after a discarded object are inserted after the predecessor of the
+// discarded object instead, or at the beginning if it has none.
+func Select(configs project.ConfigsPerType, keep func(config.Config) (bool, string)) project.ConfigsPerType {
+	result := make(project.ConfigsPerType, len(configs))
+	// discarded holds the insertAfter parameter of each discarded config, or nil if it has none
+	discarded := make(map[coordinate.Coordinate]parameter.Parameter)
+	for t, cs := range configs {
+		for _, c := range cs {
+			if ok, reason := keep(c); !ok {
+				log.WithFields(field.Coordinate(c.Coordinate)).Debug("Discarded %s. Reason: %s", c.Coordinate, reason)
+				discarded[c.Coordinate] = c.Parameters[config.InsertAfterParameter]
+				continue
+			}
+			result[t] = append(result[t], c)
+		}
+	}
+
+	if len(discarded) == 0 {
+		return result
+	}
+	for _, cs := range result {
+		for i, c := range cs {
+			if insertAfter, changed := relinkInsertAfter(c.Parameters[config.InsertAfterParameter], discarded); changed {
+				cs[i].Parameters = maps.Clone(c.Parameters)
+				if insertAfter == nil {
+					delete(cs[i].Parameters, config.InsertAfterParameter)
+				} else {
+					cs[i].Parameters[config.InsertAfterParameter] = insertAfter
+				}
+			}
+		}
+	}
+	return result
+}
+
+// relinkInsertAfter follows the given insertAfter parameter past all discarded configs. It returns the first parameter
+// not pointing to a discarded config, or nil if there is none, and whether it differs from the given parameter.
+func relinkInsertAfter(insertAfter parameter.Parameter, discarded map[coordinate.Coordinate]parameter.Parameter) (parameter.Parameter, bool) {
+	changed := false
+	for insertAfter != nil {
+		ref, ok := insertAfter.(*reference.ReferenceParameter)
+		if !ok {
+			break
+		}
+		predecessor, found := discarded[ref.Config]
+		if !found {
+			break
+		}
+		insertAfter, changed = predecessor, true
+	}
+	return insertAfter, changed
+}
+
+// Keep returns whether the given configuration is kept by the filters, and the reason if it is not.
+func (f *Filters) Keep(c config.Config) (bool, string) {
+	if f == nil {
+		return true, ""
+	}
+
+	var tf typeFilter
+	var found bool
+	switch t := c.Type.(type) {
+	case config.ClassicApiType:
+		tf, found = f.apis[t.Api]
+	case config.SettingsType:
+		tf, found = f.settings[t.SchemaId]
+	}
+	if !found {
+		return true, ""
+	}
+
+	o := newObject(c)
+	for i, r := range tf.exclude {
+		if r.matches(o) {
+			return false, fmt.Sprintf("matches exclude rule %d of the filter file", i)
+		}
+	}
+
+	if len(tf.include) == 0 {
+		return true, ""
+	}
+	for _, r := range tf.include {
+		if r.matches(o) {
+			return true, ""
+		}
+	}
+	return false, "matches none of the include rules of the filter file"
+}
+
+// object holds the properties of a configuration the filter rules are evaluated on.
+type object struct {
+	payload  any
+	name     *string
+	owner    *string
+	scope    *string
+	modified time.Time
+}
+
+func newObject(c config.Config) object {
+	var o object
+	if content, err := c.Template.Content(); err == nil {
+		if err := json.Unmarshal([]byte(content), &o.payload); err != nil {
+			o.payload = nil
+		}
+	}
+
+	o.name = stringParameter(c, config.NameParameter)
+	if o.name == nil {
+		o.name = stringProperty(o.payload, "name")
+	}
+	o.owner = stringProperty(o.payload, "owner")
+	if o.owner == nil {
+		o.owner = stringProperty(o.payload, "dashboardMetadata", "owner")
+	}
+	o.scope = stringParameter(c, config.ScopeParameter)
+	o.modified = c.OriginModified
+	return o
+}
+
+func stringParameter(c config.Config, name string) *string {
+	if p, ok := c.Parameters[name].(*value.ValueParameter); ok {
+		if s, ok := p.Value.(string); ok {
+			return &s
+		}
+	}
+	return nil
+}
+
+func stringProperty(payload any, path ...any) *string {
+	if v, found := lookup(payload, path); found {
+		if s, ok := v.(string); ok {
+			return &s
+		}
+	}
+	return nil
+}
+
+func lookup(payload any, path []any) (any, bool) {
+	current := payload
+	for _, element := range path {
+		switch e := element.(type) {
+		case string:
+			m, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if current, ok = m[e]; !ok {
+				return nil, false
+			}
+		case int:
+			s, ok := current.([]any)
+			if !ok || e >= len(s) {
+				return nil, false
+			}
+			current = s[e]
+		}
+	}
+	return current, true
+}
+
+func (r rule) matches(o object) bool {
+	if !matchesRegex(r.name, o.name) || !matchesRegex(r.owner, o.owner) || !matchesRegex(r.scope, o.scope) {
+		return false
+	}
+
+	// objects with an unknown modification time never match a condition on it
+	if !r.modifiedAfter.IsZero() && (o.modified.IsZero() || !o.modified.After(r.modifiedAfter)) {
+		return false
+	}
+	if !r.modifiedBefore.IsZero() && (o.modified.IsZero() || !o.modified.Before(r.modifiedBefore)) {
+		return false
+	}
+
+	for _, p := range r.json {
+		if !p.matchesPayload(o.payload) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesRegex(re *regexp.Regexp, s *string) bool {
+	if re == nil {
+		return true
+	}
+	return s != nil && re.MatchString(*s)
+}
+
+func (p jsonPredicate) matchesPayload(payload any) bool {
+	v, found := lookup(payload, p.path)
+	if p.exists != nil && *p.exists != found {
+		return false
+	}
+	if p.matches == nil {
+		return true
+	}
+	if !found {
+		return false
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		s = string(b)
+	}
+	return p.matches.MatchString(s)
+}
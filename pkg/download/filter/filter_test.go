@@ -0,0 +1,266 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+const schema = "builtin:alerting.profile"
+
+func dashboard(id, name, content string) config.Config {
+	return config.Config{
+		Template:   template.NewInMemoryTemplate(id, content),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: api.Dashboard, ConfigId: id},
+		Type:       config.ClassicApiType{Api: api.Dashboard},
+		Parameters: config.Parameters{config.NameParameter: value.New(name)},
+	}
+}
+
+func setting(id, scope, content string, modified time.Time) config.Config {
+	return config.Config{
+		Template:       template.NewInMemoryTemplate(id, content),
+		Coordinate:     coordinate.Coordinate{Project: "project", Type: schema, ConfigId: id},
+		Type:           config.SettingsType{SchemaId: schema},
+		Parameters:     config.Parameters{config.ScopeParameter: value.New(scope)},
+		OriginModified: modified,
+	}
+}
+
+func mustParse(t *testing.T, content string) *Filters {
+	t.Helper()
+	f, err := parse([]byte(content))
+	require.NoError(t, err)
+	return f
+}
+
+func TestKeep_IncludeByOwner(t *testing.T) {
+	f := mustParse(t, `
+apis:
+  dashboard:
+    include:
+      - owner: "@my-team\\.com$"
+`)
+
+	ours := dashboard("a", "ours", `{"dashboardMetadata": {"owner": "jane@my-team.com"}}`)
+	theirs := dashboard("b", "theirs", `{"dashboardMetadata": {"owner": "john@other-team.com"}}`)
+
+	keep, _ := f.Keep(ours)
+	assert.True(t, keep)
+	keep, reason := f.Keep(theirs)
+	assert.False(t, keep)
+	assert.NotEmpty(t, reason)
+}
+
+func TestKeep_ExcludeHasPrecedence(t *testing.T) {
+	f := mustParse(t, `
+apis:
+  dashboard:
+    include:
+      - name: "^team-"
+    exclude:
+      - name: "-test$"
+`)
+
+	keep, _ := f.Keep(dashboard("a", "team-overview", "{}"))
+	assert.True(t, keep)
+	keep, _ = f.Keep(dashboard("b", "team-overview-test", "{}"))
+	assert.False(t, keep)
+}
+
+func TestKeep_AllConditionsOfARuleNeedToMatch(t *testing.T) {
+	f := mustParse(t, `
+settings:
+  builtin:alerting.profile:
+    exclude:
+      - scope: "^HOST-"
+        json:
+          - path: "severityRules[0].severityLevel"
+            matches: "^AVAILABILITY$"
+`)
+
+	content := `{"severityRules": [{"severityLevel": "AVAILABILITY"}]}`
+	keep, _ := f.Keep(setting("a", "HOST-1234567890123456", content, time.Time{}))
+	assert.False(t, keep)
+	keep, _ = f.Keep(setting("b", "environment", content, time.Time{}))
+	assert.True(t, keep)
+	keep, _ = f.Keep(setting("c", "HOST-1234567890123456", `{"severityRules": []}`, time.Time{}))
+	assert.True(t, keep)
+}
+
+func TestKeep_JSONPredicates(t *testing.T) {
+	f := mustParse(t, `
+settings:
+  builtin:alerting.profile:
+    include:
+      - json:
+          - path: "enabled"
+            matches: "^true$"
+          - path: "managementZone"
+            exists: false
+`)
+
+	keep, _ := f.Keep(setting("a", "environment", `{"enabled": true}`, time.Time{}))
+	assert.True(t, keep)
+	keep, _ = f.Keep(setting("b", "environment", `{"enabled": false}`, time.Time{}))
+	assert.False(t, keep)
+	keep, _ = f.Keep(setting("c", "environment", `{"enabled": true, "managementZone": "zone"}`, time.Time{}))
+	assert.False(t, keep)
+}
+
+func TestKeep_ModificationTime(t *testing.T) {
+	f := mustParse(t, `
+settings:
+  builtin:alerting.profile:
+    include:
+      - modifiedAfter: "2025-01-01T00:00:00Z"
+`)
+
+	keep, _ := f.Keep(setting("a", "environment", "{}", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, keep)
+	keep, _ = f.Keep(setting("b", "environment", "{}", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, keep)
+	keep, _ = f.Keep(setting("c", "environment", "{}", time.Time{}))
+	assert.False(t, keep, "objects with an unknown modification time don't match")
+}
+
+func TestKeep_TypesWithoutFiltersAreKept(t *testing.T) {
+	f := mustParse(t, `
+apis:
+  dashboard:
+    include:
+      - name: "^nothing$"
+`)
+
+	keep, _ := f.Keep(setting("a", "environment", "{}", time.Time{}))
+	assert.True(t, keep)
+
+	var nilFilters *Filters
+	keep, _ = nilFilters.Keep(dashboard("a", "any", "{}"))
+	assert.True(t, keep)
+}
+
+func TestApply(t *testing.T) {
+	f := mustParse(t, `
+apis:
+  dashboard:
+    exclude:
+      - name: "^drop"
+`)
+
+	configs := project.ConfigsPerType{
+		api.Dashboard: {dashboard("a", "keep", "{}"), dashboard("b", "drop", "{}")},
+		schema:        {setting("c", "environment", "{}", time.Time{})},
+	}
+
+	result := f.Apply(configs)
+	require.Len(t, result[api.Dashboard], 1)
+	assert.Equal(t, "a", result[api.Dashboard][0].Coordinate.ConfigId)
+	assert.Len(t, result[schema], 1)
+}
+
+func TestApply_InsertAfterOfDiscardedSettingsIsRelinked(t *testing.T) {
+	f := mustParse(t, `
+settings:
+  builtin:alerting.profile:
+    exclude:
+      - json:
+          - path: drop
+            exists: true
+`)
+
+	first := setting("first", "environment", "{}", time.Time{})
+	dropped := setting("dropped", "environment", `{"drop": true}`, time.Time{})
+	dropped.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(first.Coordinate, "id")
+	droppedToo := setting("dropped-too", "environment", `{"drop": true}`, time.Time{})
+	droppedToo.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(dropped.Coordinate, "id")
+	last := setting("last", "environment", "{}", time.Time{})
+	last.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(droppedToo.Coordinate, "id")
+
+	droppedFirst := setting("dropped-first", "other", `{"drop": true}`, time.Time{})
+	second := setting("second", "other", "{}", time.Time{})
+	second.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(droppedFirst.Coordinate, "id")
+
+	result := f.Apply(project.ConfigsPerType{schema: {first, dropped, droppedToo, last, droppedFirst, second}})
+
+	require.Len(t, result[schema], 3)
+	assert.Equal(t, reference.NewWithCoordinate(first.Coordinate, "id"), result[schema][1].Parameters[config.InsertAfterParameter])
+	assert.NotContains(t, result[schema][2].Parameters, config.InsertAfterParameter)
+
+	// the original configurations are not modified
+	assert.Equal(t, reference.NewWithCoordinate(droppedToo.Coordinate, "id"), last.Parameters[config.InsertAfterParameter])
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown property", "apis:\n  dashboard:\n    include:\n      - unknown: x\n"},
+		{"unknown API", "apis:\n  not-an-api:\n    include:\n      - name: x\n"},
+		{"invalid regex", "apis:\n  dashboard:\n    include:\n      - name: \"(\"\n"},
+		{"invalid timestamp", "apis:\n  dashboard:\n    include:\n      - modifiedAfter: yesterday\n"},
+		{"owner of settings object", "settings:\n  builtin:alerting.profile:\n    include:\n      - owner: x\n"},
+		{"empty rule", "apis:\n  dashboard:\n    include:\n      - {}\n"},
+		{"json predicate without condition", "apis:\n  dashboard:\n    include:\n      - json:\n          - path: a\n"},
+		{"invalid path", "apis:\n  dashboard:\n    include:\n      - json:\n          - path: \"a[x]\"\n            exists: true\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse([]byte(tc.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := parsePath("a.b[0][1].c")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b", 0, 1, "c"}, path)
+
+	_, err = parsePath("a..b")
+	assert.Error(t, err)
+	_, err = parsePath("a[0")
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "filters.yaml", []byte("settings:\n  builtin:alerting.profile:\n    include:\n      - name: x\n"), 0644))
+
+	f, err := Load(fs, "filters.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, f.settings, schema)
+
+	_, err = Load(fs, "missing.yaml")
+	assert.Error(t, err)
+}
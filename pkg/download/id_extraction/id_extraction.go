@@ -18,7 +18,9 @@ package id_extraction
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -42,6 +44,11 @@ const baseParamID = "extractedIDs"
 func ExtractIDsIntoYAML(configsPerType project.ConfigsPerType) (project.ConfigsPerType, error) {
 	for _, cfgs := range configsPerType {
 		for _, c := range cfgs {
+			// extension packages are binary archives which must not be modified
+			if c.Coordinate.Type == string(config.ExtensionV2TypeID) {
+				continue
+			}
+
 			content, err := c.Template.Content()
 			if err != nil {
 				return nil, fmt.Errorf("failed to extract IDs from %s: %w", c.Coordinate, err)
@@ -95,6 +102,64 @@ func ExtractIDsIntoYAML(configsPerType project.ConfigsPerType) (project.ConfigsP
 	return configsPerType, nil
 }
 
+var extractedIDPattern = regexp.MustCompile(`{{ \.` + baseParamID + `\.(id_[a-zA-Z0-9_]+) }}`)
+
+// EnumerateExtractedIDs renames the parameters created by ExtractIDsIntoYAML to 'id_1', 'id_2', ..., numbered in the
+// order they first occur in the config's template. Other than keys derived from the IDs themselves, these keys are the
+// same for configs whose templates only differ in the extracted IDs - e.g. the same object downloaded from several
+// environments - which allows them to share a single template. It modifies the given configsPerType map.
+func EnumerateExtractedIDs(configsPerType project.ConfigsPerType) (project.ConfigsPerType, error) {
+	for _, cfgs := range configsPerType {
+		for _, c := range cfgs {
+			p, found := c.Parameters[baseParamID].(*value.ValueParameter)
+			if !found {
+				continue
+			}
+			ids, ok := p.Value.(map[string]string)
+			if !ok {
+				continue
+			}
+
+			content, err := c.Template.Content()
+			if err != nil {
+				return nil, fmt.Errorf("failed to enumerate IDs of %s: %w", c.Coordinate, err)
+			}
+
+			var orderedKeys []string
+			for _, m := range extractedIDPattern.FindAllStringSubmatch(content, -1) {
+				if _, exists := ids[m[1]]; exists && !slices.Contains(orderedKeys, m[1]) {
+					orderedKeys = append(orderedKeys, m[1])
+				}
+			}
+			// IDs that are not part of the template, e.g. the scope, are appended in a stable order
+			remainingKeys := slices.Sorted(maps.Keys(ids))
+			for _, k := range remainingKeys {
+				if !slices.Contains(orderedKeys, k) {
+					orderedKeys = append(orderedKeys, k)
+				}
+			}
+
+			enumerated := make(map[string]string, len(ids))
+			var replacements []string
+			for i, k := range orderedKeys {
+				newKey := fmt.Sprintf("id_%d", i+1)
+				enumerated[newKey] = ids[k]
+				replacements = append(replacements, fmt.Sprintf("{{ .%s.%s }}", baseParamID, k), fmt.Sprintf("{{ .%s.%s }}", baseParamID, newKey))
+
+				if scope, ok := c.Parameters[config.ScopeParameter].(*ref.ReferenceParameter); ok && scope.Config == c.Coordinate && scope.Property == baseParamID+"."+k {
+					scope.Property = baseParamID + "." + newKey
+				}
+			}
+
+			if err := c.Template.UpdateContent(strings.NewReplacer(replacements...).Replace(content)); err != nil {
+				return nil, fmt.Errorf("failed to enumerate IDs of %s: %w", c.Coordinate, err)
+			}
+			c.Parameters[baseParamID] = value.New(enumerated)
+		}
+	}
+	return configsPerType, nil
+}
+
 // invalidMeId finds ME IDs in the form of `nABC`, `rABC`, and `tABC`. They are most commonly mistakenly created by `\nABC`.
 var invalidMeId = regexp.MustCompile("[nrt][A-Z]+")
 
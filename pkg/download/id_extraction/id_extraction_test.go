@@ -19,6 +19,7 @@
 package id_extraction
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -522,3 +523,53 @@ func TestFindAllIds(t *testing.T) {
 	}
 
 }
+
+func TestEnumerateExtractedIDs(t *testing.T) {
+	newConfigs := func(hostID, mzID string) project.ConfigsPerType {
+		return project.ConfigsPerType{
+			"test-type": []config.Config{
+				{
+					Template:   template.NewInMemoryTemplate("test-tmpl", fmt.Sprintf(`{ "host": "%s", "mz": "%s", "again": "%s" }`, hostID, mzID, hostID)),
+					Parameters: config.Parameters{},
+				},
+			},
+		}
+	}
+
+	dev, err := ExtractIDsIntoYAML(newConfigs("HOST-1234567890123456", "MANAGEMENT_ZONE-ABCDEF1234567890"))
+	assert.NoError(t, err)
+	dev, err = EnumerateExtractedIDs(dev)
+	assert.NoError(t, err)
+
+	prod, err := ExtractIDsIntoYAML(newConfigs("HOST-6543210987654321", "MANAGEMENT_ZONE-0987654321FEDCBA"))
+	assert.NoError(t, err)
+	prod, err = EnumerateExtractedIDs(prod)
+	assert.NoError(t, err)
+
+	devContent, err := dev["test-type"][0].Template.Content()
+	assert.NoError(t, err)
+	prodContent, err := prod["test-type"][0].Template.Content()
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{ "host": "{{ .extractedIDs.id_1 }}", "mz": "{{ .extractedIDs.id_2 }}", "again": "{{ .extractedIDs.id_1 }}" }`, devContent)
+	assert.Equal(t, devContent, prodContent)
+
+	assert.Equal(t, value.New(map[string]string{"id_1": "HOST-1234567890123456", "id_2": "MANAGEMENT_ZONE-ABCDEF1234567890"}), dev["test-type"][0].Parameters[baseParamID])
+	assert.Equal(t, value.New(map[string]string{"id_1": "HOST-6543210987654321", "id_2": "MANAGEMENT_ZONE-0987654321FEDCBA"}), prod["test-type"][0].Parameters[baseParamID])
+}
+
+func TestEnumerateExtractedIDs_UpdatesScopeReference(t *testing.T) {
+	t.Setenv(featureflags.ExtractScopeAsParameter.EnvName(), "true")
+
+	c := config.Config{
+		Template:   template.NewInMemoryTemplate("test-tmpl", `{ "host": "HOST-1234567890123456" }`),
+		Parameters: config.Parameters{config.ScopeParameter: value.New("HOST_GROUP-1234567890123456")},
+	}
+	configs, err := ExtractIDsIntoYAML(project.ConfigsPerType{"test-type": {c}})
+	assert.NoError(t, err)
+	configs, err = EnumerateExtractedIDs(configs)
+	assert.NoError(t, err)
+
+	assert.Equal(t, value.New(map[string]string{"id_1": "HOST-1234567890123456", "id_2": "HOST_GROUP-1234567890123456"}), configs["test-type"][0].Parameters[baseParamID])
+	assert.Equal(t, &ref.ReferenceParameter{ParameterReference: parameter.ParameterReference{Property: "extractedIDs.id_2"}}, configs["test-type"][0].Parameters[config.ScopeParameter])
+}
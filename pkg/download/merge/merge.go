@@ -0,0 +1,488 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package merge merges downloaded configurations into an existing monaco project. Downloaded objects are matched to the
+// configurations of the project by their object ID, or by the IDs monaco derives when deploying a configuration.
+// Only the JSON templates of matched configurations are updated, while parameters, overrides and the file layout of
+// the project are kept. Objects without a counterpart are added as new configurations.
+package merge
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/id_extraction"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/openpipeline/partial"
+	configwriter "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/writer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Options configures into which project downloaded configurations are merged.
+type Options struct {
+	// ProjectFolder is the path of the existing project.
+	ProjectFolder string
+	// ProjectName is the name of the existing project. It needs to match the name the project was deployed with, as
+	// monaco derives the IDs of deployed objects from it.
+	ProjectName string
+	// Environment the configurations were downloaded from. Its group, variables and labels are used to load the
+	// existing project, like on deployment.
+	Environment manifest.EnvironmentDefinition
+}
+
+// Issue describes a configuration that needs a manual review after merging.
+type Issue struct {
+	Coordinate coordinate.Coordinate
+	Message    string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Coordinate, i.Message)
+}
+
+// Result summarizes the changes made to the existing project.
+type Result struct {
+	// Updated contains all existing configurations whose template was updated.
+	Updated []coordinate.Coordinate
+	// Unchanged contains all existing configurations whose template already matched the downloaded object.
+	Unchanged []coordinate.Coordinate
+	// Added contains all downloaded objects that were added to the project as new configurations.
+	Added []coordinate.Coordinate
+	// Disappeared contains all existing configurations of downloaded types that were not found in the environment.
+	Disappeared []coordinate.Coordinate
+	// Issues contains all problems that need a manual review.
+	Issues []Issue
+}
+
+const (
+	mergedConfigFileName      = "merged"
+	mergedConfigFileExtension = ".yaml"
+)
+
+// Merge merges the downloaded configurations into the existing project defined by the options.
+// The downloaded configurations are expected as returned by the download, i.e. before dependencies are resolved.
+func Merge(ctx context.Context, fs afero.Fs, opts Options, downloaded project.ConfigsPerType) (Result, error) {
+	existing, err := loadExistingConfigs(ctx, fs, opts)
+	if err != nil {
+		return Result{}, err
+	}
+
+	matches := matchConfigs(existing, downloaded)
+
+	// payloads need to be captured before the dependency resolution replaces IDs by parameters that only exist for the
+	// downloaded configurations
+	payloads := make(map[coordinate.Coordinate]string, len(matches))
+	for c := range downloaded.AllConfigs {
+		if _, found := matches[c.Coordinate]; !found {
+			continue
+		}
+		content, err := c.Template.Content()
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to read downloaded configuration %q: %w", c.Coordinate, err)
+		}
+		payloads[c.Coordinate] = content
+	}
+
+	resolved, err := dependency_resolution.ResolveDependencies(downloaded)
+	if err != nil {
+		return Result{}, err
+	}
+	// must happen after dep-resolution, as it removes IDs from the JSONs in which the dep-resolution searches as well
+	resolved, err = id_extraction.ExtractIDsIntoYAML(resolved)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	updateTemplates(fs, filepath.Dir(opts.ProjectFolder), matches, payloads, &result)
+
+	if err := addNewConfigs(fs, opts, existing, resolved, matches, &result); err != nil {
+		return Result{}, err
+	}
+
+	result.Disappeared = findDisappeared(existing, downloaded, matches)
+
+	for _, s := range [][]coordinate.Coordinate{result.Updated, result.Unchanged, result.Added, result.Disappeared} {
+		slices.SortFunc(s, compareCoordinates)
+	}
+	return result, nil
+}
+
+// loadExistingConfigs loads all configurations of the existing project, as they are defined for the given environment.
+func loadExistingConfigs(ctx context.Context, fs afero.Fs, opts Options) ([]config.Config, error) {
+	m := manifest.Manifest{
+		Projects: manifest.ProjectDefinitionByProjectID{
+			opts.ProjectName: {Name: opts.ProjectName, Path: filepath.Base(opts.ProjectFolder)},
+		},
+		Environments: manifest.Environments{
+			opts.Environment.Name: opts.Environment,
+		},
+	}
+
+	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(opts.ProjectFolder),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, []string{opts.ProjectName})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to load project %q: %w", opts.ProjectFolder, errors.Join(errs...))
+	}
+
+	var configs []config.Config
+	for _, p := range projects {
+		if p.Id != opts.ProjectName {
+			continue
+		}
+		for c := range p.Configs[opts.Environment.Name].AllConfigs {
+			configs = append(configs, c)
+		}
+	}
+	slices.SortFunc(configs, func(a, b config.Config) int { return compareCoordinates(a.Coordinate, b.Coordinate) })
+	return configs, nil
+}
+
+// matchKey identifies a remote object by one of its IDs, or by a property that is unique for its type.
+type matchKey struct {
+	configType string
+	kind       string
+	value      string
+}
+
+const (
+	objectIdKey   = "objectId"
+	externalIdKey = "externalId"
+	kindKey       = "kind"
+	partKey       = "part"
+	nameKey       = "name"
+	singletonKey  = "singleton"
+)
+
+// matchConfigs returns the existing configuration matched to each downloaded configuration, keyed by the coordinate of
+// the downloaded configuration. Every existing configuration is matched at most once.
+func matchConfigs(existing []config.Config, downloaded project.ConfigsPerType) map[coordinate.Coordinate]config.Config {
+	index := make(map[matchKey]config.Config)
+	for _, c := range existing {
+		for _, k := range existingKeys(c) {
+			if _, found := index[k]; !found {
+				index[k] = c
+			}
+		}
+	}
+
+	var configs []config.Config
+	for c := range downloaded.AllConfigs {
+		configs = append(configs, c)
+	}
+	slices.SortFunc(configs, func(a, b config.Config) int { return compareCoordinates(a.Coordinate, b.Coordinate) })
+
+	matched := make(map[coordinate.Coordinate]struct{})
+	matches := make(map[coordinate.Coordinate]config.Config)
+	for _, c := range configs {
+		for _, k := range downloadedKeys(c) {
+			e, found := index[k]
+			if _, alreadyMatched := matched[e.Coordinate]; !found || alreadyMatched {
+				continue
+			}
+			matched[e.Coordinate] = struct{}{}
+			matches[c.Coordinate] = e
+			break
+		}
+	}
+	return matches
+}
+
+// existingKeys returns all keys the remote object of an existing configuration might be found by.
+// Apart from the object ID of downloaded configurations, these are the IDs monaco uses when deploying them.
+func existingKeys(c config.Config) []matchKey {
+	t := c.Coordinate.Type
+	var keys []matchKey
+	if c.OriginObjectId != "" {
+		keys = append(keys, matchKey{t, objectIdKey, c.OriginObjectId})
+	}
+
+	switch typ := c.Type.(type) {
+	case config.SettingsType:
+		if id, err := idutils.GenerateExternalIDForSettingsObject(c.Coordinate); err == nil {
+			keys = append(keys, matchKey{t, externalIdKey, id})
+		}
+		legacy := coordinate.Coordinate{Type: c.Coordinate.Type, ConfigId: c.Coordinate.ConfigId}
+		if id, err := idutils.GenerateExternalIDForSettingsObject(legacy); err == nil {
+			keys = append(keys, matchKey{t, externalIdKey, id})
+		}
+	case config.DocumentType, config.Segment, config.ServiceLevelObjective:
+		keys = append(keys, matchKey{t, externalIdKey, idutils.GenerateExternalID(c.Coordinate)})
+	case config.AutomationType:
+		keys = append(keys, matchKey{t, objectIdKey, idutils.GenerateUUIDFromCoordinate(c.Coordinate)})
+	case config.BucketType:
+		keys = append(keys, matchKey{t, objectIdKey, idutils.GenerateBucketName(c.Coordinate)})
+	case config.OpenPipelineType:
+		if k, ok := openPipelineKey(c, typ); ok {
+			keys = append(keys, k)
+		}
+	case config.ExtensionV2Type:
+		// downloaded extensions use the extension name as config ID
+		keys = append(keys, matchKey{t, objectIdKey, c.Coordinate.ConfigId})
+	case config.ClassicApiType:
+		// configurations downloaded earlier use the object ID as config ID
+		keys = append(keys, matchKey{t, objectIdKey, c.Coordinate.ConfigId})
+		keys = append(keys, matchKey{t, objectIdKey, idutils.GenerateUUIDFromConfigId(c.Coordinate.Project, c.Coordinate.ConfigId)})
+		if name, ok := nameOf(c); ok {
+			keys = append(keys, matchKey{t, nameKey, name})
+		}
+		if isSingleton(typ.Api) {
+			keys = append(keys, matchKey{t, singletonKey, ""})
+		}
+	}
+	return keys
+}
+
+// downloadedKeys returns all keys a downloaded configuration is matched by, in order of precedence.
+func downloadedKeys(c config.Config) []matchKey {
+	t := c.Coordinate.Type
+	var keys []matchKey
+	if c.OriginObjectId != "" {
+		keys = append(keys, matchKey{t, objectIdKey, c.OriginObjectId})
+	}
+	if c.OriginExternalId != "" {
+		keys = append(keys, matchKey{t, externalIdKey, c.OriginExternalId})
+	}
+
+	switch typ := c.Type.(type) {
+	case config.OpenPipelineType:
+		if k, ok := openPipelineKey(c, typ); ok {
+			keys = append(keys, k)
+		}
+	case config.ClassicApiType:
+		// the classic download uses the object ID as config ID
+		keys = append(keys, matchKey{t, objectIdKey, c.Coordinate.ConfigId})
+		if name, ok := nameOf(c); ok {
+			keys = append(keys, matchKey{t, nameKey, name})
+		}
+		if isSingleton(typ.Api) {
+			keys = append(keys, matchKey{t, singletonKey, ""})
+		}
+	}
+	return keys
+}
+
+// openPipelineKey returns the key of an openpipeline configuration. Configurations of a whole kind are identified by
+// their kind, partial configurations by the object they define within the kind, e.g. a processor of a pipeline stage.
+func openPipelineKey(c config.Config, typ config.OpenPipelineType) (matchKey, bool) {
+	t := c.Coordinate.Type
+	if typ.Partial == nil {
+		return matchKey{t, kindKey, typ.Kind}, true
+	}
+
+	content, err := c.Template.Content()
+	if err != nil {
+		return matchKey{}, false
+	}
+	id, err := partial.ObjectID(typ.Partial.Type, []byte(content))
+	if err != nil {
+		return matchKey{}, false
+	}
+	return matchKey{t, partKey, strings.Join([]string{typ.Kind, string(typ.Partial.Type), typ.Partial.Pipeline, typ.Partial.Stage, id}, "/")}, true
+}
+
+// nameOf returns the name of a configuration, if it is defined by a plain value.
+func nameOf(c config.Config) (string, bool) {
+	p, ok := c.Parameters[config.NameParameter].(*value.ValueParameter)
+	if !ok {
+		return "", false
+	}
+	name, ok := p.Value.(string)
+	return name, ok && name != ""
+}
+
+func isSingleton(apiId string) bool {
+	a, found := api.NewAPIs()[apiId]
+	return found && a.SingleConfiguration && !a.HasParent()
+}
+
+// updateTemplates merges the downloaded payloads into the template files of the matched configurations.
+// Template files are only written if their content changes.
+func updateTemplates(fs afero.Fs, workingDir string, matches map[coordinate.Coordinate]config.Config, payloads map[coordinate.Coordinate]string, result *Result) {
+	written := make(map[string]string)
+
+	for _, downloadedCoordinate := range sortedKeys(matches) {
+		c := matches[downloadedCoordinate]
+
+		t, ok := c.Template.(*template.FileBasedTemplate)
+		if !ok {
+			result.Issues = append(result.Issues, Issue{c.Coordinate, "template is not stored in a file and can not be updated"})
+			continue
+		}
+
+		current, err := c.Template.Content()
+		if err != nil {
+			result.Issues = append(result.Issues, Issue{c.Coordinate, fmt.Sprintf("failed to read template: %v", err)})
+			continue
+		}
+
+		merged, changed, err := mergeTemplate(current, payloads[downloadedCoordinate])
+		if err != nil {
+			result.Issues = append(result.Issues, Issue{c.Coordinate, fmt.Sprintf("template %q could not be merged and needs to be updated manually: %v", t.FilePath(), err)})
+			continue
+		}
+
+		path := filepath.Join(workingDir, t.FilePath())
+		if previous, found := written[path]; found {
+			if previous != merged {
+				result.Issues = append(result.Issues, Issue{c.Coordinate, fmt.Sprintf("template %q is shared with another configuration whose object differs and was not updated", t.FilePath())})
+			}
+			continue
+		}
+		written[path] = merged
+
+		if !changed {
+			result.Unchanged = append(result.Unchanged, c.Coordinate)
+			continue
+		}
+
+		if err := afero.WriteFile(fs, path, []byte(merged), 0664); err != nil {
+			result.Issues = append(result.Issues, Issue{c.Coordinate, fmt.Sprintf("failed to write template %q: %v", t.FilePath(), err)})
+			continue
+		}
+		log.WithFields(field.Coordinate(c.Coordinate), field.F("file", t.FilePath())).Debug("Updated template %q", t.FilePath())
+		result.Updated = append(result.Updated, c.Coordinate)
+	}
+}
+
+// addNewConfigs writes all downloaded configurations that were not matched to an existing one as new configurations.
+// References to matched configurations are changed to reference the existing configuration instead.
+func addNewConfigs(fs afero.Fs, opts Options, existing []config.Config, resolved project.ConfigsPerType, matches map[coordinate.Coordinate]config.Config, result *Result) error {
+	existingCoordinates := make(map[coordinate.Coordinate]struct{}, len(existing))
+	for _, c := range existing {
+		existingCoordinates[c.Coordinate] = struct{}{}
+	}
+
+	var configs []config.Config
+	for c := range resolved.AllConfigs {
+		if _, found := matches[c.Coordinate]; found {
+			continue
+		}
+		if _, found := existingCoordinates[c.Coordinate]; found {
+			result.Issues = append(result.Issues, Issue{c.Coordinate, "a different configuration with the same ID already exists in the project - the downloaded object was not added"})
+			continue
+		}
+
+		for name, p := range c.Parameters {
+			if ref, ok := p.(*reference.ReferenceParameter); ok {
+				if e, found := matches[ref.Config]; found {
+					c.Parameters[name] = reference.NewWithCoordinate(e.Coordinate, ref.Property)
+				}
+			}
+		}
+		configs = append(configs, c)
+		result.Added = append(result.Added, c.Coordinate)
+	}
+
+	if len(configs) == 0 {
+		return nil
+	}
+
+	fileName, err := newConfigFileName(fs, opts.ProjectFolder)
+	if err != nil {
+		return err
+	}
+
+	errs := configwriter.WriteConfigs(&configwriter.WriterContext{
+		Fs:              fs,
+		OutputFolder:    opts.ProjectFolder,
+		ParametersSerde: config.DefaultParameterParsers,
+		ConfigFileName:  fileName,
+	}, configs)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to write new configurations: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// newConfigFileName returns a name for the config files of new configurations that is not used by any file of the
+// project yet, so that no existing config file is overwritten.
+func newConfigFileName(fs afero.Fs, projectFolder string) (string, error) {
+	used := make(map[string]struct{})
+	err := afero.Walk(fs, projectFolder, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		used[info.Name()] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list files of project %q: %w", projectFolder, err)
+	}
+
+	name := mergedConfigFileName + mergedConfigFileExtension
+	for i := 1; ; i++ {
+		if _, found := used[name]; !found {
+			return name, nil
+		}
+		name = mergedConfigFileName + "-" + strconv.Itoa(i) + mergedConfigFileExtension
+	}
+}
+
+// findDisappeared returns all existing configurations of downloaded types that were not matched to a downloaded object.
+func findDisappeared(existing []config.Config, downloaded project.ConfigsPerType, matches map[coordinate.Coordinate]config.Config) []coordinate.Coordinate {
+	matched := make(map[coordinate.Coordinate]struct{}, len(matches))
+	for _, e := range matches {
+		matched[e.Coordinate] = struct{}{}
+	}
+
+	var disappeared []coordinate.Coordinate
+	for _, c := range existing {
+		if _, downloadedType := downloaded[c.Coordinate.Type]; !downloadedType {
+			continue
+		}
+		if _, found := matched[c.Coordinate]; !found {
+			disappeared = append(disappeared, c.Coordinate)
+		}
+	}
+	return disappeared
+}
+
+func sortedKeys(m map[coordinate.Coordinate]config.Config) []coordinate.Coordinate {
+	keys := make([]coordinate.Coordinate, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, compareCoordinates)
+	return keys
+}
+
+func compareCoordinates(a, b coordinate.Coordinate) int {
+	return cmp.Or(cmp.Compare(a.Project, b.Project), cmp.Compare(a.Type, b.Type), cmp.Compare(a.ConfigId, b.ConfigId))
+}
@@ -0,0 +1,301 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package merge
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+const (
+	tagSchema    = "builtin:tags.auto-tagging"
+	profileApi   = api.AlertingProfile
+	existingYaml = `configs:
+- id: profile
+  config:
+    name: my-profile
+    template: profile.json
+  type:
+    api: alerting-profile
+- id: tag
+  config:
+    name: tag
+    template: tag.json
+    originObjectId: obj-1
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+- id: deployed
+  config:
+    name: deployed
+    template: deployed.json
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+- id: gone
+  config:
+    name: gone
+    template: tag.json
+    originObjectId: obj-gone
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+`
+)
+
+func setupProject(t *testing.T) (afero.Fs, Options) {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	projectFolder := filepath.Join(t.TempDir(), "project")
+	files := map[string]string{
+		"config.yaml":   existingYaml,
+		"profile.json":  `{"name": "{{ .name }}", "mzId": "{{ .mz }}", "rules": []}`,
+		"tag.json":      `{"name": "{{ .name }}", "rules": []}`,
+		"deployed.json": `{"name": "{{ .name }}", "enabled": false}`,
+	}
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(projectFolder, "configs", name), []byte(content), 0644))
+	}
+
+	return fs, Options{ProjectFolder: projectFolder, ProjectName: "project", Environment: manifest.EnvironmentDefinition{Name: "dev", Group: "default"}}
+}
+
+func downloadedSetting(configId, objectId, externalId, content string) config.Config {
+	return config.Config{
+		Template:         template.NewInMemoryTemplate(configId, content),
+		Coordinate:       coordinate.Coordinate{Project: "project", Type: tagSchema, ConfigId: configId},
+		Type:             config.SettingsType{SchemaId: tagSchema},
+		Parameters:       config.Parameters{config.ScopeParameter: value.New("environment")},
+		OriginObjectId:   objectId,
+		OriginExternalId: externalId,
+	}
+}
+
+func readFile(t *testing.T, fs afero.Fs, path string) string {
+	t.Helper()
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestMerge(t *testing.T) {
+	fs, opts := setupProject(t)
+
+	deployedExternalId, err := idutils.GenerateExternalIDForSettingsObject(coordinate.Coordinate{Project: "project", Type: tagSchema, ConfigId: "deployed"})
+	require.NoError(t, err)
+
+	tag := downloadedSetting("uuid-1", "obj-1", "", `{"name": "tag", "rules": []}`)
+	newTag := downloadedSetting("uuid-new", "obj-new", "", `{"name": "new", "rules": []}`)
+	newTag.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(tag.Coordinate, "id")
+
+	downloaded := project.ConfigsPerType{
+		profileApi: {
+			{
+				Template:   template.NewInMemoryTemplate("abc-123", `{"name": "{{.name}}", "mzId": "12345", "rules": [{"severityLevel": "AVAILABILITY"}]}`),
+				Coordinate: coordinate.Coordinate{Project: "project", Type: profileApi, ConfigId: "abc-123"},
+				Type:       config.ClassicApiType{Api: profileApi},
+				Parameters: config.Parameters{config.NameParameter: value.New("my-profile")},
+			},
+		},
+		tagSchema: {
+			tag,
+			downloadedSetting("uuid-2", "obj-2", deployedExternalId, `{"name": "deployed", "enabled": true}`),
+			newTag,
+		},
+	}
+
+	result, err := Merge(t.Context(), fs, opts, downloaded)
+	require.NoError(t, err)
+
+	assert.Equal(t, []coordinate.Coordinate{
+		{Project: "project", Type: profileApi, ConfigId: "profile"},
+		{Project: "project", Type: tagSchema, ConfigId: "deployed"},
+	}, result.Updated)
+	assert.Equal(t, []coordinate.Coordinate{{Project: "project", Type: tagSchema, ConfigId: "tag"}}, result.Unchanged)
+	assert.Equal(t, []coordinate.Coordinate{{Project: "project", Type: tagSchema, ConfigId: "uuid-new"}}, result.Added)
+	assert.Equal(t, []coordinate.Coordinate{{Project: "project", Type: tagSchema, ConfigId: "gone"}}, result.Disappeared)
+	assert.Empty(t, result.Issues)
+
+	// templates of matched configs are updated, hand-written template actions are kept
+	assert.JSONEq(t, `{"name": "{{ .name }}", "mzId": "{{ .mz }}", "rules": [{"severityLevel": "AVAILABILITY"}]}`, readFile(t, fs, filepath.Join(opts.ProjectFolder, "configs", "profile.json")))
+	assert.JSONEq(t, `{"name": "{{ .name }}", "enabled": true}`, readFile(t, fs, filepath.Join(opts.ProjectFolder, "configs", "deployed.json")))
+	assert.Equal(t, `{"name": "{{ .name }}", "rules": []}`, readFile(t, fs, filepath.Join(opts.ProjectFolder, "configs", "tag.json")))
+
+	// the existing config file is untouched
+	assert.Equal(t, existingYaml, readFile(t, fs, filepath.Join(opts.ProjectFolder, "configs", "config.yaml")))
+
+	// new configs are added in a separate file and reference existing configs
+	added := readFile(t, fs, filepath.Join(opts.ProjectFolder, "builtintags.auto-tagging", "merged.yaml"))
+	assert.Contains(t, added, "id: uuid-new")
+	assert.Contains(t, added, "configId: tag")
+	assert.NotContains(t, added, "uuid-1")
+}
+
+func TestMerge_ConfigFileNamesAreNotReused(t *testing.T) {
+	fs, opts := setupProject(t)
+	otherYaml := `configs:
+- id: other
+  config:
+    template: other.json
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+`
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(opts.ProjectFolder, "other", "merged.yaml"), []byte(otherYaml), 0644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(opts.ProjectFolder, "other", "other.json"), []byte(`{}`), 0644))
+
+	downloaded := project.ConfigsPerType{
+		tagSchema: {downloadedSetting("uuid-new", "obj-new", "", `{"name": "new"}`)},
+	}
+
+	result, err := Merge(t.Context(), fs, opts, downloaded)
+	require.NoError(t, err)
+	assert.Len(t, result.Added, 1)
+
+	exists, err := afero.Exists(fs, filepath.Join(opts.ProjectFolder, "builtintags.auto-tagging", "merged-1.yaml"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestMerge_UnmergeableTemplateIsReported(t *testing.T) {
+	fs, opts := setupProject(t)
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(opts.ProjectFolder, "configs", "tag.json"), []byte(`{"name": "{{ .name }}", {{ .additional }}}`), 0644))
+
+	downloaded := project.ConfigsPerType{
+		tagSchema: {downloadedSetting("uuid-1", "obj-1", "", `{"name": "tag"}`)},
+	}
+
+	result, err := Merge(t.Context(), fs, opts, downloaded)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, coordinate.Coordinate{Project: "project", Type: tagSchema, ConfigId: "tag"}, result.Issues[0].Coordinate)
+	assert.Contains(t, result.Issues[0].Message, "could not be merged")
+	assert.Equal(t, `{"name": "{{ .name }}", {{ .additional }}}`, readFile(t, fs, filepath.Join(opts.ProjectFolder, "configs", "tag.json")))
+}
+
+func TestMerge_EnvironmentVariablesAreAvailable(t *testing.T) {
+	fs, opts := setupProject(t)
+	withVariable := `configs:
+- id: variable
+  config:
+    name:
+      type: variable
+      name: tagName
+    template: tag.json
+    originObjectId: obj-2
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+`
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(opts.ProjectFolder, "configs", "variable.yaml"), []byte(withVariable), 0644))
+
+	_, err := Merge(t.Context(), fs, opts, project.ConfigsPerType{})
+	require.ErrorContains(t, err, "tagName")
+
+	opts.Environment.Variables = manifest.Variables{"tagName": {Value: "my-tag"}}
+	downloaded := project.ConfigsPerType{
+		tagSchema: {downloadedSetting("uuid-2", "obj-2", "", `{"name": "my-tag", "rules": [{}]}`)},
+	}
+
+	result, err := Merge(t.Context(), fs, opts, downloaded)
+	require.NoError(t, err)
+	assert.Contains(t, result.Updated, coordinate.Coordinate{Project: "project", Type: tagSchema, ConfigId: "variable"})
+}
+
+func TestMerge_InvalidProject(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	projectFolder := filepath.Join(t.TempDir(), "project")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(projectFolder, "config.yaml"), []byte("configs: [invalid"), 0644))
+
+	_, err := Merge(t.Context(), fs, Options{ProjectFolder: projectFolder, ProjectName: "project", Environment: manifest.EnvironmentDefinition{Name: "dev"}}, project.ConfigsPerType{})
+	assert.ErrorContains(t, err, "failed to load project")
+}
+
+func TestMatchConfigs_ExistingConfigIsMatchedOnce(t *testing.T) {
+	existing := []config.Config{
+		{
+			Coordinate:     coordinate.Coordinate{Project: "project", Type: tagSchema, ConfigId: "tag"},
+			Type:           config.SettingsType{SchemaId: tagSchema},
+			OriginObjectId: "obj-1",
+		},
+	}
+	downloaded := project.ConfigsPerType{
+		tagSchema: {
+			downloadedSetting("a", "obj-1", "", `{}`),
+			downloadedSetting("b", "obj-1", "", `{}`),
+		},
+	}
+
+	matches := matchConfigs(existing, downloaded)
+	assert.Equal(t, map[coordinate.Coordinate]config.Config{
+		{Project: "project", Type: tagSchema, ConfigId: "a"}: existing[0],
+	}, matches)
+}
+
+func TestMatchConfigs_PartialOpenPipelineConfigsAreMatchedByTheirObject(t *testing.T) {
+	openPipelineConfig := func(configID string, p *config.OpenPipelinePartial, content string) config.Config {
+		return config.Config{
+			Coordinate: coordinate.Coordinate{Project: "project", Type: "openpipeline", ConfigId: configID},
+			Type:       config.OpenPipelineType{Kind: "logs", Partial: p},
+			Template:   template.NewInMemoryTemplate(configID, content),
+		}
+	}
+	processor := func(pipeline string) *config.OpenPipelinePartial {
+		return &config.OpenPipelinePartial{Type: config.OpenPipelineProcessorPartial, Pipeline: pipeline, Stage: "processing"}
+	}
+
+	existing := []config.Config{
+		openPipelineConfig("logs", nil, `{"id":"logs"}`),
+		openPipelineConfig("my-processor", processor("p1"), `{"id":"proc","enabled":true}`),
+		openPipelineConfig("other-processor", processor("p2"), `{"id":"proc"}`),
+	}
+	downloaded := project.ConfigsPerType{
+		"openpipeline": {
+			openPipelineConfig("logs-base", nil, `{"id":"logs"}`),
+			openPipelineConfig("logs-processor-p1-processing-000-proc", processor("p1"), `{"id":"proc","enabled":false}`),
+			openPipelineConfig("logs-processor-p3-processing-000-proc", processor("p3"), `{"id":"proc"}`),
+		},
+	}
+
+	matches := matchConfigs(existing, downloaded)
+	assert.Equal(t, map[coordinate.Coordinate]config.Config{
+		{Project: "project", Type: "openpipeline", ConfigId: "logs-base"}:                             existing[0],
+		{Project: "project", Type: "openpipeline", ConfigId: "logs-processor-p1-processing-000-proc"}: existing[1],
+	}, matches)
+}
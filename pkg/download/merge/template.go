@@ -0,0 +1,135 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// mergeTemplate merges the downloaded JSON payload into the content of an existing template. Values of the existing
+// template that contain template actions are hand-written and kept, all other values are taken from the downloaded
+// payload. The returned bool is false if the merge does not change the existing template.
+func mergeTemplate(existing, downloaded string) (string, bool, error) {
+	masker := template.NewActionMasker()
+
+	existingValue, err := decode(masker.Mask(existing))
+	if err != nil {
+		return "", false, fmt.Errorf("existing template is not valid JSON: %w", err)
+	}
+	downloadedValue, err := decode(masker.Mask(downloaded))
+	if err != nil {
+		return "", false, fmt.Errorf("downloaded payload is not valid JSON: %w", err)
+	}
+
+	merged := mergeValue(existingValue, downloadedValue)
+	if reflect.DeepEqual(existingValue, merged) {
+		return existing, false, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(merged); err != nil {
+		return "", false, fmt.Errorf("failed to serialize merged template: %w", err)
+	}
+	return masker.Unmask(buf.String()), true, nil
+}
+
+// decode parses JSON content, keeping numbers as json.Number so that they are serialized exactly as they were read.
+func decode(content string) (any, error) {
+	dec := json.NewDecoder(bytes.NewBufferString(content))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected content after top-level value")
+	}
+	return v, nil
+}
+
+// mergeValue merges a downloaded value into an existing one. Objects are merged key by key, arrays element-wise if they
+// have the same length. Keys that are not part of the downloaded object are dropped, unless they are templated.
+func mergeValue(existing, downloaded any) any {
+	if template.IsMasked(existing) {
+		return existing
+	}
+
+	switch d := downloaded.(type) {
+	case map[string]any:
+		e, ok := existing.(map[string]any)
+		if !ok {
+			return downloaded
+		}
+		result := make(map[string]any, len(d))
+		for k, v := range d {
+			if ev, found := e[k]; found {
+				result[k] = mergeValue(ev, v)
+			} else {
+				result[k] = v
+			}
+		}
+		for k, ev := range e {
+			if _, found := d[k]; !found && containsAction(ev) {
+				result[k] = ev
+			}
+		}
+		return result
+
+	case []any:
+		e, ok := existing.([]any)
+		if !ok || len(e) != len(d) {
+			return downloaded
+		}
+		result := make([]any, len(d))
+		for i := range d {
+			result[i] = mergeValue(e[i], d[i])
+		}
+		return result
+	}
+
+	return downloaded
+}
+
+// containsAction returns whether the given value, or any value nested in it, is a masked template action.
+func containsAction(v any) bool {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, nested := range t {
+			if containsAction(nested) {
+				return true
+			}
+		}
+	case []any:
+		for _, nested := range t {
+			if containsAction(nested) {
+				return true
+			}
+		}
+	default:
+		return template.IsMasked(v)
+	}
+	return false
+}
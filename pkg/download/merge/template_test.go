@@ -0,0 +1,110 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		downloaded string
+		expected   string
+		changed    bool
+	}{
+		{
+			name:       "plain values are updated",
+			existing:   `{"name": "{{ .name }}", "enabled": false, "threshold": 10}`,
+			downloaded: `{"name": "remote", "enabled": true, "threshold": 10}`,
+			expected:   `{"name": "{{ .name }}", "enabled": true, "threshold": 10}`,
+			changed:    true,
+		},
+		{
+			name:       "unquoted actions are kept",
+			existing:   `{"count": {{ .count }}, "list": {{ .list | toJson }}}`,
+			downloaded: `{"count": 5, "list": ["a", "b"]}`,
+			expected:   `{"count": {{ .count }}, "list": {{ .list | toJson }}}`,
+		},
+		{
+			name:       "nested objects are merged",
+			existing:   `{"rule": {"mz": "{{ .mz }}", "delay": 1}}`,
+			downloaded: `{"rule": {"mz": "12345", "delay": 2, "new": true}}`,
+			expected:   `{"rule": {"mz": "{{ .mz }}", "delay": 2, "new": true}}`,
+			changed:    true,
+		},
+		{
+			name:       "arrays of the same length are merged element-wise",
+			existing:   `{"rules": [{"tag": "{{ .tag }}", "enabled": true}]}`,
+			downloaded: `{"rules": [{"tag": "team", "enabled": false}]}`,
+			expected:   `{"rules": [{"tag": "{{ .tag }}", "enabled": false}]}`,
+			changed:    true,
+		},
+		{
+			name:       "arrays of different length are replaced",
+			existing:   `{"rules": [{"tag": "{{ .tag }}"}]}`,
+			downloaded: `{"rules": [{"tag": "a"}, {"tag": "b"}]}`,
+			expected:   `{"rules": [{"tag": "a"}, {"tag": "b"}]}`,
+			changed:    true,
+		},
+		{
+			name:       "removed plain fields are dropped, templated ones kept",
+			existing:   `{"password": "{{ .secret }}", "old": 1}`,
+			downloaded: `{}`,
+			expected:   `{"password": "{{ .secret }}"}`,
+			changed:    true,
+		},
+		{
+			name:       "large numbers are kept exactly",
+			existing:   `{"id": 1234567890123456789}`,
+			downloaded: `{"id": 1234567890123456789}`,
+			expected:   `{"id": 1234567890123456789}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, changed, err := mergeTemplate(tt.existing, tt.downloaded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.changed, changed)
+			if changed {
+				assert.JSONEq(t, maskForComparison(tt.expected), maskForComparison(merged))
+			} else {
+				assert.Equal(t, tt.existing, merged, "unchanged templates must keep their formatting")
+			}
+		})
+	}
+}
+
+func TestMergeTemplate_InvalidJson(t *testing.T) {
+	_, _, err := mergeTemplate(`{{ range .items }}{{ end }}`, `{}`)
+	assert.ErrorContains(t, err, "existing template is not valid JSON")
+
+	_, _, err = mergeTemplate(`{}`, `{"a": `)
+	assert.ErrorContains(t, err, "downloaded payload is not valid JSON")
+}
+
+// maskForComparison replaces unquoted template actions, so that templates can be compared as JSON.
+func maskForComparison(content string) string {
+	return strings.NewReplacer(`{{ .count }}`, `"count"`, `{{ .list | toJson }}`, `"list"`).Replace(content)
+}
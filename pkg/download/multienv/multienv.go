@@ -0,0 +1,346 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package multienv combines the configurations downloaded from several environments into a single project.
+// The same logical object is matched across environments by its IDs, its name, or the unique properties of its settings
+// schema, and is written as a single configuration with environment overrides for everything that differs.
+//
+// Combining is done in two steps: [Match] has to be called on the configurations as returned by the download, before
+// dependencies are resolved, so that references use the same coordinates in all environments. [Combine] is called once
+// dependencies are resolved and IDs are extracted, and returns the configurations of all environments sharing equal
+// templates.
+package multienv
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Environment holds the configurations downloaded from a single environment.
+type Environment struct {
+	Name, Group string
+	Configs     project.ConfigsPerType
+}
+
+// UniquePropertiesFunc returns the sets of properties of a settings schema whose values identify a settings object
+// within its scope. It returns nil if the schema has no such properties or they can not be determined.
+type UniquePropertiesFunc func(schemaId string) [][]string
+
+// matchKey identifies an object by one of its IDs, or by a property that is unique for its type.
+type matchKey struct {
+	configType string
+	scope      string
+	kind       string
+	value      string
+}
+
+const (
+	objectIdKey   = "objectId"
+	externalIdKey = "externalId"
+	kindKey       = "kind"
+	nameKey       = "name"
+	singletonKey  = "singleton"
+	uniqueKey     = "unique"
+)
+
+// object is a logical object matched across environments.
+type object struct {
+	configId     string
+	environments map[string]struct{}
+}
+
+// Match assigns the same coordinate to the configurations of the same logical object in all environments. The config ID
+// of an object is taken from the first environment it is found in. Configurations that are assigned a new coordinate
+// keep their original ID as OriginObjectId, and all references to them are updated.
+// The configurations of the given environments are modified in place.
+func Match(environments []Environment, uniqueProperties UniquePropertiesFunc) {
+	index := make(map[matchKey]*object)
+	usedIds := make(map[string]map[string]struct{})
+
+	for _, env := range environments {
+		renamed := make(map[coordinate.Coordinate]coordinate.Coordinate)
+
+		for _, t := range slices.Sorted(maps.Keys(env.Configs)) {
+			configs := env.Configs[t]
+			slices.SortFunc(configs, func(a, b config.Config) int { return compareCoordinates(a.Coordinate, b.Coordinate) })
+
+			for i, c := range configs {
+				keys := matchKeys(c, uniqueProperties)
+
+				var obj *object
+				for _, k := range keys {
+					if o, found := index[k]; found {
+						if _, alreadyMatched := o.environments[env.Name]; !alreadyMatched {
+							obj = o
+							break
+						}
+					}
+				}
+				if obj == nil {
+					obj = &object{configId: uniqueConfigId(usedIds, t, c.Coordinate.ConfigId), environments: make(map[string]struct{})}
+				}
+				obj.environments[env.Name] = struct{}{}
+
+				for _, k := range keys {
+					if _, found := index[k]; !found {
+						index[k] = obj
+					}
+				}
+
+				if obj.configId == c.Coordinate.ConfigId {
+					continue
+				}
+
+				newCoordinate := c.Coordinate
+				newCoordinate.ConfigId = obj.configId
+				renamed[c.Coordinate] = newCoordinate
+
+				configs[i].Coordinate = newCoordinate
+				if configs[i].OriginObjectId == "" {
+					configs[i].OriginObjectId = c.Coordinate.ConfigId
+				}
+			}
+		}
+
+		updateReferences(env.Configs, renamed)
+	}
+}
+
+func uniqueConfigId(usedIds map[string]map[string]struct{}, configType, configId string) string {
+	if usedIds[configType] == nil {
+		usedIds[configType] = make(map[string]struct{})
+	}
+
+	id := configId
+	for i := 1; ; i++ {
+		if _, found := usedIds[configType][id]; !found {
+			break
+		}
+		id = fmt.Sprintf("%s_%d", configId, i)
+	}
+	usedIds[configType][id] = struct{}{}
+	return id
+}
+
+// updateReferences replaces all references to renamed configurations.
+func updateReferences(configs project.ConfigsPerType, renamed map[coordinate.Coordinate]coordinate.Coordinate) {
+	if len(renamed) == 0 {
+		return
+	}
+	for c := range configs.AllConfigs {
+		for name, p := range c.Parameters {
+			ref, ok := p.(*reference.ReferenceParameter)
+			if !ok {
+				continue
+			}
+			if newCoordinate, found := renamed[ref.Config]; found {
+				c.Parameters[name] = reference.NewWithCoordinate(newCoordinate, ref.Property)
+			}
+		}
+	}
+}
+
+// matchKeys returns all keys a configuration is matched by, in order of precedence.
+func matchKeys(c config.Config, uniqueProperties UniquePropertiesFunc) []matchKey {
+	t := c.Coordinate.Type
+	var scope string
+	if _, ok := c.Type.(config.SettingsType); ok {
+		// settings objects are only matched within the same scope
+		scope, _ = stringValue(c, config.ScopeParameter)
+	}
+	if d, ok := c.Type.(config.DocumentType); ok {
+		t = fmt.Sprintf("%s-%s", t, d.Kind)
+	}
+
+	var keys []matchKey
+	if c.OriginObjectId != "" {
+		keys = append(keys, matchKey{t, scope, objectIdKey, c.OriginObjectId})
+	}
+	if c.OriginExternalId != "" {
+		keys = append(keys, matchKey{t, scope, externalIdKey, c.OriginExternalId})
+	}
+
+	switch typ := c.Type.(type) {
+	case config.SettingsType:
+		if uniqueProperties != nil {
+			for _, properties := range uniqueProperties(typ.SchemaId) {
+				if v, ok := uniqueValue(c, properties); ok {
+					keys = append(keys, matchKey{t, scope, uniqueKey + ":" + strings.Join(properties, ","), v})
+				}
+			}
+		}
+	case config.OpenPipelineType:
+		keys = append(keys, matchKey{t, scope, kindKey, typ.Kind})
+	case config.ClassicApiType:
+		// the classic download uses the object ID as config ID
+		keys = append(keys, matchKey{t, scope, objectIdKey, c.Coordinate.ConfigId})
+		if isSingleton(typ.Api) {
+			keys = append(keys, matchKey{t, scope, singletonKey, ""})
+		}
+	}
+
+	if name, ok := stringValue(c, config.NameParameter); ok {
+		keys = append(keys, matchKey{t, scope, nameKey, name})
+	}
+	return keys
+}
+
+// stringValue returns the value of a parameter, if it is defined by a plain string value.
+func stringValue(c config.Config, parameterName string) (string, bool) {
+	p, ok := c.Parameters[parameterName].(*value.ValueParameter)
+	if !ok {
+		return "", false
+	}
+	s, ok := p.Value.(string)
+	return s, ok && s != ""
+}
+
+// uniqueValue returns the values of the given properties of a settings object, if all of them are set.
+func uniqueValue(c config.Config, properties []string) (string, bool) {
+	content, err := c.Template.Content()
+	if err != nil {
+		return "", false
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(content), &payload); err != nil {
+		return "", false
+	}
+
+	values := make([]any, 0, len(properties))
+	for _, p := range properties {
+		v, found := payload[p]
+		if !found {
+			return "", false
+		}
+		values = append(values, v)
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+func isSingleton(apiId string) bool {
+	a, found := api.NewAPIs()[apiId]
+	return found && a.SingleConfiguration && !a.HasParent()
+}
+
+// Combine returns the configurations of all environments, after they were matched by [Match] and their dependencies
+// were resolved. Configurations of the same object share a single template instance if their templates are equal.
+// Objects that are missing in an environment are added as skipped configurations for that environment, so that the
+// combined project deploys the same objects to each environment as were downloaded from it.
+func Combine(environments []Environment) (project.ConfigsPerTypePerEnvironments, error) {
+	perCoordinate := make(map[coordinate.Coordinate]map[string]config.Config)
+	for _, env := range environments {
+		for c := range env.Configs.AllConfigs {
+			if perCoordinate[c.Coordinate] == nil {
+				perCoordinate[c.Coordinate] = make(map[string]config.Config)
+			}
+			perCoordinate[c.Coordinate][env.Name] = c
+		}
+	}
+
+	result := make(project.ConfigsPerTypePerEnvironments, len(environments))
+	for _, env := range environments {
+		result[env.Name] = make(project.ConfigsPerType)
+	}
+
+	for _, coord := range slices.SortedFunc(maps.Keys(perCoordinate), compareCoordinates) {
+		configs := perCoordinate[coord]
+
+		var templates []template.Template
+		var first *config.Config
+		for _, env := range environments {
+			c, found := configs[env.Name]
+			if !found {
+				continue
+			}
+
+			t, err := sharedTemplate(&templates, coord, c.Template)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read template of %s in environment %q: %w", coord, env.Name, err)
+			}
+			c.Template = t
+			c.Environment = env.Name
+			c.Group = env.Group
+			configs[env.Name] = c
+
+			if first == nil {
+				first = &c
+			}
+		}
+
+		for _, env := range environments {
+			c, found := configs[env.Name]
+			if !found {
+				c = placeholder(*first, env)
+			}
+			result[env.Name][coord.Type] = append(result[env.Name][coord.Type], c)
+		}
+	}
+
+	return result, nil
+}
+
+// sharedTemplate returns the template with the same content as the given template, or a new template if there is
+// none yet. New templates are named after the config ID, as the original templates are named after the object IDs of
+// the environments they were downloaded from.
+func sharedTemplate(templates *[]template.Template, coord coordinate.Coordinate, t template.Template) (template.Template, error) {
+	content, err := t.Content()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range *templates {
+		if existingContent, _ := existing.Content(); existingContent == content {
+			return existing, nil
+		}
+	}
+
+	shared := template.NewInMemoryTemplate(coord.ConfigId, content)
+	*templates = append(*templates, shared)
+	return shared, nil
+}
+
+// placeholder returns a skipped copy of the configuration for an environment the object is missing in.
+func placeholder(c config.Config, env Environment) config.Config {
+	c.Parameters = maps.Clone(c.Parameters)
+	c.Environment = env.Name
+	c.Group = env.Group
+	c.Skip = true
+	c.OriginObjectId = ""
+	c.OriginExternalId = ""
+	return c
+}
+
+func compareCoordinates(a, b coordinate.Coordinate) int {
+	return cmp.Or(cmp.Compare(a.Project, b.Project), cmp.Compare(a.Type, b.Type), cmp.Compare(a.ConfigId, b.ConfigId))
+}
@@ -0,0 +1,176 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multienv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+const schema = "builtin:test.schema"
+
+func classicConfig(configId, name, content string) config.Config {
+	return config.Config{
+		Template:   template.NewInMemoryTemplate(configId, content),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: api.Dashboard, ConfigId: configId},
+		Type:       config.ClassicApiType{Api: api.Dashboard},
+		Parameters: config.Parameters{config.NameParameter: value.New(name)},
+	}
+}
+
+func settingsConfig(configId, objectId, externalId, content string) config.Config {
+	return config.Config{
+		Template:         template.NewInMemoryTemplate(configId, content),
+		Coordinate:       coordinate.Coordinate{Project: "project", Type: schema, ConfigId: configId},
+		Type:             config.SettingsType{SchemaId: schema},
+		Parameters:       config.Parameters{config.ScopeParameter: value.New("environment")},
+		OriginObjectId:   objectId,
+		OriginExternalId: externalId,
+	}
+}
+
+func newEnvironment(name string, configs ...config.Config) Environment {
+	perType := project.ConfigsPerType{}
+	for _, c := range configs {
+		perType[c.Coordinate.Type] = append(perType[c.Coordinate.Type], c)
+	}
+	return Environment{Name: name, Group: name, Configs: perType}
+}
+
+func uniqueKeyProperty(string) [][]string {
+	return [][]string{{"key"}}
+}
+
+func TestMatch_ClassicConfigsByName(t *testing.T) {
+	dev := newEnvironment("dev", classicConfig("dev-id", "my-dashboard", "{}"))
+	prod := newEnvironment("prod", classicConfig("prod-id", "my-dashboard", "{}"), classicConfig("other-id", "other", "{}"))
+
+	Match([]Environment{dev, prod}, nil)
+
+	assert.Equal(t, "dev-id", dev.Configs[api.Dashboard][0].Coordinate.ConfigId)
+	assert.Empty(t, dev.Configs[api.Dashboard][0].OriginObjectId)
+
+	prodConfigs := prod.Configs[api.Dashboard]
+	require.Len(t, prodConfigs, 2)
+	assert.Equal(t, "other-id", prodConfigs[0].Coordinate.ConfigId)
+	assert.Equal(t, "dev-id", prodConfigs[1].Coordinate.ConfigId)
+	assert.Equal(t, "prod-id", prodConfigs[1].OriginObjectId, "the original object ID is kept when a config is renamed")
+}
+
+func TestMatch_SettingsByExternalIdAndUniqueProperties(t *testing.T) {
+	dev := newEnvironment("dev",
+		settingsConfig("a-dev", "obj-a-dev", "ext-a", `{"key": "a"}`),
+		settingsConfig("b-dev", "obj-b-dev", "", `{"key": "b"}`),
+		settingsConfig("c-dev", "obj-c-dev", "", `{"key": "c"}`),
+	)
+	prod := newEnvironment("prod",
+		settingsConfig("a-prod", "obj-a-prod", "ext-a", `{"key": "changed"}`),
+		settingsConfig("b-prod", "obj-b-prod", "", `{"key": "b"}`),
+		settingsConfig("d-prod", "obj-d-prod", "", `{"key": "d"}`),
+	)
+
+	Match([]Environment{dev, prod}, uniqueKeyProperty)
+
+	var ids []string
+	for _, c := range prod.Configs[schema] {
+		ids = append(ids, c.Coordinate.ConfigId)
+	}
+	assert.ElementsMatch(t, []string{"a-dev", "b-dev", "d-prod"}, ids)
+}
+
+func TestMatch_SettingsAreOnlyMatchedWithinTheSameScope(t *testing.T) {
+	devConfig := settingsConfig("a-dev", "obj-a-dev", "ext-a", `{}`)
+	prodConfig := settingsConfig("a-prod", "obj-a-prod", "ext-a", `{}`)
+	prodConfig.Parameters[config.ScopeParameter] = value.New("HOST-1234567890123456")
+	dev := newEnvironment("dev", devConfig)
+	prod := newEnvironment("prod", prodConfig)
+
+	Match([]Environment{dev, prod}, nil)
+
+	assert.Equal(t, "a-prod", prod.Configs[schema][0].Coordinate.ConfigId)
+}
+
+func TestMatch_ReferencesToRenamedConfigsAreUpdated(t *testing.T) {
+	dev := newEnvironment("dev", settingsConfig("first-dev", "obj-1", "ext-1", "{}"), settingsConfig("second-dev", "obj-2", "ext-2", "{}"))
+
+	prodFirst := settingsConfig("first-prod", "obj-3", "ext-1", "{}")
+	prodSecond := settingsConfig("second-prod", "obj-4", "ext-2", "{}")
+	prodSecond.Parameters[config.InsertAfterParameter] = reference.NewWithCoordinate(prodFirst.Coordinate, "id")
+	prod := newEnvironment("prod", prodFirst, prodSecond)
+
+	Match([]Environment{dev, prod}, nil)
+
+	for _, c := range prod.Configs[schema] {
+		if c.Coordinate.ConfigId == "second-dev" {
+			assert.Equal(t, reference.New("project", schema, "first-dev", "id"), c.Parameters[config.InsertAfterParameter])
+			return
+		}
+	}
+	t.Fatal("config was not renamed")
+}
+
+func TestMatch_ConflictingConfigIdsAreMadeUnique(t *testing.T) {
+	dev := newEnvironment("dev", classicConfig("id", "a", "{}"))
+	// "aaa" is matched by name first, so the object with config ID "id" in prod needs a new config ID
+	prod := newEnvironment("prod", classicConfig("aaa", "a", "{}"), classicConfig("id", "b", "{}"))
+
+	Match([]Environment{dev, prod}, nil)
+
+	var ids []string
+	for _, c := range prod.Configs[api.Dashboard] {
+		ids = append(ids, c.Coordinate.ConfigId)
+	}
+	assert.ElementsMatch(t, []string{"id", "id_1"}, ids)
+}
+
+func TestCombine(t *testing.T) {
+	dev := newEnvironment("dev", classicConfig("a", "a", `{"same": true}`), classicConfig("b", "b", `{"env": "dev"}`))
+	prod := newEnvironment("prod", classicConfig("a", "a", `{"same": true}`), classicConfig("b", "b", `{"env": "prod"}`), classicConfig("c", "c", "{}"))
+
+	result, err := Combine([]Environment{dev, prod})
+	require.NoError(t, err)
+
+	devConfigs := result["dev"][api.Dashboard]
+	prodConfigs := result["prod"][api.Dashboard]
+	require.Len(t, devConfigs, 3)
+	require.Len(t, prodConfigs, 3)
+
+	for _, c := range devConfigs {
+		assert.Equal(t, "dev", c.Environment)
+		assert.Equal(t, "dev", c.Group)
+	}
+
+	assert.Same(t, devConfigs[0].Template, prodConfigs[0].Template, "equal templates are shared")
+	assert.NotSame(t, devConfigs[1].Template, prodConfigs[1].Template)
+
+	assert.Equal(t, "c", devConfigs[2].Coordinate.ConfigId)
+	assert.True(t, devConfigs[2].Skip, "objects missing in an environment are skipped")
+	assert.Same(t, prodConfigs[2].Template, devConfigs[2].Template)
+	assert.False(t, prodConfigs[2].Skip)
+}
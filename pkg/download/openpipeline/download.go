@@ -18,9 +18,11 @@ package openpipeline
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
@@ -28,6 +30,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/internal/templatetools"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/openpipeline/partial"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
@@ -48,7 +51,18 @@ func Download(ctx context.Context, client client.OpenPipelineClient, projectName
 			log.WithFields(field.Type(config.OpenPipelineTypeID), field.Error(err)).Error("Failed to convert config of type '%s': %v", config.OpenPipelineTypeID, err)
 			continue
 		}
-		configs = append(configs, c)
+
+		if !featureflags.OpenPipelinePartials.Enabled() {
+			configs = append(configs, c)
+			continue
+		}
+
+		splitConfigs, err := splitConfig(c)
+		if err != nil {
+			log.WithFields(field.Type(config.OpenPipelineTypeID), field.Error(err)).Error("Failed to split config of type '%s' into partial configs: %v", config.OpenPipelineTypeID, err)
+			continue
+		}
+		configs = append(configs, splitConfigs...)
 	}
 	result[string(config.OpenPipelineTypeID)] = configs
 
@@ -87,3 +101,80 @@ func createConfig(projectName string, response openpipeline.Response) (config.Co
 		Parameters: make(config.Parameters),
 	}, nil
 }
+
+// splitConfig splits the downloaded configuration into a config holding the remaining configuration of the kind, and
+// partial configs for each pipeline, processor, endpoint and routing entry. As the order of processors and routing
+// entries matters, their config IDs contain their position, so that they are merged in the same order again.
+func splitConfig(c config.Config) ([]config.Config, error) {
+	kind := c.Type.(config.OpenPipelineType).Kind
+
+	content, err := c.Template.Content()
+	if err != nil {
+		return nil, err
+	}
+
+	base, parts, err := partial.Split([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+
+	baseConfig, err := withPayload(c, kind, nil, base)
+	if err != nil {
+		return nil, err
+	}
+	result := []config.Config{baseConfig}
+
+	positions := make(map[string]int)
+	for _, p := range parts {
+		var obj map[string]any
+		if err := json.Unmarshal(p.Payload, &obj); err != nil {
+			return nil, err
+		}
+
+		var configID string
+		switch p.Partial.Type {
+		case config.OpenPipelineProcessorPartial:
+			list := fmt.Sprintf("%s-%s", p.Partial.Pipeline, p.Partial.Stage)
+			configID = fmt.Sprintf("%s-processor-%s-%03d-%s", kind, list, positions[list], obj["id"])
+			positions[list]++
+		case config.OpenPipelineRoutingEntryPartial:
+			configID = fmt.Sprintf("%s-routing-entry-%03d", kind, positions["routing"])
+			positions["routing"]++
+		case config.OpenPipelineEndpointPartial:
+			configID = fmt.Sprintf("%s-endpoint-%s", kind, obj["segment"])
+		default:
+			configID = fmt.Sprintf("%s-%s-%s", kind, p.Partial.Type, obj["id"])
+		}
+
+		partialConfig, err := withPayload(c, configID, &p.Partial, p.Payload)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, partialConfig)
+	}
+	return result, nil
+}
+
+// withPayload returns a copy of the config with the given ID and payload, defining the given part of the configuration.
+func withPayload(c config.Config, configID string, p *config.OpenPipelinePartial, payload []byte) (config.Config, error) {
+	jsonObj, err := templatetools.NewJSONObject(payload)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	jsonRaw, err := jsonObj.ToJSON(true)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	c.Template = template.NewInMemoryTemplate(configID, string(jsonRaw))
+	c.Coordinate.ConfigId = configID
+	if p != nil {
+		// parts are objects within the configuration of the kind, so they don't share its IDs
+		c.OriginObjectId = ""
+		c.OriginExternalId = ""
+	}
+	c.Type = config.OpenPipelineType{Kind: c.Type.(config.OpenPipelineType).Kind, Partial: p}
+	c.Parameters = make(config.Parameters)
+	return c, nil
+}
@@ -17,6 +17,8 @@
 package openpipeline
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"os"
 	"testing"
@@ -28,6 +30,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
@@ -143,3 +146,60 @@ func TestDownloader_Download(t *testing.T) {
 		require.Len(t, result["openpipeline"], 0)
 	})
 }
+
+type fakeClient struct {
+	responses []openpipeline.Response
+}
+
+func (c fakeClient) Get(_ context.Context, _ string) (openpipeline.Response, error) {
+	return openpipeline.Response{}, errors.New("unexpected call")
+}
+
+func (c fakeClient) GetAll(_ context.Context) ([]openpipeline.Response, error) {
+	return c.responses, nil
+}
+
+func (c fakeClient) Update(_ context.Context, _ string, _ []byte) (openpipeline.Response, error) {
+	return openpipeline.Response{}, errors.New("unexpected call")
+}
+
+func TestDownload_SplitsIntoPartialConfigs(t *testing.T) {
+	t.Setenv(featureflags.OpenPipelinePartials.EnvName(), "true")
+
+	opClient := fakeClient{responses: []openpipeline.Response{{Data: []byte(`{
+		"id": "logs",
+		"version": "1",
+		"pipelines": [{"id":"p1","processing":{"processors":[{"id":"second"},{"id":"first"}]}}],
+		"endpoints": [{"segment":"default"}],
+		"routing": {"entries":[{"note":"to p1","pipelineId":"p1"}]}
+	}`)}}}
+
+	result, err := Download(t.Context(), opClient, "project")
+	require.NoError(t, err)
+
+	configs := result["openpipeline"]
+	var configIDs []string
+	for _, c := range configs {
+		configIDs = append(configIDs, c.Coordinate.ConfigId)
+	}
+	assert.Equal(t, []string{
+		"logs",
+		"logs-pipeline-p1",
+		"logs-processor-p1-processing-000-second",
+		"logs-processor-p1-processing-001-first",
+		"logs-endpoint-default",
+		"logs-routing-entry-000",
+	}, configIDs)
+
+	assert.Equal(t, config.OpenPipelineType{Kind: "logs"}, configs[0].Type)
+	base, err := configs[0].Template.Content()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"logs","pipelines":[],"endpoints":[],"routing":{"entries":[]}}`, base)
+
+	assert.Equal(t, config.OpenPipelineType{Kind: "logs", Partial: &config.OpenPipelinePartial{Type: config.OpenPipelineProcessorPartial, Pipeline: "p1", Stage: "processing"}}, configs[2].Type)
+	processor, err := configs[2].Template.Content()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"second"}`, processor)
+
+	assert.Equal(t, config.OpenPipelineType{Kind: "logs", Partial: &config.OpenPipelinePartial{Type: config.OpenPipelineRoutingEntryPartial}}, configs[5].Type)
+}
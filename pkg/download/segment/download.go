@@ -69,6 +69,8 @@ func createConfig(projectName string, response openpipeline.Response) (config.Co
 		return config.Config{}, fmt.Errorf("API payload is missing 'uid'")
 	}
 
+	externalId, _ := jsonObj.Get("externalId").(string)
+
 	// delete fields that prevent a re-upload of the configuration
 	jsonObj.Delete("uid", "version", "externalId")
 
@@ -84,8 +86,9 @@ func createConfig(projectName string, response openpipeline.Response) (config.Co
 			Type:     string(config.SegmentID),
 			ConfigId: id,
 		},
-		OriginObjectId: id,
-		Type:           config.Segment{},
-		Parameters:     make(config.Parameters),
+		OriginObjectId:   id,
+		OriginExternalId: externalId,
+		Type:             config.Segment{},
+		Parameters:       make(config.Parameters),
 	}, nil
 }
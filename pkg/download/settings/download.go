@@ -24,6 +24,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
@@ -276,8 +277,12 @@ func convertAllObjects(settingsObjects []dtclient.DownloadSettingsObject, permis
 			Parameters: map[string]parameter.Parameter{
 				config.ScopeParameter: &value.ValueParameter{Value: scope},
 			},
-			Skip:           false,
-			OriginObjectId: settingsObject.ObjectId,
+			Skip:             false,
+			OriginObjectId:   settingsObject.ObjectId,
+			OriginExternalId: settingsObject.ExternalId,
+		}
+		if settingsObject.Modified > 0 {
+			c.OriginModified = time.UnixMilli(settingsObject.Modified)
 		}
 
 		insertAfterConfig, found := previousConfigForScope[scope]
@@ -163,8 +163,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
@@ -246,8 +247,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
@@ -294,8 +296,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
@@ -366,8 +369,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 				{
 					Template: template.NewInMemoryTemplate(uuid3, "{}"),
@@ -393,8 +397,9 @@ func TestDownloadAll(t *testing.T) {
 							},
 						},
 					},
-					Skip:           false,
-					OriginObjectId: "oid3",
+					Skip:             false,
+					OriginObjectId:   "oid3",
+					OriginExternalId: "ex3",
 				},
 			}},
 		},
@@ -467,8 +472,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "scope-A"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 				{
 					Template: template.NewInMemoryTemplate(uuid2, "{}"),
@@ -484,8 +490,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "scope-B"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid2",
+					Skip:             false,
+					OriginObjectId:   "oid2",
+					OriginExternalId: "ex2",
 				},
 				{
 					Template: template.NewInMemoryTemplate(uuid3, "{}"),
@@ -511,8 +518,9 @@ func TestDownloadAll(t *testing.T) {
 							},
 						},
 					},
-					Skip:           false,
-					OriginObjectId: "oid3",
+					Skip:             false,
+					OriginObjectId:   "oid3",
+					OriginExternalId: "ex3",
 				},
 			}},
 		},
@@ -583,8 +591,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 				{
 					Template: template.NewInMemoryTemplate(uuid2, "{}"),
@@ -610,8 +619,9 @@ func TestDownloadAll(t *testing.T) {
 							},
 						},
 					},
-					Skip:           false,
-					OriginObjectId: "oid2",
+					Skip:             false,
+					OriginObjectId:   "oid2",
+					OriginExternalId: "ex2",
 				},
 				{
 					Template: template.NewInMemoryTemplate(uuid3, "{}"),
@@ -627,8 +637,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid3",
+					Skip:             false,
+					OriginObjectId:   "oid3",
+					OriginExternalId: "ex3",
 				},
 			}},
 		},
@@ -687,8 +698,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
@@ -759,8 +771,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "builtin:host.monitoring.mode"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
@@ -818,8 +831,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "environment"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
@@ -871,8 +885,9 @@ func TestDownloadAll(t *testing.T) {
 					Parameters: map[string]parameter.Parameter{
 						config.ScopeParameter: &value.ValueParameter{Value: "environment"},
 					},
-					Skip:           false,
-					OriginObjectId: "oid1",
+					Skip:             false,
+					OriginObjectId:   "oid1",
+					OriginExternalId: "ex1",
 				},
 			}},
 		},
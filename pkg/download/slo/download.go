@@ -68,6 +68,8 @@ func createConfig(projectName string, data []byte) (config.Config, error) {
 		return config.Config{}, fmt.Errorf("API payload is missing 'id'")
 	}
 
+	externalId, _ := jsonObj.Get("externalId").(string)
+
 	// delete fields that prevent a re-upload of the configuration
 	jsonObj.Delete("id", "version", "externalId")
 
@@ -83,8 +85,9 @@ func createConfig(projectName string, data []byte) (config.Config, error) {
 			Type:     string(config.ServiceLevelObjectiveID),
 			ConfigId: id,
 		},
-		OriginObjectId: id,
-		Type:           config.ServiceLevelObjective{},
-		Parameters:     make(config.Parameters),
+		OriginObjectId:   id,
+		OriginExternalId: externalId,
+		Type:             config.ServiceLevelObjective{},
+		Parameters:       make(config.Parameters),
 	}, nil
 }
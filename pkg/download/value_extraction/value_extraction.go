@@ -0,0 +1,330 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package value_extraction extracts environment-specific values, like tenant URLs or email addresses, from the JSON
+// templates of downloaded configurations into parameters. This allows deploying a downloaded project to another
+// environment by only changing parameter values.
+package value_extraction
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Detector finds values of one kind in a template. If Pattern contains a capturing group, only the value of the first
+// group is extracted, otherwise the whole match is extracted.
+type Detector struct {
+	// Name of the detector, used to name the extracted parameters.
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+const (
+	TenantURL   = "tenantUrl"
+	TenantID    = "tenantId"
+	Email       = "email"
+	WebhookHost = "webhookHost"
+)
+
+// builtinDetectors are ordered, as values found by earlier detectors are not matched by later ones again.
+var builtinDetectors = []Detector{
+	{
+		// SaaS environment URLs and Managed environment URLs in the form https://<host>/e/<environment-id>
+		Name:    TenantURL,
+		Pattern: regexp.MustCompile(`https://(?:[a-z0-9]+\.(?:live|apps|sprint(?:\.apps)?|dev(?:\.apps)?)\.dynatrace(?:labs)?\.com|[a-zA-Z0-9.-]+(?::[0-9]+)?/e/[a-zA-Z0-9-]+)`),
+	},
+	{
+		// SaaS environment IDs consist of three lowercase letters followed by five digits
+		Name:    TenantID,
+		Pattern: regexp.MustCompile(`\b[a-z]{3}[0-9]{5}\b`),
+	},
+	{
+		Name:    Email,
+		Pattern: regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`),
+	},
+	{
+		// hosts of http(s) URLs, e.g. of webhook notifications
+		Name:    WebhookHost,
+		Pattern: regexp.MustCompile(`https?://([a-zA-Z0-9.-]+\.[a-zA-Z]{2,})`),
+	},
+}
+
+var detectorNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// BuiltinDetectorNames returns the names of all built-in detectors.
+func BuiltinDetectorNames() []string {
+	names := make([]string, 0, len(builtinDetectors))
+	for _, d := range builtinDetectors {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// NewDetectors returns the built-in detectors of the given names, followed by the user-defined detectors. Patterns are
+// defined as 'name=regex'.
+func NewDetectors(builtinNames []string, patterns []string) ([]Detector, error) {
+	var detectors []Detector
+	var errs []error
+	for _, name := range builtinNames {
+		i := slices.IndexFunc(builtinDetectors, func(d Detector) bool { return d.Name == name })
+		if i < 0 {
+			errs = append(errs, fmt.Errorf("unknown value detector %q, supported detectors are %s", name, strings.Join(BuiltinDetectorNames(), ", ")))
+			continue
+		}
+		detectors = append(detectors, builtinDetectors[i])
+	}
+	// keep the order of the built-in detectors, as later ones assume the values of earlier ones are already extracted
+	slices.SortStableFunc(detectors, func(a, b Detector) int {
+		return cmp.Compare(slices.Index(BuiltinDetectorNames(), a.Name), slices.Index(BuiltinDetectorNames(), b.Name))
+	})
+
+	for _, p := range patterns {
+		name, expr, found := strings.Cut(p, "=")
+		if !found || !detectorNamePattern.MatchString(name) {
+			errs = append(errs, fmt.Errorf("invalid pattern %q: expected 'name=regex' with an alphanumeric name", p))
+			continue
+		}
+		if slices.ContainsFunc(detectors, func(d Detector) bool { return d.Name == name }) {
+			errs = append(errs, fmt.Errorf("invalid pattern %q: detector %q is defined more than once", p, name))
+			continue
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern %q: %w", p, err))
+			continue
+		}
+		detectors = append(detectors, Detector{Name: name, Pattern: re})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return detectors, nil
+}
+
+// Options configures which values are extracted.
+type Options struct {
+	Detectors []Detector
+	// AsEnvironmentVariables defines that extracted values are replaced by environment variable parameters instead of
+	// value parameters, so that they need to be defined when deploying the project.
+	AsEnvironmentVariables bool
+}
+
+// extractedValue is a value extracted into a parameter.
+type extractedValue struct {
+	detector, value string
+}
+
+// ExtractValuesIntoParameters searches for the values found by the configured detectors in each given config's JSON
+// template and replaces them with a parameter. Equal values are extracted into parameters of the same name in all
+// configs, e.g. 'email_1'. It modifies the given configsPerType map.
+func ExtractValuesIntoParameters(configsPerType project.ConfigsPerType, opts Options) (project.ConfigsPerType, error) {
+	if len(opts.Detectors) == 0 {
+		return configsPerType, nil
+	}
+
+	names := make(map[extractedValue]string)
+	counts := make(map[string]int)
+	parameterName := func(v extractedValue) string {
+		if name, found := names[v]; found {
+			return name
+		}
+		counts[v.detector]++
+		name := fmt.Sprintf("%s_%d", v.detector, counts[v.detector])
+		names[v] = name
+		return name
+	}
+
+	// configs are processed in a stable order, so that the same values are assigned the same parameter names
+	for _, t := range slices.Sorted(maps.Keys(configsPerType)) {
+		cfgs := configsPerType[t]
+		slices.SortStableFunc(cfgs, func(a, b config.Config) int { return cmp.Compare(a.Coordinate.ConfigId, b.Coordinate.ConfigId) })
+
+		for i := range cfgs {
+			c := &cfgs[i]
+			// extension packages are binary archives which must not be modified
+			if c.Coordinate.Type == string(config.ExtensionV2TypeID) {
+				continue
+			}
+
+			content, err := c.Template.Content()
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract values from %s: %w", c.Coordinate, err)
+			}
+
+			extracted := make(map[string]string)
+			for _, d := range opts.Detectors {
+				content = replaceMatches(content, d, func(v string) string {
+					name := parameterName(extractedValue{d.Name, v})
+					extracted[name] = v
+					return name
+				})
+			}
+
+			if len(extracted) == 0 {
+				continue
+			}
+			if err := c.Template.UpdateContent(content); err != nil {
+				return nil, fmt.Errorf("failed to extract values from %s: %w", c.Coordinate, err)
+			}
+			if c.Parameters == nil {
+				c.Parameters = make(config.Parameters)
+			}
+			for name, v := range extracted {
+				c.Parameters[name] = newParameter(name, v, opts.AsEnvironmentVariables)
+			}
+		}
+	}
+	return configsPerType, nil
+}
+
+// replaceMatches replaces all values found by the detector with a template expression for the parameter of the name
+// returned by paramName. Only JSON string values are searched, so that keys, numbers and template actions are never
+// replaced. Values are matched in their unescaped form, and the remaining parts of a string value are escaped again.
+func replaceMatches(content string, d Detector, paramName func(value string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, segment := range stringValueSegments(content) {
+		replaced, ok := replaceInSegment(content[segment[0]:segment[1]], d, paramName)
+		if !ok {
+			continue
+		}
+		sb.WriteString(content[last:segment[0]])
+		sb.WriteString(replaced)
+		last = segment[1]
+	}
+	if last == 0 {
+		return content
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
+
+// replaceInSegment replaces all values found by the detector in the given escaped part of a JSON string. It returns
+// false if nothing was replaced.
+func replaceInSegment(escaped string, d Detector, paramName func(value string) string) (string, bool) {
+	var unescaped string
+	if err := json.Unmarshal([]byte(`"`+escaped+`"`), &unescaped); err != nil {
+		return "", false
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range d.Pattern.FindAllStringSubmatchIndex(unescaped, -1) {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		if start == end || start < last {
+			continue
+		}
+
+		sb.WriteString(escapeJSONString(unescaped[last:start]))
+		sb.WriteString(fmt.Sprintf("{{ .%s }}", paramName(unescaped[start:end])))
+		last = end
+	}
+	if last == 0 {
+		return "", false
+	}
+	sb.WriteString(escapeJSONString(unescaped[last:]))
+	return sb.String(), true
+}
+
+// stringValueSegments returns the start and end offsets of the contents of all JSON string values in the given
+// template. Object keys are skipped, and strings containing template actions are split into the parts around them.
+func stringValueSegments(content string) [][2]int {
+	var segments [][2]int
+	for i := 0; i < len(content); i++ {
+		switch {
+		case strings.HasPrefix(content[i:], "{{"):
+			i = skipAction(content, i)
+		case content[i] == '"':
+			var stringSegments [][2]int
+			start := i + 1
+			for i = start; i < len(content) && content[i] != '"'; i++ {
+				switch {
+				case content[i] == '\\':
+					i++
+				case strings.HasPrefix(content[i:], "{{"):
+					stringSegments = append(stringSegments, [2]int{start, i})
+					i = skipAction(content, i)
+					start = i + 1
+				}
+			}
+			stringSegments = append(stringSegments, [2]int{start, min(i, len(content))})
+
+			if !isKey(content[min(i+1, len(content)):]) {
+				for _, s := range stringSegments {
+					if s[0] < s[1] {
+						segments = append(segments, s)
+					}
+				}
+			}
+		}
+	}
+	return segments
+}
+
+// skipAction returns the offset of the last character of the template action starting at the given offset.
+func skipAction(content string, start int) int {
+	end := strings.Index(content[start:], "}}")
+	if end < 0 {
+		return len(content)
+	}
+	return start + end + 1
+}
+
+// isKey returns whether the content following a JSON string starts with a colon, i.e. the string is an object key.
+func isKey(following string) bool {
+	return strings.HasPrefix(strings.TrimLeft(following, " \t\r\n"), ":")
+}
+
+// escapeJSONString escapes the given value for use inside a JSON string, without the surrounding quotes.
+func escapeJSONString(v string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	escaped := strings.TrimSuffix(b.String(), "\n")
+	return escaped[1 : len(escaped)-1]
+}
+
+func newParameter(name, v string, asEnvironmentVariable bool) parameter.Parameter {
+	if asEnvironmentVariable {
+		return environment.New(environmentVariableName(name))
+	}
+	return value.New(v)
+}
+
+var upperCaseLetter = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// environmentVariableName converts a parameter name like 'tenantUrl_1' to 'TENANT_URL_1'.
+func environmentVariableName(parameterName string) string {
+	return strings.ToUpper(upperCaseLetter.ReplaceAllString(parameterName, "${1}_${2}"))
+}
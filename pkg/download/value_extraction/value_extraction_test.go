@@ -0,0 +1,188 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package value_extraction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func newConfig(id, content string) config.Config {
+	return config.Config{
+		Template:   template.NewInMemoryTemplate(id, content),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "builtin:test", ConfigId: id},
+		Type:       config.SettingsType{SchemaId: "builtin:test"},
+		Parameters: config.Parameters{config.ScopeParameter: value.New("environment")},
+	}
+}
+
+func content(t *testing.T, c config.Config) string {
+	t.Helper()
+	s, err := c.Template.Content()
+	require.NoError(t, err)
+	return s
+}
+
+func allBuiltinDetectors(t *testing.T) []Detector {
+	t.Helper()
+	detectors, err := NewDetectors(BuiltinDetectorNames(), nil)
+	require.NoError(t, err)
+	return detectors
+}
+
+func TestExtractValuesIntoParameters_BuiltinDetectors(t *testing.T) {
+	configs := project.ConfigsPerType{
+		"builtin:test": {newConfig("a", `{"link": "https://abc12345.live.dynatrace.com/ui/apps", "tenant": "abc12345", "mail": "jane.doe@example.com", "hook": "https://hooks.example.com/services/1"}`)},
+	}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{Detectors: allBuiltinDetectors(t)})
+	require.NoError(t, err)
+
+	c := result["builtin:test"][0]
+	assert.Equal(t, `{"link": "{{ .tenantUrl_1 }}/ui/apps", "tenant": "{{ .tenantId_1 }}", "mail": "{{ .email_1 }}", "hook": "https://{{ .webhookHost_1 }}/services/1"}`, content(t, c))
+	assert.Equal(t, value.New("https://abc12345.live.dynatrace.com"), c.Parameters["tenantUrl_1"])
+	assert.Equal(t, value.New("abc12345"), c.Parameters["tenantId_1"])
+	assert.Equal(t, value.New("jane.doe@example.com"), c.Parameters["email_1"])
+	assert.Equal(t, value.New("hooks.example.com"), c.Parameters["webhookHost_1"])
+}
+
+func TestExtractValuesIntoParameters_EqualValuesShareParameterNames(t *testing.T) {
+	detectors, err := NewDetectors([]string{Email}, nil)
+	require.NoError(t, err)
+
+	configs := project.ConfigsPerType{
+		"builtin:test": {
+			newConfig("b", `{"to": ["john@example.com", "jane@example.com"]}`),
+			newConfig("a", `{"to": ["jane@example.com"]}`),
+		},
+	}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{Detectors: detectors})
+	require.NoError(t, err)
+
+	a, b := result["builtin:test"][0], result["builtin:test"][1]
+	assert.Equal(t, `{"to": ["{{ .email_1 }}"]}`, content(t, a))
+	assert.Equal(t, `{"to": ["{{ .email_2 }}", "{{ .email_1 }}"]}`, content(t, b))
+	assert.Equal(t, value.New("jane@example.com"), b.Parameters["email_1"])
+	assert.Equal(t, value.New("john@example.com"), b.Parameters["email_2"])
+}
+
+func TestExtractValuesIntoParameters_AsEnvironmentVariables(t *testing.T) {
+	detectors, err := NewDetectors([]string{TenantURL}, nil)
+	require.NoError(t, err)
+
+	configs := project.ConfigsPerType{"builtin:test": {newConfig("a", `{"url": "https://my-managed.example.com/e/1234-abcd"}`)}}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{Detectors: detectors, AsEnvironmentVariables: true})
+	require.NoError(t, err)
+
+	c := result["builtin:test"][0]
+	assert.Equal(t, `{"url": "{{ .tenantUrl_1 }}"}`, content(t, c))
+	assert.Equal(t, environment.New("TENANT_URL_1"), c.Parameters["tenantUrl_1"])
+}
+
+func TestExtractValuesIntoParameters_UserPatterns(t *testing.T) {
+	detectors, err := NewDetectors(nil, []string{`team=team-([a-z]+)`})
+	require.NoError(t, err)
+
+	configs := project.ConfigsPerType{"builtin:test": {newConfig("a", `{"name": "team-payments"}`)}}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{Detectors: detectors})
+	require.NoError(t, err)
+
+	c := result["builtin:test"][0]
+	assert.Equal(t, `{"name": "team-{{ .team_1 }}"}`, content(t, c))
+	assert.Equal(t, value.New("payments"), c.Parameters["team_1"])
+}
+
+func TestExtractValuesIntoParameters_NoDetectors(t *testing.T) {
+	configs := project.ConfigsPerType{"builtin:test": {newConfig("a", `{"mail": "jane@example.com"}`)}}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"mail": "jane@example.com"}`, content(t, result["builtin:test"][0]))
+}
+
+func TestExtractValuesIntoParameters_EscapedValuesAreUnescaped(t *testing.T) {
+	detectors, err := NewDetectors(nil, []string{`path=C:\\[a-z]+`})
+	require.NoError(t, err)
+
+	configs := project.ConfigsPerType{"builtin:test": {newConfig("a", `{"dir": "in \"C:\\temp\""}`)}}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{Detectors: detectors})
+	require.NoError(t, err)
+
+	c := result["builtin:test"][0]
+	assert.Equal(t, `{"dir": "in \"{{ .path_1 }}\""}`, content(t, c))
+	assert.Equal(t, value.New(`C:\temp`), c.Parameters["path_1"])
+}
+
+func TestExtractValuesIntoParameters_OnlyStringValuesAreMatched(t *testing.T) {
+	detectors, err := NewDetectors(nil, []string{`word=secret`})
+	require.NoError(t, err)
+
+	configs := project.ConfigsPerType{"builtin:test": {newConfig("a", `{"secret": "a secret", "count": {{ .secret }}, "text": "{{ .secret }} and secret", "list": ["secret"]}`)}}
+
+	result, err := ExtractValuesIntoParameters(configs, Options{Detectors: detectors})
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"secret": "a {{ .word_1 }}", "count": {{ .secret }}, "text": "{{ .secret }} and {{ .word_1 }}", "list": ["{{ .word_1 }}"]}`, content(t, result["builtin:test"][0]))
+}
+
+func TestNewDetectors(t *testing.T) {
+	t.Run("built-in detectors keep their order", func(t *testing.T) {
+		detectors, err := NewDetectors([]string{Email, TenantURL}, []string{"custom=x"})
+		require.NoError(t, err)
+		require.Len(t, detectors, 3)
+		assert.Equal(t, TenantURL, detectors[0].Name)
+		assert.Equal(t, Email, detectors[1].Name)
+		assert.Equal(t, "custom", detectors[2].Name)
+	})
+
+	for _, tc := range []struct {
+		name     string
+		builtins []string
+		patterns []string
+	}{
+		{"unknown built-in detector", []string{"unknown"}, nil},
+		{"pattern without name", nil, []string{"regex"}},
+		{"invalid name", nil, []string{"my-name=regex"}},
+		{"invalid regex", nil, []string{"name=("}},
+		{"duplicate name", []string{Email}, []string{"email=x"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewDetectors(tc.builtins, tc.patterns)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEnvironmentVariableName(t *testing.T) {
+	assert.Equal(t, "TENANT_URL_1", environmentVariableName("tenantUrl_1"))
+	assert.Equal(t, "EMAIL_12", environmentVariableName("email_12"))
+}
@@ -36,15 +36,21 @@ type Type string
 const (
 	TypeEnvironment Type = "environment"
 	TypeValue       Type = "value"
+	TypeFile        Type = "file"
+	TypeCommand     Type = "command"
 )
 
 // TypedValue represents a value with a Type - currently these are variables that can be either:
 // - TypeEnvironment...loaded from an environment variable
 // - TypeValue...read directly
+// - TypeFile...read from a file (only supported for the accountUUID)
+// - TypeCommand...read from the output of a command (only supported for the accountUUID)
 // Additionally TypedValues can be defined directly as a string, as a shorthand for type: TypeValue
 type TypedValue struct {
-	Type  Type   `yaml:"type,omitempty" mapstructure:"type" json:"type" jsonschema:"enum=environment,enum=value,description=The type of this value - either an 'environment' variable to read, or simpy a 'value' directly in the YAML."`
-	Value string `yaml:"value" mapstructure:"value" json:"value" jsonschema:"required,description=The value is depending on 'type' either the name of an environment variable to load or just a string value."`
+	Type  Type   `yaml:"type,omitempty" mapstructure:"type" json:"type" jsonschema:"enum=environment,enum=value,enum=file,enum=command,description=The type of this value - either an 'environment' variable to read, simpy a 'value' directly in the YAML, or for the accountUUID a 'file' or the output of a 'command'."`
+	Value string `yaml:"value" mapstructure:"value" json:"value" jsonschema:"description=The value is depending on 'type' either the name of an environment variable to load, the path of a file to read or just a string value. Required for all types but 'command'."`
+	// Command to execute to read the value from its standard output. Only used for TypeCommand.
+	Command []string `yaml:"command,omitempty" mapstructure:"command" json:"command,omitempty" jsonschema:"description=The command and its arguments to execute - the value is read from its standard output. Required for type 'command'."`
 }
 
 // UnmarshalYAML Custom unmarshaler for TypedValue able to parse simple shorthands (accountUUID: 1234) and full values.
@@ -67,16 +73,21 @@ func (c *TypedValue) UnmarshalYAML(unmarshal func(any) error) error {
 }
 
 // AuthSecret represents a user-defined client id or client secret. It has a [Type] which is [TypeEnvironment] (default).
-// Secrets must never be provided as plain text, but always loaded from somewhere else. Currently, loading is only allowed from environment variables.
+// Secrets must never be provided as plain text, but always loaded from somewhere else. Loading is allowed from
+// environment variables ([TypeEnvironment]), files ([TypeFile]) or the output of a helper command ([TypeCommand]).
 //
-// [Name] contains the environment-variable to resolve the authSecret.
+// [Name] contains the environment-variable, [Path] the file and [Command] the helper command to resolve the authSecret.
 //
 // This struct is meant to be reused for fields that require the same behavior.
 type AuthSecret struct {
-	// Type exists for future compatibility - AuthSecrets are always read from 'environment' variables
-	Type Type `yaml:"type" json:"type,omitempty"`
+	// Type defines from where the secret is read. Defaults to 'environment' if not set.
+	Type Type `yaml:"type" json:"type,omitempty" jsonschema:"enum=environment,enum=file,enum=command,description=The source of the secret - either an 'environment' variable, a 'file' or the output of a 'command'. Defaults to 'environment'."`
 	//Name of the environment variable to read the secret from.
-	Name string `yaml:"name" json:"name" jsonschema:"required"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"description=The name of the environment variable to read the secret from. Required for type 'environment'."`
+	// Path of the file to read the secret from.
+	Path string `yaml:"path,omitempty" json:"path,omitempty" jsonschema:"description=The path of the file to read the secret from - relative paths are resolved from the manifest's location. Required for type 'file'."`
+	// Command to execute to read the secret from its standard output.
+	Command []string `yaml:"command,omitempty" json:"command,omitempty" jsonschema:"description=The command and its arguments to execute - the secret is read from its standard output. Required for type 'command'."`
 }
 
 // OAuth defines the required information to request oAuth bearer tokens for authenticated API calls
@@ -94,7 +105,9 @@ type Auth struct {
 	// Token defines an API access tokens used for Dynatrace Config API calls
 	Token *AuthSecret `yaml:"token,omitempty" json:"token" jsonschema:"description=An API access tokens used for Dynatrace Config API calls - for classic apis this is required"`
 	// OAuth defines client credentials used for Dynatrace Platform API calls
-	OAuth *OAuth `yaml:"oAuth,omitempty" json:"oAuth" jsonschema:"description=OAuth client credentials used for Dynatrace Platform API calls - for platform environments this is required."`
+	OAuth *OAuth `yaml:"oAuth,omitempty" json:"oAuth" jsonschema:"description=OAuth client credentials used for Dynatrace Platform API calls - for platform environments this or a platform token is required."`
+	// PlatformToken defines a platform token used for Dynatrace Platform API calls
+	PlatformToken *AuthSecret `yaml:"platformToken,omitempty" json:"platformToken" jsonschema:"description=A platform token used for Dynatrace Platform API calls. Can be used instead of OAuth client credentials, but not together with them."`
 }
 
 // Environment defines all required information for accessing a Dynatrace environment
@@ -103,12 +116,34 @@ type Environment struct {
 	URL  TypedValue `yaml:"url" json:"url" jsonschema:"required,oneof_type=string;object,description=The URL of the environment."`
 
 	Auth Auth `yaml:"auth,omitempty" json:"auth" jsonschema:"required,description=This defines all information required for authenticated access to the environment's API."`
+
+	Variables map[string]TypedValue `yaml:"variables,omitempty" json:"variables" jsonschema:"description=Variables available to all configs deployed to this environment. Overrides variables of the same name defined on the group or manifest level."`
+
+	Transport *Transport `yaml:"transport,omitempty" json:"transport" jsonschema:"description=Optional HTTP transport settings used to connect to the environment."`
+
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels" jsonschema:"description=Arbitrary key/value labels of the environment. Labels can be used to select environments via '--selector' and are available to configs via 'label' parameters."`
+}
+
+// Transport defines optional HTTP transport settings used to connect to an environment or account
+type Transport struct {
+	// Proxy is the URL of the HTTP(S) proxy to send all requests through
+	Proxy *TypedValue `yaml:"proxy,omitempty" json:"proxy" jsonschema:"oneof_type=string;object,description=The URL of the HTTP(S) proxy to send all requests through. If not set the proxy is determined by the HTTP_PROXY and HTTPS_PROXY environment variables."`
+	// CACertificate is the path to a PEM encoded CA bundle
+	CACertificate string `yaml:"caCertificate,omitempty" json:"caCertificate" jsonschema:"description=Path to a PEM encoded bundle of CA certificates trusted in addition to the system's CA certificates. Relative paths are resolved from the manifest's location."`
+	// ClientCertificate is the path to a PEM encoded client certificate used for mTLS
+	ClientCertificate string `yaml:"clientCertificate,omitempty" json:"clientCertificate" jsonschema:"description=Path to a PEM encoded client certificate used for mutual TLS. Requires 'clientKey' to be set."`
+	// ClientKey is the path to the PEM encoded private key of the ClientCertificate
+	ClientKey string `yaml:"clientKey,omitempty" json:"clientKey" jsonschema:"description=Path to the PEM encoded private key of the client certificate. Requires 'clientCertificate' to be set."`
+	// InsecureSkipVerify disables the verification of TLS certificates
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty" json:"insecureSkipVerify" jsonschema:"description=Disables the verification of TLS certificates. This is insecure and must only be used for lab/test environments."`
 }
 
 // Group defines a group of Environment
 type Group struct {
 	Name         string        `yaml:"name" json:"name" jsonschema:"required,description=The name of the group - this can be freely defined and will be used in logs, etc."`
 	Environments []Environment `yaml:"environments" json:"environments" jsonschema:"required,minItems=1,description=The environments that are part of this group."`
+	// Variables defined for all environments of this group
+	Variables map[string]TypedValue `yaml:"variables,omitempty" json:"variables" jsonschema:"description=Variables available to all configs deployed to environments of this group. Overrides variables of the same name defined on the manifest level."`
 }
 
 type Manifest struct {
@@ -119,11 +154,14 @@ type Manifest struct {
 	EnvironmentGroups []Group `yaml:"environmentGroups" json:"environmentGroups" jsonschema:"minItems=1,description=A list of environment groups that configs in the defined 'projects' will be deployed to. Required when deploying environment configurations."`
 	// Accounts is a list of accounts that account resources in Projects will be deployed to
 	Accounts []Account `yaml:"accounts,omitempty" json:"accounts" jsonschema:"minItems=1,description=A list of of accounts that account resources defined in 'projects' will be deployed to. Required when deploying account resources."`
+	// Variables is a map of variables available to all environments
+	Variables map[string]TypedValue `yaml:"variables,omitempty" json:"variables" jsonschema:"description=Variables available to all configs in all environments. Configs can access them using parameters of type 'variable'."`
 }
 
 type Account struct {
 	Name        string      `yaml:"name" json:"name" jsonschema:"description=The name of the account - this can be freely defined and will show up in logs, etc."`
-	AccountUUID TypedValue  `yaml:"accountUUID" json:"accountUUID" jsonschema:"required,oneof_type=string;object,description=The uuid of your account - you can find this in the Account Management UI."`
+	AccountUUID TypedValue  `yaml:"accountUUID" json:"accountUUID" jsonschema:"required,oneof_type=string;object,description=The uuid of your account - you can find this in the Account Management UI. Besides 'value' and 'environment' this may also be loaded from a 'file' or the output of a 'command'."`
 	ApiUrl      *TypedValue `yaml:"apiUrl,omitempty" json:"apiUrl" jsonschema:"optional,oneof_type=string;object,default=api.dynatrace.com,description=Allows to optionally define a different Account Management API URL."`
 	OAuth       OAuth       `yaml:"oAuth" json:"oAuth" jsonschema:"required,description=OAuth client credentials to authenticate API calls for this account."`
+	Transport   *Transport  `yaml:"transport,omitempty" json:"transport" jsonschema:"description=Optional HTTP transport settings used to connect to the account API."`
 }
@@ -19,6 +19,7 @@ package loader
 import (
 	"errors"
 	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
 	"github.com/google/uuid"
@@ -45,7 +46,7 @@ func (e invalidUUIDError) Unwrap() error {
 
 func parseSingleAccount(c *Context, a persistence.Account) (manifest.Account, error) {
 
-	accountUUID, err := parseAccountUUID(a.AccountUUID)
+	accountUUID, err := parseAccountUUID(c, a.AccountUUID)
 	if err != nil {
 		return manifest.Account{}, err
 	}
@@ -64,18 +65,24 @@ func parseSingleAccount(c *Context, a persistence.Account) (manifest.Account, er
 		}
 	}
 
+	transport, err := parseTransport(c, a.Transport, fmt.Sprintf("account %q", a.Name))
+	if err != nil {
+		return manifest.Account{}, fmt.Errorf("transport: %w", err)
+	}
+
 	acc := manifest.Account{
 		Name:        a.Name,
 		AccountUUID: accountUUID,
 		ApiUrl:      urlDef,
 		OAuth:       *oAuthDef,
+		Transport:   transport,
 	}
 
 	return acc, nil
 }
 
-func parseAccountUUID(u persistence.TypedValue) (uuid.UUID, error) {
-	uuidValue, err := loadAccountUUID(u)
+func parseAccountUUID(c *Context, u persistence.TypedValue) (uuid.UUID, error) {
+	uuidValue, err := loadAccountUUID(c, u)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
@@ -88,7 +95,11 @@ func parseAccountUUID(u persistence.TypedValue) (uuid.UUID, error) {
 	return accountUUID, nil
 }
 
-func loadAccountUUID(u persistence.TypedValue) (string, error) {
+func loadAccountUUID(c *Context, u persistence.TypedValue) (string, error) {
+	if u.Type == persistence.TypeCommand {
+		return loadAccountUUIDFromCommand(c, u.Command)
+	}
+
 	if u.Value == "" {
 		return "", errAccUidMissing
 	}
@@ -108,7 +119,28 @@ func loadAccountUUID(u persistence.TypedValue) (string, error) {
 		return val, nil
 	}
 
-	return "", fmt.Errorf("unexpected type: %q (expected one of %q, %q)", u.Type, persistence.TypeValue, persistence.TypeEnvironment)
+	if u.Type == persistence.TypeFile {
+		if skipSecrets(c) {
+			log.Debug("Skipped reading accountUUID file %s based on loader options", u.Value)
+			return uuid.Nil.String(), nil
+		}
+		return readSecretFile(c, u.Value)
+	}
+
+	return "", fmt.Errorf("unexpected type: %q (expected one of %q, %q, %q, %q)", u.Type, persistence.TypeValue, persistence.TypeEnvironment, persistence.TypeFile, persistence.TypeCommand)
+}
+
+func loadAccountUUIDFromCommand(c *Context, command []string) (string, error) {
+	if len(command) == 0 || command[0] == "" {
+		return "", errors.New("accountUUID command is missing")
+	}
+
+	if skipSecrets(c) {
+		log.Debug("Skipped executing accountUUID command %q based on loader options", command[0])
+		return uuid.Nil.String(), nil
+	}
+
+	return runSecretCommand(c, command)
 }
 
 // parseAccounts converts the persistence definition to the in-memory definition
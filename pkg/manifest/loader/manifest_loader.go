@@ -59,6 +59,10 @@ type Context struct {
 	// If Groups contains items that do not match any environment in the specified manifest file, the loading errors.
 	Groups []string
 
+	// Selector is an optional label selector (e.g. 'tier=prod,region in (eu,us)') that environments need to match in
+	// order to be loaded. If Environments or Groups are specified as well, environments need to match both.
+	Selector string
+
 	// Opts are Options holding optional configuration for Load
 	Opts Options
 }
@@ -73,6 +77,9 @@ type Options struct {
 	DoNotResolveEnvVars      bool
 	RequireEnvironmentGroups bool
 	RequireAccounts          bool
+	// DoNotResolveSecrets skips resolving the secrets of environments and accounts, e.g. if no environment is
+	// contacted. Unlike DoNotResolveEnvVars, all other values like URLs and variables are still resolved.
+	DoNotResolveSecrets bool
 }
 
 type ManifestLoaderError struct {
@@ -136,7 +143,7 @@ func (e ProjectLoaderError) Error() string {
 }
 
 func Load(context *Context) (manifest.Manifest, []error) {
-	log.WithFields(field.F("manifestPath", context.ManifestPath)).Info("Loading manifest %q. Restrictions: groups=%q, environments=%q", context.ManifestPath, context.Groups, context.Environments)
+	log.WithFields(field.F("manifestPath", context.ManifestPath)).Info("Loading manifest %q. Restrictions: groups=%q, environments=%q, selector=%q", context.ManifestPath, context.Groups, context.Environments, context.Selector)
 
 	manifestYAML, err := readManifestYAML(context)
 	if err != nil {
@@ -183,7 +190,7 @@ func Load(context *Context) (manifest.Manifest, []error) {
 	var environmentDefinitions map[string]manifest.EnvironmentDefinition
 	if len(manifestYAML.EnvironmentGroups) > 0 {
 		var manifestErrors []error
-		if environmentDefinitions, manifestErrors = parseEnvironments(context, manifestYAML.EnvironmentGroups); manifestErrors != nil {
+		if environmentDefinitions, manifestErrors = parseEnvironments(context, manifestYAML.Variables, manifestYAML.EnvironmentGroups); manifestErrors != nil {
 			errs = append(errs, manifestErrors...)
 		} else if len(environmentDefinitions) == 0 {
 			errs = append(errs, newManifestLoaderError(context.ManifestPath, "no environments defined in manifest"))
@@ -211,8 +218,12 @@ func Load(context *Context) (manifest.Manifest, []error) {
 func parseAuth(context *Context, a persistence.Auth) (manifest.Auth, error) {
 	var mAuth manifest.Auth
 
-	if a.Token == nil && a.OAuth == nil {
-		return manifest.Auth{}, errors.New("no token or OAuth credentials provided")
+	if a.Token == nil && a.OAuth == nil && a.PlatformToken == nil {
+		return manifest.Auth{}, errors.New("no token, OAuth credentials or platform token provided")
+	}
+
+	if a.OAuth != nil && a.PlatformToken != nil {
+		return manifest.Auth{}, errors.New("OAuth credentials and platform token are mutually exclusive")
 	}
 
 	if a.Token != nil {
@@ -231,19 +242,41 @@ func parseAuth(context *Context, a persistence.Auth) (manifest.Auth, error) {
 		mAuth.OAuth = oauth
 	}
 
+	if a.PlatformToken != nil {
+		platformToken, err := parseAuthSecret(context, a.PlatformToken)
+		if err != nil {
+			return manifest.Auth{}, fmt.Errorf("failed to parse platform token: %w", err)
+		}
+		mAuth.PlatformToken = &platformToken
+	}
+
 	return mAuth, nil
 }
 
 func parseAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
-	if s.Type != persistence.TypeEnvironment && s.Type != "" {
-		return manifest.AuthSecret{}, errors.New("type must be 'environment'")
+	switch s.Type {
+	case "", persistence.TypeEnvironment:
+		return parseEnvironmentAuthSecret(context, s)
+	case persistence.TypeFile:
+		return parseFileAuthSecret(context, s)
+	case persistence.TypeCommand:
+		return parseCommandAuthSecret(context, s)
+	default:
+		return manifest.AuthSecret{}, fmt.Errorf("type must be one of %q, %q or %q", persistence.TypeEnvironment, persistence.TypeFile, persistence.TypeCommand)
 	}
+}
+
+// skipSecrets returns whether secrets are not resolved according to the loader options.
+func skipSecrets(context *Context) bool {
+	return context.Opts.DoNotResolveEnvVars || context.Opts.DoNotResolveSecrets
+}
 
+func parseEnvironmentAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
 	if s.Name == "" {
 		return manifest.AuthSecret{}, errors.New("no name given or empty")
 	}
 
-	if context.Opts.DoNotResolveEnvVars {
+	if skipSecrets(context) {
 		log.Debug("Skipped resolving environment variable %s based on loader options", s.Name)
 		return manifest.AuthSecret{
 			Name:  s.Name,
@@ -263,6 +296,50 @@ func parseAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.Auth
 	return manifest.AuthSecret{Name: s.Name, Value: secret.MaskedString(v)}, nil
 }
 
+func parseFileAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
+	if s.Path == "" {
+		return manifest.AuthSecret{}, errors.New("no path given or empty")
+	}
+
+	if skipSecrets(context) {
+		log.Debug("Skipped reading secret file %s based on loader options", s.Path)
+		return manifest.AuthSecret{
+			Type:  manifest.FileSecretType,
+			Path:  s.Path,
+			Value: secret.MaskedString(fmt.Sprintf("SKIPPED RESOLUTION OF FILE: %s", s.Path)),
+		}, nil
+	}
+
+	v, err := readSecretFile(context, s.Path)
+	if err != nil {
+		return manifest.AuthSecret{}, err
+	}
+
+	return manifest.AuthSecret{Type: manifest.FileSecretType, Path: s.Path, Value: secret.MaskedString(v)}, nil
+}
+
+func parseCommandAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
+	if len(s.Command) == 0 || s.Command[0] == "" {
+		return manifest.AuthSecret{}, errors.New("no command given or empty")
+	}
+
+	if skipSecrets(context) {
+		log.Debug("Skipped executing secret command %q based on loader options", s.Command[0])
+		return manifest.AuthSecret{
+			Type:    manifest.CommandSecretType,
+			Command: s.Command,
+			Value:   secret.MaskedString(fmt.Sprintf("SKIPPED RESOLUTION OF COMMAND: %s", s.Command[0])),
+		}, nil
+	}
+
+	v, err := runSecretCommand(context, s.Command)
+	if err != nil {
+		return manifest.AuthSecret{}, err
+	}
+
+	return manifest.AuthSecret{Type: manifest.CommandSecretType, Command: s.Command, Value: secret.MaskedString(v)}, nil
+}
+
 func parseOAuth(context *Context, a *persistence.OAuth) (*manifest.OAuth, error) {
 	clientID, err := parseAuthSecret(context, &a.ClientID)
 	if err != nil {
@@ -346,13 +423,23 @@ func validateVersion(m persistence.Manifest) error {
 	return nil
 }
 
-func parseEnvironments(context *Context, groups []persistence.Group) (map[string]manifest.EnvironmentDefinition, []error) { // nolint:gocognit
+func parseEnvironments(context *Context, globalVariables map[string]persistence.TypedValue, groups []persistence.Group) (map[string]manifest.EnvironmentDefinition, []error) { // nolint:gocognit
 	var errors []error
 	environments := make(map[string]manifest.EnvironmentDefinition)
 
 	groupNames := make(map[string]bool, len(groups))
 	envNames := make(map[string]bool, len(groups))
 
+	selector, err := parseLabelSelector(context.Selector)
+	if err != nil {
+		return nil, []error{newManifestLoaderError(context.ManifestPath, err.Error())}
+	}
+
+	globalVars, err := parseVariables(context, globalVariables)
+	if err != nil {
+		return nil, []error{newManifestLoaderError(context.ManifestPath, fmt.Sprintf("failed to parse variables: %s", err))}
+	}
+
 	for i, group := range groups {
 		if group.Name == "" {
 			errors = append(errors, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("missing group name on index `%d`", i)))
@@ -364,6 +451,12 @@ func parseEnvironments(context *Context, groups []persistence.Group) (map[string
 
 		groupNames[group.Name] = true
 
+		groupVars, err := parseVariables(context, group.Variables)
+		if err != nil {
+			errors = append(errors, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("failed to parse variables of group %q: %s", group.Name, err)))
+		}
+		inheritedVars := mergeVariables(globalVars, groupVars)
+
 		for j, env := range group.Environments {
 
 			if env.Name == "" {
@@ -377,13 +470,14 @@ func parseEnvironments(context *Context, groups []persistence.Group) (map[string
 			}
 			envNames[env.Name] = true
 
-			// skip loading if environments is not empty, the environments does not contain the env name, or the group should not be included
-			if shouldSkipEnv(context, group, env) {
+			// skip loading if environments is not empty, the environments does not contain the env name, the group should not be included,
+			// or the labels of the environment do not match the selector
+			if shouldSkipEnv(context, selector, group, env) {
 				log.WithFields(field.F("manifestPath", context.ManifestPath)).Debug("skipping loading of environment %q", env.Name)
 				continue
 			}
 
-			parsedEnv, configErrors := parseSingleEnvironment(context, env, group.Name)
+			parsedEnv, configErrors := parseSingleEnvironment(context, env, group.Name, inheritedVars)
 
 			if configErrors != nil {
 				errors = append(errors, configErrors...)
@@ -411,10 +505,18 @@ func parseEnvironments(context *Context, groups []persistence.Group) (map[string
 		return nil, errors
 	}
 
+	if len(selector) > 0 && len(environments) == 0 {
+		return nil, []error{newManifestLoaderError(context.ManifestPath, fmt.Sprintf("no environments match selector %q", context.Selector))}
+	}
+
 	return environments, nil
 }
 
-func shouldSkipEnv(context *Context, group persistence.Group, env persistence.Environment) bool {
+func shouldSkipEnv(context *Context, selector labelSelector, group persistence.Group, env persistence.Environment) bool {
+	if !selector.matches(env.Labels) {
+		return true
+	}
+
 	// if nothing is restricted, everything is allowed
 	if len(context.Groups) == 0 && len(context.Environments) == 0 {
 		return false
@@ -431,7 +533,7 @@ func shouldSkipEnv(context *Context, group persistence.Group, env persistence.En
 	return true
 }
 
-func parseSingleEnvironment(context *Context, config persistence.Environment, group string) (manifest.EnvironmentDefinition, []error) {
+func parseSingleEnvironment(context *Context, config persistence.Environment, group string, inheritedVariables manifest.Variables) (manifest.EnvironmentDefinition, []error) {
 	var errs []error
 
 	a, err := parseAuth(context, config.Auth)
@@ -444,18 +546,94 @@ func parseSingleEnvironment(context *Context, config persistence.Environment, gr
 		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
 	}
 
+	envVars, err := parseVariables(context, config.Variables)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("failed to parse variables: %s", err)))
+	}
+
+	transport, err := parseTransport(context, config.Transport, fmt.Sprintf("environment %q", config.Name))
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("failed to parse transport section: %s", err)))
+	}
+
+	for key := range config.Labels {
+		if key == "" {
+			errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, "label keys must not be empty"))
+		}
+	}
+
 	if len(errs) > 0 {
 		return manifest.EnvironmentDefinition{}, errs
 	}
 
 	return manifest.EnvironmentDefinition{
-		Name:  config.Name,
-		URL:   urlDef,
-		Auth:  a,
-		Group: group,
+		Name:      config.Name,
+		URL:       urlDef,
+		Auth:      a,
+		Group:     group,
+		Variables: mergeVariables(inheritedVariables, envVars),
+		Transport: transport,
+		Labels:    config.Labels,
 	}, nil
 }
 
+// parseVariables resolves all user-defined variables. Variables may either be defined directly as a value,
+// or be loaded from an environment variable.
+func parseVariables(context *Context, variables map[string]persistence.TypedValue) (manifest.Variables, error) {
+	if len(variables) == 0 {
+		return nil, nil
+	}
+
+	result := make(manifest.Variables, len(variables))
+	for name, v := range variables {
+		if name == "" {
+			return nil, errors.New("variable name must not be empty")
+		}
+
+		switch v.Type {
+		case "", persistence.TypeValue:
+			result[name] = manifest.VariableDefinition{Value: v.Value}
+
+		case persistence.TypeEnvironment:
+			if v.Value == "" {
+				return nil, fmt.Errorf("variable %q: no environment variable name given", name)
+			}
+
+			if context.Opts.DoNotResolveEnvVars {
+				log.Debug("Skipped resolving environment variable %s based on loader options", v.Value)
+				result[name] = manifest.VariableDefinition{Name: v.Value, Value: fmt.Sprintf("SKIPPED RESOLUTION OF ENV_VAR: %s", v.Value)}
+				continue
+			}
+
+			val, found := os.LookupEnv(v.Value)
+			if !found {
+				return nil, fmt.Errorf("variable %q: environment variable %q could not be found", name, v.Value)
+			}
+			result[name] = manifest.VariableDefinition{Name: v.Value, Value: val}
+
+		default:
+			return nil, fmt.Errorf("variable %q: %q is not a valid type", name, v.Type)
+		}
+	}
+
+	return result, nil
+}
+
+// mergeVariables merges the given variables into one map. Variables of later maps override variables of earlier ones.
+// If no variables are defined at all, nil is returned.
+func mergeVariables(variables ...manifest.Variables) manifest.Variables {
+	var result manifest.Variables
+	for _, vars := range variables {
+		for name, v := range vars {
+			if result == nil {
+				result = make(manifest.Variables)
+			}
+			result[name] = v
+		}
+	}
+	return result
+}
+
 func parseURLDefinition(context *Context, u persistence.TypedValue) (manifest.URLDefinition, error) {
 
 	// Depending on the type, the url.value either contains the env var name or the direct value of the url
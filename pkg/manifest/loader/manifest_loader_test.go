@@ -26,6 +26,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 	"math"
 	"path/filepath"
@@ -1340,7 +1341,7 @@ manifestVersion: 1.0
 projects: [{name: a}]
 environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e, type: f}}} ]}]
 `,
-			errsContain: []string{"type must be 'environment'"},
+			errsContain: []string{"type must be one of"},
 		},
 		{
 			name: "Empty token and no oauth",
@@ -1633,7 +1634,7 @@ manifestVersion: 1.0
 projects: [{name: a, path: p}]
 environmentGroups: [{name: b, environments: [{name: c, url: {value: d}}]}]
 `,
-			errsContain: []string{"no token or OAuth credentials provided"},
+			errsContain: []string{"no token, OAuth credentials or platform token provided"},
 		},
 		{
 			name: "Unknown type",
@@ -1642,7 +1643,59 @@ manifestVersion: 1.0
 projects: [{name: a, path: p}]
 environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {type: x}}}]}]
 `,
-			errsContain: []string{"type must be 'environment'"},
+			errsContain: []string{"type must be one of"},
+		},
+		{
+			name: "Platform token",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {platformToken: {name: token-env-var}}}]}]
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {
+						Name: "a",
+						Path: "p",
+					},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"c": {
+						Name: "c",
+						URL: manifest.URLDefinition{
+							Type:  manifest.ValueURLType,
+							Value: "d",
+						},
+						Group: "b",
+						Auth: manifest.Auth{
+							PlatformToken: &manifest.AuthSecret{
+								Name:  "token-env-var",
+								Value: "mock token",
+							},
+						},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+			errsContain: []string{},
+		},
+		{
+			name: "Platform token and OAuth credentials are mutually exclusive",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {platformToken: {name: token-env-var}, oAuth: {clientId: {name: client-id}, clientSecret: {name: client-secret}}}}]}]
+`,
+			errsContain: []string{"OAuth credentials and platform token are mutually exclusive"},
+		},
+		{
+			name: "Platform token env var not found",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {platformToken: {name: not-found}}}]}]
+`,
+			errsContain: []string{"failed to parse platform token"},
 		},
 		{
 			name: "load url from env var",
@@ -1809,6 +1862,111 @@ func TestEnvVarResolutionCanBeDeactivated(t *testing.T) {
 		_, gotErr := parseAuth(&Context{Opts: Options{DoNotResolveEnvVars: true}}, e.Auth)
 		assert.NoError(t, gotErr)
 	})
+
+	t.Run("Auth tokens are not resolved if 'DoNotResolveSecrets' option is set", func(t *testing.T) {
+		_, gotErr := parseAuth(&Context{Opts: Options{DoNotResolveSecrets: true}}, e.Auth)
+		assert.NoError(t, gotErr)
+	})
+
+	t.Run("URLs are resolved if 'DoNotResolveSecrets' option is set", func(t *testing.T) {
+		_, gotErr := parseURLDefinition(&Context{Opts: Options{DoNotResolveSecrets: true}}, e.URL)
+		assert.Error(t, gotErr)
+	})
+}
+
+func TestLoadManifest_Variables(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+	t.Setenv("BUSINESS_UNIT", "payments")
+
+	tests := []struct {
+		name            string
+		manifestContent string
+		want            map[string]manifest.Variables
+		wantErr         string
+	}{
+		{
+			name: "no variables",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups: [{name: g, environments: [{name: e, url: {value: u}, auth: {token: {name: TOKEN}}}]}]
+`,
+			want: map[string]manifest.Variables{"e": nil},
+		},
+		{
+			name: "variables are merged and overridden",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a}]
+variables:
+  slackHost: hooks.slack.com
+  region: global
+  businessUnit: {type: environment, value: BUSINESS_UNIT}
+environmentGroups:
+- name: g
+  variables:
+    region: eu
+  environments:
+  - {name: e1, url: {value: u}, auth: {token: {name: TOKEN}}}
+  - {name: e2, url: {value: u}, auth: {token: {name: TOKEN}}, variables: {region: eu-west}}
+`,
+			want: map[string]manifest.Variables{
+				"e1": {
+					"slackHost":    {Value: "hooks.slack.com"},
+					"region":       {Value: "eu"},
+					"businessUnit": {Name: "BUSINESS_UNIT", Value: "payments"},
+				},
+				"e2": {
+					"slackHost":    {Value: "hooks.slack.com"},
+					"region":       {Value: "eu-west"},
+					"businessUnit": {Name: "BUSINESS_UNIT", Value: "payments"},
+				},
+			},
+		},
+		{
+			name: "missing environment variable produces error",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups: [{name: g, environments: [{name: e, url: {value: u}, auth: {token: {name: TOKEN}}, variables: {x: {type: environment, value: NOT_SET}}}]}]
+`,
+			wantErr: `environment variable "NOT_SET" could not be found`,
+		},
+		{
+			name: "invalid type produces error",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a}]
+variables: {x: {type: file, value: some.txt}}
+environmentGroups: [{name: g, environments: [{name: e, url: {value: u}, auth: {token: {name: TOKEN}}}]}]
+`,
+			wantErr: `"file" is not a valid type`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(tt.manifestContent), 0400))
+
+			mani, errs := Load(&Context{
+				Fs:           fs,
+				ManifestPath: "manifest.yaml",
+			})
+
+			if tt.wantErr != "" {
+				require.Len(t, errs, 1)
+				assert.ErrorContains(t, errs[0], tt.wantErr)
+				return
+			}
+
+			require.Empty(t, errs)
+			got := make(map[string]manifest.Variables, len(mani.Environments))
+			for name, env := range mani.Environments {
+				got[name] = env.Variables
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
 
 func TestEnvironmentsAndAccountsAreOptionalUnlessDefined(t *testing.T) {
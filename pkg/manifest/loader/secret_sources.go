@@ -0,0 +1,84 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// secretCommandTimeout is the maximum time a secret helper command may take to produce its output
+const secretCommandTimeout = 1 * time.Minute
+
+// readSecretFile reads the secret stored in the file at the given path. Relative paths are resolved from the
+// directory of the manifest. Leading and trailing whitespace (e.g. trailing newlines) is removed.
+func readSecretFile(c *Context, path string) (string, error) {
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(filepath.Clean(c.ManifestPath)), path)
+	}
+
+	content, err := afero.ReadFile(c.Fs, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file %q: %w", path, err)
+	}
+
+	v := strings.TrimSpace(string(content))
+	if v == "" {
+		return "", fmt.Errorf("secret file %q found, but it is empty", path)
+	}
+
+	return v, nil
+}
+
+// runSecretCommand executes the given command and returns its standard output as secret, similar to how git credential
+// helpers work. The command is not run in a shell, and is executed in the directory of the manifest.
+// Leading and trailing whitespace (e.g. trailing newlines) is removed.
+func runSecretCommand(c *Context, command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = filepath.Dir(filepath.Clean(c.ManifestPath))
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("secret command %q did not finish within %s", command[0], secretCommandTimeout)
+		}
+		// stdout is deliberately not part of the error, as it might contain (parts of) the secret
+		return "", fmt.Errorf("secret command %q failed: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	v := strings.TrimSpace(stdout.String())
+	if v == "" {
+		return "", fmt.Errorf("secret command %q did not produce any output", command[0])
+	}
+
+	return v, nil
+}
@@ -0,0 +1,146 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+func TestParseAuthSecret_File(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "project/secrets/token", []byte("my-token\n"), 0400))
+	require.NoError(t, afero.WriteFile(fs, "project/secrets/empty", []byte("  \n"), 0400))
+	c := &Context{Fs: fs, ManifestPath: "project/manifest.yaml"}
+
+	t.Run("relative paths are resolved from the manifest and content is trimmed", func(t *testing.T) {
+		got, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/token"})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.AuthSecret{Type: manifest.FileSecretType, Path: "secrets/token", Value: "my-token"}, got)
+	})
+
+	t.Run("missing file produces error", func(t *testing.T) {
+		_, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/not-found"})
+		assert.ErrorContains(t, err, "failed to read secret file")
+	})
+
+	t.Run("empty file produces error", func(t *testing.T) {
+		_, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/empty"})
+		assert.ErrorContains(t, err, "it is empty")
+	})
+
+	t.Run("missing path produces error", func(t *testing.T) {
+		_, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeFile})
+		assert.ErrorContains(t, err, "no path given")
+	})
+
+	t.Run("file is not read if resolution is disabled", func(t *testing.T) {
+		got, err := parseAuthSecret(&Context{Fs: fs, Opts: Options{DoNotResolveEnvVars: true}}, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "not-found"})
+		require.NoError(t, err)
+		assert.Equal(t, "not-found", got.Path)
+	})
+}
+
+func TestParseAuthSecret_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on unix commands")
+	}
+
+	c := &Context{Fs: afero.NewMemMapFs(), ManifestPath: "manifest.yaml"}
+
+	t.Run("stdout of the command is used as secret", func(t *testing.T) {
+		got, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeCommand, Command: []string{"echo", "my-token"}})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.AuthSecret{Type: manifest.CommandSecretType, Command: []string{"echo", "my-token"}, Value: "my-token"}, got)
+	})
+
+	t.Run("failing command produces error", func(t *testing.T) {
+		_, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeCommand, Command: []string{"false"}})
+		assert.ErrorContains(t, err, `secret command "false" failed`)
+	})
+
+	t.Run("command without output produces error", func(t *testing.T) {
+		_, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeCommand, Command: []string{"true"}})
+		assert.ErrorContains(t, err, "did not produce any output")
+	})
+
+	t.Run("missing command produces error", func(t *testing.T) {
+		_, err := parseAuthSecret(c, &persistence.AuthSecret{Type: persistence.TypeCommand})
+		assert.ErrorContains(t, err, "no command given")
+	})
+}
+
+func TestParseAccountUUID_File(t *testing.T) {
+	accountUUID := uuid.New()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "account-uuid", []byte(accountUUID.String()+"\n"), 0400))
+
+	got, err := parseAccountUUID(&Context{Fs: fs, ManifestPath: "manifest.yaml"}, persistence.TypedValue{Type: persistence.TypeFile, Value: "account-uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, accountUUID, got)
+}
+
+func TestParseAccountUUID_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on unix commands")
+	}
+
+	accountUUID := uuid.New()
+	c := &Context{Fs: afero.NewMemMapFs(), ManifestPath: "manifest.yaml"}
+
+	t.Run("arguments containing whitespace are passed as is", func(t *testing.T) {
+		got, err := parseAccountUUID(c, persistence.TypedValue{Type: persistence.TypeCommand, Command: []string{"sh", "-c", "echo " + accountUUID.String()}})
+		require.NoError(t, err)
+		assert.Equal(t, accountUUID, got)
+	})
+
+	t.Run("missing command produces error", func(t *testing.T) {
+		_, err := parseAccountUUID(c, persistence.TypedValue{Type: persistence.TypeCommand})
+		assert.ErrorContains(t, err, "accountUUID command is missing")
+	})
+
+	t.Run("command is not executed if resolution is disabled", func(t *testing.T) {
+		got, err := parseAccountUUID(&Context{Fs: afero.NewMemMapFs(), Opts: Options{DoNotResolveEnvVars: true}}, persistence.TypedValue{Type: persistence.TypeCommand, Command: []string{"false"}})
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, got)
+	})
+}
+
+func TestParseAccountUUID_DoNotResolve(t *testing.T) {
+	c := &Context{Fs: afero.NewMemMapFs(), ManifestPath: "manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}}
+
+	t.Run("file is not read", func(t *testing.T) {
+		got, err := parseAccountUUID(c, persistence.TypedValue{Type: persistence.TypeFile, Value: "not-found"})
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, got)
+	})
+
+	t.Run("environment variable is still resolved", func(t *testing.T) {
+		_, err := parseAccountUUID(c, persistence.TypedValue{Type: persistence.TypeEnvironment, Value: "NOT_DEFINED_ACCOUNT_UUID"})
+		assert.ErrorContains(t, err, "NOT_DEFINED_ACCOUNT_UUID")
+	})
+}
@@ -0,0 +1,181 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type selectorOperator string
+
+const (
+	opEquals       selectorOperator = "="
+	opNotEquals    selectorOperator = "!="
+	opIn           selectorOperator = "in"
+	opNotIn        selectorOperator = "notin"
+	opExists       selectorOperator = "exists"
+	opDoesNotExist selectorOperator = "!"
+)
+
+// labelRequirement is a single condition of a labelSelector, e.g. 'tier=prod' or 'region in (eu,us)'
+type labelRequirement struct {
+	key      string
+	operator selectorOperator
+	values   []string
+}
+
+// labelSelector is a list of requirements that all need to be fulfilled by the labels of an environment.
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses a comma separated list of label requirements. Supported requirements are:
+//   - 'key=value', 'key==value' and 'key!=value'
+//   - 'key in (v1,v2)' and 'key notin (v1,v2)'
+//   - 'key' and '!key' to check whether a label is defined at all
+//
+// An empty selector matches all environments.
+func parseLabelSelector(selector string) (labelSelector, error) {
+	parts, err := splitSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(labelSelector, 0, len(parts))
+	for _, p := range parts {
+		r, err := parseLabelRequirement(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// splitSelector splits the selector on all commas which are not part of a value list in parentheses.
+func splitSelector(selector string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid selector %q: unbalanced parentheses", selector)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid selector %q: unbalanced parentheses", selector)
+	}
+
+	parts = append(parts, selector[start:])
+	if len(parts) == 1 && strings.TrimSpace(parts[0]) == "" {
+		return nil, nil
+	}
+	return parts, nil
+}
+
+func parseLabelRequirement(s string) (labelRequirement, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return labelRequirement{}, errors.New("empty requirement")
+	}
+
+	if strings.HasPrefix(s, "!") && !strings.Contains(s, "=") {
+		return newLabelRequirement(s[1:], opDoesNotExist, nil)
+	}
+
+	if key, value, found := strings.Cut(s, "!="); found {
+		return newLabelRequirement(key, opNotEquals, []string{strings.TrimSpace(value)})
+	}
+
+	if key, value, found := strings.Cut(s, "="); found {
+		value = strings.TrimPrefix(value, "=")
+		return newLabelRequirement(key, opEquals, []string{strings.TrimSpace(value)})
+	}
+
+	if before, list, found := strings.Cut(s, "("); found {
+		if !strings.HasSuffix(list, ")") {
+			return labelRequirement{}, fmt.Errorf("requirement %q: value list must end with ')'", s)
+		}
+
+		fields := strings.Fields(before)
+		if len(fields) != 2 || (fields[1] != string(opIn) && fields[1] != string(opNotIn)) {
+			return labelRequirement{}, fmt.Errorf("requirement %q: expected '<key> in (...)' or '<key> notin (...)'", s)
+		}
+
+		var values []string
+		for _, v := range strings.Split(strings.TrimSuffix(list, ")"), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return labelRequirement{}, fmt.Errorf("requirement %q: value list must not be empty", s)
+		}
+		return newLabelRequirement(fields[0], selectorOperator(fields[1]), values)
+	}
+
+	return newLabelRequirement(s, opExists, nil)
+}
+
+func newLabelRequirement(key string, op selectorOperator, values []string) (labelRequirement, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return labelRequirement{}, fmt.Errorf("missing label key for operator %q", op)
+	}
+	if strings.ContainsAny(key, " \t()!=") {
+		return labelRequirement{}, fmt.Errorf("invalid label key %q", key)
+	}
+	return labelRequirement{key: key, operator: op, values: values}, nil
+}
+
+// matches returns whether the given labels fulfill all requirements of the selector.
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r labelRequirement) matches(labels map[string]string) bool {
+	value, found := labels[r.key]
+
+	switch r.operator {
+	case opExists:
+		return found
+	case opDoesNotExist:
+		return !found
+	case opEquals, opIn:
+		return found && slices.Contains(r.values, value)
+	case opNotEquals, opNotIn:
+		return !found || !slices.Contains(r.values, value)
+	default:
+		return false
+	}
+}
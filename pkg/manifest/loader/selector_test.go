@@ -0,0 +1,173 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	labels := map[string]string{"tier": "prod", "region": "eu"}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"tier=prod", true},
+		{"tier==prod", true},
+		{"tier=dev", false},
+		{"tier!=dev", true},
+		{"tier!=prod", false},
+		{"product!=payments", true},
+		{"region in (eu,us)", true},
+		{"region in (us, apac)", false},
+		{"region notin (us,apac)", true},
+		{"region notin (eu)", false},
+		{"tier", true},
+		{"product", false},
+		{"!product", true},
+		{"!tier", false},
+		{"tier=prod,region in (eu,us)", true},
+		{" tier = prod , region in ( eu , us ) ", true},
+		{"tier=prod,region in (us)", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			s, err := parseLabelSelector(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.matches(labels))
+		})
+	}
+}
+
+func TestParseLabelSelector_Errors(t *testing.T) {
+	tests := []struct {
+		selector string
+		wantErr  string
+	}{
+		{"tier=prod,", "empty requirement"},
+		{"=prod", "missing label key"},
+		{"region in (eu", "unbalanced parentheses"},
+		{"region in eu)", "unbalanced parentheses"},
+		{"region in ()", "value list must not be empty"},
+		{"region within (eu)", "expected '<key> in (...)'"},
+		{"!tier=prod", "invalid label key"},
+		{"my tier", "invalid label key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			_, err := parseLabelSelector(tt.selector)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadManifest_Selector(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	content := `
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups:
+- name: dev
+  environments:
+  - {name: dev-eu, url: {value: u}, auth: {token: {name: TOKEN}}, labels: {tier: dev, region: eu}}
+- name: prod
+  environments:
+  - {name: prod-eu, url: {value: u}, auth: {token: {name: TOKEN}}, labels: {tier: prod, region: eu}}
+  - {name: prod-us, url: {value: u}, auth: {token: {name: TOKEN}}, labels: {tier: prod, region: us}}
+  - {name: prod-apac, url: {value: u}, auth: {token: {name: TOKEN}}}
+`
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(content), 0400))
+
+	tests := []struct {
+		name         string
+		selector     string
+		groups       []string
+		environments []string
+		want         []string
+		wantErr      string
+	}{
+		{
+			name: "no selector loads all environments",
+			want: []string{"dev-eu", "prod-apac", "prod-eu", "prod-us"},
+		},
+		{
+			name:     "selector filters by labels",
+			selector: "region=eu",
+			want:     []string{"dev-eu", "prod-eu"},
+		},
+		{
+			name:     "selector with set based requirement",
+			selector: "tier=prod,region notin (us)",
+			want:     []string{"prod-eu"},
+		},
+		{
+			name:     "selector and group need to match both",
+			selector: "region=eu",
+			groups:   []string{"prod"},
+			want:     []string{"prod-eu"},
+		},
+		{
+			name:         "selector and environment need to match both",
+			selector:     "tier=dev",
+			environments: []string{"prod-us"},
+			wantErr:      `no environments match selector "tier=dev"`,
+		},
+		{
+			name:     "selector matching nothing",
+			selector: "tier=staging",
+			wantErr:  `no environments match selector "tier=staging"`,
+		},
+		{
+			name:     "invalid selector",
+			selector: "tier in (prod",
+			wantErr:  "unbalanced parentheses",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mani, errs := Load(&Context{
+				Fs:           fs,
+				ManifestPath: "manifest.yaml",
+				Groups:       tt.groups,
+				Environments: tt.environments,
+				Selector:     tt.selector,
+			})
+
+			if tt.wantErr != "" {
+				require.Len(t, errs, 1)
+				assert.ErrorContains(t, errs[0], tt.wantErr)
+				return
+			}
+
+			require.Empty(t, errs)
+			assert.ElementsMatch(t, tt.want, mani.Environments.Names())
+			if env, ok := mani.Environments["prod-eu"]; ok {
+				assert.Equal(t, map[string]string{"tier": "prod", "region": "eu"}, env.Labels)
+			}
+		})
+	}
+}
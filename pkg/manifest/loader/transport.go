@@ -0,0 +1,110 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// parseTransport converts the persisted transport settings to the in-memory definition.
+// Files are read relative to the manifest's location, while their paths are kept as configured for writing the
+// manifest again. The owner is used to identify the environment or account in logs.
+func parseTransport(context *Context, t *persistence.Transport, owner string) (*manifest.Transport, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	var result manifest.Transport
+
+	if t.Proxy != nil {
+		proxy, err := parseURLDefinition(context, *t.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("proxy: %w", err)
+		}
+
+		if !context.Opts.DoNotResolveEnvVars {
+			if u, err := url.Parse(proxy.Value); err != nil || u.Host == "" {
+				return nil, fmt.Errorf("proxy: %q is not a valid URL", proxy.Value)
+			}
+		}
+		result.ProxyURL = &proxy
+	}
+
+	if (t.ClientCertificate == "") != (t.ClientKey == "") {
+		return nil, errors.New("'clientCertificate' and 'clientKey' must be defined together")
+	}
+
+	result.CACertificatePath = t.CACertificate
+	result.ClientCertificatePath = t.ClientCertificate
+	result.ClientKeyPath = t.ClientKey
+
+	var err error
+	if result.CACertificate, err = readTransportFile(context, t.CACertificate); err != nil {
+		return nil, fmt.Errorf("caCertificate: %w", err)
+	}
+	if result.ClientCertificate, err = readTransportFile(context, t.ClientCertificate); err != nil {
+		return nil, fmt.Errorf("clientCertificate: %w", err)
+	}
+	clientKey, err := readTransportFile(context, t.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("clientKey: %w", err)
+	}
+	result.ClientKey = secret.MaskedString(clientKey)
+
+	if t.InsecureSkipVerify {
+		log.Warn("!!! TLS certificate verification is DISABLED for %s ('insecureSkipVerify: true'). Connections are NOT secure and can be intercepted. Only use this for lab/test environments !!!", owner)
+		result.InsecureSkipVerify = true
+	}
+
+	return &result, nil
+}
+
+// readTransportFile reads the file at the given path, which is resolved relative to the manifest. An empty path results
+// in no content. Files are not read if the resolution of secrets is disabled.
+func readTransportFile(context *Context, path string) ([]byte, error) {
+	if path == "" || skipSecrets(context) {
+		return nil, nil
+	}
+
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(filepath.Clean(context.ManifestPath)), path)
+	}
+
+	if exists, err := files.DoesFileExist(context.Fs, path); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, fmt.Errorf("file %q does not exist", path)
+	}
+
+	content, err := afero.ReadFile(context.Fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", path, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func TestLoadManifest_Transport(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+	t.Setenv("PROXY", "http://proxy.example.com:3128")
+
+	tests := []struct {
+		name      string
+		transport string
+		want      *manifest.Transport
+		wantErr   string
+	}{
+		{
+			name: "no transport",
+		},
+		{
+			name:      "all settings",
+			transport: `{proxy: "http://proxy:8080", caCertificate: certs/ca.pem, clientCertificate: certs/client.pem, clientKey: certs/client.key, insecureSkipVerify: true}`,
+			want: &manifest.Transport{
+				ProxyURL:              &manifest.URLDefinition{Type: manifest.ValueURLType, Value: "http://proxy:8080"},
+				CACertificatePath:     "certs/ca.pem",
+				CACertificate:         []byte("ca.pem content"),
+				ClientCertificatePath: "certs/client.pem",
+				ClientCertificate:     []byte("client.pem content"),
+				ClientKeyPath:         "certs/client.key",
+				ClientKey:             "client.key content",
+				InsecureSkipVerify:    true,
+			},
+		},
+		{
+			name:      "proxy from environment variable",
+			transport: `{proxy: {type: environment, value: PROXY}}`,
+			want: &manifest.Transport{
+				ProxyURL: &manifest.URLDefinition{Type: manifest.EnvironmentURLType, Name: "PROXY", Value: "http://proxy.example.com:3128"},
+			},
+		},
+		{
+			name:      "invalid proxy",
+			transport: `{proxy: "not a url"}`,
+			wantErr:   `proxy: "not a url" is not a valid URL`,
+		},
+		{
+			name:      "missing CA file",
+			transport: `{caCertificate: certs/not-found.pem}`,
+			wantErr:   "caCertificate: file",
+		},
+		{
+			name:      "client certificate without key",
+			transport: `{clientCertificate: certs/client.pem}`,
+			wantErr:   "'clientCertificate' and 'clientKey' must be defined together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups: [{name: g, environments: [{name: e, url: {value: u}, auth: {token: {name: TOKEN}}}]}]
+`
+			if tt.transport != "" {
+				content = `
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups: [{name: g, environments: [{name: e, url: {value: u}, auth: {token: {name: TOKEN}}, transport: ` + tt.transport + `}]}]
+`
+			}
+
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "project/manifest.yaml", []byte(content), 0400))
+			for _, f := range []string{"ca.pem", "client.pem", "client.key"} {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join("project", "certs", f), []byte(f+" content"), 0400))
+			}
+
+			mani, errs := Load(&Context{Fs: fs, ManifestPath: "project/manifest.yaml"})
+
+			if tt.wantErr != "" {
+				require.Len(t, errs, 1)
+				assert.ErrorContains(t, errs[0], tt.wantErr)
+				return
+			}
+
+			require.Empty(t, errs)
+			assert.Equal(t, tt.want, mani.Environments["e"].Transport)
+		})
+	}
+}
+
+func TestLoadManifest_TransportFilesAreNotReadIfResolutionIsDisabled(t *testing.T) {
+	content := `
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups: [{name: g, environments: [{name: e, url: {value: u}, auth: {token: {name: TOKEN}}, transport: {caCertificate: certs/not-found.pem}}]}]
+`
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "project/manifest.yaml", []byte(content), 0400))
+
+	mani, errs := Load(&Context{Fs: fs, ManifestPath: "project/manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}})
+
+	require.Empty(t, errs)
+	assert.Equal(t, &manifest.Transport{CACertificatePath: "certs/not-found.pem"}, mani.Environments["e"].Transport)
+}
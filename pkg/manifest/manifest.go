@@ -57,6 +57,13 @@ func (o OAuth) GetTokenEndpointValue() string {
 type Auth struct {
 	Token *AuthSecret
 	OAuth *OAuth
+	// PlatformToken is a platform token used as bearer token for platform APIs. It is mutually exclusive with OAuth.
+	PlatformToken *AuthSecret
+}
+
+// HasPlatformAuth returns whether the Auth holds credentials for accessing platform APIs, either via OAuth or a platform token.
+func (a Auth) HasPlatformAuth() bool {
+	return a.OAuth != nil || a.PlatformToken != nil
 }
 
 // EnvironmentDefinition holds all information about a Dynatrace environment
@@ -65,6 +72,47 @@ type EnvironmentDefinition struct {
 	Group string
 	URL   URLDefinition
 	Auth  Auth
+
+	// Variables holds all manifest variables available to configs of this environment.
+	// Variables defined on the environment override group variables, which in turn override global variables.
+	Variables Variables
+
+	// Transport holds optional HTTP transport settings. If nil, the default transport is used.
+	Transport *Transport
+
+	// Labels holds arbitrary key/value labels of the environment, used for selecting environments.
+	Labels map[string]string
+}
+
+// Transport holds optional HTTP transport settings used to connect to an environment or account.
+type Transport struct {
+	// ProxyURL is the URL of the HTTP(S) proxy all requests are sent through.
+	// If nil, the proxy is determined by the HTTP_PROXY and HTTPS_PROXY environment variables.
+	ProxyURL *URLDefinition
+
+	// CACertificatePath is the path to a PEM encoded CA bundle that is trusted in addition to the system's CA certificates,
+	// as configured in the manifest. Relative paths are relative to the manifest's location.
+	CACertificatePath string
+
+	// CACertificate is the content of the CA bundle, read from [Transport.CACertificatePath] when loading the manifest.
+	CACertificate []byte
+
+	// ClientCertificatePath is the path to a PEM encoded client certificate used for mutual TLS, as configured in the
+	// manifest. It is always set together with [Transport.ClientKeyPath].
+	ClientCertificatePath string
+
+	// ClientCertificate is the content of the client certificate, read from [Transport.ClientCertificatePath] when
+	// loading the manifest.
+	ClientCertificate []byte
+
+	// ClientKeyPath is the path to the PEM encoded private key of the client certificate, as configured in the manifest.
+	ClientKeyPath string
+
+	// ClientKey is the content of the private key, read from [Transport.ClientKeyPath] when loading the manifest.
+	ClientKey secret.MaskedString
+
+	// InsecureSkipVerify disables the verification of TLS certificates. It must only be used for lab/test environments.
+	InsecureSkipVerify bool
 }
 
 // URLType describes from where the url is loaded.
@@ -93,12 +141,60 @@ type URLDefinition struct {
 	Value string
 }
 
+// VariableDefinition holds the value and origin of a user-defined manifest variable.
+type VariableDefinition struct {
+	// Name is the name of the environment-variable the value was loaded from.
+	// It is empty if the value has been defined directly in the manifest.
+	Name string
+
+	// Value is the resolved value of the variable.
+	// It is resolved during manifest reading.
+	Value string
+}
+
+// Variables is a map of variable-name -> VariableDefinition
+type Variables map[string]VariableDefinition
+
+// Values returns the resolved values of all variables as a map of variable-name -> value
+func (v Variables) Values() map[string]string {
+	values := make(map[string]string, len(v))
+	for name, def := range v {
+		values[name] = def.Value
+	}
+	return values
+}
+
+// AuthSecretType describes from where an [AuthSecret] is loaded.
+// Possible values are [EnvironmentSecretType], [FileSecretType] and [CommandSecretType].
+// [EnvironmentSecretType] is the default value.
+type AuthSecretType int
+
+const (
+	// EnvironmentSecretType describes that the secret has been loaded from an environment variable
+	EnvironmentSecretType AuthSecretType = iota
+
+	// FileSecretType describes that the secret has been loaded from a file
+	FileSecretType
+
+	// CommandSecretType describes that the secret has been loaded from the output of a command
+	CommandSecretType
+)
+
 // AuthSecret contains a resolved secret value. It is used for the API-Token, ClientID, and ClientSecret.
 type AuthSecret struct {
-	// Name is the name of the environment-variable of the token.
+	// Type defines from where the secret has been loaded.
+	Type AuthSecretType
+
+	// Name is the name of the environment-variable of the token. It only has a value if [AuthSecret.Type] is [EnvironmentSecretType].
 	// It is used in download to store the name of the OAuth token in the new created manifest.
 	Name string
 
+	// Path is the path of the file the secret has been read from. It only has a value if [AuthSecret.Type] is [FileSecretType].
+	Path string
+
+	// Command is the command whose output has been used as secret. It only has a value if [AuthSecret.Type] is [CommandSecretType].
+	Command []string
+
 	// Value holds the actual token value for the given [AuthSecret.Name].
 	Value secret.MaskedString
 }
@@ -127,6 +223,9 @@ type Account struct {
 
 	// OAuth holds the OAuth credentials used to access the account API.
 	OAuth OAuth
+
+	// Transport holds optional HTTP transport settings. If nil, the default transport is used.
+	Transport *Transport
 }
 
 // Manifest is the central component. It holds all information that is needed to deploy projects.
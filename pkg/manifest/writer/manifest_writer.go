@@ -22,6 +22,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -127,6 +128,8 @@ func toWriteableProjects(projects map[string]manifest.ProjectDefinition) (result
 		result = append(result, projectGroup)
 	}
 
+	// sort projects so that they are stable within the manifest
+	slices.SortFunc(result, func(a, b persistence.Project) int { return strings.Compare(a.Name, b.Name) })
 	return result
 }
 
@@ -149,28 +152,82 @@ func toWriteableEnvironmentGroups(environments map[string]manifest.EnvironmentDe
 
 	for name, env := range environments {
 		e := persistence.Environment{
-			Name: name,
-			URL:  toWriteableURL(env.URL),
-			Auth: getAuth(env),
+			Name:      name,
+			URL:       toWriteableURL(env.URL),
+			Auth:      getAuth(env),
+			Variables: toWriteableVariables(env.Variables),
+			Transport: toWriteableTransport(env.Transport),
+			Labels:    env.Labels,
 		}
 
 		environmentPerGroup[env.Group] = append(environmentPerGroup[env.Group], e)
 	}
 
 	for g, envs := range environmentPerGroup {
+		slices.SortFunc(envs, func(a, b persistence.Environment) int { return strings.Compare(a.Name, b.Name) })
 		result = append(result, persistence.Group{Name: g, Environments: envs})
 	}
 
+	// sort groups and environments so that they are stable within the manifest
+	slices.SortFunc(result, func(a, b persistence.Group) int { return strings.Compare(a.Name, b.Name) })
 	return result
 }
 
+// toWriteableVariables writes all variables on the environment level. Variables loaded from environment variables
+// are written as references to the environment variable to not leak their values into the manifest.
+func toWriteableVariables(variables manifest.Variables) map[string]persistence.TypedValue {
+	if len(variables) == 0 {
+		return nil
+	}
+
+	result := make(map[string]persistence.TypedValue, len(variables))
+	for name, v := range variables {
+		if v.Name != "" {
+			result[name] = persistence.TypedValue{Type: persistence.TypeEnvironment, Value: v.Name}
+			continue
+		}
+		result[name] = persistence.TypedValue{Type: persistence.TypeValue, Value: v.Value}
+	}
+	return result
+}
+
+func toWriteableTransport(t *manifest.Transport) *persistence.Transport {
+	if t == nil {
+		return nil
+	}
+
+	var proxy *persistence.TypedValue
+	if t.ProxyURL != nil {
+		url := toWriteableURL(*t.ProxyURL)
+		proxy = &url
+	}
+
+	return &persistence.Transport{
+		Proxy:              proxy,
+		CACertificate:      filepath.ToSlash(t.CACertificatePath),
+		ClientCertificate:  filepath.ToSlash(t.ClientCertificatePath),
+		ClientKey:          filepath.ToSlash(t.ClientKeyPath),
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+}
+
 func getAuth(env manifest.EnvironmentDefinition) persistence.Auth {
 	return persistence.Auth{
-		Token: getTokenSecret(env.Auth, env.Name),
-		OAuth: getOAuthCredentials(env.Auth.OAuth),
+		Token:         getTokenSecret(env.Auth, env.Name),
+		OAuth:         getOAuthCredentials(env.Auth.OAuth),
+		PlatformToken: getPlatformToken(env.Auth.PlatformToken),
 	}
 }
 
+func getPlatformToken(s *manifest.AuthSecret) *persistence.AuthSecret {
+	if s == nil {
+		return nil
+	}
+
+	platformToken := toWriteableAuthSecret(*s)
+	return &platformToken
+}
+
 func toWriteableURL(url manifest.URLDefinition) persistence.TypedValue {
 	if url.Type == manifest.EnvironmentURLType {
 		return persistence.TypedValue{
@@ -190,6 +247,11 @@ func getTokenSecret(a manifest.Auth, envName string) *persistence.AuthSecret {
 		return nil
 	}
 
+	if a.Token.Type != manifest.EnvironmentSecretType {
+		token := toWriteableAuthSecret(*a.Token)
+		return &token
+	}
+
 	envVarName := a.Token.Name
 	if envVarName == "" {
 		envVarName = envName + "_TOKEN"
@@ -201,6 +263,27 @@ func getTokenSecret(a manifest.Auth, envName string) *persistence.AuthSecret {
 	}
 }
 
+// toWriteableAuthSecret writes the source of the secret, never its value.
+func toWriteableAuthSecret(s manifest.AuthSecret) persistence.AuthSecret {
+	switch s.Type {
+	case manifest.FileSecretType:
+		return persistence.AuthSecret{
+			Type: persistence.TypeFile,
+			Path: s.Path,
+		}
+	case manifest.CommandSecretType:
+		return persistence.AuthSecret{
+			Type:    persistence.TypeCommand,
+			Command: s.Command,
+		}
+	default:
+		return persistence.AuthSecret{
+			Type: persistence.TypeEnvironment,
+			Name: s.Name,
+		}
+	}
+}
+
 func getOAuthCredentials(a *manifest.OAuth) *persistence.OAuth {
 	if a == nil {
 		return nil
@@ -213,14 +296,8 @@ func getOAuthCredentials(a *manifest.OAuth) *persistence.OAuth {
 	}
 
 	return &persistence.OAuth{
-		ClientID: persistence.AuthSecret{
-			Type: persistence.TypeEnvironment,
-			Name: a.ClientID.Name,
-		},
-		ClientSecret: persistence.AuthSecret{
-			Type: persistence.TypeEnvironment,
-			Name: a.ClientSecret.Name,
-		},
+		ClientID:      toWriteableAuthSecret(a.ClientID),
+		ClientSecret:  toWriteableAuthSecret(a.ClientSecret),
 		TokenEndpoint: te,
 	}
 }
@@ -236,14 +313,8 @@ func toWriteableAccounts(accounts map[string]manifest.Account) []persistence.Acc
 		}
 
 		oauth := persistence.OAuth{
-			ClientID: persistence.AuthSecret{
-				Type: persistence.TypeEnvironment,
-				Name: account.OAuth.ClientID.Name,
-			},
-			ClientSecret: persistence.AuthSecret{
-				Type: persistence.TypeEnvironment,
-				Name: account.OAuth.ClientSecret.Name,
-			},
+			ClientID:     toWriteableAuthSecret(account.OAuth.ClientID),
+			ClientSecret: toWriteableAuthSecret(account.OAuth.ClientSecret),
 		}
 		if account.OAuth.TokenEndpoint != nil {
 			url := toWriteableURL(*account.OAuth.TokenEndpoint)
@@ -255,7 +326,9 @@ func toWriteableAccounts(accounts map[string]manifest.Account) []persistence.Acc
 			AccountUUID: persistence.TypedValue{Value: account.AccountUUID.String()},
 			ApiUrl:      apiURL,
 			OAuth:       oauth,
+			Transport:   toWriteableTransport(account.Transport),
 		})
 	}
+	slices.SortFunc(out, func(a, b persistence.Account) int { return strings.Compare(a.Name, b.Name) })
 	return out
 }
@@ -330,8 +330,8 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 					},
 				},
 				{
-					"group2",
-					[]persistence.Environment{
+					Name: "group2",
+					Environments: []persistence.Environment{
 						{
 							Name: "env3",
 							URL:  persistence.TypedValue{Value: "www.an.Url"},
@@ -457,6 +457,32 @@ func Test_toWritableToken(t *testing.T) {
 				Type: "environment",
 			},
 		},
+		{
+			"correctly transforms file token",
+			manifest.EnvironmentDefinition{
+				Name: "NAME",
+				Auth: manifest.Auth{
+					Token: &manifest.AuthSecret{Type: manifest.FileSecretType, Path: "secrets/token", Value: "secret"},
+				},
+			},
+			persistence.AuthSecret{
+				Path: "secrets/token",
+				Type: "file",
+			},
+		},
+		{
+			"correctly transforms command token",
+			manifest.EnvironmentDefinition{
+				Name: "NAME",
+				Auth: manifest.Auth{
+					Token: &manifest.AuthSecret{Type: manifest.CommandSecretType, Command: []string{"helper", "get"}, Value: "secret"},
+				},
+			},
+			persistence.AuthSecret{
+				Command: []string{"helper", "get"},
+				Type:    "command",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -467,6 +493,19 @@ func Test_toWritableToken(t *testing.T) {
 	}
 }
 
+func Test_getAuth_PlatformToken(t *testing.T) {
+	env := manifest.EnvironmentDefinition{
+		Name: "NAME",
+		Auth: manifest.Auth{
+			PlatformToken: &manifest.AuthSecret{Name: "PLATFORM_TOKEN", Value: "secret"},
+		},
+	}
+
+	assert.Equal(t, persistence.Auth{
+		PlatformToken: &persistence.AuthSecret{Type: "environment", Name: "PLATFORM_TOKEN"},
+	}, getAuth(env))
+}
+
 func Test_toWriteableAccounts(t *testing.T) {
 
 	tests := []struct {
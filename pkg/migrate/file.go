@@ -0,0 +1,175 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// ConfigFile rewrites the content of a config YAML file of the given project to match the migrated configurations:
+// The types of migrated configurations are changed to their Settings 2.0 schema, and references to migrated
+// configurations are rewritten. All other content, like group and environment overrides, is kept as is.
+// It returns whether the content was changed. Content that is not a config file is returned unchanged.
+func ConfigFile(content []byte, projectID string, migrated map[coordinate.Coordinate]coordinate.Coordinate) ([]byte, bool, error) {
+	var file yaml.MapSlice
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	configs, ok := get(file, "configs").([]any)
+	if !ok {
+		return content, false, nil
+	}
+
+	r := fileRewriter{projectID: projectID, migrated: migrated}
+	for _, c := range configs {
+		if entry, ok := c.(yaml.MapSlice); ok {
+			r.rewriteConfig(entry)
+		}
+	}
+
+	if !r.changed {
+		return content, false, nil
+	}
+
+	result, err := yaml.Marshal(file)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to serialize migrated config file: %w", err)
+	}
+	return result, true, nil
+}
+
+type fileRewriter struct {
+	projectID string
+	migrated  map[coordinate.Coordinate]coordinate.Coordinate
+	changed   bool
+}
+
+func (r *fileRewriter) rewriteConfig(entry yaml.MapSlice) {
+	id, _ := get(entry, "id").(string)
+	if api, ok := classicAPI(get(entry, "type")); ok {
+		if newCoord, found := r.migrated[coordinate.Coordinate{Project: r.projectID, Type: api, ConfigId: id}]; found {
+			set(entry, "type", yaml.MapSlice{{Key: "settings", Value: yaml.MapSlice{
+				{Key: "schema", Value: newCoord.Type},
+				{Key: "scope", Value: "environment"},
+			}}})
+			r.changed = true
+		}
+	}
+
+	r.rewriteParameters(get(entry, "config"))
+	for _, key := range []string{"groupOverrides", "environmentOverrides"} {
+		overrides, _ := get(entry, key).([]any)
+		for _, o := range overrides {
+			if override, ok := o.(yaml.MapSlice); ok {
+				r.rewriteParameters(get(override, "override"))
+			}
+		}
+	}
+}
+
+// rewriteParameters rewrites all reference parameters of the given config definition pointing to migrated configs.
+// References without explicit type are not rewritten, as they reference a config of the same type, which is migrated
+// the same way as the referencing config.
+func (r *fileRewriter) rewriteParameters(definition any) {
+	d, ok := definition.(yaml.MapSlice)
+	if !ok {
+		return
+	}
+	params, ok := get(d, "parameters").(yaml.MapSlice)
+	if !ok {
+		return
+	}
+
+	for i, p := range params {
+		switch v := p.Value.(type) {
+		case yaml.MapSlice:
+			if get(v, "type") != "reference" {
+				continue
+			}
+			project, _ := get(v, "project").(string)
+			if project == "" {
+				project = r.projectID
+			}
+			configType, _ := get(v, "configType").(string)
+			configID, _ := get(v, "configId").(string)
+			if newCoord, found := r.migrated[coordinate.Coordinate{Project: project, Type: configType, ConfigId: configID}]; found {
+				set(v, "configType", newCoord.Type)
+				r.changed = true
+			}
+		case []any:
+			// short references are either [type, id, property] or [project, type, id, property]
+			project, typeIndex := r.projectID, 0
+			switch len(v) {
+			case 3:
+			case 4:
+				project, _ = v[0].(string)
+				typeIndex = 1
+			default:
+				continue
+			}
+			configType, _ := v[typeIndex].(string)
+			configID, _ := v[typeIndex+1].(string)
+			if newCoord, found := r.migrated[coordinate.Coordinate{Project: project, Type: configType, ConfigId: configID}]; found {
+				ref := append([]any{}, v...)
+				ref[typeIndex] = newCoord.Type
+				params[i].Value = ref
+				r.changed = true
+			}
+		}
+	}
+}
+
+// classicAPI returns the API of the given type definition if it is a classic API type, either written as shorthand
+// string or as 'api' type.
+func classicAPI(typeDefinition any) (string, bool) {
+	switch t := typeDefinition.(type) {
+	case string:
+		return t, true
+	case yaml.MapSlice:
+		switch api := get(t, "api").(type) {
+		case string:
+			return api, true
+		case yaml.MapSlice:
+			name, ok := get(api, "name").(string)
+			return name, ok
+		}
+	}
+	return "", false
+}
+
+func get(m yaml.MapSlice, key string) any {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+func set(m yaml.MapSlice, key string, value any) {
+	for i, item := range m {
+		if item.Key == key {
+			m[i].Value = value
+			return
+		}
+	}
+}
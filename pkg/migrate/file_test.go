@@ -0,0 +1,140 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+var migratedProfile = map[coordinate.Coordinate]coordinate.Coordinate{
+	{Project: "project", Type: "alerting-profile", ConfigId: "profile"}: {Project: "project", Type: "builtin:alerting.profile", ConfigId: "profile"},
+	{Project: "other", Type: "alerting-profile", ConfigId: "profile"}:   {Project: "other", Type: "builtin:alerting.profile", ConfigId: "profile"},
+}
+
+func TestConfigFile_TypesAndReferencesAreRewritten(t *testing.T) {
+	content := `configs:
+- id: profile
+  config:
+    name: my-profile
+    template: profile.json
+  type: alerting-profile
+  environmentOverrides:
+  - environment: dev
+    override:
+      name: dev-profile
+- id: notification
+  config:
+    name: my-notification
+    template: notification.json
+    parameters:
+      profile:
+        type: reference
+        configType: alerting-profile
+        configId: profile
+        property: id
+  type:
+    api: notification
+  groupOverrides:
+  - group: default
+    override:
+      parameters:
+        profile: ["other", "alerting-profile", "profile", "id"]
+        unrelated: ["management-zone", "mz", "id"]
+`
+
+	result, changed, err := ConfigFile([]byte(content), "project", migratedProfile)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `configs:
+- id: profile
+  config:
+    name: my-profile
+    template: profile.json
+  type:
+    settings:
+      schema: builtin:alerting.profile
+      scope: environment
+  environmentOverrides:
+  - environment: dev
+    override:
+      name: dev-profile
+- id: notification
+  config:
+    name: my-notification
+    template: notification.json
+    parameters:
+      profile:
+        type: reference
+        configType: builtin:alerting.profile
+        configId: profile
+        property: id
+  type:
+    api: notification
+  groupOverrides:
+  - group: default
+    override:
+      parameters:
+        profile:
+        - other
+        - builtin:alerting.profile
+        - profile
+        - id
+        unrelated:
+        - management-zone
+        - mz
+        - id
+`, string(result))
+}
+
+func TestConfigFile_ApiTypeFormsAreRewritten(t *testing.T) {
+	for _, typeDefinition := range []string{"alerting-profile", "{api: alerting-profile}", "{api: {name: alerting-profile}}"} {
+		t.Run(typeDefinition, func(t *testing.T) {
+			content := "configs:\n- id: profile\n  type: " + typeDefinition + "\n"
+
+			result, changed, err := ConfigFile([]byte(content), "project", migratedProfile)
+			require.NoError(t, err)
+			assert.True(t, changed)
+			assert.Contains(t, string(result), "schema: builtin:alerting.profile")
+		})
+	}
+}
+
+func TestConfigFile_UnaffectedFilesAreReturnedUnchanged(t *testing.T) {
+	content := `configs:
+- id: dashboard # comments are kept in unchanged files
+  config:
+    template: dashboard.json
+  type: dashboard
+`
+
+	result, changed, err := ConfigFile([]byte(content), "project", migratedProfile)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, content, string(result))
+}
+
+func TestConfigFile_InvalidYaml(t *testing.T) {
+	_, _, err := ConfigFile([]byte("configs: ["), "project", migratedProfile)
+	assert.Error(t, err)
+}
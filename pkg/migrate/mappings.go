@@ -0,0 +1,279 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+)
+
+// mapping describes how configurations of a deprecated classic API are migrated to their Settings 2.0 replacement.
+type mapping struct {
+	// schemaId of the Settings 2.0 schema replacing the classic API
+	schemaId string
+	// migrate transforms the top-level object of a classic payload
+	migrate func(o *object)
+	// notes are reported for every migrated configuration, e.g. for semantic differences that need a manual review
+	notes []string
+}
+
+// mappings contains all classic APIs that can be migrated automatically.
+var mappings = map[string]mapping{
+	api.AlertingProfile: {
+		schemaId: "builtin:alerting.profile",
+		migrate:  migrateAlertingProfile,
+	},
+	api.ManagementZone: {
+		schemaId: "builtin:management-zones",
+		migrate:  migrateManagementZone,
+		notes: []string{
+			"references to the 'id' of a Settings 2.0 management zone resolve to the settings object ID, not the numeric management zone ID - review templates using such references",
+		},
+	},
+	api.Autotag: {
+		schemaId: "builtin:tags.auto-tagging",
+		migrate:  migrateAutoTag,
+	},
+	api.AnomalyDetectionMetrics: {
+		schemaId: "builtin:anomaly-detection.metric-events",
+		migrate:  migrateMetricEvent,
+	},
+}
+
+// classicMetadataFields are returned by classic APIs, but have no meaning for Settings 2.0 objects
+var classicMetadataFields = []string{"id", "metadata"}
+
+func migrateAlertingProfile(o *object) {
+	o.ignore(classicMetadataFields...)
+	o.rename("name", "name")
+	o.rename("displayName", "name")
+	o.rename("managementZoneId", "managementZone")
+
+	if rules, ok := o.takeList("rules"); ok {
+		severityRules := make([]any, 0, len(rules))
+		for i, r := range rules {
+			if rule := o.child(r, fmt.Sprintf("rules[%d]", i)); rule != nil {
+				severityRules = append(severityRules, migrateAlertingProfileRule(rule))
+			}
+		}
+		o.set("severityRules", severityRules)
+	}
+
+	if filters, ok := o.takeList("eventTypeFilters"); ok {
+		eventFilters := make([]any, 0, len(filters))
+		for i, f := range filters {
+			filter := o.child(f, fmt.Sprintf("eventTypeFilters[%d]", i))
+			if filter == nil {
+				continue
+			}
+			predefined, found := filter.take("predefinedEventFilter")
+			if found {
+				filter.set("type", "PREDEFINED")
+				filter.set("predefinedFilter", predefined)
+			}
+			if migrated := filter.finish(); found {
+				eventFilters = append(eventFilters, migrated)
+			}
+		}
+		o.set("eventFilters", eventFilters)
+	}
+}
+
+func migrateAlertingProfileRule(rule *object) map[string]any {
+	rule.rename("severityLevel", "severityLevel")
+	rule.rename("delayInMinutes", "delayInMinutes")
+
+	if v, found := rule.take("tagFilter"); found {
+		if tagFilter := rule.child(v, "tagFilter"); tagFilter != nil {
+			if mode, found := tagFilter.take("includeMode"); found {
+				rule.set("tagFilterIncludeMode", mode)
+			}
+			if filters, ok := tagFilter.takeList("tagFilters"); ok {
+				tags := make([]any, 0, len(filters))
+				for i, f := range filters {
+					if tag, ok := migrateTagFilter(tagFilter, f, fmt.Sprintf("tagFilters[%d]", i)); ok {
+						tags = append(tags, tag)
+					}
+				}
+				rule.set("tags", tags)
+			}
+			tagFilter.finish()
+		}
+	}
+
+	return rule.finish()
+}
+
+// migrateTagFilter converts a classic tag filter object into the string representation used by Settings 2.0,
+// i.e. '[CONTEXT]key:value'. The context is omitted for contextless tags.
+func migrateTagFilter(parent *object, v any, key string) (string, bool) {
+	if isTemplateAction(v) {
+		parent.report(key, "is defined by a template action and can not be migrated automatically")
+		return "", false
+	}
+
+	filter := parent.child(v, key)
+	if filter == nil {
+		return "", false
+	}
+
+	tag := ""
+	if ctx, found := filter.take("context"); found && ctx != "CONTEXTLESS" {
+		tag = fmt.Sprintf("[%v]", ctx)
+	}
+	if k, found := filter.take("key"); found {
+		tag += fmt.Sprint(k)
+	}
+	if value, found := filter.take("value"); found && value != nil && value != "" {
+		tag += fmt.Sprintf(":%v", value)
+	}
+	filter.finish()
+
+	return tag, true
+}
+
+func migrateManagementZone(o *object) {
+	o.ignore(classicMetadataFields...)
+	o.rename("name", "name")
+	o.rename("description", "description")
+
+	rules := migrateEntitySelectorRules(o, nil)
+	reportConditionBasedRules(o, "rules")
+	reportConditionBasedRules(o, "dimensionalRules")
+	o.set("rules", rules)
+}
+
+var valueNormalizations = map[string]string{
+	"LEAVE_TEXT_AS_IS": "Leave text as-is",
+	"TO_LOWER_CASE":    "To lower case",
+	"TO_UPPER_CASE":    "To upper case",
+}
+
+func migrateAutoTag(o *object) {
+	o.ignore(classicMetadataFields...)
+	o.rename("name", "name")
+	o.rename("description", "description")
+
+	rules := migrateEntitySelectorRules(o, func(rule *object) {
+		rule.rename("valueFormat", "valueFormat")
+		rule.renameMapped("normalization", "valueNormalization", valueNormalizations)
+	})
+	reportConditionBasedRules(o, "rules")
+	o.set("rules", rules)
+}
+
+// migrateEntitySelectorRules migrates the 'entitySelectorBasedRules' of management zones and auto-tags to 'SELECTOR'
+// rules of the respective Settings 2.0 schema.
+func migrateEntitySelectorRules(o *object, migrateAdditionalFields func(rule *object)) []any {
+	result := []any{}
+
+	selectorRules, ok := o.takeList("entitySelectorBasedRules")
+	if !ok {
+		return result
+	}
+
+	for i, r := range selectorRules {
+		rule := o.child(r, fmt.Sprintf("entitySelectorBasedRules[%d]", i))
+		if rule == nil {
+			continue
+		}
+		rule.set("type", "SELECTOR")
+		rule.rename("enabled", "enabled")
+		rule.rename("entitySelector", "entitySelector")
+		if migrateAdditionalFields != nil {
+			migrateAdditionalFields(rule)
+		}
+		result = append(result, rule.finish())
+	}
+	return result
+}
+
+// reportConditionBasedRules reports rules defined by classic conditions, as their structure differs too much from
+// Settings 2.0 attribute rules to be migrated automatically.
+func reportConditionBasedRules(o *object, key string) {
+	rules, ok := o.takeList(key)
+	if !ok {
+		return
+	}
+	for i := range rules {
+		o.report(fmt.Sprintf("%s[%d]", key, i), "is a condition based rule and can not be migrated automatically - please recreate it as an entity selector or attribute rule")
+	}
+}
+
+var metricEventModelTypes = map[string]string{
+	"STATIC_THRESHOLD":       "STATIC_THRESHOLD",
+	"AUTO_ADAPTIVE_BASELINE": "AUTO_ADAPTIVE_THRESHOLD",
+}
+
+var metricEventTypes = map[string]string{
+	"RESOURCE_CONTENTION": "RESOURCE",
+}
+
+func migrateMetricEvent(o *object) {
+	o.ignore(classicMetadataFields...)
+	o.ignore("disabledReason", "warningReason")
+	o.rename("enabled", "enabled")
+	o.rename("primaryDimensionKey", "eventEntityDimensionKey")
+
+	eventTemplate := map[string]any{}
+	if name, found := o.take("name"); found {
+		o.set("summary", name)
+		eventTemplate["title"] = name
+	}
+	if description, found := o.take("description"); found {
+		eventTemplate["description"] = description
+	}
+	if severity, found := o.take("severity"); found {
+		if s, ok := severity.(string); ok && metricEventTypes[s] != "" {
+			severity = metricEventTypes[s]
+		}
+		eventTemplate["eventType"] = severity
+	}
+	o.set("eventTemplate", eventTemplate)
+
+	queryDefinition := map[string]any{}
+	if selector, found := o.take("metricSelector"); found && selector != nil && selector != "" {
+		queryDefinition["type"] = "METRIC_SELECTOR"
+		queryDefinition["metricSelector"] = selector
+		o.ignore("metricId")
+	} else if metricId, found := o.take("metricId"); found {
+		queryDefinition["type"] = "METRIC_KEY"
+		queryDefinition["metricKey"] = metricId
+	}
+	if aggregation, found := o.take("aggregationType"); found {
+		queryDefinition["aggregation"] = aggregation
+	}
+	if offset, found := o.take("queryOffset"); found {
+		queryDefinition["queryOffset"] = offset
+	}
+	o.set("queryDefinition", queryDefinition)
+
+	if v, found := o.take("monitoringStrategy"); found {
+		if strategy := o.child(v, "monitoringStrategy"); strategy != nil {
+			strategy.renameMapped("type", "type", metricEventModelTypes)
+			strategy.rename("threshold", "threshold")
+			strategy.rename("alertCondition", "alertCondition")
+			strategy.rename("samples", "samples")
+			strategy.rename("violatingSamples", "violatingSamples")
+			strategy.rename("dealertingSamples", "dealertingSamples")
+			strategy.rename("alertingOnMissingData", "alertOnNoData")
+			strategy.rename("numberOfSignalFluctuations", "signalFluctuation")
+			o.set("modelProperties", strategy.finish())
+		}
+	}
+}
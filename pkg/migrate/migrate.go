@@ -0,0 +1,277 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migrate migrates configurations of deprecated classic config APIs to their Settings 2.0 replacements.
+package migrate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Issue is a problem found while migrating a configuration that needs to be reviewed manually.
+type Issue struct {
+	// Coordinate of the configuration the issue was found for
+	Coordinate coordinate.Coordinate
+	// Message describing the issue
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Coordinate, i.Message)
+}
+
+// Result of migrating projects.
+type Result struct {
+	// Projects contains all given projects, with all supported configurations migrated to Settings 2.0
+	Projects []project.Project
+	// Migrated maps the original coordinates of all migrated configurations to their new coordinates
+	Migrated map[coordinate.Coordinate]coordinate.Coordinate
+	// Templates maps the paths of all migrated template files to their migrated content
+	Templates map[string]string
+	// Issues found during the migration, sorted by coordinate
+	Issues []Issue
+}
+
+// SupportedAPIs returns the IDs of all classic APIs that can be migrated automatically.
+func SupportedAPIs() []string {
+	return slices.Sorted(maps.Keys(mappings))
+}
+
+// Projects migrates all configurations of supported deprecated classic APIs within the given projects to their
+// Settings 2.0 replacement. Templates are transformed to the structure of the replacing schema and reference parameters
+// pointing to migrated configurations are rewritten. Fields that can not be migrated are reported as Issue.
+// The given projects are not modified.
+func Projects(projects []project.Project) Result {
+	m := migrator{
+		apis:      api.NewAPIs(),
+		migrated:  map[coordinate.Coordinate]coordinate.Coordinate{},
+		templates: map[templateKey]template.Template{},
+		files:     map[string]templateFile{},
+		issues:    map[coordinate.Coordinate][]string{},
+	}
+
+	// first pass: find all migrated coordinates, so that references can be rewritten in the second pass
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			if mapping, ok := m.mappingFor(c); ok {
+				m.migrated[c.Coordinate] = coordinate.Coordinate{Project: c.Coordinate.Project, Type: mapping.schemaId, ConfigId: c.Coordinate.ConfigId}
+			}
+		})
+	}
+
+	result := Result{Migrated: m.migrated, Templates: map[string]string{}}
+	for _, p := range projects {
+		result.Projects = append(result.Projects, m.migrateProject(p))
+	}
+
+	for path, f := range m.files {
+		switch {
+		case f.shared:
+			m.addIssue(f.coordinate, fmt.Sprintf("template %q is shared with configurations that are not migrated the same way and needs to be split manually", path))
+		case f.migrated:
+			result.Templates[path] = f.content
+		}
+	}
+
+	for _, c := range slices.SortedFunc(maps.Keys(m.issues), byCoordinate) {
+		for _, msg := range m.issues[c] {
+			result.Issues = append(result.Issues, Issue{Coordinate: c, Message: msg})
+		}
+	}
+
+	return result
+}
+
+type migrator struct {
+	apis api.APIs
+	// migrated maps the original coordinates of migrated configs to the new ones
+	migrated map[coordinate.Coordinate]coordinate.Coordinate
+	// templates caches the migrated templates of each config, as environments usually share the same template
+	templates map[templateKey]template.Template
+	// files tracks the usage of all template files, as migrated templates are written to their original file
+	files  map[string]templateFile
+	issues map[coordinate.Coordinate][]string
+}
+
+type templateKey struct {
+	coordinate coordinate.Coordinate
+	id         string
+}
+
+type templateFile struct {
+	// coordinate of the config issues of the file are reported for. Migrated configs are preferred, and the lowest
+	// coordinate is chosen, so that the config doesn't depend on the order configs are visited in.
+	coordinate coordinate.Coordinate
+	content    string
+	migrated   bool
+	// shared is set if the file is used by configs that result in different content
+	shared bool
+}
+
+// trackFile records the usage of a template file by the given config, with the given resulting content.
+func (m *migrator) trackFile(c coordinate.Coordinate, t template.Template, content string, migrated bool) {
+	fileTemplate, ok := t.(*template.FileBasedTemplate)
+	if !ok {
+		return
+	}
+
+	path := fileTemplate.FilePath()
+	f, found := m.files[path]
+	if !found {
+		m.files[path] = templateFile{coordinate: c, content: content, migrated: migrated}
+		return
+	}
+	if f.migrated != migrated || f.content != content {
+		f.shared = true
+	}
+	if (migrated && !f.migrated) || (migrated == f.migrated && byCoordinate(c, f.coordinate) < 0) {
+		f.coordinate, f.migrated = c, migrated
+	}
+	m.files[path] = f
+}
+
+func (m *migrator) mappingFor(c config.Config) (mapping, bool) {
+	t, ok := c.Type.(config.ClassicApiType)
+	if !ok {
+		return mapping{}, false
+	}
+	mapping, ok := mappings[t.Api]
+	return mapping, ok
+}
+
+func (m *migrator) addIssue(c coordinate.Coordinate, msg string) {
+	if !slices.Contains(m.issues[c], msg) {
+		m.issues[c] = append(m.issues[c], msg)
+	}
+}
+
+func (m *migrator) migrateProject(p project.Project) project.Project {
+	configs := make(project.ConfigsPerTypePerEnvironments, len(p.Configs))
+
+	for env, configsPerType := range p.Configs {
+		migratedPerType := make(project.ConfigsPerType, len(configsPerType))
+		for _, cfgs := range configsPerType {
+			for _, c := range cfgs {
+				migrated := m.migrateConfig(c)
+				migratedPerType[migrated.Coordinate.Type] = append(migratedPerType[migrated.Coordinate.Type], migrated)
+			}
+		}
+		configs[env] = migratedPerType
+	}
+
+	p.Configs = configs
+	return p
+}
+
+func (m *migrator) migrateConfig(c config.Config) config.Config {
+	c.Parameters = m.rewriteReferences(c)
+
+	mapping, ok := m.mappingFor(c)
+	if !ok {
+		if a, found := m.apis[c.Coordinate.Type]; found && a.DeprecatedBy != "" {
+			m.addIssue(c.Coordinate, fmt.Sprintf("API %q is deprecated by %q, but can not be migrated automatically", a.ID, a.DeprecatedBy))
+		}
+		m.trackFile(c.Coordinate, c.Template, "", false)
+		return c
+	}
+
+	original := c.Coordinate
+	c.Coordinate = m.migrated[original]
+	c.Type = config.SettingsType{SchemaId: mapping.schemaId}
+	c.Parameters[config.ScopeParameter] = valueParam.New("environment")
+	// the origin object ID refers to the classic object and is not valid for the Settings 2.0 object
+	c.OriginObjectId = ""
+
+	for _, note := range mapping.notes {
+		m.addIssue(original, note)
+	}
+
+	key := templateKey{coordinate: original, id: c.Template.ID()}
+	t, found := m.templates[key]
+	if !found {
+		t = m.migrateTemplate(original, c.Template, mapping)
+		m.templates[key] = t
+	}
+	if content, err := t.Content(); err == nil && t != c.Template {
+		m.trackFile(original, c.Template, content, true)
+	}
+	c.Template = t
+
+	return c
+}
+
+func (m *migrator) migrateTemplate(c coordinate.Coordinate, t template.Template, mapping mapping) template.Template {
+	content, err := t.Content()
+	if err != nil {
+		m.addIssue(c, fmt.Sprintf("failed to read template: %v", err))
+		return t
+	}
+
+	var issues []string
+	migrated, err := transformTemplate(content, func(src map[string]any) map[string]any {
+		o := newObject(src, "", &issues)
+		mapping.migrate(o)
+		return o.finish()
+	})
+	if err != nil {
+		m.addIssue(c, fmt.Sprintf("template could not be migrated and needs to be ported manually: %v", err))
+		return t
+	}
+
+	for _, issue := range issues {
+		m.addIssue(c, issue)
+	}
+	return template.NewInMemoryTemplate(c.ConfigId, migrated)
+}
+
+// rewriteReferences returns a copy of the parameters of the given config, with all reference parameters pointing to
+// migrated configs rewritten to the new coordinates. Other parameter types referencing migrated configs are reported,
+// as they need to be adapted manually.
+func (m *migrator) rewriteReferences(c config.Config) config.Parameters {
+	result := make(config.Parameters, len(c.Parameters))
+	for name, param := range c.Parameters {
+		result[name] = param
+
+		if ref, ok := param.(*reference.ReferenceParameter); ok {
+			if newCoord, migrated := m.migrated[ref.Config]; migrated {
+				result[name] = reference.NewWithCoordinate(newCoord, ref.Property)
+			}
+			continue
+		}
+
+		for _, r := range param.GetReferences() {
+			if _, migrated := m.migrated[r.Config]; migrated {
+				m.addIssue(c.Coordinate, fmt.Sprintf("parameter %q of type %q references migrated configuration %q and needs to be updated manually", name, param.GetType(), r.Config))
+			}
+		}
+	}
+	return result
+}
+
+func byCoordinate(a, b coordinate.Coordinate) int {
+	return strings.Compare(a.String(), b.String())
+}
@@ -0,0 +1,297 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func classicConfig(apiId, configId, content string, params config.Parameters) config.Config {
+	if params == nil {
+		params = config.Parameters{}
+	}
+	params[config.NameParameter] = valueParam.New(configId)
+	return config.Config{
+		Template:    template.NewInMemoryTemplate(configId, content),
+		Coordinate:  coordinate.Coordinate{Project: "project", Type: apiId, ConfigId: configId},
+		Type:        config.ClassicApiType{Api: apiId},
+		Parameters:  params,
+		Environment: "dev",
+		Group:       "default",
+	}
+}
+
+func newProject(configs ...config.Config) project.Project {
+	perType := project.ConfigsPerType{}
+	for _, c := range configs {
+		perType[c.Coordinate.Type] = append(perType[c.Coordinate.Type], c)
+	}
+	return project.Project{Id: "project", Configs: project.ConfigsPerTypePerEnvironments{"dev": perType}}
+}
+
+func templateContent(t *testing.T, c config.Config) string {
+	t.Helper()
+	content, err := c.Template.Content()
+	require.NoError(t, err)
+	return content
+}
+
+func issueMessages(issues []Issue, c coordinate.Coordinate) []string {
+	var result []string
+	for _, i := range issues {
+		if i.Coordinate == c {
+			result = append(result, i.Message)
+		}
+	}
+	return result
+}
+
+func TestProjects_AlertingProfile(t *testing.T) {
+	profile := classicConfig(api.AlertingProfile, "profile", `{
+  "displayName": "{{ .name }}",
+  "managementZoneId": {{ .mz }},
+  "rules": [
+    {
+      "severityLevel": "AVAILABILITY",
+      "delayInMinutes": 5,
+      "tagFilter": {
+        "includeMode": "INCLUDE_ANY",
+        "tagFilters": [
+          {"context": "CONTEXTLESS", "key": "team", "value": "a"},
+          {"context": "AWS", "key": "stage"}
+        ]
+      }
+    }
+  ],
+  "eventTypeFilters": [
+    {"predefinedEventFilter": {"eventType": "OSI_HIGH_CPU", "negate": false}},
+    {"customEventFilter": {"customTitleFilter": {"value": "x"}}}
+  ]
+}`, nil)
+
+	result := Projects([]project.Project{newProject(profile)})
+
+	newCoord := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "profile"}
+	assert.Equal(t, map[coordinate.Coordinate]coordinate.Coordinate{profile.Coordinate: newCoord}, result.Migrated)
+
+	require.Len(t, result.Projects, 1)
+	migrated := result.Projects[0].Configs["dev"]["builtin:alerting.profile"]
+	require.Len(t, migrated, 1)
+	assert.Equal(t, newCoord, migrated[0].Coordinate)
+	assert.Equal(t, config.SettingsType{SchemaId: "builtin:alerting.profile"}, migrated[0].Type)
+	assert.Equal(t, valueParam.New("environment"), migrated[0].Parameters[config.ScopeParameter])
+	assert.Equal(t, valueParam.New("profile"), migrated[0].Parameters[config.NameParameter])
+
+	assert.JSONEq(t, `{
+  "name": "{{ .name }}",
+  "managementZone": "__MZ__",
+  "severityRules": [
+    {
+      "severityLevel": "AVAILABILITY",
+      "delayInMinutes": 5,
+      "tagFilterIncludeMode": "INCLUDE_ANY",
+      "tags": ["team:a", "[AWS]stage"]
+    }
+  ],
+  "eventFilters": [
+    {"type": "PREDEFINED", "predefinedFilter": {"eventType": "OSI_HIGH_CPU", "negate": false}}
+  ]
+}`, strings.Replace(templateContent(t, migrated[0]), "{{ .mz }}", `"__MZ__"`, 1))
+
+	assert.Equal(t, []string{`field "eventTypeFilters[1].customEventFilter" is not supported by the Settings 2.0 schema and was dropped`}, issueMessages(result.Issues, profile.Coordinate))
+
+	// original project is not modified
+	assert.Equal(t, config.ClassicApiType{Api: api.AlertingProfile}, profile.Type)
+	assert.NotContains(t, profile.Parameters, config.ScopeParameter)
+}
+
+func TestProjects_ReferencesAreRewritten(t *testing.T) {
+	mz := classicConfig(api.ManagementZone, "mz", `{"name": "{{ .name }}", "entitySelectorBasedRules": [{"enabled": true, "entitySelector": "type(HOST)"}]}`, nil)
+	dashboard := classicConfig(api.Dashboard, "dashboard", `{"mz": "{{ .mz }}"}`, config.Parameters{
+		"mz": reference.New("project", api.ManagementZone, "mz", "id"),
+	})
+
+	result := Projects([]project.Project{newProject(mz, dashboard)})
+
+	dashboards := result.Projects[0].Configs["dev"][api.Dashboard]
+	require.Len(t, dashboards, 1)
+	assert.Equal(t, reference.New("project", "builtin:management-zones", "mz", "id"), dashboards[0].Parameters["mz"])
+
+	zones := result.Projects[0].Configs["dev"]["builtin:management-zones"]
+	require.Len(t, zones, 1)
+	assert.JSONEq(t, `{"name": "{{ .name }}", "rules": [{"type": "SELECTOR", "enabled": true, "entitySelector": "type(HOST)"}]}`, templateContent(t, zones[0]))
+	assert.Equal(t, mappings[api.ManagementZone].notes, issueMessages(result.Issues, mz.Coordinate))
+}
+
+func TestProjects_ConditionBasedRulesAreReported(t *testing.T) {
+	autoTag := classicConfig(api.Autotag, "tag", `{
+  "name": "{{ .name }}",
+  "rules": [{"type": "HOST", "conditions": []}],
+  "entitySelectorBasedRules": [{"enabled": true, "entitySelector": "type(SERVICE)", "valueFormat": "{service}", "normalization": "TO_LOWER_CASE"}]
+}`, nil)
+
+	result := Projects([]project.Project{newProject(autoTag)})
+
+	tags := result.Projects[0].Configs["dev"]["builtin:tags.auto-tagging"]
+	require.Len(t, tags, 1)
+	assert.JSONEq(t, `{
+  "name": "{{ .name }}",
+  "rules": [{"type": "SELECTOR", "enabled": true, "entitySelector": "type(SERVICE)", "valueFormat": "{service}", "valueNormalization": "To lower case"}]
+}`, templateContent(t, tags[0]))
+	assert.Equal(t, []string{`field "rules[0]" is a condition based rule and can not be migrated automatically - please recreate it as an entity selector or attribute rule`}, issueMessages(result.Issues, autoTag.Coordinate))
+}
+
+func TestProjects_MetricEvent(t *testing.T) {
+	event := classicConfig(api.AnomalyDetectionMetrics, "event", `{
+  "name": "{{ .name }}",
+  "description": "CPU too high",
+  "metricSelector": "builtin:host.cpu.usage",
+  "severity": "RESOURCE_CONTENTION",
+  "enabled": true,
+  "primaryDimensionKey": "dt.entity.host",
+  "monitoringStrategy": {
+    "type": "STATIC_THRESHOLD",
+    "threshold": 90,
+    "alertCondition": "ABOVE",
+    "samples": 5,
+    "violatingSamples": 3,
+    "dealertingSamples": 5,
+    "alertingOnMissingData": false,
+    "unit": "PERCENT"
+  },
+  "alertingScope": []
+}`, nil)
+
+	result := Projects([]project.Project{newProject(event)})
+
+	events := result.Projects[0].Configs["dev"]["builtin:anomaly-detection.metric-events"]
+	require.Len(t, events, 1)
+	assert.JSONEq(t, `{
+  "enabled": true,
+  "summary": "{{ .name }}",
+  "eventEntityDimensionKey": "dt.entity.host",
+  "eventTemplate": {"title": "{{ .name }}", "description": "CPU too high", "eventType": "RESOURCE"},
+  "queryDefinition": {"type": "METRIC_SELECTOR", "metricSelector": "builtin:host.cpu.usage"},
+  "modelProperties": {
+    "type": "STATIC_THRESHOLD",
+    "threshold": 90,
+    "alertCondition": "ABOVE",
+    "samples": 5,
+    "violatingSamples": 3,
+    "dealertingSamples": 5,
+    "alertOnNoData": false
+  }
+}`, templateContent(t, events[0]))
+	assert.Equal(t, []string{
+		`field "monitoringStrategy.unit" is not supported by the Settings 2.0 schema and was dropped`,
+		`field "alertingScope" is not supported by the Settings 2.0 schema and was dropped`,
+	}, issueMessages(result.Issues, event.Coordinate))
+}
+
+func TestProjects_UnsupportedDeprecatedApiIsReported(t *testing.T) {
+	notification := classicConfig(api.Notification, "notification", `{"name": "{{ .name }}"}`, nil)
+
+	result := Projects([]project.Project{newProject(notification)})
+
+	assert.Empty(t, result.Migrated)
+	assert.Equal(t, []config.Config{notification}, result.Projects[0].Configs["dev"][api.Notification])
+	assert.Equal(t, []string{`API "notification" is deprecated by "builtin:problem.notifications", but can not be migrated automatically`}, issueMessages(result.Issues, notification.Coordinate))
+}
+
+func TestProjects_InvalidTemplateIsReported(t *testing.T) {
+	profile := classicConfig(api.AlertingProfile, "profile", `{{ .content }}`, nil)
+
+	result := Projects([]project.Project{newProject(profile)})
+
+	migrated := result.Projects[0].Configs["dev"]["builtin:alerting.profile"]
+	require.Len(t, migrated, 1)
+	assert.Equal(t, profile.Template, migrated[0].Template)
+
+	messages := issueMessages(result.Issues, profile.Coordinate)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "template could not be migrated and needs to be ported manually")
+}
+
+func TestProjects_OtherReferencingParametersAreReported(t *testing.T) {
+	mz := classicConfig(api.ManagementZone, "mz", `{"name": "{{ .name }}"}`, nil)
+	dashboard := classicConfig(api.Dashboard, "dashboard", `{}`, config.Parameters{
+		"mz": &referencingParameter{refs: []parameter.ParameterReference{{Config: mz.Coordinate, Property: "id"}}},
+	})
+
+	result := Projects([]project.Project{newProject(mz, dashboard)})
+
+	assert.Equal(t, []string{`parameter "mz" of type "test" references migrated configuration "project:management-zone:mz" and needs to be updated manually`}, issueMessages(result.Issues, dashboard.Coordinate))
+}
+
+func TestProjects_MigratedTemplateFilesAreReturned(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "project/profile.json", []byte(`{"displayName": "{{ .name }}", "rules": []}`), 0644))
+	tmpl, err := template.NewFileTemplate(fs, "project/profile.json")
+	require.NoError(t, err)
+
+	profile := classicConfig(api.AlertingProfile, "profile", "", nil)
+	profile.Template = tmpl
+
+	result := Projects([]project.Project{newProject(profile)})
+
+	require.Contains(t, result.Templates, "project/profile.json")
+	assert.JSONEq(t, `{"name": "{{ .name }}", "severityRules": []}`, result.Templates["project/profile.json"])
+	assert.Empty(t, result.Issues)
+}
+
+func TestProjects_SharedTemplateFilesAreReported(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "project/shared.json", []byte(`{"name": "{{ .name }}"}`), 0644))
+	tmpl, err := template.NewFileTemplate(fs, "project/shared.json")
+	require.NoError(t, err)
+
+	profile := classicConfig(api.AlertingProfile, "profile", "", nil)
+	profile.Template = tmpl
+	dashboard := classicConfig(api.Dashboard, "dashboard", "", nil)
+	dashboard.Template = tmpl
+
+	result := Projects([]project.Project{newProject(profile, dashboard)})
+
+	assert.NotContains(t, result.Templates, "project/shared.json")
+	assert.Contains(t, issueMessages(result.Issues, profile.Coordinate), `template "project/shared.json" is shared with configurations that are not migrated the same way and needs to be split manually`)
+}
+
+type referencingParameter struct {
+	refs []parameter.ParameterReference
+}
+
+func (p *referencingParameter) GetType() string { return "test" }
+
+func (p *referencingParameter) GetReferences() []parameter.ParameterReference { return p.refs }
+
+func (p *referencingParameter) ResolveValue(parameter.ResolveContext) (any, error) { return nil, nil }
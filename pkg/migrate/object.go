@@ -0,0 +1,135 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"slices"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// object tracks the migration of a single JSON object of a classic payload into its Settings 2.0 counterpart.
+// Every field of the source object that is neither migrated nor explicitly ignored is reported as unsupported once
+// finish is called.
+type object struct {
+	src    map[string]any
+	dst    map[string]any
+	path   string
+	used   map[string]struct{}
+	issues *[]string
+}
+
+func newObject(src map[string]any, path string, issues *[]string) *object {
+	return &object{
+		src:    src,
+		dst:    map[string]any{},
+		path:   path,
+		used:   map[string]struct{}{},
+		issues: issues,
+	}
+}
+
+// child creates an object for a nested value of this object. If the value is not a JSON object, the problem is
+// reported and nil is returned.
+func (o *object) child(v any, key string) *object {
+	m, ok := v.(map[string]any)
+	if !ok {
+		o.report(key, "has an unexpected structure and can not be migrated automatically")
+		return nil
+	}
+	return newObject(m, o.path+key+".", o.issues)
+}
+
+// take returns the value of the given key and marks it as migrated.
+func (o *object) take(key string) (any, bool) {
+	v, found := o.src[key]
+	if found {
+		o.used[key] = struct{}{}
+	}
+	return v, found
+}
+
+// takeList returns the value of the given key as list. If the value is no list, the problem is reported.
+func (o *object) takeList(key string) ([]any, bool) {
+	v, found := o.take(key)
+	if !found || v == nil {
+		return nil, false
+	}
+	l, ok := v.([]any)
+	if !ok {
+		o.report(key, "has an unexpected structure and can not be migrated automatically")
+		return nil, false
+	}
+	return l, true
+}
+
+// rename copies the value of the field 'from' to the field 'to' of the migrated object.
+func (o *object) rename(from, to string) {
+	if v, found := o.take(from); found {
+		o.dst[to] = v
+	}
+}
+
+// renameMapped copies the value of the field 'from' to the field 'to' of the migrated object, translating known
+// values using the given mapping. Unknown values (e.g. template actions) are copied as-is.
+func (o *object) renameMapped(from, to string, mapping map[string]string) {
+	v, found := o.take(from)
+	if !found {
+		return
+	}
+	if s, ok := v.(string); ok {
+		if mapped, ok := mapping[s]; ok {
+			v = mapped
+		}
+	}
+	o.dst[to] = v
+}
+
+func (o *object) set(key string, v any) {
+	o.dst[key] = v
+}
+
+// ignore marks fields that have no meaning for Settings 2.0, e.g. IDs and metadata, as migrated.
+func (o *object) ignore(keys ...string) {
+	for _, k := range keys {
+		o.take(k)
+	}
+}
+
+func (o *object) report(key string, reason string) {
+	*o.issues = append(*o.issues, fmt.Sprintf("field %q %s", o.path+key, reason))
+}
+
+// finish reports all fields that were not migrated and returns the migrated object.
+func (o *object) finish() map[string]any {
+	keys := maps.Keys(o.src)
+	slices.Sort(keys)
+	for _, k := range keys {
+		if _, used := o.used[k]; !used {
+			o.report(k, "is not supported by the Settings 2.0 schema and was dropped")
+		}
+	}
+	return o.dst
+}
+
+// isTemplateAction returns whether the given value was a template action in the original template.
+func isTemplateAction(v any) bool {
+	return template.IsMasked(v)
+}
@@ -0,0 +1,46 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// transformTemplate parses the given JSON template, applies the transformation and serializes the result again.
+// Go template actions are masked before parsing and restored afterward, see template.ActionMasker.
+func transformTemplate(content string, transform func(map[string]any) map[string]any) (string, error) {
+	masker := template.NewActionMasker()
+
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(masker.Mask(content)), &parsed); err != nil {
+		return "", fmt.Errorf("template is not a JSON object: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(transform(parsed)); err != nil {
+		return "", fmt.Errorf("failed to serialize migrated template: %w", err)
+	}
+
+	return masker.Unmask(buf.String()), nil
+}
@@ -0,0 +1,55 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformTemplate_KeepsTemplateActions(t *testing.T) {
+	content := `{
+  "name": "{{ .name }}",
+  "description": "Team {{ .team }} - \"quoted\"",
+  "count": {{ .count }},
+  "list": {{ .list | toJson }}
+}`
+
+	result, err := transformTemplate(content, func(m map[string]any) map[string]any {
+		m["renamed"] = m["count"]
+		delete(m, "count")
+		return m
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "description": "Team {{ .team }} - \"quoted\"",
+  "list": {{ .list | toJson }},
+  "name": "{{ .name }}",
+  "renamed": {{ .count }}
+}
+`, result)
+}
+
+func TestTransformTemplate_InvalidJson(t *testing.T) {
+	_, err := transformTemplate(`{"name": `, func(m map[string]any) map[string]any { return m })
+	assert.Error(t, err)
+}
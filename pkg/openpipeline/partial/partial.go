@@ -0,0 +1,323 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package partial merges partial OpenPipeline configurations, e.g. single pipelines, processors or routing entries, into
+// one configuration per kind, and splits configurations into such parts.
+package partial
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// idKeys defines the property identifying the objects of each partial type within their list.
+// Routing entries do not have an ID, so their note is used instead.
+var idKeys = map[config.OpenPipelinePartialType]string{
+	config.OpenPipelinePipelinePartial:     "id",
+	config.OpenPipelineProcessorPartial:    "id",
+	config.OpenPipelineEndpointPartial:     "segment",
+	config.OpenPipelineRoutingEntryPartial: "note",
+}
+
+// Part is a single object of an OpenPipeline configuration.
+type Part struct {
+	// Coordinate is the coordinate of the config defining the part. It defines the order of parts and is used to report
+	// conflicts. It is empty for parts returned by Split.
+	Coordinate coordinate.Coordinate
+	Partial    config.OpenPipelinePartial
+	// Payload is the JSON object of the part, e.g. a single processor.
+	Payload []byte
+}
+
+// Merge merges the parts into the base OpenPipeline configuration. An object of the base configuration with the same ID
+// as a part is replaced in place, all other parts are appended to their list.
+// Parts are merged by their partial type in the order of [config.KnownOpenPipelinePartialTypes], and by their coordinate
+// within the same type, so the result does not depend on the order of the given parts. It is an error if multiple
+// parts define the same object.
+func Merge(base []byte, parts []Part) ([]byte, error) {
+	return merge(base, parts, false)
+}
+
+// MergeWithoutBase merges the parts into an empty OpenPipeline configuration, e.g. for dry-runs in which the deployed
+// configuration is unknown. Pipelines that processors are added to are created if no part defines them, so only
+// conflicts between the parts themselves are reported.
+func MergeWithoutBase(parts []Part) ([]byte, error) {
+	return merge([]byte(`{}`), parts, true)
+}
+
+func merge(base []byte, parts []Part, createMissingPipelines bool) ([]byte, error) {
+	var merged map[string]any
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal openpipeline configuration: %w", err)
+	}
+	if merged == nil {
+		merged = make(map[string]any)
+	}
+
+	sorted := slices.Clone(parts)
+	slices.SortStableFunc(sorted, func(a, b Part) int {
+		if c := cmp.Compare(slices.Index(config.KnownOpenPipelinePartialTypes, a.Partial.Type), slices.Index(config.KnownOpenPipelinePartialTypes, b.Partial.Type)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Coordinate.String(), b.Coordinate.String())
+	})
+
+	definedBy := make(map[string]coordinate.Coordinate)
+	for _, p := range sorted {
+		idKey := idKeys[p.Partial.Type]
+
+		obj, id, err := objectOf(p.Partial.Type, p.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("openpipeline config %s %w", p.Coordinate, err)
+		}
+
+		conflictKey := fmt.Sprintf("%s/%s/%s/%s", p.Partial.Type, p.Partial.Pipeline, p.Partial.Stage, id)
+		if other, found := definedBy[conflictKey]; found {
+			return nil, fmt.Errorf("openpipeline configs %s and %s both define the %s %q", other, p.Coordinate, p.Partial.Type, id)
+		}
+		definedBy[conflictKey] = p.Coordinate
+
+		owner, listKey, err := listOwner(merged, p.Partial, createMissingPipelines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge openpipeline config %s: %w", p.Coordinate, err)
+		}
+
+		list, err := listOf(owner, listKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge openpipeline config %s: %w", p.Coordinate, err)
+		}
+		owner[listKey] = upsert(list, idKey, id, obj)
+	}
+
+	return json.Marshal(merged)
+}
+
+// ObjectID returns the ID of the object defined by the payload of a part of the given type, e.g. the ID of a processor or
+// the note of a routing entry.
+func ObjectID(partialType config.OpenPipelinePartialType, payload []byte) (string, error) {
+	_, id, err := objectOf(partialType, payload)
+	return id, err
+}
+
+func objectOf(partialType config.OpenPipelinePartialType, payload []byte) (map[string]any, string, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(payload, &obj); err != nil || obj == nil {
+		return nil, "", errors.New("does not define a JSON object")
+	}
+
+	idKey := idKeys[partialType]
+	id, ok := obj[idKey].(string)
+	if !ok || id == "" {
+		return nil, "", fmt.Errorf("does not define the %q of its %s", idKey, partialType)
+	}
+	return obj, id, nil
+}
+
+// listOwner returns the object holding the list the partial is part of, and the key of the list. If the pipeline of a
+// processor does not exist, it is either created or an error is returned.
+func listOwner(cfg map[string]any, p config.OpenPipelinePartial, createMissingPipelines bool) (map[string]any, string, error) {
+	switch p.Type {
+	case config.OpenPipelinePipelinePartial:
+		return cfg, "pipelines", nil
+
+	case config.OpenPipelineEndpointPartial:
+		return cfg, "endpoints", nil
+
+	case config.OpenPipelineRoutingEntryPartial:
+		routing, err := childObject(cfg, "routing")
+		return routing, "entries", err
+
+	case config.OpenPipelineProcessorPartial:
+		pipelines, err := listOf(cfg, "pipelines")
+		if err != nil {
+			return nil, "", err
+		}
+
+		i := indexOf(pipelines, "id", p.Pipeline)
+		if i < 0 && createMissingPipelines {
+			pipelines = append(pipelines, map[string]any{"id": p.Pipeline})
+			cfg["pipelines"] = pipelines
+			i = len(pipelines) - 1
+		}
+		if i < 0 {
+			return nil, "", fmt.Errorf("pipeline %q does not exist", p.Pipeline)
+		}
+
+		pipeline, ok := pipelines[i].(map[string]any)
+		if !ok {
+			return nil, "", fmt.Errorf("pipeline %q is not an object", p.Pipeline)
+		}
+
+		stage, err := childObject(pipeline, p.Stage)
+		return stage, "processors", err
+	}
+
+	return nil, "", fmt.Errorf("unknown openpipeline partial type %q", p.Type)
+}
+
+// childObject returns the object with the given key, and creates it if it does not exist yet.
+func childObject(parent map[string]any, key string) (map[string]any, error) {
+	v, found := parent[key]
+	if !found || v == nil {
+		child := make(map[string]any)
+		parent[key] = child
+		return child, nil
+	}
+
+	child, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("property %q is not an object", key)
+	}
+	return child, nil
+}
+
+// listOf returns the list with the given key. It is empty if the list does not exist yet.
+func listOf(parent map[string]any, key string) ([]any, error) {
+	v, found := parent[key]
+	if !found || v == nil {
+		return nil, nil
+	}
+
+	list, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("property %q is not a list", key)
+	}
+	return list, nil
+}
+
+func indexOf(list []any, idKey, id string) int {
+	return slices.IndexFunc(list, func(v any) bool {
+		obj, ok := v.(map[string]any)
+		return ok && obj[idKey] == id
+	})
+}
+
+func upsert(list []any, idKey, id string, obj map[string]any) []any {
+	if i := indexOf(list, idKey, id); i >= 0 {
+		list[i] = obj
+		return list
+	}
+	return append(list, obj)
+}
+
+// Split splits an OpenPipeline configuration into parts for all its pipelines, their processors, its endpoints and its
+// routing entries, in the order they are defined in. The returned base configuration holds all other properties.
+// Objects without a unique ID can not be merged again, so they are kept in the base configuration.
+func Split(payload []byte) ([]byte, []Part, error) {
+	var cfg map[string]any
+	if err := json.Unmarshal(payload, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal openpipeline configuration: %w", err)
+	}
+	if cfg == nil {
+		return nil, nil, errors.New("openpipeline configuration is not a JSON object")
+	}
+
+	pipelineParts, err := splitList(cfg, "pipelines", config.OpenPipelinePartial{Type: config.OpenPipelinePipelinePartial}, splitProcessors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpointParts, err := splitList(cfg, "endpoints", config.OpenPipelinePartial{Type: config.OpenPipelineEndpointPartial}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var routingEntryParts []Part
+	if routing, ok := cfg["routing"].(map[string]any); ok {
+		if routingEntryParts, err = splitList(routing, "entries", config.OpenPipelinePartial{Type: config.OpenPipelineRoutingEntryPartial}, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	base, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal openpipeline configuration: %w", err)
+	}
+	return base, slices.Concat(pipelineParts, endpointParts, routingEntryParts), nil
+}
+
+// splitProcessors splits the processors of all stages of the pipeline into parts.
+func splitProcessors(pipeline map[string]any, pipelineID string) ([]Part, error) {
+	var parts []Part
+	for _, stage := range slices.Sorted(maps.Keys(pipeline)) {
+		stageObj, ok := pipeline[stage].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		processorParts, err := splitList(stageObj, "processors", config.OpenPipelinePartial{Type: config.OpenPipelineProcessorPartial, Pipeline: pipelineID, Stage: stage}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split processors of pipeline %q: %w", pipelineID, err)
+		}
+		parts = append(parts, processorParts...)
+	}
+	return parts, nil
+}
+
+// splitList splits the objects of the list with the given key into parts, followed by the parts splitChildren returns
+// for the object. Only the objects that can not be split off remain in the list.
+func splitList(owner map[string]any, listKey string, partial config.OpenPipelinePartial, splitChildren func(obj map[string]any, id string) ([]Part, error)) ([]Part, error) {
+	list, err := listOf(owner, listKey)
+	if err != nil || list == nil {
+		return nil, err
+	}
+
+	idKey := idKeys[partial.Type]
+	remaining := make([]any, 0)
+	var parts []Part
+	for _, v := range list {
+		obj, ok := v.(map[string]any)
+		id, _ := obj[idKey].(string)
+		if !ok || id == "" || countOf(list, idKey, id) > 1 {
+			remaining = append(remaining, v)
+			continue
+		}
+
+		var children []Part
+		if splitChildren != nil {
+			if children, err = splitChildren(obj, id); err != nil {
+				return nil, err
+			}
+		}
+
+		payload, err := json.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s %q: %w", partial.Type, id, err)
+		}
+		parts = append(append(parts, Part{Partial: partial, Payload: payload}), children...)
+	}
+
+	owner[listKey] = remaining
+	return parts, nil
+}
+
+func countOf(list []any, idKey, id string) int {
+	count := 0
+	for _, v := range list {
+		if obj, ok := v.(map[string]any); ok && obj[idKey] == id {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,219 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package partial_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/openpipeline/partial"
+)
+
+func part(project, configID string, p config.OpenPipelinePartial, payload string) partial.Part {
+	return partial.Part{
+		Coordinate: coordinate.Coordinate{Project: project, Type: "openpipeline", ConfigId: configID},
+		Partial:    p,
+		Payload:    []byte(payload),
+	}
+}
+
+var (
+	pipelinePartial     = config.OpenPipelinePartial{Type: config.OpenPipelinePipelinePartial}
+	endpointPartial     = config.OpenPipelinePartial{Type: config.OpenPipelineEndpointPartial}
+	routingEntryPartial = config.OpenPipelinePartial{Type: config.OpenPipelineRoutingEntryPartial}
+)
+
+func processorPartial(pipeline, stage string) config.OpenPipelinePartial {
+	return config.OpenPipelinePartial{Type: config.OpenPipelineProcessorPartial, Pipeline: pipeline, Stage: stage}
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("parts are appended in order of their type and coordinate", func(t *testing.T) {
+		merged, err := partial.Merge([]byte(`{"id":"logs"}`), []partial.Part{
+			part("b", "entry", routingEntryPartial, `{"note":"b","pipelineId":"p1"}`),
+			part("b", "processor", processorPartial("p1", "processing"), `{"id":"proc-b"}`),
+			part("a", "entry", routingEntryPartial, `{"note":"a","pipelineId":"p1"}`),
+			part("a", "processor", processorPartial("p1", "processing"), `{"id":"proc-a"}`),
+			part("a", "endpoint", endpointPartial, `{"segment":"custom"}`),
+			part("c", "pipeline", pipelinePartial, `{"id":"p1"}`),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id": "logs",
+			"pipelines": [{"id":"p1","processing":{"processors":[{"id":"proc-a"},{"id":"proc-b"}]}}],
+			"endpoints": [{"segment":"custom"}],
+			"routing": {"entries":[{"note":"a","pipelineId":"p1"},{"note":"b","pipelineId":"p1"}]}
+		}`, string(merged))
+	})
+
+	t.Run("parts replace objects of the base with the same ID in place", func(t *testing.T) {
+		base := `{
+			"id": "logs",
+			"pipelines": [{"id":"p1","processing":{"processors":[{"id":"old-1","enabled":false},{"id":"old-2"}]}}],
+			"routing": {"editable":true,"entries":[{"note":"a","matcher":"old"},{"note":"b"}]}
+		}`
+		merged, err := partial.Merge([]byte(base), []partial.Part{
+			part("a", "processor", processorPartial("p1", "processing"), `{"id":"old-1","enabled":true}`),
+			part("a", "entry", routingEntryPartial, `{"note":"a","matcher":"new"}`),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id": "logs",
+			"pipelines": [{"id":"p1","processing":{"processors":[{"id":"old-1","enabled":true},{"id":"old-2"}]}}],
+			"routing": {"editable":true,"entries":[{"note":"a","matcher":"new"},{"note":"b"}]}
+		}`, string(merged))
+	})
+
+	t.Run("merging the same parts in a different order gives the same result", func(t *testing.T) {
+		parts := []partial.Part{
+			part("a", "one", routingEntryPartial, `{"note":"one"}`),
+			part("b", "two", routingEntryPartial, `{"note":"two"}`),
+		}
+		first, err := partial.Merge([]byte(`{}`), parts)
+		require.NoError(t, err)
+
+		second, err := partial.Merge([]byte(`{}`), []partial.Part{parts[1], parts[0]})
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("parts defining the same object conflict", func(t *testing.T) {
+		_, err := partial.Merge([]byte(`{}`), []partial.Part{
+			part("a", "pipeline", pipelinePartial, `{"id":"p1"}`),
+			part("b", "pipeline", pipelinePartial, `{"id":"p1"}`),
+		})
+		assert.ErrorContains(t, err, `openpipeline configs a:openpipeline:pipeline and b:openpipeline:pipeline both define the pipeline "p1"`)
+	})
+
+	t.Run("processors with the same ID in different pipelines do not conflict", func(t *testing.T) {
+		_, err := partial.Merge([]byte(`{"pipelines":[{"id":"p1"},{"id":"p2"}]}`), []partial.Part{
+			part("a", "processor", processorPartial("p1", "processing"), `{"id":"proc"}`),
+			part("b", "processor", processorPartial("p2", "processing"), `{"id":"proc"}`),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("processor of unknown pipeline fails", func(t *testing.T) {
+		_, err := partial.Merge([]byte(`{}`), []partial.Part{
+			part("a", "processor", processorPartial("p1", "processing"), `{"id":"proc"}`),
+		})
+		assert.ErrorContains(t, err, `pipeline "p1" does not exist`)
+	})
+
+	t.Run("part without ID fails", func(t *testing.T) {
+		_, err := partial.Merge([]byte(`{}`), []partial.Part{
+			part("a", "entry", routingEntryPartial, `{"matcher":"true"}`),
+		})
+		assert.ErrorContains(t, err, `does not define the "note" of its routing-entry`)
+	})
+
+	t.Run("part that is not a JSON object fails", func(t *testing.T) {
+		_, err := partial.Merge([]byte(`{}`), []partial.Part{
+			part("a", "pipeline", pipelinePartial, `[]`),
+		})
+		assert.ErrorContains(t, err, "does not define a JSON object")
+	})
+}
+
+func TestMergeWithoutBase(t *testing.T) {
+	t.Run("pipelines of processors are created if no part defines them", func(t *testing.T) {
+		merged, err := partial.MergeWithoutBase([]partial.Part{
+			part("a", "processor", processorPartial("remote", "processing"), `{"id":"proc-a"}`),
+			part("a", "other", processorPartial("local", "processing"), `{"id":"proc-b"}`),
+			part("b", "pipeline", pipelinePartial, `{"id":"local","enabled":true}`),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"pipelines": [
+			{"id":"local","enabled":true,"processing":{"processors":[{"id":"proc-b"}]}},
+			{"id":"remote","processing":{"processors":[{"id":"proc-a"}]}}
+		]}`, string(merged))
+	})
+
+	t.Run("parts defining the same object still conflict", func(t *testing.T) {
+		_, err := partial.MergeWithoutBase([]partial.Part{
+			part("a", "processor", processorPartial("remote", "processing"), `{"id":"proc"}`),
+			part("b", "processor", processorPartial("remote", "processing"), `{"id":"proc"}`),
+		})
+		assert.ErrorContains(t, err, `both define the processor "proc"`)
+	})
+}
+
+func TestObjectID(t *testing.T) {
+	id, err := partial.ObjectID(config.OpenPipelineRoutingEntryPartial, []byte(`{"note":"entry","pipelineId":"p1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "entry", id)
+
+	_, err = partial.ObjectID(config.OpenPipelineProcessorPartial, []byte(`{"enabled":true}`))
+	assert.ErrorContains(t, err, `does not define the "id" of its processor`)
+}
+
+func TestSplit(t *testing.T) {
+	payload := `{
+		"id": "logs",
+		"customBasePath": "/platform/ingest/custom/logs",
+		"pipelines": [{"id":"p1","processing":{"processors":[{"id":"proc-1"},{"id":"proc-2"}]},"storage":{"processors":[{"id":"store"}]}}],
+		"endpoints": [{"segment":"default"},{"segment":"dup"},{"segment":"dup"}],
+		"routing": {"editable":true,"entries":[{"note":"a"},{"matcher":"no-note"}]}
+	}`
+
+	base, parts, err := partial.Split([]byte(payload))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"id": "logs",
+		"customBasePath": "/platform/ingest/custom/logs",
+		"pipelines": [],
+		"endpoints": [{"segment":"dup"},{"segment":"dup"}],
+		"routing": {"editable":true,"entries":[{"matcher":"no-note"}]}
+	}`, string(base))
+
+	require.Len(t, parts, 6)
+	assert.Equal(t, pipelinePartial, parts[0].Partial)
+	assert.JSONEq(t, `{"id":"p1","processing":{"processors":[]},"storage":{"processors":[]}}`, string(parts[0].Payload))
+	assert.Equal(t, processorPartial("p1", "processing"), parts[1].Partial)
+	assert.JSONEq(t, `{"id":"proc-1"}`, string(parts[1].Payload))
+	assert.Equal(t, processorPartial("p1", "processing"), parts[2].Partial)
+	assert.JSONEq(t, `{"id":"proc-2"}`, string(parts[2].Payload))
+	assert.Equal(t, processorPartial("p1", "storage"), parts[3].Partial)
+	assert.JSONEq(t, `{"id":"store"}`, string(parts[3].Payload))
+	assert.Equal(t, endpointPartial, parts[4].Partial)
+	assert.JSONEq(t, `{"segment":"default"}`, string(parts[4].Payload))
+	assert.Equal(t, routingEntryPartial, parts[5].Partial)
+	assert.JSONEq(t, `{"note":"a"}`, string(parts[5].Payload))
+
+	t.Run("merging the split parts again restores the configuration", func(t *testing.T) {
+		for i := range parts {
+			parts[i].Coordinate = coordinate.Coordinate{Project: "p", Type: "openpipeline", ConfigId: string(rune('a' + i))}
+		}
+
+		merged, err := partial.Merge(base, parts)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id": "logs",
+			"customBasePath": "/platform/ingest/custom/logs",
+			"pipelines": [{"id":"p1","processing":{"processors":[{"id":"proc-1"},{"id":"proc-2"}]},"storage":{"processors":[{"id":"store"}]}}],
+			"endpoints": [{"segment":"dup"},{"segment":"dup"},{"segment":"default"}],
+			"routing": {"editable":true,"entries":[{"matcher":"no-note"},{"note":"a"}]}
+		}`, string(merged))
+	})
+}
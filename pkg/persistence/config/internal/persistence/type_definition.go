@@ -33,6 +33,9 @@ const (
 	BucketType                = "bucket"
 	SegmentType               = "segment"
 	ServiceLevelObjectiveType = "slo-v2"
+	ExtensionV2Type           = "extension-v2"
+
+	ExtensionV2MonitoringConfigurationType = "extension-v2-monitoring-configuration"
 )
 
 type TypeDefinition struct {
@@ -65,10 +68,41 @@ type AutomationDefinition struct {
 type DocumentDefinition struct {
 	Kind    config.DocumentKind `yaml:"kind" json:"kind" jsonschema:"required,enum=dashboard,enum=notebook,description=This defines the kind of document this config is for." mapstructure:"kind"`
 	Private bool                `yaml:"private,omitempty" json:"private,omitempty" jsonschema:"description=Set to true to make the document private"  mapstructure:"private"`
+	Sharing *SharingDefinition  `yaml:"sharing,omitempty" json:"sharing,omitempty" jsonschema:"description=The optional owner and shares of the document. Shares not defined here are removed on deployment." mapstructure:"sharing"`
+}
+
+type SharingDefinition struct {
+	Owner       string            `yaml:"owner,omitempty" json:"owner,omitempty" jsonschema:"description=The ID of the user owning the document." mapstructure:"owner"`
+	Environment string            `yaml:"environment,omitempty" json:"environment,omitempty" jsonschema:"enum=read,enum=read-write,description=The access granted to all users of the environment." mapstructure:"environment"`
+	Users       []ShareDefinition `yaml:"users,omitempty" json:"users,omitempty" jsonschema:"description=The users the document is directly shared with." mapstructure:"users"`
+	Groups      []ShareDefinition `yaml:"groups,omitempty" json:"groups,omitempty" jsonschema:"description=The groups the document is directly shared with." mapstructure:"groups"`
+}
+
+type ShareDefinition struct {
+	ID     string `yaml:"id,omitempty" json:"id,omitempty" jsonschema:"description=The ID of the user or group." mapstructure:"id"`
+	Name   string `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"description=The name of the account group. Only allowed for groups if no ID is given." mapstructure:"name"`
+	Access string `yaml:"access" json:"access" jsonschema:"required,enum=read,enum=read-write,description=The access granted to the user or group." mapstructure:"access"`
+}
+
+type BucketDefinition struct {
+	AllowDestructive bool `yaml:"allowDestructive,omitempty" json:"allowDestructive,omitempty" jsonschema:"description=Set to true to allow changes deleting data of the bucket, like decreasing its retention, changing its table or deleting it." mapstructure:"allowDestructive"`
+	Protected        bool `yaml:"protected,omitempty" json:"protected,omitempty" jsonschema:"description=Set to true to prevent the bucket from being deleted by delete or purge." mapstructure:"protected"`
 }
 
 type OpenPipelineDefinition struct {
-	Kind string `yaml:"kind" json:"kind" jsonschema:"required,description=This defines the kind of OpenPipeline this config is for." mapstructure:"kind"`
+	Kind    string                         `yaml:"kind" json:"kind" jsonschema:"required,description=This defines the kind of OpenPipeline this config is for." mapstructure:"kind"`
+	Partial *OpenPipelinePartialDefinition `yaml:"partial,omitempty" json:"partial,omitempty" jsonschema:"description=Set if this config only defines a part of the OpenPipeline configuration of its kind. All configs of a kind are merged before they are deployed." mapstructure:"partial"`
+}
+
+type OpenPipelinePartialDefinition struct {
+	Type     config.OpenPipelinePartialType `yaml:"type" json:"type" jsonschema:"required,enum=pipeline,enum=processor,enum=endpoint,enum=routing-entry,description=The part of the OpenPipeline configuration this config defines." mapstructure:"type"`
+	Pipeline string                         `yaml:"pipeline,omitempty" json:"pipeline,omitempty" jsonschema:"description=The ID of the pipeline the processor is part of. Required for processors." mapstructure:"pipeline"`
+	Stage    string                         `yaml:"stage,omitempty" json:"stage,omitempty" jsonschema:"description=The stage of the pipeline the processor is part of, e.g. 'processing'. Required for processors." mapstructure:"stage"`
+}
+
+type ExtensionV2MonitoringConfigurationDefinition struct {
+	Extension string          `yaml:"extension" json:"extension" jsonschema:"required,description=The name of the Extensions 2.0 extension this monitoring configuration is for." mapstructure:"extension"`
+	Scope     ConfigParameter `yaml:"scope,omitempty" json:"scope,omitempty" jsonschema:"required,description=This defines the scope in which the monitoring configuration applies." mapstructure:"scope"`
 }
 
 // UnmarshalYAML Custom unmarshaler that knows how to handle TypeDefinition.
@@ -93,6 +127,11 @@ func (c *TypeDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error
 				return fmt.Errorf("unknown config-type %q", str)
 			}
 			c.Type = config.ServiceLevelObjective{}
+		case ExtensionV2Type:
+			if !featureflags.ExtensionsV2.Enabled() {
+				return fmt.Errorf("unknown config-type %q", str)
+			}
+			c.Type = config.ExtensionV2Type{}
 		default:
 			c.Type = config.ClassicApiType{Api: str}
 		}
@@ -129,6 +168,14 @@ func (c *TypeDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error
 		unmarshalers["openpipeline"] = c.parseOpenPipelineType
 	}
 
+	if featureflags.BucketSafety.Enabled() {
+		unmarshalers[BucketType] = c.parseBucketType
+	}
+
+	if featureflags.ExtensionsV2.Enabled() {
+		unmarshalers[ExtensionV2MonitoringConfigurationType] = c.parseExtensionV2MonitoringConfigurationType
+	}
+
 	if unm, f := unmarshalers[ttype]; !f {
 		return fmt.Errorf("unknown config-type %q", ttype)
 	} else {
@@ -197,9 +244,69 @@ func (c *TypeDefinition) parseDocumentType(a any) error {
 		return fmt.Errorf("failed to unmarshal document-type: %w", err)
 	}
 
+	if !featureflags.DocumentSharing.Enabled() && r.Sharing != nil {
+		return fmt.Errorf("unknown document configuration property 'sharing'")
+	}
+
 	c.Type = config.DocumentType{
 		Kind:    r.Kind,
 		Private: r.Private,
+		Sharing: toDocumentSharing(r.Sharing),
+	}
+
+	return nil
+}
+
+func toDocumentSharing(s *SharingDefinition) *config.DocumentSharing {
+	if s == nil {
+		return nil
+	}
+	return &config.DocumentSharing{
+		Owner:       s.Owner,
+		Environment: s.Environment,
+		Users:       toDocumentShares(s.Users),
+		Groups:      toDocumentShares(s.Groups),
+	}
+}
+
+func toDocumentShares(shares []ShareDefinition) []config.DocumentShare {
+	var result []config.DocumentShare
+	for _, s := range shares {
+		result = append(result, config.DocumentShare{ID: s.ID, Name: s.Name, Access: s.Access})
+	}
+	return result
+}
+
+func fromDocumentSharing(s *config.DocumentSharing) *SharingDefinition {
+	if s == nil {
+		return nil
+	}
+	return &SharingDefinition{
+		Owner:       s.Owner,
+		Environment: s.Environment,
+		Users:       fromDocumentShares(s.Users),
+		Groups:      fromDocumentShares(s.Groups),
+	}
+}
+
+func fromDocumentShares(shares []config.DocumentShare) []ShareDefinition {
+	var result []ShareDefinition
+	for _, s := range shares {
+		result = append(result, ShareDefinition{ID: s.ID, Name: s.Name, Access: s.Access})
+	}
+	return result
+}
+
+func (c *TypeDefinition) parseBucketType(a any) error {
+	var r BucketDefinition
+	err := mapstructure.Decode(a, &r)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal bucket-type: %w", err)
+	}
+
+	c.Type = config.BucketType{
+		AllowDestructive: r.AllowDestructive,
+		Protected:        r.Protected,
 	}
 
 	return nil
@@ -212,13 +319,44 @@ func (c *TypeDefinition) parseOpenPipelineType(a any) error {
 		return fmt.Errorf("failed to unmarshal openpipeline-type: %w", err)
 	}
 
+	if !featureflags.OpenPipelinePartials.Enabled() && r.Partial != nil {
+		return fmt.Errorf("unknown openpipeline configuration property 'partial'")
+	}
+
 	c.Type = config.OpenPipelineType{
-		Kind: r.Kind,
+		Kind:    r.Kind,
+		Partial: toOpenPipelinePartial(r.Partial),
 	}
 
 	return nil
 }
 
+func toOpenPipelinePartial(p *OpenPipelinePartialDefinition) *config.OpenPipelinePartial {
+	if p == nil {
+		return nil
+	}
+	return &config.OpenPipelinePartial{Type: p.Type, Pipeline: p.Pipeline, Stage: p.Stage}
+}
+
+func fromOpenPipelinePartial(p *config.OpenPipelinePartial) *OpenPipelinePartialDefinition {
+	if p == nil {
+		return nil
+	}
+	return &OpenPipelinePartialDefinition{Type: p.Type, Pipeline: p.Pipeline, Stage: p.Stage}
+}
+
+func (c *TypeDefinition) parseExtensionV2MonitoringConfigurationType(a any) error {
+	var r ExtensionV2MonitoringConfigurationDefinition
+	err := mapstructure.Decode(a, &r)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s-type: %w", ExtensionV2MonitoringConfigurationType, err)
+	}
+
+	c.Type = config.ExtensionV2MonitoringConfigurationType{Extension: r.Extension}
+	c.Scope = r.Scope
+	return nil
+}
+
 // Validate verifies whether the given type definition is valid (correct APIs, fields set, etc)
 func (c *TypeDefinition) Validate(apis map[string]struct{}) error {
 	switch t := c.Type.(type) {
@@ -260,16 +398,31 @@ func (c *TypeDefinition) Validate(apis map[string]struct{}) error {
 			return errors.New("missing document kind property")
 		}
 
-		if slices.Contains(config.KnownDocumentKinds, t.Kind) {
-			return nil
+		if !slices.Contains(config.KnownDocumentKinds, t.Kind) && !featureflags.AnyDocumentKind.Enabled() {
+			return fmt.Errorf("unknown document kind %q", t.Kind)
 		}
 
-		return fmt.Errorf("unknown document kind %q", t.Kind)
+		if t.Sharing != nil {
+			return validateDocumentSharing(*t.Sharing)
+		}
 
 	case config.OpenPipelineType:
 		if t.Kind == "" {
 			return errors.New("missing openpipeline kind property")
 		}
+
+		if t.Partial != nil {
+			return validateOpenPipelinePartial(*t.Partial)
+		}
+
+	case config.ExtensionV2MonitoringConfigurationType:
+		if t.Extension == "" {
+			return errors.New("missing extension property")
+		}
+
+		if c.Scope == nil {
+			return errors.New("missing monitoring configuration scope")
+		}
 	}
 
 	return nil
@@ -293,6 +446,10 @@ func (c *TypeDefinition) GetApiType() string {
 		return string(t.ID())
 	case config.ServiceLevelObjective:
 		return string(t.ID())
+	case config.ExtensionV2Type:
+		return string(t.ID())
+	case config.ExtensionV2MonitoringConfigurationType:
+		return string(t.ID())
 	}
 
 	return ""
@@ -347,6 +504,14 @@ func (c TypeDefinition) MarshalYAML() (interface{}, error) {
 		}, nil
 
 	case config.BucketType:
+		if featureflags.BucketSafety.Enabled() && (t.AllowDestructive || t.Protected) {
+			return map[string]any{
+				BucketType: BucketDefinition{
+					AllowDestructive: t.AllowDestructive,
+					Protected:        t.Protected,
+				},
+			}, nil
+		}
 		return BucketType, nil
 
 	case config.DocumentType:
@@ -354,6 +519,7 @@ func (c TypeDefinition) MarshalYAML() (interface{}, error) {
 			"document": DocumentDefinition{
 				Kind:    t.Kind,
 				Private: t.Private,
+				Sharing: fromDocumentSharing(t.Sharing),
 			},
 		}, nil
 
@@ -361,7 +527,8 @@ func (c TypeDefinition) MarshalYAML() (interface{}, error) {
 		if featureflags.OpenPipeline.Enabled() {
 			return map[string]any{
 				"openpipeline": OpenPipelineDefinition{
-					Kind: t.Kind,
+					Kind:    t.Kind,
+					Partial: fromOpenPipelinePartial(t.Partial),
 				},
 			}, nil
 		}
@@ -375,10 +542,74 @@ func (c TypeDefinition) MarshalYAML() (interface{}, error) {
 		if featureflags.ServiceLevelObjective.Enabled() {
 			return ServiceLevelObjectiveType, nil
 		}
+
+	case config.ExtensionV2Type:
+		if featureflags.ExtensionsV2.Enabled() {
+			return ExtensionV2Type, nil
+		}
+
+	case config.ExtensionV2MonitoringConfigurationType:
+		if featureflags.ExtensionsV2.Enabled() {
+			return map[string]any{
+				ExtensionV2MonitoringConfigurationType: ExtensionV2MonitoringConfigurationDefinition{
+					Extension: t.Extension,
+					Scope:     c.Scope,
+				},
+			}, nil
+		}
 	}
 	return nil, fmt.Errorf("unknown type: %T", c.Type)
 }
 
+func validateDocumentSharing(s config.DocumentSharing) error {
+	if s.Environment != "" && !slices.Contains(config.KnownDocumentAccess, s.Environment) {
+		return fmt.Errorf("unknown environment sharing access %q, allowed: %v", s.Environment, config.KnownDocumentAccess)
+	}
+
+	for _, u := range s.Users {
+		if u.ID == "" {
+			return errors.New("missing id of user share")
+		}
+		if u.Name != "" {
+			return fmt.Errorf("user share %q must not define a name", u.ID)
+		}
+		if !slices.Contains(config.KnownDocumentAccess, u.Access) {
+			return fmt.Errorf("unknown access %q of user share %q, allowed: %v", u.Access, u.ID, config.KnownDocumentAccess)
+		}
+	}
+
+	for _, g := range s.Groups {
+		if (g.ID == "") == (g.Name == "") {
+			return errors.New("group share must define either an id or a name")
+		}
+		if !slices.Contains(config.KnownDocumentAccess, g.Access) {
+			return fmt.Errorf("unknown access %q of group share %q, allowed: %v", g.Access, g.ID+g.Name, config.KnownDocumentAccess)
+		}
+	}
+	return nil
+}
+
+func validateOpenPipelinePartial(p config.OpenPipelinePartial) error {
+	if !slices.Contains(config.KnownOpenPipelinePartialTypes, p.Type) {
+		return fmt.Errorf("unknown openpipeline partial type %q, allowed: %v", p.Type, config.KnownOpenPipelinePartialTypes)
+	}
+
+	if p.Type != config.OpenPipelineProcessorPartial {
+		if p.Pipeline != "" || p.Stage != "" {
+			return fmt.Errorf("openpipeline partial of type %q must not define a pipeline or stage", p.Type)
+		}
+		return nil
+	}
+
+	if p.Pipeline == "" {
+		return errors.New("missing pipeline of openpipeline processor partial")
+	}
+	if p.Stage == "" {
+		return errors.New("missing stage of openpipeline processor partial")
+	}
+	return nil
+}
+
 // getAllUserPermission returns the allUsers permission and falls back to "none" if permission are set but allUsers is missing
 func getAllUserPermission(p *PermissionDefinition) *config.AllUserPermissionKind {
 	if p == nil {
@@ -267,7 +267,7 @@ func parseSkip(fs afero.Fs,
 	}
 
 	if !isSupportedParamTypeForSkip(parsed) {
-		return false, newParameterDefinitionParserError(config.SkipParameter, configId, context, environmentDefinition, "must be of type 'value' or 'environment'")
+		return false, newParameterDefinitionParserError(config.SkipParameter, configId, context, environmentDefinition, "must be of type 'value', 'environment', 'variable' or 'label'")
 	}
 
 	resolved, err := parsed.ResolveValue(parameter.ResolveContext{
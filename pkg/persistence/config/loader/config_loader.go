@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/persistence/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entityids"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
@@ -41,6 +43,9 @@ type LoaderContext struct {
 	Environments    []manifest.EnvironmentDefinition
 	KnownApis       map[string]struct{}
 	ParametersSerDe map[string]parameter.ParameterSerDe
+	// ConfigTypes restricts loading to configs of these types. Definitions of other types are skipped without being
+	// parsed. If empty, configs of all types are loaded.
+	ConfigTypes []config.TypeID
 }
 
 // configFileLoaderContext is a context for each config-file
@@ -76,6 +81,11 @@ func LoadConfigFile(ctx context.Context, fs afero.Fs, context *LoaderContext, fi
 		}
 	}
 
+	if entityids.IsEntityIDsFile(content) {
+		log.WithFields(field.F("file", filePath)).Debug("File %q contains entity ID annotations, skipping loading", filePath)
+		return []config.Config{}, nil
+	}
+
 	// Validate that the config has only accounts OR configs specified. We do not allow defining both in one file.
 	if loader.HasAnyAccountKeyDefined(content) {
 		if content["configs"] != nil {
@@ -103,6 +113,9 @@ func LoadConfigFile(ctx context.Context, fs afero.Fs, context *LoaderContext, fi
 	var configs []config.Config
 
 	for _, cgf := range loadedConfigEntries {
+		if len(context.ConfigTypes) > 0 && (cgf.Type.Type == nil || !slices.Contains(context.ConfigTypes, cgf.Type.Type.ID())) {
+			continue
+		}
 
 		result, definitionErrors := parseConfigEntry(fs, configLoaderContext, cgf.Id, cgf)
 
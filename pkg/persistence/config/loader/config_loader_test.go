@@ -35,9 +35,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/label"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
 	ref "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
@@ -59,6 +61,11 @@ func Test_parseConfigs(t *testing.T) {
 				Auth: manifest.Auth{
 					Token: &manifest.AuthSecret{Name: "token var"},
 				},
+				Variables: manifest.Variables{
+					"region":   {Value: "eu"},
+					"skipProd": {Name: "ENV_VAR_SKIP_TRUE", Value: "true"},
+				},
+				Labels: map[string]string{"tier": "prod"},
 			},
 		},
 		ParametersSerDe: config.DefaultParameterParsers,
@@ -93,6 +100,13 @@ func Test_parseConfigs(t *testing.T) {
 			fileContentOnDisk: "this_should_say_config:\n- id: profile\n  config:\n    name: Star Trek Service\n    skip: false\n",
 			wantErrorsContain: []string{"failed to load config from file \"test-file.yaml"},
 		},
+		{
+			name:              "skips entity ID annotation files",
+			filePathArgument:  "ids.yaml",
+			filePathOnDisk:    "ids.yaml",
+			fileContentOnDisk: "entityIds:\n  HOST-0000000000000001:\n    type: HOST\n    displayName: my-host\n",
+			wantConfigs:       []config.Config{},
+		},
 		{
 			name:              "reports detailed error for invalid v2 config if template is missing",
 			filePathArgument:  "test-file.yaml",
@@ -358,7 +372,7 @@ configs:
         configType: something
   type:
     api: some-api`,
-			wantErrorsContain: []string{"must be of type 'value' or 'environment'"},
+			wantErrorsContain: []string{"must be of type 'value', 'environment', 'variable' or 'label'"},
 		},
 		{
 			name:              "reports error for empty v2 config",
@@ -981,6 +995,153 @@ configs:
 				},
 			},
 		},
+		{
+			name:             "loads with a variable parameter",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    parameters:
+      region:
+        type: variable
+        name: region
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope:
+        type: variable
+        name: region`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: variable.New("region", "eu"),
+						"region":              variable.New("region", "eu"),
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "Skip parameter is defined as a variable",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  config:
+    name: Star Trek Service
+    template: profile.json
+    skip:
+      type: variable
+      name: skipProd
+  type:
+    api: some-api`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name": &value.ValueParameter{Value: "Star Trek Service"},
+					},
+					Skip:        true,
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "fails to load with an undefined variable",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  config:
+    name: Star Trek Service
+    template: profile.json
+    parameters:
+      host:
+        type: variable
+        name: slackHost
+  type:
+    api: some-api`,
+			wantErrorsContain: []string{"variable `slackHost` is not defined in the manifest"},
+		},
+		{
+			name:             "loads with a label parameter",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  config:
+    name: Star Trek Service
+    template: profile.json
+    parameters:
+      tier:
+        type: label
+        name: tier
+  type:
+    api: some-api`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name": &value.ValueParameter{Value: "Star Trek Service"},
+						"tier": label.New("tier", "prod"),
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "fails to load with an undefined label",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  config:
+    name: Star Trek Service
+    template: profile.json
+    skip:
+      type: label
+      name: skipMe
+  type:
+    api: some-api`,
+			wantErrorsContain: []string{"label `skipMe` is not defined on this environment"},
+		},
 		{
 			name:             "load a workflow",
 			filePathArgument: "test-file.yaml",
@@ -1414,6 +1575,53 @@ configs:
 				},
 			},
 		},
+		{
+			name:             "Bucket config with safety settings",
+			envVars:          map[string]string{featureflags.BucketSafety.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+  type:
+    bucket:
+      allowDestructive: true
+      protected: true
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "bucket",
+						ConfigId: "profile-id",
+					},
+					Type:        config.BucketType{AllowDestructive: true, Protected: true},
+					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters:  config.Parameters{},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "Bucket config with safety settings with FF off",
+			envVars:          map[string]string{featureflags.BucketSafety.EnvName(): "false"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+  type:
+    bucket:
+      protected: true
+`,
+			wantErrorsContain: []string{"unknown config-type"},
+		},
 		{
 			name:             "Bucket written as api config",
 			filePathArgument: "test-file.yaml",
@@ -1883,6 +2091,37 @@ configs:
 				"unknown document kind \"other\"",
 			},
 		},
+		{
+			name:             "Document config with app-specific type with any document kind FF on",
+			envVars:          map[string]string{featureflags.AnyDocumentKind.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: document-id
+  config:
+    name: Test document
+    template: 'profile.json'
+  type:
+    document:
+      kind: my.app:custom`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "document-id",
+					},
+					Type:     config.DocumentType{Kind: "my.app:custom"},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test document"},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
 		{
 			name: "OpenPipeline config with FF off",
 			envVars: map[string]string{
@@ -1956,6 +2195,285 @@ configs:
 				"missing openpipeline kind property",
 			},
 		},
+		{
+			name:             "Extensions 2.0 config with FF on",
+			envVars:          map[string]string{featureflags.ExtensionsV2.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: extension-id
+  config:
+    template: 'profile.json'
+  type: extension-v2
+- id: monitoring-configuration-id
+  config:
+    template: 'profile.json'
+  type:
+    extension-v2-monitoring-configuration:
+      extension: com.example.ext
+      scope: HOST-1
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "extension-v2",
+						ConfigId: "extension-id",
+					},
+					Type:        config.ExtensionV2Type{},
+					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters:  config.Parameters{},
+					Environment: "env name",
+					Group:       "default",
+				},
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "extension-v2-monitoring-configuration",
+						ConfigId: "monitoring-configuration-id",
+					},
+					Type:     config.ExtensionV2MonitoringConfigurationType{Extension: "com.example.ext"},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.ScopeParameter: &value.ValueParameter{Value: "HOST-1"},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "Extensions 2.0 config with FF off",
+			envVars:          map[string]string{featureflags.ExtensionsV2.EnvName(): "false"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: extension-id
+  config:
+    template: 'profile.json'
+  type: extension-v2
+`,
+			wantErrorsContain: []string{"unknown config-type \"extension-v2\""},
+		},
+		{
+			name:             "Extensions 2.0 monitoring configuration without scope",
+			envVars:          map[string]string{featureflags.ExtensionsV2.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: monitoring-configuration-id
+  config:
+    template: 'profile.json'
+  type:
+    extension-v2-monitoring-configuration:
+      extension: com.example.ext
+`,
+			wantErrorsContain: []string{"missing monitoring configuration scope"},
+		},
+		{
+			name:             "Document sharing with FF on",
+			envVars:          map[string]string{featureflags.DocumentSharing.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      sharing:
+        owner: user-1
+        environment: read
+        users:
+        - id: user-2
+          access: read-write
+        groups:
+        - id: group-1
+          access: read
+        - name: Admins
+          access: read-write`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "dashboard-id",
+					},
+					Type: config.DocumentType{Kind: config.DashboardKind, Sharing: &config.DocumentSharing{
+						Owner:       "user-1",
+						Environment: config.DocumentReadAccess,
+						Users:       []config.DocumentShare{{ID: "user-2", Access: config.DocumentReadWriteAccess}},
+						Groups: []config.DocumentShare{
+							{ID: "group-1", Access: config.DocumentReadAccess},
+							{Name: "Admins", Access: config.DocumentReadWriteAccess},
+						},
+					}},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "Document sharing with FF off",
+			envVars:          map[string]string{featureflags.DocumentSharing.EnvName(): "false"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      sharing:
+        environment: read`,
+			wantErrorsContain: []string{"unknown document configuration property 'sharing'"},
+		},
+		{
+			name:             "Document sharing with invalid group share",
+			envVars:          map[string]string{featureflags.DocumentSharing.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      sharing:
+        groups:
+        - id: group-1
+          name: Admins
+          access: read`,
+			wantErrorsContain: []string{"group share must define either an id or a name"},
+		},
+		{
+			name:             "Document sharing with unknown access",
+			envVars:          map[string]string{featureflags.DocumentSharing.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      sharing:
+        environment: write`,
+			wantErrorsContain: []string{"unknown environment sharing access \"write\""},
+		},
+		{
+			name:             "OpenPipeline partial config with FF on",
+			envVars:          map[string]string{featureflags.OpenPipelinePartials.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: logs-processor
+  config:
+    name: Test processor
+    template: 'profile.json'
+  type:
+    openpipeline:
+      kind: logs
+      partial:
+        type: processor
+        pipeline: team-a
+        stage: processing`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "openpipeline",
+						ConfigId: "logs-processor",
+					},
+					Type: config.OpenPipelineType{Kind: "logs", Partial: &config.OpenPipelinePartial{
+						Type:     config.OpenPipelineProcessorPartial,
+						Pipeline: "team-a",
+						Stage:    "processing",
+					}},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test processor"},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "OpenPipeline partial config with FF off",
+			envVars:          map[string]string{featureflags.OpenPipelinePartials.EnvName(): "false"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: logs-routing-entry
+  config:
+    name: Test routing entry
+    template: 'profile.json'
+  type:
+    openpipeline:
+      kind: logs
+      partial:
+        type: routing-entry`,
+			wantErrorsContain: []string{"unknown openpipeline configuration property 'partial'"},
+		},
+		{
+			name:             "OpenPipeline partial processor config without stage",
+			envVars:          map[string]string{featureflags.OpenPipelinePartials.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: logs-processor
+  config:
+    name: Test processor
+    template: 'profile.json'
+  type:
+    openpipeline:
+      kind: logs
+      partial:
+        type: processor
+        pipeline: team-a`,
+			wantErrorsContain: []string{"missing stage of openpipeline processor partial"},
+		},
+		{
+			name:             "OpenPipeline partial config with unknown type",
+			envVars:          map[string]string{featureflags.OpenPipelinePartials.EnvName(): "true"},
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: logs-stage
+  config:
+    name: Test stage
+    template: 'profile.json'
+  type:
+    openpipeline:
+      kind: logs
+      partial:
+        type: stage`,
+			wantErrorsContain: []string{"unknown openpipeline partial type \"stage\""},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -27,8 +27,10 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	labelParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/label"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	variableParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
 )
@@ -37,6 +39,8 @@ var allowedScopeParameterTypes = []string{
 	refParam.ReferenceParameterType,
 	valueParam.ValueParameterType,
 	envParam.EnvironmentVariableParameterType,
+	variableParam.VariableParameterType,
+	labelParam.LabelParameterType,
 }
 
 // isSupportedParamTypeForSkip check is 'skip' section of configuration supports specified param type
@@ -46,6 +50,10 @@ func isSupportedParamTypeForSkip(p parameter.Parameter) bool {
 		return true
 	case envParam.EnvironmentVariableParameterType:
 		return true
+	case variableParam.VariableParameterType:
+		return true
+	case labelParam.LabelParameterType:
+		return true
 	default:
 		return false
 	}
@@ -136,6 +144,8 @@ func parseParameter(fs afero.Fs, context *singleConfigEntryLoadContext, environm
 			Fs:            fs,
 			ParameterName: name,
 			Value:         maps.ToStringMap(val),
+			Variables:     environment.Variables.Values(),
+			Labels:        environment.Labels,
 		})
 	}
 
@@ -42,12 +42,22 @@ type WriterContext struct {
 	OutputFolder    string
 	ProjectFolder   string
 	ParametersSerde map[string]parameter.ParameterSerDe
+	// ConfigFileName is the name of the config YAML files written for each type. Defaults to "config.yaml".
+	ConfigFileName string
+
+	// fileNameClashes counts the uses of each template file name per config folder, to make template file names unique
+	fileNameClashes map[string]map[string]int
 }
 
+const defaultConfigFileName = "config.yaml"
+
 type serializerContext struct {
 	*WriterContext
 	configFolder string
 	config       coordinate.Coordinate
+	// templateNames holds the file names of the templates already written for the config, so that a template shared
+	// by several environments is only written once
+	templateNames map[*template.InMemoryTemplate]string
 }
 
 type environmentDetails struct {
@@ -135,7 +145,17 @@ func toTopLevelDefinitions(context *WriterContext, configs []config.Config) (map
 	knownTemplates := map[string]struct{}{}
 	var configTemplates []configTemplate
 
-	for coord, confs := range configsPerCoordinate {
+	// configs are converted in a stable order, so that template file names are stable between writes
+	coords := make([]extendedCoordinate, 0, len(configsPerCoordinate))
+	for coord := range configsPerCoordinate {
+		coords = append(coords, coord)
+	}
+	slices.SortFunc(coords, compareExtendedCoordinates)
+
+	for _, coord := range coords {
+		confs := configsPerCoordinate[coord]
+		slices.SortStableFunc(confs, byGroupAndEnvironment)
+
 		sanitizedType := mystrings.Sanitize(coord.extendedType)
 		configContext := &serializerContext{
 			WriterContext: context,
@@ -184,6 +204,20 @@ func byConfigId(a, b persistence.TopLevelConfigDefinition) int {
 	return strings.Compare(a.Id, b.Id)
 }
 
+func compareExtendedCoordinates(a, b extendedCoordinate) int {
+	if c := strings.Compare(a.extendedType, b.extendedType); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Coordinate.String(), b.Coordinate.String())
+}
+
+func byGroupAndEnvironment(a, b config.Config) int {
+	if c := strings.Compare(a.Group, b.Group); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Environment, b.Environment)
+}
+
 func writeTopLevelDefinitionToDisk(context *WriterContext, apiCoord apiCoordinate, definition persistence.TopLevelDefinition) error {
 	// sort configs so that they are stable within a config file
 	slices.SortFunc(definition.Configs, byConfigId)
@@ -194,7 +228,11 @@ func writeTopLevelDefinitionToDisk(context *WriterContext, apiCoord apiCoordinat
 	}
 
 	sanitizedApi := mystrings.Sanitize(apiCoord.api)
-	targetConfigFile := filepath.Join(context.OutputFolder, context.ProjectFolder, sanitizedApi, "config.yaml")
+	configFileName := context.ConfigFileName
+	if configFileName == "" {
+		configFileName = defaultConfigFileName
+	}
+	targetConfigFile := filepath.Join(context.OutputFolder, context.ProjectFolder, sanitizedApi, configFileName)
 
 	err = context.Fs.MkdirAll(filepath.Dir(targetConfigFile), 0777)
 
@@ -246,6 +284,10 @@ func toTopLevelConfigDefinition(context *serializerContext, configs []config.Con
 		config = *baseConfig
 	}
 
+	// sort overrides so that they are stable within a config file
+	slices.SortFunc(reducedGroupOverrides, func(a, b extendedConfigDefinition) int { return strings.Compare(a.group, b.group) })
+	slices.SortFunc(environmentOverrides, func(a, b extendedConfigDefinition) int { return strings.Compare(a.environment, b.environment) })
+
 	for _, conf := range reducedGroupOverrides {
 		groupOverrideConfigs = append(groupOverrideConfigs, persistence.GroupOverride{
 			Group:    conf.group,
@@ -305,6 +347,13 @@ func extractConfigType(context *serializerContext, cfg config.Config) (persisten
 		if err == nil {
 			ttype.Scope = serializedScope
 		}
+
+	case config.ExtensionV2MonitoringConfigurationType:
+		serializedScope, err := getSerializedParam(context, cfg, config.ScopeParameter, true)
+		if err != nil {
+			return persistence.TypeDefinition{}, err
+		}
+		ttype.Scope = serializedScope
 	}
 	return ttype, nil
 }
@@ -387,7 +436,7 @@ func createConfigDefinitionWithoutSharedValues(toReduce extendedConfigDefinition
 	}
 
 	if allParametersShared && checkResult.shareName &&
-		checkResult.shareSkip && checkResult.shareTemplate {
+		checkResult.shareSkip && checkResult.shareTemplate && checkResult.shareOriginObjectId {
 		return nil
 	}
 
@@ -407,6 +456,10 @@ func createConfigDefinitionWithoutSharedValues(toReduce extendedConfigDefinition
 		result.Skip = toReduce.Skip
 	}
 
+	if !checkResult.shareOriginObjectId {
+		result.OriginObjectId = toReduce.OriginObjectId
+	}
+
 	return result
 }
 
@@ -425,6 +478,10 @@ func createCommonConfigDefinition(checkResult propertyCheckResult, sharedParamet
 		result.Skip = checkResult.skip
 	}
 
+	if checkResult.shareOriginObjectId {
+		result.OriginObjectId = checkResult.originObjectId
+	}
+
 	if len(sharedParameters) > 0 {
 		result.Parameters = sharedParameters
 	}
@@ -467,17 +524,22 @@ type propertyCheckResult struct {
 	shareSkip bool
 	foundSkip bool
 	skip      interface{}
+
+	shareOriginObjectId bool
+	originObjectId      string
 }
 
 func testForSameProperties(configs []extendedConfigDefinition) propertyCheckResult {
 	name := configs[0].Name
 	templ := configs[0].Template
 	skip := configs[0].Skip
+	originObjectId := configs[0].OriginObjectId
 
 	var (
 		sameName,
 		sameTemplate,
-		sameSkip = true, true, true
+		sameSkip,
+		sameOriginObjectId = true, true, true, true
 	)
 
 	for _, c := range configs {
@@ -486,6 +548,7 @@ func testForSameProperties(configs []extendedConfigDefinition) propertyCheckResu
 		sameSkip = sameSkip && (reflect.DeepEqual(skip, c.Skip) ||
 			(skip == nil && c.Skip == false) ||
 			(skip == false && c.Skip == nil))
+		sameOriginObjectId = sameOriginObjectId && originObjectId == c.OriginObjectId
 	}
 
 	if !sameName {
@@ -512,6 +575,9 @@ func testForSameProperties(configs []extendedConfigDefinition) propertyCheckResu
 		shareSkip: sameSkip,
 		foundSkip: skip != nil || !sameSkip,
 		skip:      skip,
+
+		shareOriginObjectId: sameOriginObjectId,
+		originObjectId:      originObjectId,
 	}
 }
 
@@ -599,9 +665,16 @@ func extractTemplate(context *detailedSerializerContext, cfg config.Config) (str
 				return "", configTemplate{}, newDetailedConfigWriterError(context.serializerContext, err)
 			}
 			name = n
+		} else if n, found := context.templateNames[t]; found {
+			name = n
+			path = filepath.Join(context.configFolder, name)
 		} else {
-			name = prepareFileName(t.ID(), ".json")
+			name = prepareFileName(t.ID(), templateFileExtension(cfg), context.fileNameClashesOf(context.configFolder))
 			path = filepath.Join(context.configFolder, name)
+			if context.templateNames == nil {
+				context.templateNames = make(map[*template.InMemoryTemplate]string)
+			}
+			context.templateNames[t] = name
 		}
 	default:
 		return "", configTemplate{}, newDetailedConfigWriterError(context.serializerContext, fmt.Errorf("can not persist unexpected template type %q", t))
@@ -618,6 +691,15 @@ func extractTemplate(context *detailedSerializerContext, cfg config.Config) (str
 	}, nil
 }
 
+// templateFileExtension returns the file extension of the config's template, which is JSON for all types except
+// extension packages.
+func templateFileExtension(cfg config.Config) string {
+	if cfg.Type.ID() == config.ExtensionV2TypeID {
+		return ".zip"
+	}
+	return ".json"
+}
+
 func convertParameters(context *detailedSerializerContext, parameters config.Parameters) (map[string]persistence.ConfigParameter, []error) {
 	var errs []error
 	result := make(map[string]persistence.ConfigParameter)
@@ -772,10 +854,21 @@ func newDetailedConfigWriterError(context *serializerContext, err error) configE
 	}
 }
 
+// fileNameClashesOf returns the uses of template file names in the given config folder.
+func (c *WriterContext) fileNameClashesOf(folder string) map[string]int {
+	if c.fileNameClashes == nil {
+		c.fileNameClashes = make(map[string]map[string]int)
+	}
+	if c.fileNameClashes[folder] == nil {
+		c.fileNameClashes[folder] = make(map[string]int)
+	}
+	return c.fileNameClashes[folder]
+}
+
 // prepareFileName makes sure that a given file name meets all requirements like no forbidden characters
 // and max file name length. It takes the name (without file extension) and the file extension (with the separating ".", e.g. ".json")
-// and returns the filename combined with the file extension
-func prepareFileName(name string, fileExtension string) string {
+// and returns the filename combined with the file extension. Names are made unique among the names in fileNameClashes.
+func prepareFileName(name string, fileExtension string, fileNameClashes map[string]int) string {
 
 	const reservedForUniqueCounter = 2
 	maxFileNameLen := environment.GetEnvValueInt(environment.MaxFilenameLenKey)
@@ -791,7 +884,7 @@ func prepareFileName(name string, fileExtension string) string {
 		sanitizedName = string(runes[:maxLen])
 	}
 
-	finishedName := getUniqueFileName(sanitizedName) + fileExtension
+	finishedName := getUniqueFileName(sanitizedName, fileNameClashes) + fileExtension
 
 	if len(finishedName) > maxFileNameLen {
 		panic("cannot use file name " + finishedName + " as it is too long")
@@ -800,9 +893,7 @@ func prepareFileName(name string, fileExtension string) string {
 	return finishedName
 }
 
-var fileNameClashes = make(map[string]int)
-
-func getUniqueFileName(name string) string {
+func getUniqueFileName(name string, fileNameClashes map[string]int) string {
 	if _, ok := fileNameClashes[name]; ok {
 		fileNameClashes[name]++
 		return fmt.Sprintf("%s%d", name, fileNameClashes[name])
@@ -1671,6 +1671,120 @@ func TestOrderedConfigs(t *testing.T) {
 
 }
 
+func TestWriteConfigs_CustomConfigFileName(t *testing.T) {
+	configs := []config.Config{
+		{
+			Template:   template.NewInMemoryTemplate("a", "{}"),
+			Coordinate: coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "a"},
+			Type:       config.ClassicApiType{Api: "alerting-profile"},
+			Parameters: map[string]parameter.Parameter{config.NameParameter: &value.ValueParameter{Value: "name"}},
+		},
+	}
+
+	fs := afero.NewMemMapFs()
+	errs := WriteConfigs(&WriterContext{
+		Fs:              fs,
+		OutputFolder:    "test",
+		ProjectFolder:   "project",
+		ParametersSerde: config.DefaultParameterParsers,
+		ConfigFileName:  "merged.yaml",
+	}, configs)
+	assert.NoError(t, errors.Join(errs...))
+
+	found, err := afero.Exists(fs, "test/project/alerting-profile/merged.yaml")
+	assert.NoError(t, err)
+	assert.True(t, found, "config file should be written with the given name")
+
+	found, err = afero.Exists(fs, "test/project/alerting-profile/config.yaml")
+	assert.NoError(t, err)
+	assert.False(t, found, "default config file should not be written")
+}
+
+func TestWriteConfigs_SharedTemplateOfSeveralEnvironments(t *testing.T) {
+	shared := template.NewInMemoryTemplate("shared-template", "{}")
+	newConfig := func(env, group, originObjectId string, tmpl template.Template) config.Config {
+		return config.Config{
+			Template:       tmpl,
+			Coordinate:     coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "a"},
+			Type:           config.ClassicApiType{Api: "alerting-profile"},
+			Parameters:     map[string]parameter.Parameter{config.NameParameter: &value.ValueParameter{Value: "name"}},
+			Environment:    env,
+			Group:          group,
+			OriginObjectId: originObjectId,
+		}
+	}
+	configs := []config.Config{
+		newConfig("dev", "dev", "id-dev", shared),
+		newConfig("prod", "prod", "id-prod", shared),
+		newConfig("test", "test", "id-test", template.NewInMemoryTemplate("other-template", `{"other": true}`)),
+	}
+
+	fs := afero.NewMemMapFs()
+	errs := WriteConfigs(&WriterContext{
+		Fs:              fs,
+		OutputFolder:    "test",
+		ProjectFolder:   "project",
+		ParametersSerde: config.DefaultParameterParsers,
+	}, configs)
+	assert.NoError(t, errors.Join(errs...))
+
+	content, err := afero.ReadFile(fs, "test/project/alerting-profile/config.yaml")
+	assert.NoError(t, err)
+
+	var s persistence.TopLevelDefinition
+	assert.NoError(t, yaml.Unmarshal(content, &s))
+	assert.Len(t, s.Configs, 1)
+
+	templatePerGroup := map[string]string{}
+	originObjectIdPerGroup := map[string]string{}
+	for _, o := range s.Configs[0].GroupOverrides {
+		templatePerGroup[o.Group] = o.Override.Template
+		originObjectIdPerGroup[o.Group] = o.Override.OriginObjectId
+	}
+	assert.Equal(t, templatePerGroup["dev"], templatePerGroup["prod"], "environments sharing a template instance should share the template file")
+	assert.NotEqual(t, templatePerGroup["dev"], templatePerGroup["test"])
+	assert.Equal(t, map[string]string{"dev": "id-dev", "prod": "id-prod", "test": "id-test"}, originObjectIdPerGroup)
+
+	files, err := afero.ReadDir(fs, "test/project/alerting-profile")
+	assert.NoError(t, err)
+	assert.Len(t, files, 3, "expected the config file and two templates")
+}
+
+func TestWriteConfigs_IsStableRegardlessOfConfigOrder(t *testing.T) {
+	newConfig := func(env, content string) config.Config {
+		return config.Config{
+			Template:    template.NewInMemoryTemplate("template", content),
+			Coordinate:  coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "a"},
+			Type:        config.ClassicApiType{Api: "alerting-profile"},
+			Parameters:  map[string]parameter.Parameter{config.NameParameter: &value.ValueParameter{Value: "name-" + env}},
+			Environment: env,
+			Group:       env,
+		}
+	}
+	write := func(configs ...config.Config) afero.Fs {
+		fs := afero.NewMemMapFs()
+		errs := WriteConfigs(&WriterContext{
+			Fs:              fs,
+			OutputFolder:    "test",
+			ProjectFolder:   "project",
+			ParametersSerde: config.DefaultParameterParsers,
+		}, configs)
+		assert.NoError(t, errors.Join(errs...))
+		return fs
+	}
+
+	a := write(newConfig("dev", `{"env": "dev"}`), newConfig("prod", `{"env": "prod"}`), newConfig("test", `{"env": "test"}`))
+	b := write(newConfig("test", `{"env": "test"}`), newConfig("prod", `{"env": "prod"}`), newConfig("dev", `{"env": "dev"}`))
+
+	for _, file := range []string{"config.yaml", "template.json", "template1.json", "template2.json"} {
+		contentA, err := afero.ReadFile(a, filepath.Join("test/project/alerting-profile", file))
+		assert.NoError(t, err)
+		contentB, err := afero.ReadFile(b, filepath.Join("test/project/alerting-profile", file))
+		assert.NoError(t, err)
+		assert.Equal(t, string(contentA), string(contentB), "expected %s to be equal", file)
+	}
+}
+
 func TestPrepareFileName(t *testing.T) {
 	t.Setenv(environment.MaxFilenameLenKey, "20")
 	tests := []struct {
@@ -1696,7 +1810,7 @@ func TestPrepareFileName(t *testing.T) {
 				}()
 			}
 
-			result := prepareFileName(tt.name, tt.fileExtension)
+			result := prepareFileName(tt.name, tt.fileExtension, map[string]int{})
 			if result != tt.expected && !tt.expectPanic {
 				t.Errorf("expected %s, got %s", tt.expected, result)
 			}
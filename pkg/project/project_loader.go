@@ -41,6 +41,8 @@ type ProjectLoaderContext struct {
 	WorkingDir      string
 	Manifest        manifest.Manifest
 	ParametersSerde map[string]parameter.ParameterSerDe
+	// ConfigTypes restricts loading to configs of these types. If empty, configs of all types are loaded.
+	ConfigTypes []config.TypeID
 }
 
 // DuplicateConfigIdentifierError occurs if configuration IDs are found more than once
@@ -329,6 +331,7 @@ func loadConfigsOfProject(ctx context.Context, fs afero.Fs, loadingContext Proje
 		Path:            projectDefinition.Path,
 		KnownApis:       loadingContext.KnownApis,
 		ParametersSerDe: loadingContext.ParametersSerde,
+		ConfigTypes:     loadingContext.ConfigTypes,
 	}
 
 	for _, file := range configFiles {